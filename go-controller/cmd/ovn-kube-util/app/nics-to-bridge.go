@@ -13,16 +13,33 @@ import (
 var NicsToBridgeCommand = cli.Command{
 	Name:  "nics-to-bridge",
 	Usage: "Create ovs bridge for nic interfaces",
-	Flags: []cli.Flag{},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "bond",
+			Usage: "name of an ovs bond of the nic interfaces to create a single ovs bridge for",
+		},
+		&cli.StringFlag{
+			Name:  "bond-mode",
+			Usage: "bond_mode of the ovs bond, e.g. active-backup, balance-slb or balance-tcp (default of ovs if unset)",
+		},
+	},
 	Action: func(context *cli.Context) error {
 		args := context.Args()
 		if args.Len() == 0 {
 			return fmt.Errorf("please specify list of nic interfaces")
 		}
 
-		if err := util.SetSpecificExec(kexec.New(), "ovs-vsctl"); err != nil {
+		if err := util.SetSpecificExec(kexec.New(), "ovs-vsctl", "nmcli"); err != nil {
+			return err
+		}
+
+		if bond := context.String("bond"); bond != "" {
+			_, err := util.NicsToBondBridge(bond, args.Slice(), context.String("bond-mode"))
 			return err
 		}
+		if context.IsSet("bond-mode") {
+			return fmt.Errorf("--bond-mode requires --bond")
+		}
 
 		var errorList []error
 		for _, nic := range args.Slice() {
@@ -46,7 +63,7 @@ var BridgesToNicCommand = cli.Command{
 			return fmt.Errorf("please specify list of bridges")
 		}
 
-		if err := util.SetSpecificExec(kexec.New(), "ovs-vsctl"); err != nil {
+		if err := util.SetSpecificExec(kexec.New(), "ovs-vsctl", "nmcli"); err != nil {
 			return err
 		}
 
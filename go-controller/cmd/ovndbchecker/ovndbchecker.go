@@ -88,6 +88,60 @@ func main() {
 	c.Action = func(c *cli.Context) error {
 		return runOvnKubeDBChecker(c)
 	}
+	c.Commands = []*cli.Command{
+		{
+			Name:  "backup",
+			Usage: "take compacted snapshots of the OVN databases served by the local servers",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "backup-dir",
+					Usage:    "directory the snapshots are written to",
+					Required: true,
+				},
+				&cli.StringSliceFlag{
+					Name:  "db",
+					Usage: "databases to back up: nb and/or sb",
+					Value: cli.NewStringSlice("nb", "sb"),
+				},
+				&cli.DurationFlag{
+					Name:  "interval",
+					Usage: "interval between two snapshots, a single snapshot is taken if 0",
+				},
+				&cli.IntFlag{
+					Name:  "retention",
+					Usage: "number of snapshots kept per database, all of them are kept if 0",
+					Value: 5,
+				},
+			},
+			Action: runOvnDBBackup,
+		},
+		{
+			Name:  "restore",
+			Usage: "restore an OVN database from a snapshot, the database server must be stopped",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "snapshot",
+					Usage:    "snapshot to restore, verified against its checksum",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name:     "db-file",
+					Usage:    "database file to create, e.g. /etc/ovn/ovnnb_db.db",
+					Required: true,
+				},
+				&cli.StringFlag{
+					Name: "local-address",
+					Usage: "Raft address of the local server, e.g. ssl:10.1.1.185:9643; the database is restored as a " +
+						"single member cluster the other members must join, or as a standalone database if not set",
+				},
+				&cli.BoolFlag{
+					Name:  "force",
+					Usage: "back up and replace an existing database file",
+				},
+			},
+			Action: runOvnDBRestore,
+		},
+	}
 
 	ctx := context.Background()
 
@@ -190,3 +244,27 @@ func runOvnKubeDBChecker(ctx *cli.Context) error {
 	close(stopChan)
 	return nil
 }
+
+func runOvnDBBackup(ctx *cli.Context) error {
+	if err := util.SetExec(kexec.New()); err != nil {
+		return fmt.Errorf("failed to initialize exec helper: %v", err)
+	}
+	return ovndbmanager.RunDBBackups(&ovndbmanager.BackupConfig{
+		Dir:       ctx.String("backup-dir"),
+		Databases: ctx.StringSlice("db"),
+		Interval:  ctx.Duration("interval"),
+		Retention: ctx.Int("retention"),
+	}, ctx.Context.Done())
+}
+
+func runOvnDBRestore(ctx *cli.Context) error {
+	if err := util.SetExec(kexec.New()); err != nil {
+		return fmt.Errorf("failed to initialize exec helper: %v", err)
+	}
+	return ovndbmanager.RestoreDB(&ovndbmanager.RestoreConfig{
+		Snapshot:     ctx.String("snapshot"),
+		DBFile:       ctx.String("db-file"),
+		LocalAddress: ctx.String("local-address"),
+		Force:        ctx.Bool("force"),
+	})
+}
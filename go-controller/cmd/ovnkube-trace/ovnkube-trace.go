@@ -14,6 +14,7 @@ import (
 	"strconv"
 	"strings"
 
+	nadclientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 	types "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	util "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	kapi "k8s.io/api/core/v1"
@@ -21,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
 	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
@@ -89,29 +91,40 @@ type NodeInfo struct {
 	RoutingViaHost         bool   // The gateway mode, true for 'routingViaHost' or false for 'routingViaOVN'
 }
 
+// NetworkInfo describes the network that a trace runs on.
+type NetworkInfo struct {
+	NADName  string // namespaced name of the network attachment definition, empty for the default network
+	NetName  string // name of the network as found in the network attachment definition config
+	Topology string // topology of the network: layer3, layer2 or localnet
+	Prefix   string // prefix of the network's logical entities, empty for the default network
+}
+
 // PodInfo contains pod information.
 type PodInfo struct {
 	NodeInfo
-	PrimaryInterfaceName string // primary pod interface name inside the pod
-	IP                   string // the primary interface's primary IP address
-	IPVer                string // the address family of the primary IP address
-	MAC                  string // the primary interface's MAC address
-	VethName             string // veth peer of the primary interface of the pod
-	OfportNum            string // ofport number of veth interface or for host net pods of ovn-k8s-mp0
-	PodName              string // name of the pod
-	PodNamespace         string // the pod's namespace
-	ContainerName        string // the pod's principal container name (the first container found atm)
-	OvnKubeContainerName string // name of the container running ovnkube-node component
-	RtosMAC              string // router to switch mac address, the L2 address of the first hop router of the pod
-	RtotsMAC             string // router to transit switch port mac address
-	HostNetwork          bool   // if this pod is host networked or not
-	IsInterConnect       bool   // indicates if the pod is running on ovn interconnect environment or not
-	InterConnectZoneName string // contains interconnect zone name of the pod's hosting node.
-	NbURI                string // pod's ovn nb db uri string
-	SbURI                string // pod's ovn sb db uri string
-	SslCertKeys          string // ssl cert keys string to access ovn nbdb/sbdb
-	NbCommand            string // contains subset of nb command string to execute on ovn nbdb
-	SbCommand            string // contains subset of sb command string to execute on ovn sbdb
+	Network              *NetworkInfo // the network that is traced for this pod
+	LogicalSwitch        string       // logical switch of the pod's logical port on the traced network
+	LogicalPort          string       // the pod's logical switch port on the traced network, k8s-<nodeName> for host networked pods
+	PrimaryInterfaceName string       // primary pod interface name inside the pod
+	IP                   string       // the primary interface's primary IP address
+	IPVer                string       // the address family of the primary IP address
+	MAC                  string       // the primary interface's MAC address
+	VethName             string       // veth peer of the primary interface of the pod
+	OfportNum            string       // ofport number of veth interface or for host net pods of ovn-k8s-mp0
+	PodName              string       // name of the pod, or of the node when tracing from the node's host network
+	PodNamespace         string       // the pod's namespace
+	ContainerName        string       // the pod's principal container name (the first container found atm)
+	OvnKubeContainerName string       // name of the container running ovnkube-node component
+	RtosMAC              string       // router to switch mac address, the L2 address of the first hop router of the pod
+	RtotsMAC             string       // router to transit switch port mac address
+	HostNetwork          bool         // if this pod is host networked or not
+	IsInterConnect       bool         // indicates if the pod is running on ovn interconnect environment or not
+	InterConnectZoneName string       // contains interconnect zone name of the pod's hosting node.
+	NbURI                string       // pod's ovn nb db uri string
+	SbURI                string       // pod's ovn sb db uri string
+	SslCertKeys          string       // ssl cert keys string to access ovn nbdb/sbdb
+	NbCommand            string       // contains subset of nb command string to execute on ovn nbdb
+	SbCommand            string       // contains subset of sb command string to execute on ovn sbdb
 }
 
 // String returns a JSON representation of the SvcInfo object, or "" on failure.
@@ -139,6 +152,69 @@ func (si SvcInfo) getL3Ver() string {
 	return "ip6"
 }
 
+// IsSecondary returns true if the network is not the cluster default network.
+func (ni *NetworkInfo) IsSecondary() bool {
+	return ni.NADName != ""
+}
+
+// scopedName returns the name of a logical entity of this network.
+func (ni *NetworkInfo) scopedName(name string) string {
+	return ni.Prefix + name
+}
+
+// hasRouter returns true if pods of this network are attached to a cluster router.
+func (ni *NetworkInfo) hasRouter() bool {
+	return ni.Topology == types.Layer3Topology
+}
+
+// switchName returns the name of the logical switch that pods of the given node are attached to.
+func (ni *NetworkInfo) switchName(nodeName string) string {
+	switch ni.Topology {
+	case types.Layer2Topology:
+		return ni.scopedName(types.OVNLayer2Switch)
+	case types.LocalnetTopology:
+		return ni.scopedName(types.OVNLocalnetSwitch)
+	default:
+		return ni.scopedName(nodeName)
+	}
+}
+
+// remoteZoneEgressPort returns the logical port that traffic leaves the local zone through
+// when it is destined to a pod in another interconnect zone.
+func (ni *NetworkInfo) remoteZoneEgressPort(dstPodInfo *PodInfo) string {
+	switch ni.Topology {
+	case types.Layer2Topology:
+		// Remote pods have a logical port of type remote on the layer2 switch.
+		return dstPodInfo.LogicalPort
+	case types.LocalnetTopology:
+		return ni.scopedName(types.OVNLocalnetPort)
+	default:
+		return ni.scopedName(types.TransitSwitchToRouterPrefix + dstPodInfo.NodeName)
+	}
+}
+
+// remoteZoneIngressPort returns the logical port that traffic from a pod in another
+// interconnect zone enters the local zone through.
+func (ni *NetworkInfo) remoteZoneIngressPort(srcPodInfo *PodInfo) string {
+	switch ni.Topology {
+	case types.Layer2Topology:
+		return srcPodInfo.LogicalPort
+	case types.LocalnetTopology:
+		return ni.scopedName(types.OVNLocalnetPort)
+	default:
+		return ni.scopedName(types.TransitSwitchToRouterPrefix + srcPodInfo.NodeName)
+	}
+}
+
+// nextHopMAC returns the L2 destination address of traffic sent from this pod to dstPodInfo.
+// It is the pod's first hop router when the network has one, otherwise the destination pod itself.
+func (pi *PodInfo) nextHopMAC(dstPodInfo *PodInfo) string {
+	if pi.Network.hasRouter() {
+		return pi.RtosMAC
+	}
+	return dstPodInfo.MAC
+}
+
 // FullyQualifiedPodName returns the full name of the pod, <namespace>_<pod>.
 func (si *SvcInfo) FullyQualifiedPodName() string {
 	return si.PodInfo.FullyQualifiedPodName()
@@ -162,7 +238,7 @@ func execInPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, n
 
 	scheme := runtime.NewScheme()
 	if err := kapi.AddToScheme(scheme); err != nil {
-		klog.Exitf("Error adding to scheme: %v", err)
+		return "", "", fmt.Errorf("error adding to scheme: %v", err)
 	}
 	parameterCodec := runtime.NewParameterCodec(scheme)
 
@@ -311,12 +387,13 @@ func getOvnKubePodOnNode(coreclient *corev1client.CoreV1Client, ovnNamespace str
 
 // getPodOvsInterfaceNameAndOfport searches the node's OVS database for information
 // about this pod's OVS interface and returns the name and ofport fields.
-// It will run `ovs-vsctl --columns name,ofport find interface external_ids:iface-id=%s` with the given `$namespace-$pod` tuple and it will then parse the
-// result into a map[string]string that maps the keys to their values.
-func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, fullyQualifiedPodName string) (*OvsInterface, error) {
+// It will run `ovs-vsctl --columns name,ofport find interface external_ids:iface-id=%s` with the given iface-id, which
+// is the `$namespace_$pod` tuple for the default network and is prefixed by the NAD name on secondary networks, and it
+// will then parse the result into a map[string]string that maps the keys to their values.
+func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, ifaceID string) (*OvsInterface, error) {
 	var interfaceInfo OvsInterface
 
-	findInterfaceCmd := fmt.Sprintf("ovs-vsctl --columns name,ofport find interface external_ids:iface-id=%s", fullyQualifiedPodName)
+	findInterfaceCmd := fmt.Sprintf("ovs-vsctl --columns name,ofport find interface external_ids:iface-id=%s", ifaceID)
 	findInterfaceStdout, findInterfaceStderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, findInterfaceCmd, "")
 	if err != nil {
 		return nil, err
@@ -343,8 +420,8 @@ func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, rest
 
 	if interfaceInfo.Name == "" || interfaceInfo.Ofport == "" {
 		return nil, fmt.Errorf("could not find interface info for: "+
-			"fullyQualifiedPodName: %s, ovnNamespace: %s, ovnkubePodName: %s, cmd: %s. Got: %s, %s, parsed interface info: %v",
-			fullyQualifiedPodName,
+			"ifaceID: %s, ovnNamespace: %s, ovnkubePodName: %s, cmd: %s. Got: %s, %s, parsed interface info: %v",
+			ifaceID,
 			ovnNamespace,
 			podInfo.OvnKubePodName,
 			findInterfaceCmd,
@@ -357,7 +434,7 @@ func getPodOvsInterfaceNameAndOfport(coreclient *corev1client.CoreV1Client, rest
 }
 
 // getSvcInfo builds the SvcInfo object for this service. PodName/PodNamespace/PodIP are for the first valid endpoint pod that can be found for this service.
-func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily string) (svcInfo *SvcInfo, err error) {
+func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, svcName string, ovnNamespace string, namespace, addressFamily string, network *NetworkInfo) (svcInfo *SvcInfo, err error) {
 	// Get service with the name supplied by svcName
 	svc, err := coreclient.Services(namespace).Get(context.TODO(), svcName, metav1.GetOptions{})
 	if err != nil {
@@ -384,7 +461,7 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	}
 	klog.V(5).Infof("==> Got Endpoint %v for service %s in namespace %s\n", ep, svcName, namespace)
 
-	err = extractSubsetInfo(coreclient, restconfig, ep.Subsets, svcInfo, ovnNamespace, addressFamily)
+	err = extractSubsetInfo(coreclient, restconfig, ep.Subsets, svcInfo, ovnNamespace, addressFamily, network)
 	if err != nil {
 		return nil, err
 	}
@@ -394,7 +471,7 @@ func getSvcInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 
 // extractSubsetInfo copies information from the endpoint subsets into the SvcInfo object.
 // Modifies the svcInfo object the pointer of which is passed to it.
-func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, subsets []kapi.EndpointSubset, svcInfo *SvcInfo, ovnNamespace, addressFamily string) error {
+func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, subsets []kapi.EndpointSubset, svcInfo *SvcInfo, ovnNamespace, addressFamily string, network *NetworkInfo) error {
 	for _, subset := range subsets {
 		klog.V(5).Infof("==> Trying to extract information for service %s in namespace %s from subset %v",
 			svcInfo.SvcName, svcInfo.SvcNamespace, subset)
@@ -428,9 +505,9 @@ func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.C
 			}
 
 			// Get info needed for the src Pod
-			svcPodInfo, err := getPodInfo(coreclient, restconfig, epAddress.TargetRef.Name, ovnNamespace, epAddress.TargetRef.Namespace, addressFamily)
+			svcPodInfo, err := getPodInfo(coreclient, restconfig, epAddress.TargetRef.Name, ovnNamespace, epAddress.TargetRef.Namespace, addressFamily, network)
 			if err != nil {
-				klog.Exitf("Failed to get information from pod %s: %v", epAddress.TargetRef.Name, err)
+				return fmt.Errorf("failed to get information from pod %s: %v", epAddress.TargetRef.Name, err)
 			}
 			klog.V(5).Infof("svcPodInfo is %s\n", svcPodInfo)
 
@@ -447,7 +524,7 @@ func extractSubsetInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.C
 }
 
 // getPodInfo returns a pointer to a fully populated PodInfo struct, or error on failure.
-func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string) (podInfo *PodInfo, err error) {
+func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podName string, ovnNamespace string, namespace, addressFamily string, network *NetworkInfo) (podInfo *PodInfo, err error) {
 	// Create a PodInfo object with the base information already added, such as
 	// IP, PodName, ContainerName, NodeName, HostNetwork, Namespace, PrimaryInterfaceName
 	pod, err := coreclient.Pods(namespace).Get(context.TODO(), podName, metav1.GetOptions{})
@@ -456,58 +533,180 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 		return nil, err
 	}
 
-	podIP, err := getDesiredPodIP(pod, addressFamily)
-	if err != nil {
-		klog.V(1).Infof("Pod %s in namespace %s doesn't have desired ip address configured\n", podName, namespace)
-		return nil, err
-	}
-
 	podInfo = &PodInfo{
-		IP:            podIP,
 		IPVer:         addressFamily,
 		PodName:       pod.Name,
 		ContainerName: pod.Spec.Containers[0].Name,
 		HostNetwork:   pod.Spec.HostNetwork,
 		PodNamespace:  pod.Namespace,
+		Network:       network,
 	}
 	podInfo.NodeName = pod.Spec.NodeName
 
-	// Get the pod's ovnkubePod.
-	podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
-	if err != nil {
-		klog.V(1).Infof("Problem obtaining ovnkube pod name of Pod %s in namespace %s\n", podName, namespace)
+	if network.IsSecondary() {
+		if podInfo.HostNetwork {
+			return nil, fmt.Errorf("pod %s in namespace %s is host networked and not attached to network %s", podName, namespace, network.NADName)
+		}
+		// Both the IP and MAC address on a secondary network are found in the pod's network annotation.
+		podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, network.NADName)
+		if err != nil {
+			klog.V(1).Infof("Pod %s in namespace %s is not attached to network %s\n", podName, namespace, network.NADName)
+			return nil, err
+		}
+		for _, podIP := range podAnnotation.IPs {
+			if getIPVer(podIP.IP) == addressFamily {
+				podInfo.IP = podIP.IP.String()
+				break
+			}
+		}
+		if podInfo.IP == "" {
+			return nil, fmt.Errorf("pod %s in namespace %s doesn't have a %s address on network %s", podName, namespace, addressFamily, network.NADName)
+		}
+		podInfo.MAC = podAnnotation.MAC.String()
+	} else {
+		podInfo.IP, err = getDesiredPodIP(pod, addressFamily)
+		if err != nil {
+			klog.V(1).Infof("Pod %s in namespace %s doesn't have desired ip address configured\n", podName, namespace)
+			return nil, err
+		}
+
+		// Get the pod's MAC address.
+		podInfo.MAC, err = getPodMAC(coreclient, pod)
+		if err != nil {
+			klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
+			return nil, err
+		}
+	}
+
+	if err = populateNodeInfo(coreclient, restconfig, ovnNamespace, podInfo); err != nil {
 		return nil, err
 	}
 
-	// Get the node's gateway mode
-	podInfo.RoutingViaHost, err = isRoutingViaHost(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.NodeName)
+	// Set information specific to host networked pods or non-host networked pods.
+	if podInfo.HostNetwork {
+		podInfo.PrimaryInterfaceName = util.GetLegacyK8sMgmtIntfName(podInfo.NodeName)
+		podInfo.K8sNodeNamePort = types.K8sPrefix + podInfo.NodeName
+		podInfo.LogicalPort = podInfo.K8sNodeNamePort
+		podInfo.VethName = podInfo.OvnK8sMp0PortName
+		podInfo.OfportNum = podInfo.OvnK8sMp0OfportNum
+		return podInfo, nil
+	}
+
+	ifaceID := util.GetIfaceId(pod.Namespace, pod.Name)
+	podInfo.LogicalPort = util.GetLogicalPortName(pod.Namespace, pod.Name)
+	podInfo.PrimaryInterfaceName = "eth0"
+	if network.IsSecondary() {
+		ifaceID = util.GetSecondaryNetworkIfaceId(pod.Namespace, pod.Name, network.NADName)
+		podInfo.LogicalPort = util.GetSecondaryNetworkLogicalPortName(pod.Namespace, pod.Name, network.NADName)
+		podInfo.PrimaryInterfaceName = ""
+	}
+	// Get the pod's interface information
+	ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, podInfo, ovnNamespace, ifaceID)
 	if err != nil {
 		return nil, err
 	}
+	podInfo.VethName = ovsInterfaceInformation.Name
+	podInfo.OfportNum = ovsInterfaceInformation.Ofport
 
-	// Get the pod's MAC address.
-	podInfo.MAC, err = getPodMAC(coreclient, pod)
+	return podInfo, nil
+}
+
+// getNodeInfo returns a pointer to a PodInfo struct that describes the host network of the given node as the
+// source or destination of a trace. Traffic from the host enters OVN through the node's management port, so the
+// management port's addresses are used.
+func getNodeInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, nodeName string, ovnNamespace string, addressFamily string, network *NetworkInfo) (*PodInfo, error) {
+	node, err := coreclient.Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
 	if err != nil {
-		klog.V(1).Infof("Problem obtaining Ethernet address of Pod %s in namespace %s\n", podName, namespace)
+		klog.V(1).Infof("Node %s not found\n", nodeName)
 		return nil, err
 	}
 
-	podInfo, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, podInfo)
+	podInfo := &PodInfo{
+		IPVer:       addressFamily,
+		PodName:     node.Name,
+		HostNetwork: true,
+		Network:     network,
+	}
+	podInfo.NodeName = node.Name
+
+	// Without the management port MAC the generated ovn-trace command would not
+	// match any port, so fail instead of tracing from an empty eth.src.
+	nodeMAC, err := util.ParseNodeManagementPortMACAddress(node)
 	if err != nil {
-		klog.Exitf("Failed to get database URIs: %v\n", err)
+		return nil, fmt.Errorf("failed to get the management port MAC address of node %s: %w", nodeName, err)
 	}
+	if nodeMAC == nil {
+		return nil, fmt.Errorf("node %s doesn't have a management port MAC address annotation", nodeName)
+	}
+	podInfo.MAC = nodeMAC.String()
 
-	// Find rtos MAC (this is the pod's first hop router).
-	podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToSwitchPrefix)
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
 	if err != nil {
 		return nil, err
 	}
+	for _, hostSubnet := range hostSubnets {
+		if getIPVer(hostSubnet.IP) == addressFamily {
+			podInfo.IP = util.GetNodeManagementIfAddr(hostSubnet).IP.String()
+			break
+		}
+	}
+	if podInfo.IP == "" {
+		return nil, fmt.Errorf("node %s doesn't have a %s host subnet", nodeName, addressFamily)
+	}
 
-	// Find rtots MAC (this is the pod's first hop router when ovn is in interconnected zone).
-	if podInfo.IsInterConnect {
-		podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace, types.RouterToTransitSwitchPrefix)
+	if err = populateNodeInfo(coreclient, restconfig, ovnNamespace, podInfo); err != nil {
+		return nil, err
+	}
+
+	podInfo.PrimaryInterfaceName = util.GetLegacyK8sMgmtIntfName(podInfo.NodeName)
+	podInfo.K8sNodeNamePort = types.K8sPrefix + podInfo.NodeName
+	podInfo.LogicalPort = podInfo.K8sNodeNamePort
+	podInfo.VethName = podInfo.OvnK8sMp0PortName
+	podInfo.OfportNum = podInfo.OvnK8sMp0OfportNum
+
+	return podInfo, nil
+}
+
+// populateNodeInfo fills in the information of the node that podInfo is running on: its ovnkube-node pod,
+// gateway mode, database URIs, first hop router ports and external bridge.
+func populateNodeInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, ovnNamespace string, podInfo *PodInfo) error {
+	var err error
+
+	podInfo.LogicalSwitch = podInfo.Network.switchName(podInfo.NodeName)
+
+	// Get the pod's ovnkubePod.
+	podInfo.OvnKubePodName, err = getOvnKubePodOnNode(coreclient, ovnNamespace, podInfo.NodeName)
+	if err != nil {
+		klog.V(1).Infof("Problem obtaining ovnkube pod name on node %s\n", podInfo.NodeName)
+		return err
+	}
+
+	// Get the node's gateway mode
+	podInfo.RoutingViaHost, err = isRoutingViaHost(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.NodeName)
+	if err != nil {
+		return err
+	}
+
+	_, err = getDatabaseURIs(coreclient, restconfig, ovnNamespace, podInfo)
+	if err != nil {
+		return fmt.Errorf("failed to get database URIs: %v", err)
+	}
+
+	if podInfo.Network.hasRouter() {
+		// Find rtos MAC (this is the pod's first hop router).
+		podInfo.RtosMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace,
+			types.RouterToSwitchPrefix+podInfo.Network.scopedName(podInfo.NodeName))
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		// Find rtots MAC (this is the pod's first hop router when ovn is in interconnected zone).
+		if podInfo.IsInterConnect {
+			podInfo.RtotsMAC, err = getRouterPortMacAddress(coreclient, restconfig, podInfo, ovnNamespace,
+				podInfo.Network.scopedName(types.RouterToTransitSwitchPrefix+podInfo.NodeName))
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -517,37 +716,17 @@ func getPodInfo(coreclient *corev1client.CoreV1Client, restconfig *rest.Config,
 	portCmd := fmt.Sprintf("ovs-vsctl get Interface %s ofport", podInfo.OvnK8sMp0PortName)
 	localOutput, localError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, portCmd, "")
 	if err != nil {
-		return nil, fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
+		return fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, localError, localOutput, podInfo)
 	}
 	podInfo.OvnK8sMp0OfportNum = strings.Replace(localOutput, "\n", "", -1)
 
-	// Set information specific to host networked pods or non-host networked pods.
-	if podInfo.HostNetwork {
-		podInfo.PrimaryInterfaceName = util.GetLegacyK8sMgmtIntfName(podInfo.NodeName)
-		podInfo.K8sNodeNamePort = types.K8sPrefix + podInfo.NodeName
-		podInfo.VethName = podInfo.OvnK8sMp0PortName
-		podInfo.OfportNum = podInfo.OvnK8sMp0OfportNum
-	} else {
-		// Get the pod's interface information
-		ovsInterfaceInformation, err := getPodOvsInterfaceNameAndOfport(coreclient, restconfig, podInfo, ovnNamespace, podInfo.FullyQualifiedPodName())
-		if err != nil {
-			return nil, err
-		}
-		podInfo.PrimaryInterfaceName = "eth0"
-		podInfo.VethName = ovsInterfaceInformation.Name
-		podInfo.OfportNum = ovsInterfaceInformation.Ofport
-	}
-
 	podInfo.NodeExternalBridgeName, err = getNodeExternalBridgeName(coreclient, restconfig, ovnNamespace, podInfo)
-	if err != nil {
-		return nil, err
-	}
-
-	return podInfo, err
+	return err
 }
 
-func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portPrefix string) (string, error) {
-	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portPrefix + podInfo.NodeName
+// getRouterPortMacAddress returns the MAC address of the given logical router port.
+func getRouterPortMacAddress(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace, portName string) (string, error) {
+	tspCmd := "ovn-sbctl --no-leader-only " + podInfo.SbCommand + " --bare --no-heading --column=mac list Port_Binding " + portName
 	ipOutput, ipError, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, tspCmd, "")
 	if err != nil {
 		return "", fmt.Errorf("execInPod() failed. err: %s, stderr: %s, stdout: %s, podInfo: %v", err, ipError, ipOutput, podInfo)
@@ -582,6 +761,44 @@ func getNodeExternalBridgeName(coreclient *corev1client.CoreV1Client, restconfig
 	return "", fmt.Errorf("could not find external bridge for node %s in getNodeBridgeName()", podInfo.NodeName)
 }
 
+// getNetworkInfo returns the NetworkInfo of the network defined by the given network attachment definition, in the
+// form of <namespace>/<name>. An empty nadName refers to the cluster default network.
+func getNetworkInfo(nadClient nadclientset.Interface, nadName string) (*NetworkInfo, error) {
+	if nadName == "" {
+		return &NetworkInfo{
+			NetName:  types.DefaultNetworkName,
+			Topology: types.Layer3Topology,
+		}, nil
+	}
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+	if err != nil {
+		return nil, err
+	}
+	if namespace == "" {
+		return nil, fmt.Errorf("network %s must be given as <namespace>/<name>", nadName)
+	}
+	nad, err := nadClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(namespace).Get(context.TODO(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("network attachment definition %s not found, err: %v", nadName, err)
+	}
+	netconf, err := util.ParseNetConf(nad)
+	if err != nil {
+		return nil, err
+	}
+	if netconf.Name == types.DefaultNetworkName {
+		return getNetworkInfo(nadClient, "")
+	}
+	klog.V(5).Infof("==> Network attachment definition %s belongs to network %s with topology %s", nadName, netconf.Name, netconf.Topology)
+
+	return &NetworkInfo{
+		NADName:  nadName,
+		NetName:  netconf.Name,
+		Topology: netconf.Topology,
+		Prefix:   util.GetSecondaryNetworkPrefix(netconf.Name),
+	}, nil
+}
+
 // getOvnNamespace searches all namespaces for pods with the label selector app=ovnkube-node.
 // If it can find such pods, it returns the namespace that they reside in, or error otherwise.
 func getOvnNamespace(coreclient *corev1client.CoreV1Client, override string) (string, error) {
@@ -689,105 +906,123 @@ func getDatabaseURIs(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 
 // printSuccessOrFailure will print a success or failure message. If searchString is set, then we expect to find a match for the
 // regexp given in searchString.
-func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) {
+func printSuccessOrFailure(commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) error {
+	return reportSuccessOrFailure(&TraceStep{Command: commandDescription, Src: src, Dst: dst}, commandStdout, commandStderr, err, searchString)
+}
+
+// reportSuccessOrFailure reports the success or failure of the given trace step, either as a message or, in JSON output
+// mode, by adding it to the trace result. It returns an error wrapping errTraceFailed if the step failed.
+func reportSuccessOrFailure(step *TraceStep, commandStdout, commandStderr string, err error, searchString string) error {
+	commandDescription, src, dst := step.Command, step.Src, step.Dst
 	if err != nil {
-		klog.Exitf("%s error %v stdOut: %s\n stdErr: %s", commandDescription, err, commandStdout, commandStderr)
+		if jsonOutput {
+			step.Error = fmt.Sprintf("%v, stdErr: %s", err, commandStderr)
+			recordTraceStep(step, commandStdout)
+		}
+		return fmt.Errorf("%w: %s error %v stdOut: %s\n stdErr: %s", errTraceFailed, commandDescription, err, commandStdout, commandStderr)
 	}
 	klog.V(2).Infof("%s Output:\n%s%s%s\n", commandDescription, italic, commandStdout, reset)
 
+	step.Success = true
 	if searchString != "" {
 		match, err := regexp.MatchString(searchString, commandStdout)
 		if err != nil {
-			klog.Exitf("Unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
+			return fmt.Errorf("unexpected failure matching regex '%s' to commandStdout '%s', err: %s", searchString, commandStdout, err)
 		}
+		step.Success = match
 		if match {
-			// Write the result to stdout.
-			fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
 			// Log further info on log level 1.
 			klog.V(1).Infof("%sSearch string matched:\n%s%s\n", green, searchString, reset)
 		} else {
-			// Write the result to stdout.
-			fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, src, dst, reset)
 			// Log further info on log level 1.
 			klog.V(1).Infof("%sSearch string not matched:\n%s%s\n", red, searchString, reset)
-			os.Exit(-1)
 		}
-	} else {
+	}
+
+	if jsonOutput {
+		recordTraceStep(step, commandStdout)
+	} else if step.Success {
 		// Write the result to stdout.
 		fmt.Printf("%s%s%s indicates success from %s to %s%s\n", green, bold, commandDescription, src, dst, reset)
+	} else {
+		fmt.Printf("%s%s%s indicates failure from %s to %s%s\n", red, bold, commandDescription, src, dst, reset)
+		if step.DropCause != nil && step.DropCause.Object.Owner != nil {
+			fmt.Printf("%s%s dropped by %s %s owned by %s%s\n", red, commandDescription, step.DropCause.Object.Table,
+				step.DropCause.Object.UUID, step.DropCause.Object.Owner, reset)
+		}
+	}
+	if !step.Success {
+		return fmt.Errorf("%w: %s from %s to %s", errTraceFailed, commandDescription, src, dst)
 	}
+	return nil
 }
 
 // runOvnTraceToService runs an ovntrace from src pod to dst service. If dstSvcInfo == nil, then skip all steps.
-func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, ovnNamespace, protocol, dstPort string) {
-	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
-	if srcPodInfo.HostNetwork {
-		inport = srcPodInfo.K8sNodeNamePort
-	}
+func runOvnTraceToService(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstSvcInfo *SvcInfo, ovnNamespace, protocol, dstPort string) error {
 	svcL3Ver := dstSvcInfo.getL3Ver()
 	if srcPodInfo.IPVer != svcL3Ver {
-		klog.Exitf("Pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
+		return fmt.Errorf("pod src IP address family (address: %s) and service IP address family (address: %s) do not match",
 			srcPodInfo.IP, dstSvcInfo.ClusterIP)
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s --ct=new `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888' --lb-dst %[12]s:%[13]s`,
-		srcPodInfo.SbCommand,  // 1
-		srcPodInfo.NodeName,   // 2
-		inport,                // 3
-		srcPodInfo.MAC,        // 4
-		srcPodInfo.RtosMAC,    // 5
-		srcPodInfo.IPVer,      // 6
-		srcPodInfo.IP,         // 7
-		svcL3Ver,              // 8
-		dstSvcInfo.ClusterIP,  // 9
-		protocol,              // 10
-		dstPort,               // 11
-		dstSvcInfo.PodInfo.IP, // 12
-		dstSvcInfo.PodPort,    // 13
+		srcPodInfo.SbCommand,     // 1
+		srcPodInfo.LogicalSwitch, // 2
+		srcPodInfo.LogicalPort,   // 3
+		srcPodInfo.MAC,           // 4
+		srcPodInfo.RtosMAC,       // 5
+		srcPodInfo.IPVer,         // 6
+		srcPodInfo.IP,            // 7
+		svcL3Ver,                 // 8
+		dstSvcInfo.ClusterIP,     // 9
+		protocol,                 // 10
+		dstPort,                  // 11
+		dstSvcInfo.PodInfo.IP,    // 12
+		dstSvcInfo.PodPort,       // 13
 	)
 	klog.V(4).Infof("ovn-trace command from src to service clusterIP is %s", cmd)
 
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
 	var successString string
 	if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstSvcInfo.PodInfo) {
-		successString = fmt.Sprintf(`output to "%s"`, dstSvcInfo.FullyQualifiedPodName())
+		successString = fmt.Sprintf(`output to "%s"`, dstSvcInfo.PodInfo.LogicalPort)
 	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstSvcInfo.PodInfo.NodeName)
+		successString = fmt.Sprintf(`output to "%s"`, srcPodInfo.Network.remoteZoneEgressPort(dstSvcInfo.PodInfo))
 	}
 	direction := "source pod to service clusterIP"
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
-	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort)
-
+	if err := printOvnTraceSuccessOrFailure(coreclient, restconfig, srcPodInfo, ovnNamespace, "ovn-trace "+direction, srcPodInfo.PodName, dstSvcInfo.SvcName, ovnSrcDstOut, ovnSrcDstErr, err, successString); err != nil {
+		return err
+	}
+	return runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstSvcInfo.PodInfo, ovnNamespace, protocol, dstPort)
 }
 
 // runOvnTraceToIP runs an ovntrace from src pod to dst IP address (should be external to the cluster).
 // Returns the node that the trace will exit on.
-func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string) {
+func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) (string, string, error) {
 	if srcPodInfo.HostNetwork {
-		klog.Exitf("Pod cannot be on Host Network when tracing to an IP address; use ping\n")
+		return "", "", fmt.Errorf("pod cannot be on Host Network when tracing to an IP address; use ping")
 	}
 
 	l3ver := getIPVer(parsedDstIP)
 
 	if srcPodInfo.IPVer != l3ver {
-		klog.Exitf("Pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
+		return "", "", fmt.Errorf("pod src IP address family (address: %s) and destination IP address family (address: %s) do not match",
 			srcPodInfo.IP, parsedDstIP)
 	}
 
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		srcPodInfo.SbCommand,               // 1
-		srcPodInfo.NodeName,                // 2
-		srcPodInfo.FullyQualifiedPodName(), // 3
-		srcPodInfo.MAC,                     // 4
-		srcPodInfo.RtosMAC,                 // 5
-		l3ver,                              // 6
-		srcPodInfo.IP,                      // 7
-		l3ver,                              // 8
-		parsedDstIP,                        // 9
-		protocol,                           // 10
-		dstPort,                            // 11
+		srcPodInfo.SbCommand,     // 1
+		srcPodInfo.LogicalSwitch, // 2
+		srcPodInfo.LogicalPort,   // 3
+		srcPodInfo.MAC,           // 4
+		srcPodInfo.RtosMAC,       // 5
+		l3ver,                    // 6
+		srcPodInfo.IP,            // 7
+		l3ver,                    // 8
+		parsedDstIP,              // 9
+		protocol,                 // 10
+		dstPort,                  // 11
 	)
 	klog.V(4).Infof("ovn-trace command from pod to IP is %s", cmd)
 
@@ -798,7 +1033,9 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	successString := fmt.Sprintf(`output to "(.*)_(.*)", type "localnet"|output to "k8s-%s"|remote`, srcPodInfo.NodeName)
 	// Run the command and check if succesString was found.
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	if err := printOvnTraceSuccessOrFailure(coreclient, restconfig, srcPodInfo, ovnNamespace, "ovn-trace from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString); err != nil {
+		return "", "", err
+	}
 
 	// Print some additional information about the node where this request leaves from as well
 	// as the SNAT IP address.
@@ -812,13 +1049,13 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 		subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 		// We should never hit this (printSuccessOrFailure checks the same already above).
 		if len(subMatches) < 3 {
-			klog.Exitf("Could not determine the output port for this trace command, subMatches: %q\n", subMatches)
+			return "", "", fmt.Errorf("could not determine the output port for this trace command, subMatches: %q", subMatches)
 		}
 		node := subMatches[len(subMatches)-1]
 		bridgeName := subMatches[len(subMatches)-2]
 		klog.V(1).Infof("%sout on node %s via Logical_Switch_Port %s with SNAT %s%s\n", green, node, bridgeName, snat, reset)
 
-		return string(node), string(bridgeName)
+		return string(node), string(bridgeName), nil
 	}
 
 	// Try to find egress node name when ovnSrcDstOut contains "output to tstor-<egress-node>"".
@@ -828,7 +1065,7 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	if len(subMatches) > 1 {
 		node := subMatches[len(subMatches)-1]
 		klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
-		return string(node), ""
+		return string(node), "", nil
 	}
 
 	klog.V(5).Infof("Could not find SNAT for this trace command, this must be routingViaHost gateway mode without EgressIP.")
@@ -836,33 +1073,28 @@ func runOvnTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Con
 	re = regexp.MustCompile(nodeNameRegex)
 	subMatches = re.FindSubmatch([]byte(ovnSrcDstOut))
 	if len(subMatches) < 2 {
-		klog.Exitf("Could not determine node name / bridge name of egress node in runOvnTraceToIP()")
+		return "", "", fmt.Errorf("could not determine node name / bridge name of egress node in runOvnTraceToIP()")
 	}
 	node := subMatches[len(subMatches)-1]
 	klog.V(1).Infof("%sout on node %s%s\n", green, node, reset)
-	return string(node), ""
+	return string(node), "", nil
 }
 
 // runOvnTraceToPod runs an ovntrace from src pod to dst pod.
-func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
-	var inport string
-	inport = srcPodInfo.FullyQualifiedPodName()
-	if srcPodInfo.HostNetwork {
-		inport = srcPodInfo.K8sNodeNamePort
-	}
+func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) error {
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s %[2]s `+
 		`'inport=="%[3]s" && eth.src==%[4]s && eth.dst==%[5]s && %[6]s.src==%[7]s && %[8]s.dst==%[9]s && ip.ttl==64 && %[10]s.dst==%[11]s && %[10]s.src==52888'`,
-		srcPodInfo.SbCommand, // 1
-		srcPodInfo.NodeName,  // 2
-		inport,               // 3
-		srcPodInfo.MAC,       // 4
-		srcPodInfo.RtosMAC,   // 5
-		srcPodInfo.IPVer,     // 6
-		srcPodInfo.IP,        // 7
-		dstPodInfo.IPVer,     // 8
-		dstPodInfo.IP,        // 9
-		protocol,             // 10
-		dstPort,              // 11
+		srcPodInfo.SbCommand,              // 1
+		srcPodInfo.LogicalSwitch,          // 2
+		srcPodInfo.LogicalPort,            // 3
+		srcPodInfo.MAC,                    // 4
+		srcPodInfo.nextHopMAC(dstPodInfo), // 5
+		srcPodInfo.IPVer,                  // 6
+		srcPodInfo.IP,                     // 7
+		dstPodInfo.IPVer,                  // 8
+		dstPodInfo.IP,                     // 9
+		protocol,                          // 10
+		dstPort,                           // 11
 	)
 	klog.V(4).Infof("ovn-trace command from %s is %s", direction, cmd)
 
@@ -877,36 +1109,69 @@ func runOvnTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 			successString = fmt.Sprintf(`output to "%s_%s"`, srcPodInfo.NodeExternalBridgeName, srcPodInfo.NodeName)
 		}
 	} else if !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
-		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
+		successString = fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPort)
 	} else {
-		successString = fmt.Sprintf(`output to "tstor-%s"`, dstPodInfo.NodeName)
+		successString = fmt.Sprintf(`output to "%s"`, srcPodInfo.Network.remoteZoneEgressPort(dstPodInfo))
 	}
 	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
-	runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort)
+	if err := printOvnTraceSuccessOrFailure(coreclient, restconfig, srcPodInfo, ovnNamespace, "ovn-trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString); err != nil {
+		return err
+	}
+	return runOvnTraceToRemotePod(coreclient, restconfig, direction, srcPodInfo, dstPodInfo, ovnNamespace, protocol, dstPort)
 }
 
-func runOvnTraceToRemotePod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) {
+// runOvnTraceToRemotePod continues an ovn-trace that left the source pod's interconnect zone. It traces the packet
+// from the port it enters the destination pod's zone through to the destination pod, using the destination zone's SBDB.
+func runOvnTraceToRemotePod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) error {
 	if dstPodInfo.HostNetwork || !srcPodInfo.IsInterConnect || podsInSameInterconnectZone(srcPodInfo, dstPodInfo) {
-		return
+		return nil
+	}
+	// With a cluster router the packet enters the remote zone from the transit switch and is routed by the
+	// destination node's router, otherwise it is switched directly to the destination pod.
+	ethDst := dstPodInfo.MAC
+	if dstPodInfo.Network.hasRouter() {
+		ethDst = dstPodInfo.RtotsMAC
 	}
 	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s `+
 		`'inport=="%[2]s" && eth.src==%[3]s && eth.dst==%[4]s && %[5]s.src==%[6]s && %[7]s.dst==%[8]s && ip.ttl==64 && %[9]s.dst==%[10]s && %[9]s.src==52888'`,
 		dstPodInfo.SbCommand, // 1
-		types.TransitSwitchToRouterPrefix+srcPodInfo.NodeName, // 2
-		srcPodInfo.MAC,      // 3
-		dstPodInfo.RtotsMAC, // 4
-		srcPodInfo.IPVer,    // 5
-		srcPodInfo.IP,       // 6
-		dstPodInfo.IPVer,    // 7
-		dstPodInfo.IP,       // 8
-		protocol,            // 9
-		dstPort,             // 10
+		dstPodInfo.Network.remoteZoneIngressPort(srcPodInfo), // 2
+		srcPodInfo.MAC,   // 3
+		ethDst,           // 4
+		srcPodInfo.IPVer, // 5
+		srcPodInfo.IP,    // 6
+		dstPodInfo.IPVer, // 7
+		dstPodInfo.IP,    // 8
+		protocol,         // 9
+		dstPort,          // 10
 	)
 	klog.V(4).Infof("ovn-trace command on destination pod node is %s", cmd)
-	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.FullyQualifiedPodName())
-	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, dstPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+	successString := fmt.Sprintf(`output to "%s"`, dstPodInfo.LogicalPort)
+	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, dstPodInfo.OvnKubePodName, dstPodInfo.OvnKubeContainerName, cmd, "")
+	return printOvnTraceSuccessOrFailure(coreclient, restconfig, dstPodInfo, ovnNamespace, "ovn-trace (remote) "+direction, srcPodInfo.PodName, dstPodInfo.PodName, ovnSrcDstOut, ovnSrcDstErr, err, successString)
+}
+
+// runOvnTraceToIPOnRemoteNode continues an ovn-trace to an IP address that left the source pod's interconnect zone
+// through the transit switch, e.g. because of an EgressIP assigned to a node in another zone. It traces the packet
+// from the transit switch to the egress node's external port, using the egress node's SBDB.
+func runOvnTraceToIPOnRemoteNode(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo, egressNodeInfo *PodInfo, parsedDstIP net.IP, ovnNamespace, protocol, dstPort string) error {
+	l3ver := getIPVer(parsedDstIP)
+	cmd := fmt.Sprintf(`ovn-trace --no-leader-only %[1]s `+
+		`'inport=="%[2]s" && eth.src==%[3]s && eth.dst==%[4]s && %[5]s.src==%[6]s && %[5]s.dst==%[7]s && ip.ttl==64 && %[8]s.dst==%[9]s && %[8]s.src==52888'`,
+		egressNodeInfo.SbCommand,                                 // 1
+		egressNodeInfo.Network.remoteZoneIngressPort(srcPodInfo), // 2
+		srcPodInfo.MAC,          // 3
+		egressNodeInfo.RtotsMAC, // 4
+		l3ver,                   // 5
+		srcPodInfo.IP,           // 6
+		parsedDstIP,             // 7
+		protocol,                // 8
+		dstPort,                 // 9
+	)
+	klog.V(4).Infof("ovn-trace command on egress node is %s", cmd)
+	successString := fmt.Sprintf(`output to "(.*)_%[1]s", type "localnet"|output to "k8s-%[1]s"`, egressNodeInfo.NodeName)
+	ovnSrcDstOut, ovnSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, egressNodeInfo.OvnKubePodName, egressNodeInfo.OvnKubeContainerName, cmd, "")
+	return printOvnTraceSuccessOrFailure(coreclient, restconfig, egressNodeInfo, ovnNamespace, "ovn-trace (remote) from pod to IP", srcPodInfo.PodName, parsedDstIP.String(), ovnSrcDstOut, ovnSrcDstErr, err, successString)
 }
 
 func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
@@ -915,21 +1180,21 @@ func podsInSameInterconnectZone(srcPodInfo, dstPodInfo *PodInfo) bool {
 }
 
 // runOfprotoTraceToPod runs an ofproto/trace command from the src to the destination pod.
-func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) string {
+func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo, dstPodInfo *PodInfo, ovnNamespace, protocol, dstPort string) (string, error) {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, net.ParseIP(dstPodInfo.IP))
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[9]s, dl_src=%[3]s, dl_dst=%[4]s, %[10]s=%[5]s, %[11]s=%[6]s, nw_ttl=64, %[7]s_dst=%[8]s, %[7]s_src=12345"`,
-		srcPodInfo.VethName, // 1
-		protocol,            // 2
-		srcPodInfo.MAC,      // 3
-		srcPodInfo.RtosMAC,  // 4
-		srcPodInfo.IP,       // 5
-		dstPodInfo.IP,       // 6
-		protocol,            // 7
-		dstPort,             // 8
-		protocolSelector,    // 9
-		nwSrc,               // 10
-		nwDst,               // 11
+		srcPodInfo.VethName,               // 1
+		protocol,                          // 2
+		srcPodInfo.MAC,                    // 3
+		srcPodInfo.nextHopMAC(dstPodInfo), // 4
+		srcPodInfo.IP,                     // 5
+		dstPodInfo.IP,                     // 6
+		protocol,                          // 7
+		dstPort,                           // 8
+		protocolSelector,                  // 9
+		nwSrc,                             // 10
+		nwDst,                             // 11
 	)
 	klog.V(4).Infof("ovs-appctl ofproto/trace command from %s is %s", direction, cmd)
 
@@ -949,21 +1214,28 @@ func runOfprotoTraceToPod(coreclient *corev1client.CoreV1Client, restconfig *res
 		} else {
 			successString = fmt.Sprintf(`output:%s\n\nFinal flow:`, srcPodInfo.OvnK8sMp0OfportNum)
 		}
+	} else if srcPodInfo.Network.Topology == types.LocalnetTopology {
+		klog.V(5).Infof("Pods are on node: %s and node %s, connected through the localnet physical network", srcPodInfo.NodeName, dstPodInfo.NodeName)
+		// Traffic leaves br-int through the patch port to the bridge mapped to the localnet network, so look for
+		// the trace continuing on any bridge other than br-int.
+		successString = `bridge\("([^b"]|b[^r"]|br[^-"]|br-[^i"]|br-i[^n"]|br-in[^t"]|br-int[^"])[^"]*"\)`
 	} else {
 		klog.V(5).Infof("Pods are on node: %s and node %s", srcPodInfo.NodeName, dstPodInfo.NodeName)
 		successString = "-> output to kernel tunnel"
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString)
+	if err := printSuccessOrFailure("ovs-appctl ofproto/trace "+direction, srcPodInfo.PodName, dstPodInfo.PodName, appSrcDstOut, appSrcDstErr, err, successString); err != nil {
+		return "", err
+	}
 
-	return appSrcDstOut
+	return appSrcDstOut, nil
 }
 
 // runOfprotoTraceToIP runs an ofproto/trace command from the src to the destination pod.
 // egressNodeName is the exit node, as determined by an ovn-trace command that was run earlier.
 // egressBridgeName is the name of the exit bridge (for EgressIPs, EgressGW and also for routingViaOVN mode).
 // If egressBridgeName == "", then this is routingViaHost Gateway mode without an EgressIP / EgressGW.
-func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) string {
+func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, dstIP net.IP, ovnNamespace, protocol, dstPort, egressNodeName, egressBridgeName string) (string, error) {
 	protocolSelector, nwSrc, nwDst := getOfprotoIPFamilyArgs(protocol, dstIP)
 	cmd := fmt.Sprintf(`ovs-appctl ofproto/trace br-int `+
 		`"in_port=%[1]s, %[8]s, dl_src=%[3]s, dl_dst=%[4]s, %[9]s=%[5]s, %[10]s=%[6]s, nw_ttl=64, %[2]s_dst=%[7]s, %[2]s_src=12345"`,
@@ -997,9 +1269,11 @@ func runOfprotoTraceToIP(coreclient *corev1client.CoreV1Client, restconfig *rest
 		}
 	}
 	appSrcDstOut, appSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, "")
-	printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString)
+	if err := printSuccessOrFailure(fmt.Sprintf("ovs-appctl ofproto/trace %s", direction), srcPodInfo.PodName, dstIP.String(), appSrcDstOut, appSrcDstErr, err, successString); err != nil {
+		return "", err
+	}
 
-	return appSrcDstOut
+	return appSrcDstOut, nil
 }
 
 // getOfprotoIPFamilyArgs generates the protocol parameter name and the src and dst parameter names.
@@ -1061,10 +1335,9 @@ func verifyDependency(coreclient *corev1client.CoreV1Client, restconfig *rest.Co
 	return trueFalse, depVerifyErr, nil
 }
 
-// runOvnDetrace runs an ovn-detrace command for the given input.
-// Returns error if dependencies are not met (allows for graceful handling of those issues).
-func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
-	dstName string, appSrcDstOut, ovnNamespace string) error {
+// checkOvnDetrace returns an error if ovn-detrace cannot be run from the ovnkube pod of srcPodInfo, because the NBDB
+// is not reachable or its dependencies are missing (allows for graceful handling of those issues).
+func checkOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, srcPodInfo *PodInfo, ovnNamespace string) error {
 	// If NBDB connectivity is not available do not run ovn-detrace.
 	if _, stdErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, fmt.Sprintf("ovn-nbctl %s get-connection", srcPodInfo.NbCommand), ""); err != nil {
 		return fmt.Errorf("nbdb is not available %q", stdErr)
@@ -1073,6 +1346,12 @@ func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Confi
 	if err := installOvnDetraceDependencies(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
 		return fmt.Errorf("dependencies check failed: %q", err)
 	}
+	return nil
+}
+
+// runOvnDetrace runs an ovn-detrace command for the given input. checkOvnDetrace must have succeeded for srcPodInfo.
+func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, direction string, srcPodInfo *PodInfo,
+	dstName string, appSrcDstOut, ovnNamespace string) error {
 
 	cmd := fmt.Sprintf(`ovn-detrace --ovnnb=%[1]s --ovnsb=%[2]s %[3]s --ovsdb=unix:/var/run/openvswitch/db.sock`,
 		srcPodInfo.NbURI,       // 1
@@ -1082,17 +1361,15 @@ func runOvnDetrace(coreclient *corev1client.CoreV1Client, restconfig *rest.Confi
 	klog.V(4).Infof("ovn-detrace command from %s is %s", direction, cmd)
 
 	dtraceSrcDstOut, dtraceSrcDstErr, err := execInPod(coreclient, restconfig, ovnNamespace, srcPodInfo.OvnKubePodName, srcPodInfo.OvnKubeContainerName, cmd, appSrcDstOut)
-	printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
-
-	return nil
+	return printSuccessOrFailure("ovn-detrace "+direction, srcPodInfo.PodName, dstName, dtraceSrcDstOut, dtraceSrcDstErr, err, "")
 }
 
 // displayNodeInfo shows a summary about nodes in this cluster.
-func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
+func displayNodeInfo(coreclient *corev1client.CoreV1Client) error {
 	// List all Nodes.
 	nodes, err := coreclient.Nodes().List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		return err
 	}
 
 	masters := make(map[string]string)
@@ -1130,6 +1407,7 @@ func displayNodeInfo(coreclient *corev1client.CoreV1Client) {
 	if len(masters) < 3 {
 		klog.V(5).Infof("Cluster does not have 3 masters, found %d", len(masters))
 	}
+	return nil
 }
 
 func getDesiredPodIP(pod *kapi.Pod, addressFamily string) (string, error) {
@@ -1150,17 +1428,35 @@ func getIPVer(ip net.IP) string {
 }
 
 // setLogLevel sets the log level for this application.
-func setLogLevel(loglevel string) {
+func setLogLevel(loglevel string) error {
 	klog.InitFlags(nil)
 	klog.SetOutput(os.Stderr)
 	err := level.Set(loglevel)
 	if err != nil {
-		klog.Exitf("fatal: cannot set logging level\n")
+		return fmt.Errorf("cannot set logging level: %v", err)
 	}
 	klog.V(1).Infof("Log level set to: %s", loglevel)
+	return nil
 }
 
 func main() {
+	err := run()
+	if jsonOutput {
+		if writeErr := writeTraceResult(os.Stdout, err); writeErr != nil {
+			klog.Exitf("Failed to write trace result: %v", writeErr)
+		}
+		if err != nil {
+			os.Exit(-1)
+		}
+	}
+	if err != nil {
+		klog.Exit(err)
+	}
+}
+
+// run runs the traces requested on the command line. It returns an error wrapping errTraceFailed if a trace does not
+// reach its destination, or any other error that stopped the trace.
+func run() error {
 	var protocol string
 	var parsedDstIP net.IP
 	var err error
@@ -1171,6 +1467,7 @@ func main() {
 	srcNamespace := flag.String("src-namespace", "default", "k8s namespace of source pod")
 	dstNamespace := flag.String("dst-namespace", "default", "k8s namespace of dest pod")
 	srcPodName := flag.String("src", "", "src: source pod name")
+	srcNodeName := flag.String("src-node", "", "src-node: source node name, traces from the node's host network")
 	dstPodName := flag.String("dst", "", "dest: destination pod name")
 	dstSvcName := flag.String("service", "", "service: destination service name")
 	dstIP := flag.String("dst-ip", "", "destination IP address (meant for tests to external targets)")
@@ -1178,29 +1475,44 @@ func main() {
 	tcp := flag.Bool("tcp", false, "use tcp transport protocol")
 	udp := flag.Bool("udp", false, "use udp transport protocol")
 	addressFamily := flag.String("addr-family", ip4, "Address family (ip4 or ip6) to be used for tracing")
+	output := flag.String("output", outputText, "output format of the trace result (text or json)")
+	network := flag.String("network", "", "network attachment definition (<namespace>/<name>) of the secondary network to trace, the default network if unset")
 	skipOvnDetrace := flag.Bool("skip-detrace", false, "skip ovn-detrace command")
 	loglevel := flag.String("loglevel", "0", "loglevel: klog level")
 	flag.Parse()
 
+	// Verify CLI flags, starting with the output format so that the other errors are reported in it.
+	switch *output {
+	case outputText:
+	case outputJSON:
+		jsonOutput = true
+	default:
+		return fmt.Errorf("usage: output must be one of %s or %s", outputText, outputJSON)
+	}
+
 	// Set the application's log level.
-	setLogLevel(*loglevel)
+	if err := setLogLevel(*loglevel); err != nil {
+		return err
+	}
 
-	// Verify CLI flags.
-	if *srcPodName == "" {
-		klog.Exitf("Usage: source pod must be specified")
+	if *srcPodName == "" && *srcNodeName == "" {
+		return fmt.Errorf("usage: either source pod or source node must be specified")
+	}
+	if *srcPodName != "" && *srcNodeName != "" {
+		return fmt.Errorf("usage: both source pod and source node cannot be specified at the same time")
 	}
 	if !*tcp && !*udp {
-		klog.Exitf("Usage: either tcp or udp must be specified")
+		return fmt.Errorf("usage: either tcp or udp must be specified")
 	}
 	if *udp && *tcp {
-		klog.Exitf("Usage: Both tcp and udp cannot be specified at the same time")
+		return fmt.Errorf("usage: both tcp and udp cannot be specified at the same time")
 	}
 	if *tcp {
 		protocol = "tcp"
 	}
 	if *udp {
 		if *dstSvcName != "" {
-			klog.Exitf("Usage: udp option is not compatible with destination service trace")
+			return fmt.Errorf("usage: udp option is not compatible with destination service trace")
 		}
 		protocol = "udp"
 	}
@@ -1215,11 +1527,19 @@ func main() {
 		targetOptions++
 		parsedDstIP = net.ParseIP(*dstIP)
 		if parsedDstIP == nil {
-			klog.Exitf("Usage: cannot parse IP address provided in -dst-ip")
+			return fmt.Errorf("usage: cannot parse IP address provided in -dst-ip")
 		}
 	}
 	if targetOptions != 1 {
-		klog.Exitf("Usage: exactly one of -dst, -service or -dst-ip must be set")
+		return fmt.Errorf("usage: exactly one of -dst, -service or -dst-ip must be set")
+	}
+	if *network != "" {
+		if *srcNodeName != "" {
+			return fmt.Errorf("usage: -src-node option is not compatible with secondary network trace")
+		}
+		if *dstPodName == "" {
+			return fmt.Errorf("usage: only -dst is compatible with secondary network trace")
+		}
 	}
 
 	// Get the ClientConfig.
@@ -1230,7 +1550,7 @@ func main() {
 		// use the current context in kubeconfig
 		restconfig, err = clientcmd.BuildConfigFromFlags("", *cliConfig)
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			return fmt.Errorf("unexpected error: %v", err)
 		}
 	} else {
 		// Instantiate loader for kubeconfig file.
@@ -1243,48 +1563,89 @@ func main() {
 		// the client objects we create.
 		restconfig, err = kubeconfig.ClientConfig()
 		if err != nil {
-			klog.Exitf(" Unexpected error: %v", err)
+			return fmt.Errorf("unexpected error: %v", err)
 		}
 	}
 
 	// Create a Kubernetes core/v1 client.
 	coreclient, err := corev1client.NewForConfig(restconfig)
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		return fmt.Errorf("unexpected error: %v", err)
+	}
+
+	// Create a network attachment definition client.
+	nadClient, err := nadclientset.NewForConfig(restconfig)
+	if err != nil {
+		return fmt.Errorf("unexpected error: %v", err)
 	}
 
 	// Get the namespace that OVN pods reside in.
 	ovnNamespace, err := getOvnNamespace(coreclient, *cfgNamespace)
 	if err != nil {
-		klog.Exitf(" Unexpected error: %v", err)
+		return fmt.Errorf("unexpected error: %v", err)
 	}
 	klog.V(5).Infof("OVN Kubernetes namespace is %s", ovnNamespace)
 
 	// Show some information about the nodes in this cluster - only if log level 5 or higher.
 	if lvl, err := strconv.Atoi(*loglevel); err == nil && lvl >= 5 {
-		displayNodeInfo(coreclient)
+		if err := displayNodeInfo(coreclient); err != nil {
+			return err
+		}
 	}
 
-	// Get info needed for the src Pod
-	srcPodInfo, err := getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, *addressFamily)
+	// Get the network to trace on.
+	networkInfo, err := getNetworkInfo(nadClient, *network)
 	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *srcPodName, err)
+		return fmt.Errorf("failed to get information about network %s: %v", *network, err)
+	}
+
+	// Get info needed for the src Pod, or the src node when tracing from the host network.
+	var srcPodInfo *PodInfo
+	if *srcNodeName != "" {
+		srcPodInfo, err = getNodeInfo(coreclient, restconfig, *srcNodeName, ovnNamespace, *addressFamily, networkInfo)
+		if err != nil {
+			return fmt.Errorf("failed to get information from node %s: %v", *srcNodeName, err)
+		}
+	} else {
+		srcPodInfo, err = getPodInfo(coreclient, restconfig, *srcPodName, ovnNamespace, *srcNamespace, *addressFamily, networkInfo)
+		if err != nil {
+			return fmt.Errorf("failed to get information from pod %s: %v", *srcPodName, err)
+		}
 	}
 	klog.V(5).Infof("srcPodInfo is %s\n", srcPodInfo)
 
 	// 1) Either run a trace from source pod to destination IP and return ...
 	if parsedDstIP != nil {
 		klog.V(5).Infof("Running a trace to an IP address")
-		egressNodeName, egressBridgeName := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort)
-		appSrcDstOut := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort, egressNodeName, egressBridgeName)
-		if *skipOvnDetrace {
-			return
+		egressNodeName, egressBridgeName, err := runOvnTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort)
+		if err != nil {
+			return err
+		}
+		if srcPodInfo.IsInterConnect && egressNodeName != srcPodInfo.NodeName && egressBridgeName == "" {
+			// The trace left the source zone through the transit switch, continue it in the egress node's zone.
+			egressNodeInfo, err := getNodeInfo(coreclient, restconfig, egressNodeName, ovnNamespace, *addressFamily, networkInfo)
+			if err != nil {
+				return fmt.Errorf("failed to get information from node %s: %v", egressNodeName, err)
+			}
+			if !podsInSameInterconnectZone(srcPodInfo, egressNodeInfo) {
+				err = runOvnTraceToIPOnRemoteNode(coreclient, restconfig, srcPodInfo, egressNodeInfo, parsedDstIP, ovnNamespace, protocol, *dstPort)
+				if err != nil {
+					return err
+				}
+			}
 		}
-		err = runOvnDetrace(coreclient, restconfig, "pod to external IP", srcPodInfo, parsedDstIP.String(), appSrcDstOut, ovnNamespace)
+		appSrcDstOut, err := runOfprotoTraceToIP(coreclient, restconfig, srcPodInfo, parsedDstIP, ovnNamespace, protocol, *dstPort, egressNodeName, egressBridgeName)
 		if err != nil {
+			return err
+		}
+		if *skipOvnDetrace {
+			return nil
+		}
+		if err = checkOvnDetrace(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
 			klog.Infof("Skipped ovn-detrace due to: %q", err)
+			return nil
 		}
-		return
+		return runOvnDetrace(coreclient, restconfig, "pod to external IP", srcPodInfo, parsedDstIP.String(), appSrcDstOut, ovnNamespace)
 	}
 
 	// 2) ... or run a trace to destination service / destination pod.
@@ -1293,9 +1654,9 @@ func main() {
 	var dstSvcInfo *SvcInfo
 	if *dstSvcName != "" {
 		// Get dst service
-		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily)
+		dstSvcInfo, err = getSvcInfo(coreclient, restconfig, *dstSvcName, ovnNamespace, *dstNamespace, *addressFamily, networkInfo)
 		if err != nil {
-			klog.Exitf("Failed to get information from service %s: %v", *dstSvcName, err)
+			return fmt.Errorf("failed to get information from service %s: %v", *dstSvcName, err)
 		}
 		klog.V(5).Infof("dstSvcInfo is %s\n", dstSvcInfo)
 		// Set dst pod name, we'll use this to run through pod-pod tests as if use supplied this pod
@@ -1304,40 +1665,54 @@ func main() {
 	}
 
 	// Now get info needed for the dst Pod
-	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, *addressFamily)
+	dstPodInfo, err := getPodInfo(coreclient, restconfig, *dstPodName, ovnNamespace, *dstNamespace, *addressFamily, networkInfo)
 	if err != nil {
-		klog.Exitf("Failed to get information from pod %s: %v", *dstPodName, err)
+		return fmt.Errorf("failed to get information from pod %s: %v", *dstPodName, err)
 	}
 	klog.V(5).Infof("dstPodInfo is %s\n", dstPodInfo)
 
 	// At least one pod must not be on the Host Network
 	if srcPodInfo.HostNetwork && dstPodInfo.HostNetwork {
-		klog.Exitf("Both pods cannot be on Host Network; use ping")
+		return fmt.Errorf("both pods cannot be on Host Network; use ping")
 	}
 
 	// ovn-trace commands
 	if dstSvcInfo != nil {
-		runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, ovnNamespace, protocol, *dstPort)
+		if err = runOvnTraceToService(coreclient, restconfig, srcPodInfo, dstSvcInfo, ovnNamespace, protocol, *dstPort); err != nil {
+			return err
+		}
+	}
+	if err = runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort); err != nil {
+		return err
+	}
+	if err = runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort); err != nil {
+		return err
 	}
-	runOvnTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
-	runOvnTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
 
 	// ovs-appctl ofproto/trace commands
-	appSrcDstOut := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
-	appDstSrcOut := runOfprotoTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
+	appSrcDstOut, err := runOfprotoTraceToPod(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo, ovnNamespace, protocol, *dstPort)
+	if err != nil {
+		return err
+	}
+	appDstSrcOut, err := runOfprotoTraceToPod(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo, ovnNamespace, protocol, *dstPort)
+	if err != nil {
+		return err
+	}
 
 	// ovn-detrace commands below
 	if *skipOvnDetrace {
-		return
+		return nil
 	}
-	err = runOvnDetrace(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo.PodName, appSrcDstOut, ovnNamespace)
-	if err != nil {
+	if err = checkOvnDetrace(coreclient, restconfig, srcPodInfo, ovnNamespace); err != nil {
 		klog.Infof("Skipped ovn-detrace due to: %q", err)
-		return
+		return nil
 	}
-	err = runOvnDetrace(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo.PodName, appDstSrcOut, ovnNamespace)
-	if err != nil {
+	if err = runOvnDetrace(coreclient, restconfig, "source pod to destination pod", srcPodInfo, dstPodInfo.PodName, appSrcDstOut, ovnNamespace); err != nil {
+		return err
+	}
+	if err = checkOvnDetrace(coreclient, restconfig, dstPodInfo, ovnNamespace); err != nil {
 		klog.Infof("Skipped ovn-detrace due to: %q", err)
-		return
+		return nil
 	}
+	return runOvnDetrace(coreclient, restconfig, "destination pod to source pod", dstPodInfo, srcPodInfo.PodName, appDstSrcOut, ovnNamespace)
 }
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadfake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+func newTestNAD(namespace, name, config string) *nadapi.NetworkAttachmentDefinition {
+	return &nadapi.NetworkAttachmentDefinition{
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name},
+		Spec:       nadapi.NetworkAttachmentDefinitionSpec{Config: config},
+	}
+}
+
+func TestGetNetworkInfo(t *testing.T) {
+	// the NADs are created through the client, the fake clientset doesn't track the objects it is given under the
+	// resource name of the NADs
+	nadClient := nadfake.NewSimpleClientset()
+	for _, nad := range []*nadapi.NetworkAttachmentDefinition{
+		newTestNAD("ns1", "l3net", `{"cniVersion": "0.4.0", "name": "l3net", "type": "ovn-k8s-cni-overlay",
+			"topology": "layer3", "subnets": "10.128.0.0/16/24", "netAttachDefName": "ns1/l3net"}`),
+		newTestNAD("ns1", "l2net", `{"cniVersion": "0.4.0", "name": "l2net", "type": "ovn-k8s-cni-overlay",
+			"topology": "layer2", "subnets": "10.100.0.0/16", "netAttachDefName": "ns1/l2net"}`),
+		newTestNAD("ns1", "default", `{"cniVersion": "0.4.0", "name": "default", "type": "ovn-k8s-cni-overlay"}`),
+	} {
+		_, err := nadClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).Create(context.TODO(), nad,
+			metav1.CreateOptions{})
+		if err != nil {
+			t.Fatalf("failed to create network attachment definition %s/%s: %v", nad.Namespace, nad.Name, err)
+		}
+	}
+	defaultNetwork := &NetworkInfo{NetName: types.DefaultNetworkName, Topology: types.Layer3Topology}
+
+	tests := []struct {
+		desc      string
+		nadName   string
+		expected  *NetworkInfo
+		expectErr bool
+	}{
+		{
+			desc:     "default network",
+			expected: defaultNetwork,
+		},
+		{
+			desc:    "layer3 secondary network",
+			nadName: "ns1/l3net",
+			expected: &NetworkInfo{
+				NADName:  "ns1/l3net",
+				NetName:  "l3net",
+				Topology: types.Layer3Topology,
+				Prefix:   "l3net_",
+			},
+		},
+		{
+			desc:    "layer2 secondary network",
+			nadName: "ns1/l2net",
+			expected: &NetworkInfo{
+				NADName:  "ns1/l2net",
+				NetName:  "l2net",
+				Topology: types.Layer2Topology,
+				Prefix:   "l2net_",
+			},
+		},
+		{
+			desc:     "network attachment definition of the default network",
+			nadName:  "ns1/default",
+			expected: defaultNetwork,
+		},
+		{
+			desc:      "network without namespace",
+			nadName:   "l3net",
+			expectErr: true,
+		},
+		{
+			desc:      "unknown network attachment definition",
+			nadName:   "ns1/missing",
+			expectErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			network, err := getNetworkInfo(nadClient, tt.nadName)
+			if tt.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got network %+v", network)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(network, tt.expected) {
+				t.Fatalf("expected network %+v, got %+v", tt.expected, network)
+			}
+		})
+	}
+}
+
+func TestNetworkInfoNames(t *testing.T) {
+	srcPodInfo := &PodInfo{
+		NodeInfo:    NodeInfo{NodeName: "node1"},
+		LogicalPort: "ns1_client",
+		MAC:         "0a:58:0a:80:01:03",
+		RtosMAC:     "0a:58:0a:80:01:01",
+	}
+	dstPodInfo := &PodInfo{
+		NodeInfo:    NodeInfo{NodeName: "node2"},
+		LogicalPort: "ns1_server",
+		MAC:         "0a:58:0a:80:02:03",
+	}
+
+	tests := []struct {
+		desc                  string
+		network               *NetworkInfo
+		expectedSecondary     bool
+		expectedSwitch        string
+		expectedEgressPort    string
+		expectedIngressPort   string
+		expectedNextHopMAC    string
+		expectedScopedCluster string
+	}{
+		{
+			desc:                  "default network",
+			network:               &NetworkInfo{NetName: types.DefaultNetworkName, Topology: types.Layer3Topology},
+			expectedSwitch:        "node1",
+			expectedEgressPort:    types.TransitSwitchToRouterPrefix + "node2",
+			expectedIngressPort:   types.TransitSwitchToRouterPrefix + "node1",
+			expectedNextHopMAC:    srcPodInfo.RtosMAC,
+			expectedScopedCluster: types.OVNClusterRouter,
+		},
+		{
+			desc: "layer3 secondary network",
+			network: &NetworkInfo{NADName: "ns1/l3net", NetName: "l3net", Topology: types.Layer3Topology,
+				Prefix: "l3net_"},
+			expectedSecondary:     true,
+			expectedSwitch:        "l3net_node1",
+			expectedEgressPort:    "l3net_" + types.TransitSwitchToRouterPrefix + "node2",
+			expectedIngressPort:   "l3net_" + types.TransitSwitchToRouterPrefix + "node1",
+			expectedNextHopMAC:    srcPodInfo.RtosMAC,
+			expectedScopedCluster: "l3net_" + types.OVNClusterRouter,
+		},
+		{
+			desc: "layer2 secondary network",
+			network: &NetworkInfo{NADName: "ns1/l2net", NetName: "l2net", Topology: types.Layer2Topology,
+				Prefix: "l2net_"},
+			expectedSecondary:     true,
+			expectedSwitch:        "l2net_" + types.OVNLayer2Switch,
+			expectedEgressPort:    dstPodInfo.LogicalPort,
+			expectedIngressPort:   srcPodInfo.LogicalPort,
+			expectedNextHopMAC:    dstPodInfo.MAC,
+			expectedScopedCluster: "l2net_" + types.OVNClusterRouter,
+		},
+		{
+			desc: "localnet secondary network",
+			network: &NetworkInfo{NADName: "ns1/lnet", NetName: "lnet", Topology: types.LocalnetTopology,
+				Prefix: "lnet_"},
+			expectedSecondary:     true,
+			expectedSwitch:        "lnet_" + types.OVNLocalnetSwitch,
+			expectedEgressPort:    "lnet_" + types.OVNLocalnetPort,
+			expectedIngressPort:   "lnet_" + types.OVNLocalnetPort,
+			expectedNextHopMAC:    dstPodInfo.MAC,
+			expectedScopedCluster: "lnet_" + types.OVNClusterRouter,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			src := *srcPodInfo
+			src.Network = tt.network
+			if secondary := tt.network.IsSecondary(); secondary != tt.expectedSecondary {
+				t.Fatalf("expected secondary %v, got %v", tt.expectedSecondary, secondary)
+			}
+			if name := tt.network.scopedName(types.OVNClusterRouter); name != tt.expectedScopedCluster {
+				t.Fatalf("expected scoped name %s, got %s", tt.expectedScopedCluster, name)
+			}
+			if name := tt.network.switchName(src.NodeName); name != tt.expectedSwitch {
+				t.Fatalf("expected switch %s, got %s", tt.expectedSwitch, name)
+			}
+			if port := tt.network.remoteZoneEgressPort(dstPodInfo); port != tt.expectedEgressPort {
+				t.Fatalf("expected remote zone egress port %s, got %s", tt.expectedEgressPort, port)
+			}
+			if port := tt.network.remoteZoneIngressPort(&src); port != tt.expectedIngressPort {
+				t.Fatalf("expected remote zone ingress port %s, got %s", tt.expectedIngressPort, port)
+			}
+			if mac := src.nextHopMAC(dstPodInfo); mac != tt.expectedNextHopMAC {
+				t.Fatalf("expected next hop MAC %s, got %s", tt.expectedNextHopMAC, mac)
+			}
+		})
+	}
+}
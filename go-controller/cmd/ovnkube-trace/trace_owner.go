@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+)
+
+// TraceOwner is the Kubernetes object that a NB object, e.g. an ACL, was created for.
+type TraceOwner struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	RuleIndex string `json:"ruleIndex,omitempty"`
+	// Rule describes which part of the object the NB object implements, when it is not one of its rules,
+	// e.g. the default deny of the pods selected by network policies.
+	Rule string `json:"rule,omitempty"`
+}
+
+// String returns a human readable description of the owner, e.g. "NetworkPolicy default/deny-all, Ingress rule 0".
+func (owner *TraceOwner) String() string {
+	var b strings.Builder
+	b.WriteString(owner.Kind)
+	switch {
+	case owner.Namespace != "" && owner.Name != "":
+		fmt.Fprintf(&b, " %s/%s", owner.Namespace, owner.Name)
+	case owner.Namespace != "":
+		fmt.Fprintf(&b, " in namespace %s", owner.Namespace)
+	case owner.Name != "":
+		fmt.Fprintf(&b, " %s", owner.Name)
+	}
+	if owner.Direction != "" {
+		fmt.Fprintf(&b, ", %s", owner.Direction)
+	}
+	if owner.RuleIndex != "" {
+		fmt.Fprintf(&b, " rule %s", owner.RuleIndex)
+	}
+	if owner.Rule != "" {
+		fmt.Fprintf(&b, " (%s)", owner.Rule)
+	}
+	return b.String()
+}
+
+// getTraceOwner maps the external IDs set through libovsdbops.DbObjectIDs back to the Kubernetes object that owns
+// the NB object. It returns nil if the object is not owned by a known ovn-kubernetes controller.
+func getTraceOwner(externalIDs map[string]string) *TraceOwner {
+	ownerType := externalIDs[libovsdbops.OwnerTypeKey.String()]
+	name := externalIDs[libovsdbops.ObjectNameKey.String()]
+	direction := externalIDs[libovsdbops.PolicyDirectionKey.String()]
+	gressIdx := externalIDs[libovsdbops.GressIdxKey.String()]
+
+	switch ownerType {
+	case string(libovsdbops.NetworkPolicyOwnerType), string(libovsdbops.NetworkPolicyPortIndexOwnerType):
+		// name is the policy's <namespace>:<name>, see getACLPolicyKey
+		namespace, policyName, _ := strings.Cut(name, ":")
+		return &TraceOwner{
+			Kind:      "NetworkPolicy",
+			Namespace: namespace,
+			Name:      policyName,
+			Direction: direction,
+			RuleIndex: gressIdx,
+		}
+	case string(libovsdbops.NetpolNamespaceOwnerType):
+		// name is the namespace of the policies
+		return &TraceOwner{
+			Kind:      "NetworkPolicy",
+			Namespace: name,
+			Direction: direction,
+			Rule:      externalIDs[libovsdbops.TypeKey.String()],
+		}
+	case string(libovsdbops.NetpolDefaultOwnerType):
+		return &TraceOwner{
+			Kind:      "NetworkPolicy",
+			Direction: direction,
+			Rule:      name,
+		}
+	case string(libovsdbops.NetpolNodeOwnerType):
+		return &TraceOwner{
+			Kind: "Node",
+			Name: name,
+			Rule: "allow from management port " + externalIDs[libovsdbops.IpKey.String()],
+		}
+	case string(libovsdbops.AdminNetworkPolicyOwnerType):
+		return &TraceOwner{
+			Kind:      "AdminNetworkPolicy",
+			Name:      name,
+			Direction: direction,
+			RuleIndex: gressIdx,
+		}
+	case string(libovsdbops.BaselineAdminNetworkPolicyOwnerType):
+		return &TraceOwner{
+			Kind:      "BaselineAdminNetworkPolicy",
+			Name:      name,
+			Direction: direction,
+			RuleIndex: gressIdx,
+		}
+	case string(libovsdbops.EgressFirewallOwnerType):
+		// name is the namespace, there can only be one EgressFirewall per namespace
+		return &TraceOwner{
+			Kind:      "EgressFirewall",
+			Namespace: name,
+			Name:      "default",
+			RuleIndex: externalIDs[libovsdbops.RuleIndex.String()],
+		}
+	case string(libovsdbops.MulticastNamespaceOwnerType):
+		return &TraceOwner{
+			Kind:      "Namespace",
+			Name:      name,
+			Direction: direction,
+			Rule:      "multicast",
+		}
+	case string(libovsdbops.MulticastClusterOwnerType):
+		return &TraceOwner{
+			Kind:      "Multicast",
+			Direction: direction,
+			Rule:      externalIDs[libovsdbops.TypeKey.String()],
+		}
+	default:
+		return nil
+	}
+}
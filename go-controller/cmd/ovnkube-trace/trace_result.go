@@ -0,0 +1,327 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+
+	corev1client "k8s.io/client-go/kubernetes/typed/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// Output formats.
+	outputText = "text"
+	outputJSON = "json"
+
+	// Trace verdicts.
+	verdictDelivered = "delivered"
+	verdictDropped   = "dropped"
+	verdictUnknown   = "unknown"
+
+	// Prefix of the external IDs that ovn-kubernetes stamps on the NB objects it owns.
+	ovnKubeExternalIDsPrefix = "k8s.ovn.org/"
+)
+
+var (
+	// jsonOutput is set when the trace result should be emitted as JSON instead of human readable text.
+	jsonOutput bool
+	// traceResult collects the result of every trace command when jsonOutput is set.
+	traceResult = &TraceResult{}
+	// errTraceFailed is returned when a trace command does not reach its destination.
+	errTraceFailed = errors.New("trace failed")
+
+	ovnTraceDatapathRegex = regexp.MustCompile(`^(ingress|egress)\(dp="([^"]+)"`)
+	ovnTraceHopRegex      = regexp.MustCompile(`^\s*(\d+)\. (\S+) \(([^)]*)\): (.*), priority (\d+), uuid ([0-9a-f]+)$`)
+	ovnTraceOutputRegex   = regexp.MustCompile(`output to "([^"]+)"`)
+	ofprotoTraceDropRegex = regexp.MustCompile(`Datapath actions: drop`)
+)
+
+// TraceResult is the machine readable result of an ovnkube-trace run.
+type TraceResult struct {
+	Success bool         `json:"success"`
+	Steps   []*TraceStep `json:"steps"`
+	Error   string       `json:"error,omitempty"` // the error that stopped the trace before a step failed, if any
+}
+
+// TraceStep is the result of a single trace command, e.g. an ovn-trace from the source to the destination pod.
+type TraceStep struct {
+	Command string    `json:"command"`
+	Src     string    `json:"src"`
+	Dst     string    `json:"dst"`
+	Success bool      `json:"success"`
+	Verdict string    `json:"verdict"`
+	Output  string    `json:"output,omitempty"`    // the last logical port the packet was output to, for ovn-trace
+	DropHop *TraceHop `json:"droppedBy,omitempty"` // the logical flow that dropped the packet, for ovn-trace
+	// DropCause is the logical flow of the ACL or router policy that caused the drop, for ovn-trace.
+	DropCause *TraceHop   `json:"dropCause,omitempty"`
+	Hops      []*TraceHop `json:"hops,omitempty"` // the logical flows the packet hit, for ovn-trace
+	Error     string      `json:"error,omitempty"`
+}
+
+// TraceHop is a logical flow hit by the packet in an ovn-trace.
+type TraceHop struct {
+	Pipeline string       `json:"pipeline"` // ingress or egress
+	Datapath string       `json:"datapath"` // logical switch or router
+	Table    int          `json:"table"`
+	Stage    string       `json:"stage"`
+	Match    string       `json:"match"`
+	Priority int          `json:"priority"`
+	FlowUUID string       `json:"flowUUID"` // abbreviated UUID of the SB Logical_Flow
+	Actions  []string     `json:"actions,omitempty"`
+	Object   *TraceObject `json:"object,omitempty"` // the NB object (ACL, Load_Balancer or NAT) that the flow was created for
+}
+
+// TraceObject is a NB object that a logical flow was created for, with the ovn-kubernetes external IDs of its owner.
+type TraceObject struct {
+	Table       string            `json:"table"`
+	UUID        string            `json:"uuid"`
+	ExternalIDs map[string]string `json:"externalIDs,omitempty"`
+	Owner       *TraceOwner       `json:"owner,omitempty"` // the Kubernetes object the NB object was created for
+}
+
+// isDrop returns true if the hop's actions drop the packet.
+func (hop *TraceHop) isDrop() bool {
+	for _, action := range hop.Actions {
+		if action == "drop;" || action == "/* drop */" {
+			return true
+		}
+	}
+	return false
+}
+
+// nbTables returns the NB tables that the logical flow of this hop may have been created for, based on its stage.
+func (hop *TraceHop) nbTables() []string {
+	switch {
+	case strings.Contains(hop.Stage, "_acl"):
+		return []string{"ACL"}
+	case strings.Contains(hop.Stage, "_lb"):
+		return []string{"Load_Balancer"}
+	case strings.Contains(hop.Stage, "nat"):
+		return []string{"NAT", "Load_Balancer"}
+	case strings.Contains(hop.Stage, "_policy"):
+		return []string{"Logical_Router_Policy"}
+	default:
+		return nil
+	}
+}
+
+// isPolicyStage returns true if the hop is in a stage that may decide to drop the packet on behalf of an ACL or a
+// router policy.
+func (hop *TraceHop) isPolicyStage() bool {
+	return strings.Contains(hop.Stage, "_acl") || strings.Contains(hop.Stage, "_policy")
+}
+
+// parseOvnTraceHops parses the detailed output of ovn-trace into the list of logical flows hit by the packet.
+func parseOvnTraceHops(ovnTraceOutput string) []*TraceHop {
+	var hops []*TraceHop
+	var pipeline, datapath string
+	var hop *TraceHop
+
+	scanner := bufio.NewScanner(strings.NewReader(ovnTraceOutput))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if match := ovnTraceDatapathRegex.FindStringSubmatch(line); match != nil {
+			pipeline, datapath = match[1], match[2]
+			hop = nil
+			continue
+		}
+		if match := ovnTraceHopRegex.FindStringSubmatch(line); match != nil {
+			table, _ := strconv.Atoi(match[1])
+			priority, _ := strconv.Atoi(match[5])
+			hop = &TraceHop{
+				Pipeline: pipeline,
+				Datapath: datapath,
+				Table:    table,
+				Stage:    match[2],
+				Match:    match[4],
+				Priority: priority,
+				FlowUUID: match[6],
+			}
+			hops = append(hops, hop)
+			continue
+		}
+		// Actions are indented below their logical flow, anything else ends the flow.
+		if hop == nil || !strings.HasPrefix(line, "    ") {
+			hop = nil
+			continue
+		}
+		if action := strings.TrimSpace(line); action != "" {
+			hop.Actions = append(hop.Actions, action)
+		}
+	}
+
+	return hops
+}
+
+// parseCtlMapColumn parses the output of `ovn-[ns]bctl --format=json --columns=<map column> list <table> <record>`
+// into the map held by the column.
+func parseCtlMapColumn(ctlOutput string) (map[string]string, error) {
+	var table struct {
+		Data [][]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(ctlOutput), &table); err != nil {
+		return nil, err
+	}
+	if len(table.Data) != 1 || len(table.Data[0]) != 1 {
+		return nil, fmt.Errorf("expected exactly one row with one column, got %s", ctlOutput)
+	}
+	// A map column is encoded as ["map", [[key, value], ...]].
+	var column []json.RawMessage
+	if err := json.Unmarshal(table.Data[0][0], &column); err != nil {
+		return nil, err
+	}
+	if len(column) != 2 {
+		return nil, fmt.Errorf("unexpected map column encoding %s", table.Data[0][0])
+	}
+	var pairs [][]string
+	if err := json.Unmarshal(column[1], &pairs); err != nil {
+		return nil, err
+	}
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		if len(pair) == 2 {
+			result[pair[0]] = pair[1]
+		}
+	}
+	return result, nil
+}
+
+// resolveTraceHopObjects resolves the NB object of every ACL, load balancer, NAT or router policy hop.
+func resolveTraceHopObjects(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace string, hops []*TraceHop) {
+	for _, hop := range hops {
+		resolveTraceHopObject(coreclient, restconfig, podInfo, ovnNamespace, hop)
+	}
+}
+
+// resolveTraceHopObject looks up the NB object that the logical flow of an ACL, load balancer, NAT or router policy
+// hop was created for, through the stage-hint of the SB Logical_Flow, and records the object's ovn-kubernetes external
+// IDs and owner. Hops that cannot be resolved are left as they are.
+func resolveTraceHopObject(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace string, hop *TraceHop) {
+	tables := hop.nbTables()
+	if len(tables) == 0 || hop.Object != nil {
+		return
+	}
+	cmd := fmt.Sprintf("ovn-sbctl --no-leader-only %s --format=json --columns=external_ids list Logical_Flow %s", podInfo.SbCommand, hop.FlowUUID)
+	stdout, stderr, err := execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+	if err != nil {
+		klog.V(5).Infof("Could not find logical flow %s: %v, stderr: %s", hop.FlowUUID, err, stderr)
+		return
+	}
+	flowExternalIDs, err := parseCtlMapColumn(stdout)
+	if err != nil {
+		klog.V(5).Infof("Could not parse external IDs of logical flow %s: %v", hop.FlowUUID, err)
+		return
+	}
+	stageHint := flowExternalIDs["stage-hint"]
+	if stageHint == "" {
+		return
+	}
+	for _, table := range tables {
+		cmd = fmt.Sprintf("ovn-nbctl --no-leader-only %s --format=json --columns=external_ids list %s %s", podInfo.NbCommand, table, stageHint)
+		stdout, _, err = execInPod(coreclient, restconfig, ovnNamespace, podInfo.OvnKubePodName, podInfo.OvnKubeContainerName, cmd, "")
+		if err != nil {
+			continue
+		}
+		externalIDs, err := parseCtlMapColumn(stdout)
+		if err != nil {
+			continue
+		}
+		hop.Object = &TraceObject{
+			Table:       table,
+			UUID:        stageHint,
+			ExternalIDs: map[string]string{},
+			Owner:       getTraceOwner(externalIDs),
+		}
+		for key, value := range externalIDs {
+			if strings.HasPrefix(key, ovnKubeExternalIDsPrefix) {
+				hop.Object.ExternalIDs[key] = value
+			}
+		}
+		return
+	}
+}
+
+// findDropCause returns the hop that dropped the packet, if any, and the hop of the ACL or router policy that
+// decided to drop it. With tiered ACLs the drop action is applied in a later stage than the one of the ACL that
+// matched, so the latter is the closest resolvable ACL or policy hop before the drop.
+func findDropCause(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace string, hops []*TraceHop) (*TraceHop, *TraceHop) {
+	dropIdx := -1
+	for i, hop := range hops {
+		if hop.isDrop() {
+			dropIdx = i
+			break
+		}
+	}
+	if dropIdx < 0 {
+		return nil, nil
+	}
+	for i := dropIdx; i >= 0; i-- {
+		if !hops[i].isPolicyStage() {
+			continue
+		}
+		resolveTraceHopObject(coreclient, restconfig, podInfo, ovnNamespace, hops[i])
+		if hops[i].Object != nil && (hops[i].Object.Table == "ACL" || hops[i].Object.Table == "Logical_Router_Policy") {
+			return hops[dropIdx], hops[i]
+		}
+	}
+	return hops[dropIdx], nil
+}
+
+// printOvnTraceSuccessOrFailure is printSuccessOrFailure for ovn-trace commands run against the databases of
+// podInfo. In JSON output mode, the logical flows hit by the packet are added to the trace result. When the packet
+// is dropped, the ACL or policy that dropped it is resolved to the Kubernetes object that owns it.
+func printOvnTraceSuccessOrFailure(coreclient *corev1client.CoreV1Client, restconfig *rest.Config, podInfo *PodInfo, ovnNamespace,
+	commandDescription, src, dst, commandStdout, commandStderr string, err error, searchString string) error {
+	step := &TraceStep{Command: commandDescription, Src: src, Dst: dst}
+	if err == nil {
+		step.Hops = parseOvnTraceHops(commandStdout)
+		if jsonOutput {
+			resolveTraceHopObjects(coreclient, restconfig, podInfo, ovnNamespace, step.Hops)
+		}
+		if outputs := ovnTraceOutputRegex.FindAllStringSubmatch(commandStdout, -1); len(outputs) > 0 {
+			step.Output = outputs[len(outputs)-1][1]
+		}
+		if match, _ := regexp.MatchString(searchString, commandStdout); !match {
+			step.DropHop, step.DropCause = findDropCause(coreclient, restconfig, podInfo, ovnNamespace, step.Hops)
+		}
+	}
+	return reportSuccessOrFailure(step, commandStdout, commandStderr, err, searchString)
+}
+
+// recordTraceStep adds the step to the trace result with its verdict.
+func recordTraceStep(step *TraceStep, commandStdout string) {
+	switch {
+	case step.Success:
+		step.Verdict = verdictDelivered
+	case step.DropHop != nil, ofprotoTraceDropRegex.MatchString(commandStdout):
+		step.Verdict = verdictDropped
+	default:
+		step.Verdict = verdictUnknown
+	}
+	traceResult.Steps = append(traceResult.Steps, step)
+}
+
+// writeTraceResult writes the trace result as JSON to w. traceErr is the error that stopped the trace, if any; the
+// failure of a trace step is already part of the step.
+func writeTraceResult(w io.Writer, traceErr error) error {
+	traceResult.Success = traceErr == nil
+	if traceErr != nil && !errors.Is(traceErr, errTraceFailed) {
+		traceResult.Error = traceErr.Error()
+	}
+	for _, step := range traceResult.Steps {
+		traceResult.Success = traceResult.Success && step.Success
+	}
+	b, err := json.MarshalIndent(traceResult, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal trace result: %v", err)
+	}
+	_, err = fmt.Fprintln(w, string(b))
+	return err
+}
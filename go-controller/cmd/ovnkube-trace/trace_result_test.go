@@ -0,0 +1,219 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseOvnTraceHops(t *testing.T) {
+	output := `# udp,reg14=0x3,vlan_tci=0x0000,dl_src=0a:58:0a:f4:02:03,dl_dst=0a:58:0a:f4:02:01,nw_src=10.244.2.3,nw_dst=10.244.1.6
+
+ingress(dp="ovn-worker2", inport="default_client")
+--------------------------------------------------
+ 0. ls_in_check_port_sec (northd.c:8583): 1, priority 50, uuid de664d3a
+    reg0[15] = check_in_port_sec();
+    next;
+ 9. ls_in_acl_action (northd.c:6764): reg8[30..31] == 0, priority 500, uuid 3eec76bb
+    reg8[30..31] = 1;
+    next(8);
+
+egress(dp="ovn-worker2", inport="default_client", outport="default_server")
+---------------------------------------------------------------------------
+ 5. ls_out_acl_action (northd.c:6764): reg8[17] == 1, priority 1000, uuid ea58bb8e
+    drop;
+`
+	expected := []*TraceHop{
+		{
+			Pipeline: "ingress",
+			Datapath: "ovn-worker2",
+			Table:    0,
+			Stage:    "ls_in_check_port_sec",
+			Match:    "1",
+			Priority: 50,
+			FlowUUID: "de664d3a",
+			Actions:  []string{"reg0[15] = check_in_port_sec();", "next;"},
+		},
+		{
+			Pipeline: "ingress",
+			Datapath: "ovn-worker2",
+			Table:    9,
+			Stage:    "ls_in_acl_action",
+			Match:    "reg8[30..31] == 0",
+			Priority: 500,
+			FlowUUID: "3eec76bb",
+			Actions:  []string{"reg8[30..31] = 1;", "next(8);"},
+		},
+		{
+			Pipeline: "egress",
+			Datapath: "ovn-worker2",
+			Table:    5,
+			Stage:    "ls_out_acl_action",
+			Match:    "reg8[17] == 1",
+			Priority: 1000,
+			FlowUUID: "ea58bb8e",
+			Actions:  []string{"drop;"},
+		},
+	}
+
+	hops := parseOvnTraceHops(output)
+	if !reflect.DeepEqual(hops, expected) {
+		t.Fatalf("expected hops %+v, got %+v", expected, hops)
+	}
+	if hops[1].isDrop() || !hops[2].isDrop() {
+		t.Fatalf("unexpected drop detection for hops %+v", hops)
+	}
+}
+
+func TestParseCtlMapColumn(t *testing.T) {
+	output := `{"data":[[["map",[["k8s.ovn.org/name","allow-dns"],["k8s.ovn.org/owner-type","NetworkPolicy"],["direction","Ingress"]]]]],"headings":["external_ids"]}`
+	expected := map[string]string{
+		"k8s.ovn.org/name":       "allow-dns",
+		"k8s.ovn.org/owner-type": "NetworkPolicy",
+		"direction":              "Ingress",
+	}
+
+	result, err := parseCtlMapColumn(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(result, expected) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if _, err = parseCtlMapColumn(`{"data":[],"headings":["external_ids"]}`); err == nil {
+		t.Fatalf("expected an error when no row is found")
+	}
+}
+
+func TestTraceResultOnFailure(t *testing.T) {
+	defer func() {
+		jsonOutput = false
+		traceResult = &TraceResult{}
+	}()
+
+	tests := []struct {
+		desc          string
+		stderr        string
+		err           error
+		stdout        string
+		expectVerdict string
+	}{
+		{
+			desc:          "trace command fails",
+			stderr:        "connection refused",
+			err:           errors.New("command terminated with exit code 1"),
+			expectVerdict: verdictUnknown,
+		},
+		{
+			desc:          "packet is dropped",
+			stdout:        "Datapath actions: drop",
+			expectVerdict: verdictDropped,
+		},
+	}
+	for _, tt := range tests {
+		jsonOutput = true
+		traceResult = &TraceResult{}
+		step := &TraceStep{Command: "ovs-appctl ofproto/trace", Src: "client", Dst: "server"}
+		err := reportSuccessOrFailure(step, tt.stdout, tt.stderr, tt.err, "output:3")
+		if !errors.Is(err, errTraceFailed) {
+			t.Fatalf("%s: expected a trace failure, got %v", tt.desc, err)
+		}
+
+		var b bytes.Buffer
+		if err = writeTraceResult(&b, err); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.desc, err)
+		}
+		result := &TraceResult{}
+		if err = json.Unmarshal(b.Bytes(), result); err != nil {
+			t.Fatalf("%s: failed to unmarshal %s: %v", tt.desc, b.String(), err)
+		}
+		if result.Success || result.Error != "" || len(result.Steps) != 1 {
+			t.Fatalf("%s: unexpected trace result %s", tt.desc, b.String())
+		}
+		if result.Steps[0].Success || result.Steps[0].Verdict != tt.expectVerdict {
+			t.Fatalf("%s: unexpected trace step %s", tt.desc, b.String())
+		}
+		if tt.err != nil && !strings.Contains(result.Steps[0].Error, tt.stderr) {
+			t.Fatalf("%s: expected the step error to contain %q, got %q", tt.desc, tt.stderr, result.Steps[0].Error)
+		}
+	}
+
+	// An error that stops the trace before any step is reported in the result.
+	traceResult = &TraceResult{}
+	var b bytes.Buffer
+	if err := writeTraceResult(&b, errors.New("failed to get information from pod client")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := &TraceResult{}
+	if err := json.Unmarshal(b.Bytes(), result); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", b.String(), err)
+	}
+	if result.Success || result.Error != "failed to get information from pod client" {
+		t.Fatalf("unexpected trace result %s", b.String())
+	}
+}
+
+func TestGetTraceOwner(t *testing.T) {
+	tests := []struct {
+		desc        string
+		externalIDs map[string]string
+		expected    string
+	}{
+		{
+			desc: "network policy rule",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "NetworkPolicy",
+				"k8s.ovn.org/name":       "default:allow-dns",
+				"direction":              "Ingress",
+				"gress-index":            "1",
+			},
+			expected: "NetworkPolicy default/allow-dns, Ingress rule 1",
+		},
+		{
+			desc: "network policy default deny",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "NetpolNamespace",
+				"k8s.ovn.org/name":       "default",
+				"direction":              "Egress",
+				"type":                   "defaultDeny",
+			},
+			expected: "NetworkPolicy in namespace default, Egress (defaultDeny)",
+		},
+		{
+			desc: "admin network policy rule",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "AdminNetworkPolicy",
+				"k8s.ovn.org/name":       "cluster-control",
+				"direction":              "Egress",
+				"gress-index":            "0",
+			},
+			expected: "AdminNetworkPolicy cluster-control, Egress rule 0",
+		},
+		{
+			desc: "egress firewall rule",
+			externalIDs: map[string]string{
+				"k8s.ovn.org/owner-type": "EgressFirewall",
+				"k8s.ovn.org/name":       "web",
+				"rule-index":             "3",
+			},
+			expected: "EgressFirewall web/default rule 3",
+		},
+	}
+	for _, tt := range tests {
+		owner := getTraceOwner(tt.externalIDs)
+		if owner == nil {
+			t.Fatalf("%s: expected an owner", tt.desc)
+		}
+		if owner.String() != tt.expected {
+			t.Fatalf("%s: expected %q, got %q", tt.desc, tt.expected, owner.String())
+		}
+	}
+
+	if owner := getTraceOwner(map[string]string{"k8s.ovn.org/owner-type": "Unknown"}); owner != nil {
+		t.Fatalf("expected no owner for an unknown owner type, got %v", owner)
+	}
+}
@@ -26,9 +26,11 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	controllerManager "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/network-controller-manager"
 	ovnnode "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/aclverdicts"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
@@ -240,6 +242,10 @@ func determineOvnkubeRunMode(ctx *cli.Context) (*ovnkubeRunMode, error) {
 		return nil, fmt.Errorf("cannot run in both cluster manager and node mode")
 	}
 
+	if config.OVNKubernetesFeature.EnableShadowMode && (!mode.ovnkubeController || mode.clusterManager || mode.node) {
+		return nil, fmt.Errorf("shadow mode is only supported by ovnkube controller running alone")
+	}
+
 	identities := sets.NewString(master, cm, ovnkController, node, cleanup)
 	identities.Delete("")
 	if identities.Len() != 1 {
@@ -321,6 +327,12 @@ func startOvnKube(ctx *cli.Context, cancel context.CancelFunc) error {
 		return runOvnKube(ctx.Context, runMode, ovnClientset, eventRecorder)
 	}
 
+	// a shadow ovnkube-controller doesn't take the lease of the active one
+	if config.OVNKubernetesFeature.EnableShadowMode {
+		metrics.RegisterOVNKubeControllerBase()
+		return runOvnKube(ctx.Context, runMode, ovnClientset, eventRecorder)
+	}
+
 	// Register prometheus metrics that do not depend on becoming ovnkube-controller
 	// leader and get the proper HA config depending on the mode. For ovnkube
 	// controller mode or combined cluster manager and ovnkube-controller modes (the classic
@@ -434,6 +446,18 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 		return ovnnode.CleanupClusterNode(runMode.identity)
 	}
 
+	var cancel context.CancelFunc
+	ctx, cancel = context.WithCancel(ctx)
+	defer cancel()
+
+	if runMode.node && runMode.ovnkubeController && config.OVNKubernetesFeature.EnableInterconnect {
+		// the gateway mode of the node can be changed as the ovnkube-controller of its zone runs in this process,
+		// ovnkube is stopped to be restarted in the requested gateway mode
+		if err := ovnnode.ApplyNodeGatewayMode(ovnClientset.KubeClient, runMode.identity, cancel); err != nil {
+			return err
+		}
+	}
+
 	watchFactory, err := newWatchFactory(runMode, ovnClientset)
 	if err != nil {
 		return fmt.Errorf("failed to initialize watch factory: %w", err)
@@ -442,8 +466,6 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 	// there might be dependencies across components when starting so run them
 	// in separate threads
 	wg := &sync.WaitGroup{}
-	var cancel context.CancelFunc
-	ctx, cancel = context.WithCancel(ctx)
 	var managerErr, controllerErr, nodeErr error
 
 	if runMode.clusterManager {
@@ -497,6 +519,24 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 				return
 			}
 
+			if config.OVNKubernetesFeature.EnableShadowMode {
+				libovsdbops.EnableShadowMode(libovsdbOvnNBClient, libovsdbops.DefaultShadowMaxOperations)
+				libovsdbops.EnableShadowMode(libovsdbOvnSBClient, libovsdbops.DefaultShadowMaxOperations)
+				metrics.RegisterShadowModeMetrics()
+			}
+			if runMode.node && config.Metrics.EnableACLHitMetrics && config.OvnKubeNode.Mode != types.NodeModeDPUHost &&
+				config.Metrics.OVNMetricsBindAddress != "" {
+				// the ACLs and logical flows are read from the zone databases the controller is connected to
+				metrics.RegisterACLHitMetrics(libovsdbOvnNBClient, libovsdbOvnSBClient,
+					config.Metrics.ACLHitMetricsMaxObjects, ctx.Done())
+			}
+			if config.OVNKubernetesFeature.NBTxnCoalescingWindow > 0 {
+				metrics.RegisterNBTxnCoalescingMetrics()
+				libovsdbops.EnableTransactionCoalescing(libovsdbOvnNBClient,
+					time.Duration(config.OVNKubernetesFeature.NBTxnCoalescingWindow)*time.Millisecond,
+					config.OVNKubernetesFeature.NBTxnCoalescingMaxOperations, metrics.RecordNBTxnBatch, ctx.Done())
+			}
+
 			networkControllerManager, err := controllerManager.NewNetworkControllerManager(
 				ovnClientset,
 				watchFactory,
@@ -568,6 +608,26 @@ func runOvnKube(ctx context.Context, runMode *ovnkubeRunMode, ovnClientset *util
 			metrics.RegisterOvsMetricsWithOvnMetrics(ctx.Done())
 		}
 		metrics.RegisterOvnMetrics(ovnClientset.KubeClient, runMode.identity, ctx.Done())
+		if runMode.node && config.Metrics.EnablePodMetrics {
+			metrics.RegisterPodMetrics(strings.Split(config.Metrics.PodMetricsLabels, ","), ctx.Done())
+		}
+		if runMode.node && !runMode.ovnkubeController && config.Metrics.EnableACLHitMetrics {
+			klog.Warningf("ACL hit metrics are only counted on the nodes running ovnkube-controller for their zone")
+		}
+		if runMode.node && (config.Metrics.EnableACLVerdictsStream || config.Metrics.EnableACLVerdictMetrics) {
+			if config.Metrics.EnableACLVerdictMetrics {
+				metrics.RegisterACLVerdictMetrics(config.Metrics.ACLVerdictMetricsMaxObjects)
+			}
+			aclVerdicts := aclverdicts.NewStream(config.Metrics.ACLVerdictsLogFile, config.Metrics.EnableACLVerdictMetrics)
+			if config.Metrics.EnableACLVerdictsStream {
+				metrics.SetACLVerdictsSource(aclVerdicts.Subscribe)
+			}
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				aclVerdicts.Run(ctx.Done())
+			}()
+		}
 		metrics.StartOVNMetricsServer(config.Metrics.OVNMetricsBindAddress,
 			config.Metrics.NodeServerCert, config.Metrics.NodeServerPrivKey, ctx.Done(), wg)
 	}
@@ -18,6 +18,7 @@ import (
 // identified by a name. Allocator should be threadsafe.
 type Allocator interface {
 	AddOrUpdateSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error
+	ExpandSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error
 	DeleteSubnet(name string)
 	GetSubnets(name string) ([]*net.IPNet, error)
 	AllocateUntilFull(name string) error
@@ -97,6 +98,52 @@ func (allocator *allocator) AddOrUpdateSubnet(name string, subnets []*net.IPNet,
 		ipams:   ipams,
 	}
 
+	return excludeSubnetsFromIPAMs(name, subnets, ipams, excludeSubnets)
+}
+
+// ExpandSubnet adds subnets and exclude subnets to an existing subnet set. The
+// IPAM of the subnets already in the set is kept along with its allocations;
+// subnets can't be removed from the set.
+func (allocator *allocator) ExpandSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
+	allocator.Lock()
+	defer allocator.Unlock()
+	current, ok := allocator.cache[name]
+	if !ok {
+		return fmt.Errorf("failed to expand subnets of %s: %w", name, ErrSubnetNotFound)
+	}
+
+	currentIPAMs := make(map[string]ipallocator.Interface, len(current.subnets))
+	for i, subnet := range current.subnets {
+		currentIPAMs[subnet.String()] = current.ipams[i]
+	}
+	ipams := make([]ipallocator.Interface, 0, len(subnets))
+	for _, subnet := range subnets {
+		if ipam, ok := currentIPAMs[subnet.String()]; ok {
+			ipams = append(ipams, ipam)
+			delete(currentIPAMs, subnet.String())
+			continue
+		}
+		ipam, err := allocator.ipamFunc(subnet)
+		if err != nil {
+			return fmt.Errorf("failed to initialize IPAM of subnet %s for %s: %w", subnet, name, err)
+		}
+		ipams = append(ipams, ipam)
+	}
+	for subnet := range currentIPAMs {
+		return fmt.Errorf("failed to expand subnets of %s: subnet %s can't be removed", name, subnet)
+	}
+
+	if err := excludeSubnetsFromIPAMs(name, subnets, ipams, excludeSubnets); err != nil {
+		return err
+	}
+	allocator.cache[name] = subnetInfo{
+		subnets: subnets,
+		ipams:   ipams,
+	}
+	return nil
+}
+
+func excludeSubnetsFromIPAMs(name string, subnets []*net.IPNet, ipams []ipallocator.Interface, excludeSubnets []*net.IPNet) error {
 	for _, excludeSubnet := range excludeSubnets {
 		var excluded bool
 		for i, subnet := range subnets {
@@ -89,6 +89,27 @@ var _ = ginkgo.Describe("Subnet IP allocator operations", func() {
 			}
 		})
 
+		ginkgo.It("expands the subnets keeping the existing allocations", func() {
+			subnetName := "subnet1"
+			err := allocator.AddOrUpdateSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/24"))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ips, err := allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ips).To(gomega.Equal(ovntest.MustParseIPNets("10.1.1.1/24")))
+
+			err = allocator.ExpandSubnet(subnetName, ovntest.MustParseIPNets("10.1.1.0/24", "10.1.2.0/24"),
+				ovntest.MustParseIPNets("10.1.1.2/31", "10.1.2.0/30")...)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			ips, err = allocator.AllocateNextIPs(subnetName)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(ips).To(gomega.Equal(ovntest.MustParseIPNets("10.1.1.4/24", "10.1.2.4/24")))
+
+			err = allocator.ExpandSubnet(subnetName, ovntest.MustParseIPNets("10.1.2.0/24"))
+			gomega.Expect(err).To(gomega.HaveOccurred())
+		})
+
 	})
 
 	ginkgo.Context("when allocating IP addresses", func() {
@@ -45,6 +45,8 @@ type ClusterManager struct {
 	// used for leader election
 	identity      string
 	statusManager *status_manager.StatusManager
+	// networkProgrammingTracker aggregates the network programming duration reported by the zones
+	networkProgrammingTracker *networkProgrammingTracker
 }
 
 // NewClusterManager creates a new cluster manager to manage the cluster nodes.
@@ -104,6 +106,9 @@ func NewClusterManager(ovnClient *util.OVNClusterManagerClientset, wf *factory.W
 			return nil, err
 		}
 	}
+	if config.Metrics.EnableConfigDuration {
+		cm.networkProgrammingTracker = newNetworkProgrammingTracker(wf, ovnClient.KubeClient)
+	}
 	if config.Kubernetes.OVNEmptyLbEvents {
 		if _, err := unidling.NewUnidledAtController(&kube.Kube{KClient: ovnClient.KubeClient}, wf.ServiceInformer()); err != nil {
 			return nil, err
@@ -151,6 +156,12 @@ func (cm *ClusterManager) Start(ctx context.Context) error {
 		return err
 	}
 
+	if config.Metrics.EnableConfigDuration {
+		if err := cm.networkProgrammingTracker.Start(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -169,4 +180,7 @@ func (cm *ClusterManager) Stop() {
 		cm.egressServiceController.Stop()
 	}
 	cm.statusManager.Stop()
+	if config.Metrics.EnableConfigDuration {
+		cm.networkProgrammingTracker.Stop()
+	}
 }
@@ -462,6 +462,18 @@ func (eIPC *egressIPClusterController) Start() error {
 	return nil
 }
 
+// checkEgressNodeHealthChecks warns if egress IP health checks are requested on the node while its reachability is
+// not probed with gRPC: the node reports the combined result of its checks through the gRPC health check only.
+func checkEgressNodeHealthChecks(node *v1.Node) {
+	if _, ok := node.Annotations[util.OvnNodeEgressIPHealthChecks]; !ok {
+		return
+	}
+	if config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout == 0 || config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort == 0 {
+		klog.Warningf("Ignoring the egress IP health checks requested on node %s: they require the egress IP node "+
+			"reachability to be checked with gRPC", node.Name)
+	}
+}
+
 // WatchEgressNodes starts the watching of egress assignable nodes and calls
 // back the appropriate handler logic.
 func (eIPC *egressIPClusterController) WatchEgressNodes() (*factory.Handler, error) {
@@ -1833,6 +1833,65 @@ var _ = ginkgo.Describe("OVN cluster-manager EgressIP Operations", func() {
 			table.Entry("Secondary host egress IPs", "0:0:1:0:0:fecf:c0a8:8e0d", "0:0:1:0:0:febf:c0a8:8e0f"),
 			table.Entry("OVN and secondary host egress IPs", "0:0:1:0:0:fecf:c0a8:8e0d", "0:0:0:0:0:feff:c0a8:8e0f"))
 
+		ginkgo.It("should assign the egress IPs of a secondary network hosted by the OVN network", func() {
+			app.Action = func(ctx *cli.Context) error {
+				ovnEgressIP := "0:0:0:0:0:feff:c0a8:8e0d"
+				node1IPv6OVN := "0:0:0:0:0:feff:c0a8:8e0c/64"
+				node1IPv6SecondaryHost := "0:0:1:0:0:feff:c0a8:8e0c/64"
+
+				node1 := v1.Node{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: node1Name,
+						Annotations: map[string]string{
+							"k8s.ovn.org/node-primary-ifaddr": fmt.Sprintf("{\"ipv6\": \"%s\"}", node1IPv6OVN),
+							"k8s.ovn.org/node-subnets":        fmt.Sprintf("{\"default\":[\"%s\", \"%s\"]}", v4NodeSubnet, v6NodeSubnet),
+							util.OVNNodeHostCIDRs:             fmt.Sprintf("[\"%s\",\"%s\"]", node1IPv6OVN, node1IPv6SecondaryHost),
+						},
+						Labels: map[string]string{
+							"k8s.ovn.org/egress-assignable": "",
+						},
+					},
+					Status: v1.NodeStatus{
+						Conditions: []v1.NodeCondition{
+							{
+								Type:   v1.NodeReady,
+								Status: v1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				eIP := egressipv1.EgressIP{
+					ObjectMeta: newEgressIPMeta(egressIPName),
+					Spec: egressipv1.EgressIPSpec{
+						EgressIPs: []string{ovnEgressIP},
+						Network:   "blue/l3-network",
+					},
+				}
+
+				fakeClusterManagerOVN.start(
+					&v1.NodeList{Items: []v1.Node{node1}},
+					&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+				)
+
+				egressNode1 := setupNode(node1Name, []string{node1IPv6OVN}, map[string]string{})
+				fakeClusterManagerOVN.eIPC.allocator.cache[egressNode1.name] = &egressNode1
+
+				assignedStatuses := fakeClusterManagerOVN.eIPC.assignEgressIPs(eIP.Name, eIP.Spec.EgressIPs)
+				gomega.Expect(assignedStatuses).To(gomega.HaveLen(1))
+				gomega.Expect(assignedStatuses[0].Node).To(gomega.Equal(egressNode1.name))
+				gomega.Expect(assignedStatuses[0].EgressIP).To(gomega.Equal(net.ParseIP(ovnEgressIP).String()))
+
+				valid, invalid := fakeClusterManagerOVN.eIPC.validateEgressIPStatus(eIP.Name, assignedStatuses)
+				gomega.Expect(valid).To(gomega.HaveLen(1))
+				gomega.Expect(invalid).To(gomega.BeEmpty())
+				return nil
+			}
+
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+
 		table.DescribeTable("should be able to allocate several EgressIPs and avoid the same node and leave one un-assigned without error", func(egressIP1, egressIP2, egressIP3 string) {
 			app.Action = func(ctx *cli.Context) error {
 				node1IPv4OVN := ""
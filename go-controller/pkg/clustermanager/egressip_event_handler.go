@@ -44,6 +44,7 @@ func (h *egressIPClusterControllerEventHandler) AddResource(obj interface{}, fro
 		if err := h.eIPC.initEgressIPAllocator(node); err != nil {
 			klog.Warningf("Egress node initialization error: %v", err)
 		}
+		checkEgressNodeHealthChecks(node)
 		nodeEgressLabel := util.GetNodeEgressLabel()
 		nodeLabels := node.GetLabels()
 		_, hasEgressLabel := nodeLabels[nodeEgressLabel]
@@ -99,6 +100,9 @@ func (h *egressIPClusterControllerEventHandler) UpdateResource(oldObj, newObj in
 		if err := h.eIPC.initEgressIPAllocator(newNode); err != nil {
 			klog.Warningf("Egress node initialization error: %v", err)
 		}
+		if oldNode.Annotations[util.OvnNodeEgressIPHealthChecks] != newNode.Annotations[util.OvnNodeEgressIPHealthChecks] {
+			checkEgressNodeHealthChecks(newNode)
+		}
 		nodeEgressLabel := util.GetNodeEgressLabel()
 		oldLabels := oldNode.GetLabels()
 		newLabels := newNode.GetLabels()
@@ -9,6 +9,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	cache "k8s.io/client-go/tools/cache"
 	"k8s.io/client-go/util/retry"
 	"k8s.io/klog/v2"
@@ -174,6 +175,39 @@ func (ncc *networkClusterController) Start(ctx context.Context) error {
 	return nil
 }
 
+// Reconfigure applies the changes of the network configuration that can be
+// applied live: the subnets added to the network are added to the node subnet
+// and pod IP allocators.
+func (ncc *networkClusterController) Reconfigure(netInfo util.BasicNetInfo) error {
+	currentSubnets := sets.New[string]()
+	for _, subnet := range ncc.Subnets() {
+		currentSubnets.Insert(subnet.String())
+	}
+	if err := util.UpdateNetInfo(ncc.NetInfo, netInfo); err != nil {
+		return err
+	}
+
+	if ncc.nodeAllocator != nil {
+		var addedSubnets []config.CIDRNetworkEntry
+		for _, subnet := range ncc.Subnets() {
+			if !currentSubnets.Has(subnet.String()) {
+				addedSubnets = append(addedSubnets, subnet)
+			}
+		}
+		if err := ncc.nodeAllocator.AddClusterSubnets(addedSubnets); err != nil {
+			return fmt.Errorf("failed to add subnets to host subnet allocator: %w", err)
+		}
+	}
+
+	if ncc.podAllocator != nil {
+		if err := ncc.podAllocator.Reconfigure(); err != nil {
+			return fmt.Errorf("failed to add subnets to pod ip allocator: %w", err)
+		}
+	}
+
+	return nil
+}
+
 func (ncc *networkClusterController) Stop() {
 	close(ncc.stopChan)
 	ncc.wg.Wait()
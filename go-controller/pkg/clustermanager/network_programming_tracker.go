@@ -0,0 +1,276 @@
+package clustermanager
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/clustermanager/status_manager/zone_tracker"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// networkProgrammingMatchWindow is the maximum difference between the times the zones started to apply a change
+	// for their reports to be considered as the same change
+	networkProgrammingMatchWindow = time.Minute
+	// networkProgrammingMaxAge is the time after which a change not reported by all the zones is considered
+	// incomplete, it matches the lifetime of the measurements of the zones
+	networkProgrammingMaxAge = 20 * time.Minute
+)
+
+// networkProgrammingChange is a change of an object being applied by the zones
+type networkProgrammingChange struct {
+	start, end time.Time
+	zones      sets.Set[string]
+	// expiresAt is the time after which the change is considered incomplete
+	expiresAt time.Time
+}
+
+// networkProgrammingTracker aggregates the network programming duration of the objects measured by every zone and
+// reported on the ConfigMap of the zone labelled k8s.ovn.org/network-programming-reports. A change is complete
+// when every zone reported it, its duration is from the earliest start to the latest end of the zones.
+// As the times are measured by different zones, the duration is subject to the clock skew between the zones.
+type networkProgrammingTracker struct {
+	lock sync.Mutex
+	// zones are the zones expected to report every change
+	zones sets.Set[string]
+	// zoneEpochs and zoneSeqs are the epoch and the last processed report sequence of each zone, the sequence is
+	// reset when the reporter of the zone restarts with a new epoch
+	zoneEpochs map[string]int64
+	zoneSeqs   map[string]uint64
+	// changes are the changes that were not reported by every zone yet, by kind/namespace/name, oldest first
+	changes map[string][]*networkProgrammingChange
+
+	recordDuration   func(kind string, duration float64)
+	recordIncomplete func(kind string)
+
+	stopChan          chan struct{}
+	wg                *sync.WaitGroup
+	informerFactory   informers.SharedInformerFactory
+	configMapLister   corelisters.ConfigMapLister
+	configMapInformer cache.SharedIndexInformer
+	reportsController controller.Controller
+	zoneTracker       *zone_tracker.ZoneTracker
+}
+
+func newNetworkProgrammingTracker(wf *factory.WatchFactory, client kubernetes.Interface) *networkProgrammingTracker {
+	// only watch the ConfigMaps holding the reports of the zones
+	informerFactory := informers.NewSharedInformerFactoryWithOptions(client, 0,
+		informers.WithNamespace(config.Kubernetes.OVNConfigNamespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = util.NetworkProgrammingReportsLabel
+		}))
+	configMapInformer := informerFactory.Core().V1().ConfigMaps()
+	t := &networkProgrammingTracker{
+		zones:             sets.New[string](),
+		zoneEpochs:        map[string]int64{},
+		zoneSeqs:          map[string]uint64{},
+		changes:           map[string][]*networkProgrammingChange{},
+		recordDuration:    metrics.RecordNetworkProgrammingDuration,
+		recordIncomplete:  metrics.RecordNetworkProgrammingIncomplete,
+		stopChan:          make(chan struct{}),
+		wg:                &sync.WaitGroup{},
+		informerFactory:   informerFactory,
+		configMapLister:   configMapInformer.Lister(),
+		configMapInformer: configMapInformer.Informer(),
+	}
+	controllerConfig := &controller.Config[corev1.ConfigMap]{
+		RateLimiter:    workqueue.NewItemFastSlowRateLimiter(time.Second, 5*time.Second, 5),
+		Informer:       t.configMapInformer,
+		Lister:         t.configMapLister.List,
+		ObjNeedsUpdate: t.needsUpdate,
+		Reconcile:      t.reconcileConfigMap,
+	}
+	t.reportsController = controller.NewController[corev1.ConfigMap]("network_programming_tracker", controllerConfig)
+	t.zoneTracker = zone_tracker.NewZoneTracker(wf.NodeCoreInformer(), t.onZonesUpdate)
+	return t
+}
+
+func (t *networkProgrammingTracker) Start() error {
+	if err := t.zoneTracker.Start(); err != nil {
+		return err
+	}
+	t.informerFactory.Start(t.stopChan)
+	if err := t.reportsController.Start(1); err != nil {
+		return fmt.Errorf("failed to start network programming tracker: %w", err)
+	}
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+		for {
+			select {
+			case now := <-ticker.C:
+				t.expireChanges(now)
+			case <-t.stopChan:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (t *networkProgrammingTracker) Stop() {
+	close(t.stopChan)
+	t.wg.Wait()
+	t.reportsController.Stop()
+	t.informerFactory.Shutdown()
+	t.zoneTracker.Stop()
+}
+
+func (t *networkProgrammingTracker) needsUpdate(oldConfigMap, newConfigMap *corev1.ConfigMap) bool {
+	if oldConfigMap == nil || newConfigMap == nil {
+		return true
+	}
+	return util.NetworkProgrammingReportsChanged(oldConfigMap, newConfigMap)
+}
+
+func (t *networkProgrammingTracker) reconcileConfigMap(key string) error {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+	configMap, err := t.configMapLister.ConfigMaps(namespace).Get(name)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	reports, err := util.ParseNetworkProgrammingReports(configMap)
+	if err != nil {
+		// the ConfigMap won't be fixed by retrying
+		klog.Errorf("Network programming tracker: %v", err)
+		return nil
+	}
+	if reports != nil {
+		t.addReports(reports, time.Now())
+	}
+	return nil
+}
+
+// onZonesUpdate completes the changes that were waiting for the reports of the zones that were removed
+func (t *networkProgrammingTracker) onZonesUpdate(zones sets.Set[string]) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.zones = zones
+	for key := range t.changes {
+		t.completeChanges(key)
+	}
+}
+
+// addReports adds the reports of a zone that were not processed yet. Reports of changes that already expired are
+// ignored, e.g. the reports left in the ConfigMaps when cluster-manager restarts.
+func (t *networkProgrammingTracker) addReports(reports *util.NetworkProgrammingReports, now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.zoneEpochs[reports.Zone] != reports.Epoch {
+		t.zoneEpochs[reports.Zone] = reports.Epoch
+		t.zoneSeqs[reports.Zone] = 0
+	}
+	for _, report := range reports.Reports {
+		if report.Seq <= t.zoneSeqs[reports.Zone] {
+			continue
+		}
+		t.zoneSeqs[reports.Zone] = report.Seq
+		if now.Sub(report.Start) >= networkProgrammingMaxAge {
+			continue
+		}
+		t.addReport(reports.Zone, report, now)
+	}
+}
+
+// addReport adds the report of a zone to the oldest change of the object the zone didn't report yet and which
+// started close enough, or to a new change. Must be called with lock.
+func (t *networkProgrammingTracker) addReport(zone string, report util.NetworkProgrammingReport, now time.Time) {
+	key := fmt.Sprintf("%s/%s/%s", report.Kind, report.Namespace, report.Name)
+	var change *networkProgrammingChange
+	for _, c := range t.changes[key] {
+		if !c.zones.Has(zone) && report.Start.Sub(c.start).Abs() <= networkProgrammingMatchWindow {
+			change = c
+			break
+		}
+	}
+	if change == nil {
+		change = &networkProgrammingChange{
+			start:     report.Start,
+			end:       report.End,
+			zones:     sets.New[string](),
+			expiresAt: now.Add(networkProgrammingMaxAge),
+		}
+		t.changes[key] = append(t.changes[key], change)
+	}
+	change.zones.Insert(zone)
+	if report.Start.Before(change.start) {
+		change.start = report.Start
+	}
+	if report.End.After(change.end) {
+		change.end = report.End
+	}
+	t.completeChanges(key)
+}
+
+// completeChanges records the duration of the changes of an object reported by all the zones. Must be called with
+// lock.
+func (t *networkProgrammingTracker) completeChanges(key string) {
+	kind := kindFromKey(key)
+	pending := []*networkProgrammingChange{}
+	for _, change := range t.changes[key] {
+		if change.zones.IsSuperset(t.zones) {
+			t.recordDuration(kind, change.end.Sub(change.start).Seconds())
+			continue
+		}
+		pending = append(pending, change)
+	}
+	if len(pending) == 0 {
+		delete(t.changes, key)
+		return
+	}
+	t.changes[key] = pending
+}
+
+// expireChanges drops the changes that were not reported by all the zones in time
+func (t *networkProgrammingTracker) expireChanges(now time.Time) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for key, changes := range t.changes {
+		pending := []*networkProgrammingChange{}
+		for _, change := range changes {
+			if now.After(change.expiresAt) {
+				klog.V(5).Infof("Network programming tracker: change of %s was only reported by zones %v", key,
+					sets.List(change.zones))
+				t.recordIncomplete(kindFromKey(key))
+				continue
+			}
+			pending = append(pending, change)
+		}
+		if len(pending) == 0 {
+			delete(t.changes, key)
+			continue
+		}
+		t.changes[key] = pending
+	}
+}
+
+// kindFromKey returns the kind of a kind/namespace/name key
+func kindFromKey(key string) string {
+	kind, _, _ := strings.Cut(key, "/")
+	return kind
+}
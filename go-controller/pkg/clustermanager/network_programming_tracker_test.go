@@ -0,0 +1,124 @@
+package clustermanager
+
+import (
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/util/sets"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+)
+
+var _ = Describe("Network programming tracker", func() {
+	var (
+		tracker    *networkProgrammingTracker
+		durations  map[string][]float64
+		incomplete map[string]int
+		now        time.Time
+	)
+
+	// report returns a report of a service that started at the given second after now and took the given seconds
+	report := func(seq uint64, name string, start, duration int) util.NetworkProgrammingReport {
+		startTime := now.Add(time.Duration(start) * time.Second)
+		return util.NetworkProgrammingReport{
+			Seq:       seq,
+			Kind:      "service",
+			Namespace: "namespace1",
+			Name:      name,
+			Start:     startTime,
+			End:       startTime.Add(time.Duration(duration) * time.Second),
+		}
+	}
+
+	addReports := func(zone string, epoch int64, reports ...util.NetworkProgrammingReport) {
+		tracker.addReports(&util.NetworkProgrammingReports{Zone: zone, Epoch: epoch, Reports: reports}, now)
+	}
+
+	BeforeEach(func() {
+		durations = map[string][]float64{}
+		incomplete = map[string]int{}
+		now = time.Now()
+		tracker = &networkProgrammingTracker{
+			zones:      sets.New("zone1", "zone2"),
+			zoneEpochs: map[string]int64{},
+			zoneSeqs:   map[string]uint64{},
+			changes:    map[string][]*networkProgrammingChange{},
+			recordDuration: func(kind string, duration float64) {
+				durations[kind] = append(durations[kind], duration)
+			},
+			recordIncomplete: func(kind string) {
+				incomplete[kind]++
+			},
+		}
+	})
+
+	It("records the duration of a change once all the zones reported it", func() {
+		addReports("zone1", 1, report(1, "service1", 0, 2))
+		Expect(durations).To(BeEmpty())
+		addReports("zone2", 1, report(1, "service1", 1, 4))
+		Expect(durations).To(Equal(map[string][]float64{"service": {5}}))
+		Expect(tracker.changes).To(BeEmpty())
+	})
+
+	It("processes the reports held by the ConfigMap of a zone", func() {
+		indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{})
+		tracker.configMapLister = corelisters.NewConfigMapLister(indexer)
+		for _, zone := range []string{"zone1", "zone2"} {
+			configMap, err := util.NewNetworkProgrammingReportsConfigMap("ovn-kubernetes", &util.NetworkProgrammingReports{
+				Zone:    zone,
+				Epoch:   1,
+				Reports: []util.NetworkProgrammingReport{report(1, "service1", 0, 2)},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(indexer.Add(configMap)).To(Succeed())
+			Expect(tracker.reconcileConfigMap("ovn-kubernetes/" + configMap.Name)).To(Succeed())
+		}
+		Expect(durations).To(Equal(map[string][]float64{"service": {2}}))
+		// the ConfigMap of a zone that was deleted is ignored
+		deletedKey := "ovn-kubernetes/" + util.GetNetworkProgrammingReportsConfigMapName("zone3")
+		Expect(tracker.reconcileConfigMap(deletedKey)).To(Succeed())
+	})
+
+	It("matches the successive changes of an object reported by every zone in order", func() {
+		addReports("zone1", 1, report(1, "service1", 0, 1), report(2, "service1", 30, 1))
+		addReports("zone2", 1, report(1, "service1", 0, 2))
+		addReports("zone2", 1, report(1, "service1", 0, 2), report(2, "service1", 31, 3))
+		Expect(durations).To(Equal(map[string][]float64{"service": {2, 4}}))
+	})
+
+	It("does not match the changes that started too far apart", func() {
+		addReports("zone1", 1, report(1, "service1", 0, 1))
+		addReports("zone2", 1, report(1, "service1", 120, 1))
+		Expect(durations).To(BeEmpty())
+		Expect(tracker.changes["service/namespace1/service1"]).To(HaveLen(2))
+	})
+
+	It("processes the reports of a zone again when its reporter restarted", func() {
+		addReports("zone1", 1, report(1, "service1", 0, 1))
+		addReports("zone2", 1, report(1, "service1", 0, 1))
+		addReports("zone1", 2, report(1, "service2", 0, 1))
+		addReports("zone2", 1, report(1, "service1", 0, 1), report(2, "service2", 0, 1))
+		Expect(durations).To(Equal(map[string][]float64{"service": {1, 1}}))
+	})
+
+	It("completes the changes waiting for a zone that was removed", func() {
+		addReports("zone1", 1, report(1, "service1", 0, 1))
+		tracker.onZonesUpdate(sets.New("zone1"))
+		Expect(durations).To(Equal(map[string][]float64{"service": {1}}))
+	})
+
+	It("counts the changes not reported by all the zones in time as incomplete", func() {
+		addReports("zone1", 1, report(1, "service1", 0, 1))
+		tracker.expireChanges(now.Add(networkProgrammingMaxAge / 2))
+		Expect(incomplete).To(BeEmpty())
+		tracker.expireChanges(now.Add(networkProgrammingMaxAge + time.Second))
+		Expect(incomplete).To(Equal(map[string]int{"service": 1}))
+		Expect(tracker.changes).To(BeEmpty())
+		// reports of changes that already expired are ignored
+		addReports("zone2", 1, report(1, "service1", -int(networkProgrammingMaxAge.Seconds()), 1))
+		Expect(tracker.changes).To(BeEmpty())
+	})
+})
@@ -90,6 +90,24 @@ func (na *NodeAllocator) Init() error {
 	return nil
 }
 
+// AddClusterSubnets adds cluster subnets to the pool the node subnets are
+// allocated from, used when subnets are added to a network live.
+func (na *NodeAllocator) AddClusterSubnets(clusterSubnets []config.CIDRNetworkEntry) error {
+	if !na.hasNodeSubnetAllocation() {
+		return nil
+	}
+
+	for _, clusterSubnet := range clusterSubnets {
+		if err := na.clusterSubnetAllocator.AddNetworkRange(clusterSubnet.CIDR, clusterSubnet.HostSubnetLength); err != nil {
+			return err
+		}
+		klog.V(5).Infof("Added network range %s to cluster subnet allocator", clusterSubnet.CIDR)
+	}
+
+	na.recordSubnetCount()
+	return nil
+}
+
 func (na *NodeAllocator) hasHybridOverlayAllocation() bool {
 	// When config.HybridOverlay.ClusterSubnets is empty, assume the subnet allocation will be managed by an external component.
 	return config.HybridOverlay.Enabled && !na.netInfo.IsSecondary() && len(config.HybridOverlay.ClusterSubnets) > 0
@@ -323,3 +323,47 @@ func TestController_allocateNodeSubnets_ReleaseOnError(t *testing.T) {
 		t.Fatalf("Expected %d v6 allocated subnets, but got %d", v6usedBefore, v6usedAfter)
 	}
 }
+
+func TestController_AddClusterSubnets(t *testing.T) {
+	netInfo, err := util.NewNetInfo(
+		&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: "tenantred"},
+			Topology: types.Layer3Topology,
+			Subnets:  "172.16.0.0/23/24",
+		},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	na := &NodeAllocator{
+		netInfo:                netInfo,
+		clusterSubnetAllocator: NewSubnetAllocator(),
+	}
+	if err := na.Init(); err != nil {
+		t.Fatalf("Failed to initialize node allocator: %v", err)
+	}
+
+	for _, nodeName := range []string{"node1", "node2"} {
+		if _, _, err := na.allocateNodeSubnets(na.clusterSubnetAllocator, nodeName, nil, true, false); err != nil {
+			t.Fatalf("allocateNodeSubnets() for %s expected no error but got: %v", nodeName, err)
+		}
+	}
+	if _, _, err := na.allocateNodeSubnets(na.clusterSubnetAllocator, "node3", nil, true, false); err == nil {
+		t.Fatalf("allocateNodeSubnets() expected error on an exhausted cluster subnet but got success")
+	}
+
+	addedSubnets, err := rangesFromStrings([]string{"172.17.0.0/24"}, []int{24})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := na.AddClusterSubnets(addedSubnets); err != nil {
+		t.Fatalf("AddClusterSubnets() expected no error but got: %v", err)
+	}
+	_, allocated, err := na.allocateNodeSubnets(na.clusterSubnetAllocator, "node3", nil, true, false)
+	if err != nil {
+		t.Fatalf("allocateNodeSubnets() expected no error but got: %v", err)
+	}
+	if !reflect.DeepEqual(allocated, ovntest.MustParseIPNets("172.17.0.0/24")) {
+		t.Fatalf("Expected node3 to get a subnet of the added cluster subnet, got %v", allocated)
+	}
+}
@@ -92,6 +92,21 @@ func (a *PodAllocator) Init() error {
 	return nil
 }
 
+// Reconfigure adds the subnets and exclude subnets added to the network to the
+// IP allocator, keeping the IPs already allocated to the pods
+func (a *PodAllocator) Reconfigure() error {
+	if !util.DoesNetworkRequireIPAM(a.netInfo) {
+		return nil
+	}
+
+	subnets := a.netInfo.Subnets()
+	ipNets := make([]*net.IPNet, 0, len(subnets))
+	for _, subnet := range subnets {
+		ipNets = append(ipNets, subnet.CIDR)
+	}
+	return a.ipAllocator.ExpandSubnet(a.netInfo.GetNetworkName(), ipNets, a.netInfo.ExcludeSubnets()...)
+}
+
 // Reconcile allocates or releases IPs for pods updating the pod annotation
 // as necessary with all the additional information derived from those IPs
 func (a *PodAllocator) Reconcile(old, new *corev1.Pod) error {
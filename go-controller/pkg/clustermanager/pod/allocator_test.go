@@ -75,6 +75,10 @@ func (a *ipAllocatorStub) AddOrUpdateSubnet(name string, subnets []*net.IPNet, e
 	panic("not implemented") // TODO: Implement
 }
 
+func (a *ipAllocatorStub) ExpandSubnet(name string, subnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
+	panic("not implemented") // TODO: Implement
+}
+
 func (a ipAllocatorStub) DeleteSubnet(name string) {
 	panic("not implemented") // TODO: Implement
 }
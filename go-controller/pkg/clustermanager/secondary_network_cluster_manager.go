@@ -56,6 +56,7 @@ func newSecondaryNetworkClusterManager(ovnClient *util.OVNClusterManagerClientse
 	if err != nil {
 		return nil, err
 	}
+	sncm.nadController.ReportAcceptedStatus()
 	return sncm, nil
 }
 
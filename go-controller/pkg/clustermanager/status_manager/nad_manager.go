@@ -0,0 +1,119 @@
+package status_manager
+
+import (
+	"fmt"
+	"strings"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadclientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
+	nadinformers "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/informers/externalversions"
+	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// nadManager aggregates the status of the network topology of the net-attach-defs reported by every zone
+// into the TopologyReady condition. Zones report their status with annotations instead of status messages,
+// since net-attach-defs have no status.
+type nadManager struct {
+	lister nadlisters.NetworkAttachmentDefinitionLister
+	client nadclientset.Interface
+}
+
+func newNADManager(lister nadlisters.NetworkAttachmentDefinitionLister, client nadclientset.Interface) *nadManager {
+	return &nadManager{
+		lister: lister,
+		client: client,
+	}
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives https://github.com/dominikh/go-tools/issues/1440
+func (m *nadManager) get(namespace, name string) (*nettypes.NetworkAttachmentDefinition, error) {
+	return m.lister.NetworkAttachmentDefinitions(namespace).Get(name)
+}
+
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *nadManager) getMessages(nad *nettypes.NetworkAttachmentDefinition) []string {
+	return util.GetNADZoneStatusMessages(nad)
+}
+
+// updateStatus ignores applyOpts, annotations are merge patched.
+//
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *nadManager) updateStatus(nad *nettypes.NetworkAttachmentDefinition, applyOpts *metav1.ApplyOptions,
+	applyEmptyOrFailed bool) error {
+	if nad == nil {
+		return nil
+	}
+	failedMessages := []string{}
+	for _, message := range util.GetNADZoneStatusMessages(nad) {
+		if strings.Contains(message, types.NetworkTopologyErrorMsg) {
+			failedMessages = append(failedMessages, message)
+		}
+	}
+	if len(failedMessages) == 0 && applyEmptyOrFailed {
+		return util.RemoveNADAnnotation(m.client, nad, util.OvnNetworkTopologyReadyAnnotation)
+	}
+
+	condition := metav1.Condition{
+		Type:    util.NetworkConditionTopologyReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  util.NetworkReasonTopologyCreated,
+		Message: "network topology created in all zones",
+	}
+	if len(failedMessages) > 0 {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = util.NetworkReasonTopologyFailed
+		condition.Message = strings.Join(failedMessages, "; ")
+	}
+	_, err := util.SetNADCondition(m.client, nad, util.OvnNetworkTopologyReadyAnnotation, condition)
+	return err
+}
+
+// cleanupStatus removes the status reported by the zone given as the field manager of applyOpts.
+//
+//lint:ignore U1000 generic interfaces throw false-positives
+func (m *nadManager) cleanupStatus(nad *nettypes.NetworkAttachmentDefinition, applyOpts *metav1.ApplyOptions) error {
+	return util.RemoveNADAnnotation(m.client, nad, util.GetNetworkZoneStatusAnnotation(applyOpts.FieldManager))
+}
+
+// nadStatusManager runs the status manager of the net-attach-defs with its own informer, since
+// the watch factory doesn't watch net-attach-defs.
+type nadStatusManager struct {
+	*typedStatusManager[nettypes.NetworkAttachmentDefinition]
+	nadFactory nadinformers.SharedInformerFactory
+	stopChan   chan struct{}
+}
+
+func newNADStatusManager(client nadclientset.Interface,
+	withZonesRLock func(f func(zones sets.Set[string]) error) error) *nadStatusManager {
+	nadFactory := nadinformers.NewSharedInformerFactory(client, 0)
+	nadInformer := nadFactory.K8sCniCncfIo().V1().NetworkAttachmentDefinitions()
+	return &nadStatusManager{
+		typedStatusManager: newStatusManager[nettypes.NetworkAttachmentDefinition](
+			"networkattachmentdefinitions_statusmanager",
+			nadInformer.Informer(),
+			nadInformer.Lister().List,
+			newNADManager(nadInformer.Lister(), client),
+			withZonesRLock,
+		),
+		nadFactory: nadFactory,
+		stopChan:   make(chan struct{}),
+	}
+}
+
+func (m *nadStatusManager) Start() error {
+	m.nadFactory.Start(m.stopChan)
+	if err := m.typedStatusManager.Start(); err != nil {
+		return fmt.Errorf("failed to start net-attach-def status manager: %w", err)
+	}
+	return nil
+}
+
+func (m *nadStatusManager) Stop() {
+	close(m.stopChan)
+	m.typedStatusManager.Stop()
+}
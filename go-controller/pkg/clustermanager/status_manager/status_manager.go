@@ -189,6 +189,9 @@ func NewStatusManager(wf *factory.WatchFactory, ovnClient *util.OVNClusterManage
 		)
 		sm.typedManagers["egressfirewalls"] = egressFirewallManager
 	}
+	if config.OVNKubernetesFeature.EnableMultiNetwork {
+		sm.typedManagers["networkattachmentdefinitions"] = newNADStatusManager(ovnClient.NetworkAttchDefClient, sm.withZonesRLock)
+	}
 	return sm
 }
 
@@ -7,6 +7,8 @@ import (
 	. "github.com/onsi/gomega"
 	"strings"
 
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/clustermanager/status_manager/zone_tracker"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
@@ -126,6 +128,60 @@ func checkEmptyAPBRouteStatusConsistently(apbRoute *adminpolicybasedrouteapi.Adm
 	}).Should(BeTrue(), "expected Status to be consistently empty")
 }
 
+func newNAD(namespace, name string) *nettypes.NetworkAttachmentDefinition {
+	return &nettypes.NetworkAttachmentDefinition{
+		ObjectMeta: util.NewObjectMeta(name, namespace),
+		Spec: nettypes.NetworkAttachmentDefinitionSpec{
+			Config: fmt.Sprintf(`{"cniVersion": "0.4.0", "name": "%s", "type": "ovn-k8s-cni-overlay", "topology": "layer2", "netAttachDefName": "%s/%s"}`,
+				name, namespace, name),
+		},
+	}
+}
+
+// createNAD creates the net-attach-def with the client, since the fake clientset tracker doesn't
+// map the initial objects to the network-attachment-definitions resource
+func createNAD(nad *nettypes.NetworkAttachmentDefinition, fakeClient *util.OVNClusterManagerClientset) {
+	_, err := fakeClient.NetworkAttchDefClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).
+		Create(context.TODO(), nad, metav1.CreateOptions{})
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func setNADZoneStatus(nad *nettypes.NetworkAttachmentDefinition, zone, message string, fakeClient *util.OVNClusterManagerClientset) {
+	nad, err := fakeClient.NetworkAttchDefClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).
+		Get(context.TODO(), nad.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	err = util.SetNADZoneStatus(fakeClient.NetworkAttchDefClient, nad, zone, message)
+	Expect(err).NotTo(HaveOccurred())
+}
+
+func getNADTopologyReadyCondition(nad *nettypes.NetworkAttachmentDefinition, fakeClient *util.OVNClusterManagerClientset) *metav1.Condition {
+	nad, err := fakeClient.NetworkAttchDefClient.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).
+		Get(context.TODO(), nad.Name, metav1.GetOptions{})
+	Expect(err).NotTo(HaveOccurred())
+	condition, err := util.GetNADCondition(nad, util.OvnNetworkTopologyReadyAnnotation)
+	Expect(err).NotTo(HaveOccurred())
+	return condition
+}
+
+func checkNADStatusEventually(nad *nettypes.NetworkAttachmentDefinition, expectFailure bool, fakeClient *util.OVNClusterManagerClientset) {
+	Eventually(func() bool {
+		condition := getNADTopologyReadyCondition(nad, fakeClient)
+		if condition == nil {
+			return false
+		}
+		if expectFailure {
+			return condition.Status == metav1.ConditionFalse && strings.Contains(condition.Message, types.NetworkTopologyErrorMsg)
+		}
+		return condition.Status == metav1.ConditionTrue
+	}).Should(BeTrue(), fmt.Sprintf("expected net-attach-def TopologyReady condition with expectFailure=%v", expectFailure))
+}
+
+func checkEmptyNADStatusConsistently(nad *nettypes.NetworkAttachmentDefinition, fakeClient *util.OVNClusterManagerClientset) {
+	Consistently(func() *metav1.Condition {
+		return getNADTopologyReadyCondition(nad, fakeClient)
+	}).Should(BeNil(), "expected TopologyReady condition to be consistently empty")
+}
+
 var _ = Describe("Cluster Manager Status Manager", func() {
 	var (
 		statusManager *StatusManager
@@ -278,5 +334,37 @@ var _ = Describe("Cluster Manager Status Manager", func() {
 		}, fakeClient)
 		checkAPBRouteStatusEventually(apbRoute, false, false, fakeClient)
 	})
+
+	It("updates net-attach-def status with 2 zones", func() {
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		zones := sets.New[string]("zone1", "zone2")
+		nad := newNAD(namespace1Name, "l2-network")
+		start(zones)
+		createNAD(nad, fakeClient)
+
+		setNADZoneStatus(nad, "zone1", "network topology created", fakeClient)
+		checkEmptyNADStatusConsistently(nad, fakeClient)
+
+		setNADZoneStatus(nad, "zone2", "network topology created", fakeClient)
+		checkNADStatusEventually(nad, false, fakeClient)
+	})
+
+	It("updates net-attach-def status when a zone fails", func() {
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		zones := sets.New[string]("zone1", "zone2")
+		nad := newNAD(namespace1Name, "l2-network")
+		start(zones)
+		createNAD(nad, fakeClient)
+
+		// the failure is reported even if not all zones reported their status
+		setNADZoneStatus(nad, "zone1", types.NetworkTopologyErrorMsg+": boom", fakeClient)
+		checkNADStatusEventually(nad, true, fakeClient)
+
+		setNADZoneStatus(nad, "zone2", "network topology created", fakeClient)
+		checkNADStatusEventually(nad, true, fakeClient)
+
+		setNADZoneStatus(nad, "zone1", "network topology created", fakeClient)
+		checkNADStatusEventually(nad, false, fakeClient)
+	})
 	// cleanup can't be tested by unit test apiserver, since it relies on SSA logic with FieldManagers
 })
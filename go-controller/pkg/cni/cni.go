@@ -99,7 +99,7 @@ func (pr *PodRequest) checkOrUpdatePodUID(pod *kapi.Pod) error {
 	return nil
 }
 
-func (pr *PodRequest) cmdAdd(kubeAuth *KubeAPIAuth, clientset *ClientSet) (*Response, error) {
+func (pr *PodRequest) cmdAdd(kubeAuth *KubeAPIAuth, clientset *ClientSet) (response *Response, err error) {
 	namespace := pr.PodNamespace
 	podName := pr.PodName
 	if namespace == "" || podName == "" {
@@ -138,6 +138,35 @@ func (pr *PodRequest) cmdAdd(kubeAuth *KubeAPIAuth, clientset *ClientSet) (*Resp
 		return nil, err
 	}
 
+	if pr.CNIConf.DevicePool != "" {
+		// the device is released by the CNI server once the interface is unconfigured, which the CNI shim does in
+		// unprivileged mode after the CNI server replied
+		if config.UnprivilegedMode {
+			return nil, fmt.Errorf("device pool %s is not supported in unprivileged mode", pr.CNIConf.DevicePool)
+		}
+		if pr.devicePools == nil {
+			return nil, fmt.Errorf("device pool %s is not configured on the node", pr.CNIConf.DevicePool)
+		}
+		// the device is created with the MAC address of the pod interface, required by vDPA devices
+		pr.CNIConf.DeviceID, err = pr.devicePools.Allocate(pr.CNIConf.DevicePool, pr.SandboxID, pr.nadName,
+			podNADAnnotation.MAC)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate a device from device pool %s: %v", pr.CNIConf.DevicePool, err)
+		}
+		defer func() {
+			if err != nil {
+				if relErr := pr.devicePools.Release(pr.CNIConf.DevicePool, pr.SandboxID, pr.nadName); relErr != nil {
+					klog.Errorf("Failed to release device %s of device pool %s: %v", pr.CNIConf.DeviceID,
+						pr.CNIConf.DevicePool, relErr)
+				}
+			}
+		}()
+		netdevName, err = util.GetNetdevNameFromDeviceId(pr.CNIConf.DeviceID, pr.deviceInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed in cmdAdd while getting Netdevice name: %v", err)
+		}
+	}
+
 	podInterfaceInfo, err := PodAnnotation2PodInfo(annotations, podNADAnnotation, pr.PodUID, netdevName,
 		pr.nadName, pr.netName, pr.CNIConf.MTU)
 	if err != nil {
@@ -146,7 +175,7 @@ func (pr *PodRequest) cmdAdd(kubeAuth *KubeAPIAuth, clientset *ClientSet) (*Resp
 
 	podInterfaceInfo.SkipIPConfig = kubevirt.IsPodLiveMigratable(pod)
 
-	response := &Response{KubeAuth: kubeAuth}
+	response = &Response{KubeAuth: kubeAuth}
 	if !config.UnprivilegedMode {
 		response.Result, err = pr.getCNIResult(clientset, podInterfaceInfo)
 		if err != nil {
@@ -170,6 +199,11 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 		return nil, fmt.Errorf("required CNI variable missing")
 	}
 
+	if pr.CNIConf.DevicePool != "" && pr.devicePools != nil {
+		// the device created for the pod interface is torn down like a device provisioned by a device plugin
+		pr.CNIConf.DeviceID = pr.devicePools.GetDeviceID(pr.CNIConf.DevicePool, pr.SandboxID, pr.nadName)
+	}
+
 	netdevName := ""
 	if pr.CNIConf.DeviceID != "" {
 		if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
@@ -230,6 +264,12 @@ func (pr *PodRequest) cmdDel(clientset *ClientSet) (*Response, error) {
 		if err != nil {
 			return nil, err
 		}
+		// no device is allocated in unprivileged mode
+		if pr.CNIConf.DevicePool != "" && pr.devicePools != nil {
+			if err = pr.devicePools.Release(pr.CNIConf.DevicePool, pr.SandboxID, pr.nadName); err != nil {
+				return nil, err
+			}
+		}
 	} else {
 		// pass the isDPU flag and vfNetdevName back to cniShim
 		response.Result = nil
@@ -47,8 +47,9 @@ import (
 // removed and re-created with 0700 permissions each time ovnkube on the node is
 // started.
 
-// NewCNIServer creates and returns a new Server object which will listen on a socket in the given path
-func NewCNIServer(factory factory.NodeWatchFactory, kclient kubernetes.Interface) (*Server, error) {
+// NewCNIServer creates and returns a new Server object which will listen on a socket in the given path. The devices
+// of the pod interfaces using a device pool are created by devicePools, nil when the node has no device pools.
+func NewCNIServer(factory factory.NodeWatchFactory, kclient kubernetes.Interface, devicePools *DevicePoolManager) (*Server, error) {
 	if config.OvnKubeNode.Mode == types.NodeModeDPU {
 		return nil, fmt.Errorf("unsupported ovnkube-node mode for CNI server: %s", config.OvnKubeNode.Mode)
 	}
@@ -69,6 +70,7 @@ func NewCNIServer(factory factory.NodeWatchFactory, kclient kubernetes.Interface
 			KubeAPITokenFile: config.Kubernetes.TokenFile,
 		},
 		handlePodRequestFunc: HandlePodRequest,
+		devicePools:          devicePools,
 	}
 
 	if len(config.Kubernetes.CAData) > 0 {
@@ -177,6 +179,9 @@ func cniRequestToPodRequest(cr *Request) (*PodRequest, error) {
 		req.nadName = conf.NADName
 	}
 
+	if conf.DeviceID != "" && conf.DevicePool != "" {
+		return nil, fmt.Errorf("deviceID %s and devicePool %s are mutually exclusive", conf.DeviceID, conf.DevicePool)
+	}
 	if conf.DeviceID != "" {
 		if util.IsPCIDeviceName(conf.DeviceID) {
 			// DeviceID is a PCI address
@@ -212,6 +217,7 @@ func (s *Server) handleCNIRequest(r *http.Request) ([]byte, error) {
 		return nil, err
 	}
 	defer req.cancel()
+	req.devicePools = s.devicePools
 
 	result, err := s.handlePodRequestFunc(req, s.clientSet, s.kubeAuth)
 	if err != nil {
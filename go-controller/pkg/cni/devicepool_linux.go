@@ -0,0 +1,421 @@
+//go:build linux
+// +build linux
+
+package cni
+
+import (
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+// devicePoolSFNumBase is the first SF number of the device pools of a PF, the lower SF numbers are left to the SFs
+// provisioned by device plugins
+const devicePoolSFNumBase = 1000
+
+// devicePoolDeviceTimeout is how long to wait for the auxiliary device and the netdev or vDPA device of a new SF
+var devicePoolDeviceTimeout = 10 * time.Second
+
+// poolDevice is a SF created on demand for a pod interface, with its vDPA device for the vdpa pools
+type poolDevice struct {
+	sfNum uint32
+	// portIndex is the index of the devlink port of the SF on the PF
+	portIndex uint32
+	// representor is the netdev of the representor of the SF
+	representor string
+	// auxDev is the auxiliary device of the SF, e.g. mlx5_core.sf.4, used as the device ID of the pod interface
+	auxDev string
+	// vdpaName is the name of the vDPA device created on the SF
+	vdpaName string
+}
+
+type devicePool struct {
+	config.DevicePoolConfig
+	// pfNum is the PCI function number of the PF
+	pfNum uint16
+	// sfNumBase is the first SF number of the pool, the pool uses the Size SF numbers following it
+	sfNumBase uint32
+	// devices of the pool by the sandbox and the NAD of their pod interface
+	devices map[string]*poolDevice
+	// reserved are the SF numbers of the devices being created, by the sandbox and the NAD of their pod interface
+	reserved map[string]uint32
+	// broken are the SF numbers of the existing SFs that could not be recovered nor deleted, by their auxiliary
+	// device, they are left unused
+	broken map[string]uint32
+}
+
+// DevicePoolManager creates and destroys the SFs and vDPA devices of the device pools of the node on demand, for the
+// pod interfaces whose network configuration sets a devicePool. The lock is not held while waiting for the devices of
+// a new SF, the SF numbers of the devices being created are reserved instead.
+type DevicePoolManager struct {
+	sync.Mutex
+	pools map[string]*devicePool
+}
+
+// NewDevicePoolManager returns a DevicePoolManager for the given device pools. The pools of a PF share the SF numbers
+// from devicePoolSFNumBase, in their order.
+func NewDevicePoolManager(pools []config.DevicePoolConfig) (*DevicePoolManager, error) {
+	m := &DevicePoolManager{pools: map[string]*devicePool{}}
+	sfNumBases := map[string]uint32{}
+	pfNums := map[string]uint16{}
+	for _, poolConfig := range pools {
+		if _, ok := sfNumBases[poolConfig.PFPciAddress]; !ok {
+			pfNum, err := util.GetPfNumber(poolConfig.PFPciAddress)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get the PF number of device pool %s: %v", poolConfig.Name, err)
+			}
+			pfNums[poolConfig.PFPciAddress] = pfNum
+			sfNumBases[poolConfig.PFPciAddress] = devicePoolSFNumBase
+		}
+		m.pools[poolConfig.Name] = &devicePool{
+			DevicePoolConfig: poolConfig,
+			pfNum:            pfNums[poolConfig.PFPciAddress],
+			sfNumBase:        sfNumBases[poolConfig.PFPciAddress],
+			devices:          map[string]*poolDevice{},
+			reserved:         map[string]uint32{},
+			broken:           map[string]uint32{},
+		}
+		sfNumBases[poolConfig.PFPciAddress] += uint32(poolConfig.Size)
+		metrics.MetricDevicePoolSize.WithLabelValues(poolConfig.Name, poolConfig.Type).Set(float64(poolConfig.Size))
+		metrics.MetricDevicePoolAllocated.WithLabelValues(poolConfig.Name, poolConfig.Type).Set(0)
+	}
+	return m, nil
+}
+
+func devicePoolKey(sandboxID, nadName string) string {
+	return sandboxID + "/" + nadName
+}
+
+// Sync recovers the devices created before a restart of ovnkube-node. The devices whose representor is plugged in OVS
+// for a pod are kept, the others are deleted. The SFs that can't be recovered nor deleted are skipped and their SF
+// number is left unused.
+func (m *DevicePoolManager) Sync() error {
+	m.Lock()
+	defer m.Unlock()
+	ports, err := util.GetNetLinkOps().DevLinkGetAllPortList()
+	if err != nil {
+		return fmt.Errorf("failed to list the devlink ports: %v", err)
+	}
+	for _, pool := range m.pools {
+		if err := pool.sync(ports); err != nil {
+			return fmt.Errorf("failed to sync device pool %s: %v", pool.Name, err)
+		}
+		pool.updateMetrics()
+	}
+	return nil
+}
+
+// Allocate creates a device from the given pool for the interface of the given sandbox on the given NAD, with the
+// given MAC address, and returns its device ID. The device already created for the interface is returned if any.
+func (m *DevicePoolManager) Allocate(poolName, sandboxID, nadName string, mac net.HardwareAddr) (string, error) {
+	m.Lock()
+	pool, ok := m.pools[poolName]
+	if !ok {
+		m.Unlock()
+		return "", fmt.Errorf("device pool %s is not configured on the node", poolName)
+	}
+	key := devicePoolKey(sandboxID, nadName)
+	if dev, ok := pool.devices[key]; ok {
+		m.Unlock()
+		return dev.auxDev, nil
+	}
+	if _, ok := pool.reserved[key]; ok {
+		m.Unlock()
+		return "", fmt.Errorf("device of device pool %s for sandbox %s NAD %s is being created", pool.Name, sandboxID,
+			nadName)
+	}
+	sfNum, ok := pool.freeSFNum()
+	if !ok {
+		m.Unlock()
+		metrics.MetricDevicePoolAllocationFailures.WithLabelValues(pool.Name, pool.Type).Inc()
+		return "", fmt.Errorf("device pool %s is exhausted, all its %d devices are in use", pool.Name, pool.Size)
+	}
+	pool.reserved[key] = sfNum
+	m.Unlock()
+
+	dev, err := pool.createDevice(sfNum, mac)
+
+	m.Lock()
+	defer m.Unlock()
+	delete(pool.reserved, key)
+	if err != nil {
+		metrics.MetricDevicePoolAllocationFailures.WithLabelValues(pool.Name, pool.Type).Inc()
+		return "", err
+	}
+	klog.Infof("Created device %s (SF %d) of device pool %s for sandbox %s NAD %s", dev.auxDev, dev.sfNum,
+		pool.Name, sandboxID, nadName)
+	pool.devices[key] = dev
+	pool.updateMetrics()
+	return dev.auxDev, nil
+}
+
+// GetDeviceID returns the device ID of the device of the given pool created for the interface of the given sandbox
+// on the given NAD, or an empty string if there is none.
+func (m *DevicePoolManager) GetDeviceID(poolName, sandboxID, nadName string) string {
+	m.Lock()
+	defer m.Unlock()
+	pool, ok := m.pools[poolName]
+	if !ok {
+		return ""
+	}
+	if dev, ok := pool.devices[devicePoolKey(sandboxID, nadName)]; ok {
+		return dev.auxDev
+	}
+	return ""
+}
+
+// Release deletes the device of the given pool created for the interface of the given sandbox on the given NAD, if
+// any.
+func (m *DevicePoolManager) Release(poolName, sandboxID, nadName string) error {
+	m.Lock()
+	defer m.Unlock()
+	pool, ok := m.pools[poolName]
+	if !ok {
+		return nil
+	}
+	key := devicePoolKey(sandboxID, nadName)
+	dev, ok := pool.devices[key]
+	if !ok {
+		return nil
+	}
+	if err := pool.deleteDevice(dev); err != nil {
+		return fmt.Errorf("failed to delete device %s of device pool %s: %v", dev.auxDev, pool.Name, err)
+	}
+	klog.Infof("Deleted device %s (SF %d) of device pool %s for sandbox %s NAD %s", dev.auxDev, dev.sfNum,
+		pool.Name, sandboxID, nadName)
+	delete(pool.devices, key)
+	pool.updateMetrics()
+	return nil
+}
+
+// ResourceCapacity returns the extended resources advertising the device pools on the node, the pods using a pool
+// are expected to request one of its resource per interface.
+func (m *DevicePoolManager) ResourceCapacity() kapi.ResourceList {
+	m.Lock()
+	defer m.Unlock()
+	capacity := kapi.ResourceList{}
+	for _, pool := range m.pools {
+		capacity[kapi.ResourceName(types.DevicePoolResourcePrefix+pool.Name)] =
+			*resource.NewQuantity(int64(pool.Size-len(pool.broken)), resource.DecimalSI)
+	}
+	return capacity
+}
+
+func (p *devicePool) updateMetrics() {
+	metrics.MetricDevicePoolAllocated.WithLabelValues(p.Name, p.Type).Set(float64(len(p.devices)))
+}
+
+func (p *devicePool) ownsSFNum(sfNum int) bool {
+	return sfNum >= int(p.sfNumBase) && sfNum < int(p.sfNumBase)+p.Size
+}
+
+// freeSFNum returns the first SF number of the pool not used by one of its devices, reserved for a device being
+// created, or of a broken SF
+func (p *devicePool) freeSFNum() (uint32, bool) {
+	used := map[uint32]bool{}
+	for _, dev := range p.devices {
+		used[dev.sfNum] = true
+	}
+	for _, sfNum := range p.reserved {
+		used[sfNum] = true
+	}
+	for _, sfNum := range p.broken {
+		used[sfNum] = true
+	}
+	for sfNum := p.sfNumBase; sfNum < p.sfNumBase+uint32(p.Size); sfNum++ {
+		if !used[sfNum] {
+			return sfNum, true
+		}
+	}
+	return 0, false
+}
+
+// findAuxDev returns the auxiliary device of the SF of the PF with the given SF number, or an empty string if there
+// is none
+func (p *devicePool) findAuxDev(sfNum uint32) (string, error) {
+	auxDevs, err := util.GetSriovnetOps().GetAuxNetDevicesFromPci(p.PFPciAddress)
+	if err != nil {
+		return "", fmt.Errorf("failed to list the auxiliary devices of PF %s: %v", p.PFPciAddress, err)
+	}
+	for _, auxDev := range auxDevs {
+		if index, err := util.GetSriovnetOps().GetSfIndexByAuxDev(auxDev); err == nil && index == int(sfNum) {
+			return auxDev, nil
+		}
+	}
+	return "", nil
+}
+
+func (p *devicePool) sync(ports []*netlink.DevlinkPort) error {
+	auxDevs, err := util.GetSriovnetOps().GetAuxNetDevicesFromPci(p.PFPciAddress)
+	if err != nil {
+		return fmt.Errorf("failed to list the auxiliary devices of PF %s: %v", p.PFPciAddress, err)
+	}
+	for _, auxDev := range auxDevs {
+		// the auxiliary devices that are not SFs have no SF number
+		sfNum, err := util.GetSriovnetOps().GetSfIndexByAuxDev(auxDev)
+		if err != nil || !p.ownsSFNum(sfNum) {
+			continue
+		}
+		if err := p.syncDevice(ports, auxDev, uint32(sfNum)); err != nil {
+			klog.Errorf("Skipping device %s (SF %d) of device pool %s, its SF number is left unused: %v", auxDev,
+				sfNum, p.Name, err)
+			p.broken[auxDev] = uint32(sfNum)
+		}
+	}
+	return nil
+}
+
+// syncDevice recovers the device of the given SF if its representor is plugged in OVS for a pod, or deletes it
+func (p *devicePool) syncDevice(ports []*netlink.DevlinkPort, auxDev string, sfNum uint32) error {
+	dev := &poolDevice{sfNum: sfNum, auxDev: auxDev}
+	var err error
+	dev.representor, err = util.GetFunctionRepresentorName(auxDev)
+	if err != nil {
+		return fmt.Errorf("failed to get the representor of device %s: %v", auxDev, err)
+	}
+	found := false
+	for _, port := range ports {
+		if port.BusName == "pci" && port.DeviceName == p.PFPciAddress &&
+			port.PortFlavour == nl.DEVLINK_PORT_FLAVOUR_PCI_SF && port.NetdeviceName == dev.representor {
+			dev.portIndex = port.PortIndex
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("failed to find the devlink port of device %s", auxDev)
+	}
+	if p.Type == config.DevicePoolTypeVdpa {
+		if vdpaDevice, err := util.GetVdpaOps().GetVdpaDeviceByAuxDev(auxDev); err == nil && vdpaDevice != nil {
+			dev.vdpaName = vdpaDevice.Name()
+		}
+	}
+
+	sandboxID, err := ovsGet("Interface", dev.representor, "external_ids", "sandbox")
+	if err != nil {
+		return fmt.Errorf("failed to get the sandbox of the OVS interface %s: %v", dev.representor, err)
+	}
+	if sandboxID == "" {
+		klog.Infof("Deleting stale device %s (SF %d) of device pool %s", auxDev, dev.sfNum, p.Name)
+		if err := p.deleteDevice(dev); err != nil {
+			return fmt.Errorf("failed to delete stale device %s: %v", auxDev, err)
+		}
+		return nil
+	}
+	nadName, err := ovsGet("Interface", dev.representor, "external_ids", types.NADExternalID)
+	if err != nil {
+		return fmt.Errorf("failed to get the NAD of the OVS interface %s: %v", dev.representor, err)
+	}
+	if nadName == "" {
+		nadName = types.DefaultNetworkName
+	}
+	klog.Infof("Recovered device %s (SF %d) of device pool %s for sandbox %s NAD %s", auxDev, dev.sfNum, p.Name,
+		sandboxID, nadName)
+	p.devices[devicePoolKey(sandboxID, nadName)] = dev
+	return nil
+}
+
+// createDevice adds and activates the SF with the given number and MAC address on the PF, and creates its vDPA device
+// for the vdpa pools. The device is deleted if it cannot be fully set up. It only reads the configuration of the pool,
+// and is called without holding the lock of the manager.
+func (p *devicePool) createDevice(sfNum uint32, mac net.HardwareAddr) (_ *poolDevice, err error) {
+	port, err := util.GetNetLinkOps().DevLinkPortAdd("pci", p.PFPciAddress, nl.DEVLINK_PORT_FLAVOUR_PCI_SF,
+		netlink.DevLinkPortAddAttrs{PfNumber: p.pfNum, SfNumber: sfNum, SfNumberValid: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add SF %d on PF %s: %v", sfNum, p.PFPciAddress, err)
+	}
+	dev := &poolDevice{sfNum: sfNum, portIndex: port.PortIndex, representor: port.NetdeviceName}
+	defer func() {
+		if err != nil {
+			if delErr := p.deleteDevice(dev); delErr != nil {
+				klog.Errorf("Failed to delete SF %d of device pool %s after a failed creation: %v", sfNum, p.Name, delErr)
+			}
+		}
+	}()
+
+	fnAttrs := netlink.DevlinkPortFnSetAttrs{
+		FnAttrs:     netlink.DevlinkPortFn{HwAddr: mac, State: nl.DEVLINK_PORT_FN_STATE_ACTIVE},
+		HwAddrValid: len(mac) > 0,
+		StateValid:  true,
+	}
+	if err = util.GetNetLinkOps().DevlinkPortFnSet("pci", p.PFPciAddress, port.PortIndex, fnAttrs); err != nil {
+		return nil, fmt.Errorf("failed to activate SF %d on PF %s: %v", sfNum, p.PFPciAddress, err)
+	}
+
+	// the auxiliary device and the netdev of the SF are probed once it is active
+	err = wait.PollImmediate(100*time.Millisecond, devicePoolDeviceTimeout, func() (bool, error) {
+		if dev.auxDev == "" {
+			auxDev, err := p.findAuxDev(sfNum)
+			if err != nil || auxDev == "" {
+				return false, err
+			}
+			dev.auxDev = auxDev
+		}
+		if p.Type == config.DevicePoolTypeVdpa {
+			return true, nil
+		}
+		netdevs, err := util.GetSriovnetOps().GetNetDevicesFromAux(dev.auxDev)
+		return err == nil && len(netdevs) > 0, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed waiting for the device of SF %d on PF %s: %v", sfNum, p.PFPciAddress, err)
+	}
+
+	if p.Type == config.DevicePoolTypeVdpa {
+		vdpaName := fmt.Sprintf("%s-%d", p.Name, sfNum)
+		if err = util.GetVdpaOps().AddVdpaDevice("auxiliary/"+dev.auxDev, vdpaName); err != nil {
+			return nil, fmt.Errorf("failed to add vDPA device %s on %s: %v", vdpaName, dev.auxDev, err)
+		}
+		dev.vdpaName = vdpaName
+		// the device is usable once bound to the vhost or virtio vDPA driver
+		err = wait.PollImmediate(100*time.Millisecond, devicePoolDeviceTimeout, func() (bool, error) {
+			vdpaDevice, err := util.GetVdpaOps().GetVdpaDeviceByAuxDev(dev.auxDev)
+			return err == nil && vdpaDevice != nil && vdpaDevice.Driver() != "", nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed waiting for vDPA device %s to be bound to a driver: %v", vdpaName, err)
+		}
+	}
+	return dev, nil
+}
+
+// deleteDevice deletes the vDPA device, the OVS port of the representor and the SF of the given device. It can be
+// retried after a failure.
+func (p *devicePool) deleteDevice(dev *poolDevice) error {
+	if dev.vdpaName != "" {
+		if err := util.GetVdpaOps().DeleteVdpaDevice(dev.vdpaName); err != nil {
+			return fmt.Errorf("failed to delete vDPA device %s: %v", dev.vdpaName, err)
+		}
+		dev.vdpaName = ""
+	}
+	// the representor goes away with the SF, do not leave its port dangling in OVS
+	if dev.representor != "" {
+		if _, err := ovsExec("--if-exists", "del-port", dev.representor); err != nil {
+			return err
+		}
+	}
+	fnAttrs := netlink.DevlinkPortFnSetAttrs{
+		FnAttrs:    netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_INACTIVE},
+		StateValid: true,
+	}
+	if err := util.GetNetLinkOps().DevlinkPortFnSet("pci", p.PFPciAddress, dev.portIndex, fnAttrs); err != nil {
+		klog.Warningf("Failed to deactivate SF %d on PF %s: %v", dev.sfNum, p.PFPciAddress, err)
+	}
+	if err := util.GetNetLinkOps().DevLinkPortDel("pci", p.PFPciAddress, dev.portIndex); err != nil {
+		return fmt.Errorf("failed to delete SF %d on PF %s: %v", dev.sfNum, p.PFPciAddress, err)
+	}
+	return nil
+}
@@ -0,0 +1,307 @@
+//go:build linux
+// +build linux
+
+package cni
+
+import (
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+	"github.com/vishvananda/netlink"
+	"github.com/vishvananda/netlink/nl"
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+)
+
+var _ = Describe("CNI device pool tests", func() {
+	const pf = "0000:03:00.0"
+	var fexec *ovntest.FakeExec
+	var netlinkOps *utilMocks.NetLinkOps
+	var sriovnetOps *utilMocks.SriovnetOps
+	var vdpaOps *utilMocks.VdpaOps
+	var m *DevicePoolManager
+	var mac net.HardwareAddr
+
+	origSriovnetOps := util.GetSriovnetOps()
+	origVdpaOps := util.GetVdpaOps()
+
+	sfFlavour := uint16(nl.DEVLINK_PORT_FLAVOUR_PCI_SF)
+	activate := func(mac net.HardwareAddr) netlink.DevlinkPortFnSetAttrs {
+		return netlink.DevlinkPortFnSetAttrs{
+			FnAttrs:     netlink.DevlinkPortFn{HwAddr: mac, State: nl.DEVLINK_PORT_FN_STATE_ACTIVE},
+			HwAddrValid: true,
+			StateValid:  true,
+		}
+	}
+	deactivate := netlink.DevlinkPortFnSetAttrs{
+		FnAttrs:    netlink.DevlinkPortFn{State: nl.DEVLINK_PORT_FN_STATE_INACTIVE},
+		StateValid: true,
+	}
+
+	// expectPfNum expects the lookup of the PF number of the PF from its uplink representor
+	expectPfNum := func(physPortName string) {
+		sriovnetOps.On("GetUplinkRepresentor", pf).Return("enp3s0f0np0", nil).Once()
+		sriovnetOps.On("GetNetDevPhysPortName", "enp3s0f0np0").Return(physPortName, nil).Once()
+	}
+
+	// expectSF expects the creation of the SF of the given number, with the given auxiliary device and representor
+	expectSF := func(sfNum uint32, auxDev, representor string) {
+		netlinkOps.On("DevLinkPortAdd", "pci", pf, sfFlavour,
+			netlink.DevLinkPortAddAttrs{SfNumber: sfNum, SfNumberValid: true}).
+			Return(&netlink.DevlinkPort{PortIndex: sfNum, NetdeviceName: representor}, nil).Once()
+		netlinkOps.On("DevlinkPortFnSet", "pci", pf, sfNum, activate(mac)).Return(nil).Once()
+		sriovnetOps.On("GetAuxNetDevicesFromPci", pf).Return([]string{"mlx5_core.eth.0", auxDev}, nil).Once()
+		sriovnetOps.On("GetSfIndexByAuxDev", "mlx5_core.eth.0").Return(-1, fmt.Errorf("not a SF")).Once()
+		sriovnetOps.On("GetSfIndexByAuxDev", auxDev).Return(int(sfNum), nil).Once()
+	}
+
+	// expectDelete expects the deletion of the SF of the given number with the given representor
+	expectDelete := func(sfNum uint32, representor string) {
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=30 --if-exists del-port " + representor,
+		})
+		netlinkOps.On("DevlinkPortFnSet", "pci", pf, sfNum, deactivate).Return(nil).Once()
+		netlinkOps.On("DevLinkPortDel", "pci", pf, sfNum).Return(nil).Once()
+	}
+
+	BeforeEach(func() {
+		fexec = ovntest.NewFakeExec()
+		Expect(SetExec(fexec)).To(Succeed())
+		netlinkOps = &utilMocks.NetLinkOps{}
+		sriovnetOps = &utilMocks.SriovnetOps{}
+		vdpaOps = &utilMocks.VdpaOps{}
+		util.SetNetLinkOpMockInst(netlinkOps)
+		util.SetSriovnetOpsInst(sriovnetOps)
+		util.SetVdpaOpsInst(vdpaOps)
+
+		var err error
+		mac, err = net.ParseMAC("0a:58:0a:f4:00:05")
+		Expect(err).NotTo(HaveOccurred())
+		expectPfNum("p0")
+		m, err = NewDevicePoolManager([]config.DevicePoolConfig{
+			{Name: "fast", Type: config.DevicePoolTypeSF, PFPciAddress: pf, Size: 1},
+			{Name: "vms", Type: config.DevicePoolTypeVdpa, PFPciAddress: pf, Size: 2},
+		})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		util.ResetNetLinkOpMockInst()
+		util.SetSriovnetOpsInst(origSriovnetOps)
+		util.SetVdpaOpsInst(origVdpaOps)
+	})
+
+	It("creates a SF with the MAC address of the pod interface", func() {
+		expectSF(1000, "mlx5_core.sf.2", "pf0sf1000")
+		sriovnetOps.On("GetNetDevicesFromAux", "mlx5_core.sf.2").Return([]string{"enp3s0f0s1000"}, nil).Once()
+
+		deviceID, err := m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deviceID).To(Equal("mlx5_core.sf.2"))
+
+		// a retried ADD gets the same device
+		deviceID, err = m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deviceID).To(Equal("mlx5_core.sf.2"))
+		Expect(m.GetDeviceID("fast", "sandbox1", types.DefaultNetworkName)).To(Equal("mlx5_core.sf.2"))
+		netlinkOps.AssertExpectations(GinkgoT())
+		sriovnetOps.AssertExpectations(GinkgoT())
+	})
+
+	It("fails when the pool is exhausted", func() {
+		expectSF(1000, "mlx5_core.sf.2", "pf0sf1000")
+		sriovnetOps.On("GetNetDevicesFromAux", "mlx5_core.sf.2").Return([]string{"enp3s0f0s1000"}, nil).Once()
+
+		_, err := m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).NotTo(HaveOccurred())
+		_, err = m.Allocate("fast", "sandbox2", types.DefaultNetworkName, mac)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exhausted"))
+	})
+
+	It("creates the SFs with the PF number of the uplink representor of the PF", func() {
+		expectPfNum("p1")
+		var err error
+		m, err = NewDevicePoolManager([]config.DevicePoolConfig{
+			{Name: "fast", Type: config.DevicePoolTypeSF, PFPciAddress: pf, Size: 1},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		netlinkOps.On("DevLinkPortAdd", "pci", pf, sfFlavour,
+			netlink.DevLinkPortAddAttrs{PfNumber: 1, SfNumber: 1000, SfNumberValid: true}).
+			Return(&netlink.DevlinkPort{PortIndex: 1000, NetdeviceName: "pf1sf1000"}, nil).Once()
+		netlinkOps.On("DevlinkPortFnSet", "pci", pf, uint32(1000), activate(mac)).Return(nil).Once()
+		sriovnetOps.On("GetAuxNetDevicesFromPci", pf).Return([]string{"mlx5_core.sf.2"}, nil).Once()
+		sriovnetOps.On("GetSfIndexByAuxDev", "mlx5_core.sf.2").Return(1000, nil).Once()
+		sriovnetOps.On("GetNetDevicesFromAux", "mlx5_core.sf.2").Return([]string{"enp3s0f1s1000"}, nil).Once()
+
+		deviceID, err := m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deviceID).To(Equal("mlx5_core.sf.2"))
+		netlinkOps.AssertExpectations(GinkgoT())
+	})
+
+	It("fails when the PF number can't be found", func() {
+		sriovnetOps.On("GetUplinkRepresentor", "0000:04:00.0").Return("", fmt.Errorf("uplink not found")).Once()
+		_, err := NewDevicePoolManager([]config.DevicePoolConfig{
+			{Name: "fast", Type: config.DevicePoolTypeSF, PFPciAddress: "0000:04:00.0", Size: 1},
+		})
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to get the PF number of device pool fast"))
+	})
+
+	It("reserves the SF number of a device being created without holding the lock", func() {
+		expectSF(1000, "mlx5_core.sf.2", "pf0sf1000")
+		probing := make(chan struct{})
+		probed := make(chan struct{})
+		sriovnetOps.On("GetNetDevicesFromAux", "mlx5_core.sf.2").Run(func(mock.Arguments) {
+			close(probing)
+			<-probed
+		}).Return([]string{"enp3s0f0s1000"}, nil).Once()
+
+		allocated := make(chan error)
+		go func() {
+			defer GinkgoRecover()
+			_, err := m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+			allocated <- err
+		}()
+		Eventually(probing).Should(BeClosed())
+		// the manager is usable while the device is probed, and its SF number is not allocated again
+		Expect(m.GetDeviceID("fast", "sandbox1", types.DefaultNetworkName)).To(BeEmpty())
+		_, err := m.Allocate("fast", "sandbox2", types.DefaultNetworkName, mac)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("exhausted"))
+		_, err = m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("is being created"))
+
+		close(probed)
+		Eventually(allocated).Should(Receive(BeNil()))
+		Expect(m.GetDeviceID("fast", "sandbox1", types.DefaultNetworkName)).To(Equal("mlx5_core.sf.2"))
+	})
+
+	It("fails for a pool that is not configured", func() {
+		_, err := m.Allocate("slow", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("not configured"))
+	})
+
+	It("deletes the SF when it cannot be activated", func() {
+		netlinkOps.On("DevLinkPortAdd", "pci", pf, sfFlavour,
+			netlink.DevLinkPortAddAttrs{SfNumber: 1000, SfNumberValid: true}).
+			Return(&netlink.DevlinkPort{PortIndex: 1000, NetdeviceName: "pf0sf1000"}, nil).Once()
+		netlinkOps.On("DevlinkPortFnSet", "pci", pf, uint32(1000), activate(mac)).Return(fmt.Errorf("no resources")).Once()
+		expectDelete(1000, "pf0sf1000")
+
+		_, err := m.Allocate("fast", "sandbox1", types.DefaultNetworkName, mac)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("failed to activate SF 1000"))
+		Expect(m.GetDeviceID("fast", "sandbox1", types.DefaultNetworkName)).To(BeEmpty())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		netlinkOps.AssertExpectations(GinkgoT())
+	})
+
+	It("creates and deletes the vDPA device of a SF of a vdpa pool", func() {
+		vdpaDevice := &utilMocks.VdpaDevice{}
+		vdpaDevice.On("Driver").Return("virtio_vdpa")
+		expectSF(1001, "mlx5_core.sf.3", "pf0sf1001")
+		vdpaOps.On("AddVdpaDevice", "auxiliary/mlx5_core.sf.3", "vms-1001").Return(nil).Once()
+		vdpaOps.On("GetVdpaDeviceByAuxDev", "mlx5_core.sf.3").Return(vdpaDevice, nil).Once()
+
+		deviceID, err := m.Allocate("vms", "sandbox1", "ns1/nad1", mac)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(deviceID).To(Equal("mlx5_core.sf.3"))
+
+		vdpaOps.On("DeleteVdpaDevice", "vms-1001").Return(nil).Once()
+		expectDelete(1001, "pf0sf1001")
+		Expect(m.Release("vms", "sandbox1", "ns1/nad1")).To(Succeed())
+		Expect(m.GetDeviceID("vms", "sandbox1", "ns1/nad1")).To(BeEmpty())
+		// releasing again is a no-op
+		Expect(m.Release("vms", "sandbox1", "ns1/nad1")).To(Succeed())
+
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		netlinkOps.AssertExpectations(GinkgoT())
+		vdpaOps.AssertExpectations(GinkgoT())
+	})
+
+	It("recovers the devices in use and deletes the stale ones on sync", func() {
+		expectPfNum("p0")
+		var err error
+		m, err = NewDevicePoolManager([]config.DevicePoolConfig{
+			{Name: "fast", Type: config.DevicePoolTypeSF, PFPciAddress: pf, Size: 2},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		netlinkOps.On("DevLinkGetAllPortList").Return([]*netlink.DevlinkPort{
+			{BusName: "pci", DeviceName: pf, PortIndex: 1000, PortFlavour: sfFlavour, NetdeviceName: "pf0sf1000"},
+			{BusName: "pci", DeviceName: pf, PortIndex: 1001, PortFlavour: sfFlavour, NetdeviceName: "pf0sf1001"},
+		}, nil).Once()
+		sriovnetOps.On("GetAuxNetDevicesFromPci", pf).
+			Return([]string{"mlx5_core.sf.1", "mlx5_core.sf.2", "mlx5_core.sf.3"}, nil).Once()
+		// a SF provisioned by a device plugin
+		sriovnetOps.On("GetSfIndexByAuxDev", "mlx5_core.sf.1").Return(1, nil)
+		for _, sfNum := range []int{1000, 1001} {
+			auxDev := fmt.Sprintf("mlx5_core.sf.%d", sfNum-998)
+			sriovnetOps.On("GetSfIndexByAuxDev", auxDev).Return(sfNum, nil)
+			sriovnetOps.On("GetUplinkRepresentorFromAux", auxDev).Return("p0", nil)
+			sriovnetOps.On("GetSfRepresentor", "p0", sfNum).Return(fmt.Sprintf("pf0sf%d", sfNum), nil)
+		}
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface pf0sf1000 external_ids:sandbox",
+			Output: "sandbox1",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface pf0sf1000 external_ids:" + types.NADExternalID,
+			Output: "",
+		})
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=30 --if-exists get Interface pf0sf1001 external_ids:sandbox",
+			Output: "",
+		})
+		expectDelete(1001, "pf0sf1001")
+
+		Expect(m.Sync()).To(Succeed())
+		Expect(m.GetDeviceID("fast", "sandbox1", types.DefaultNetworkName)).To(Equal("mlx5_core.sf.2"))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		netlinkOps.AssertExpectations(GinkgoT())
+	})
+
+	It("skips the SFs that can't be recovered nor deleted on sync and leaves their SF number unused", func() {
+		expectPfNum("p0")
+		var err error
+		m, err = NewDevicePoolManager([]config.DevicePoolConfig{
+			{Name: "fast", Type: config.DevicePoolTypeSF, PFPciAddress: pf, Size: 2},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		// the devlink port of the SF is missing
+		netlinkOps.On("DevLinkGetAllPortList").Return([]*netlink.DevlinkPort{}, nil).Once()
+		sriovnetOps.On("GetAuxNetDevicesFromPci", pf).Return([]string{"mlx5_core.sf.2"}, nil).Once()
+		sriovnetOps.On("GetSfIndexByAuxDev", "mlx5_core.sf.2").Return(1000, nil)
+		sriovnetOps.On("GetUplinkRepresentorFromAux", "mlx5_core.sf.2").Return("p0", nil)
+		sriovnetOps.On("GetSfRepresentor", "p0", 1000).Return("pf0sf1000", nil)
+
+		Expect(m.Sync()).To(Succeed())
+		sfNum, ok := m.pools["fast"].freeSFNum()
+		Expect(ok).To(BeTrue())
+		Expect(sfNum).To(Equal(uint32(1001)))
+		Expect(m.ResourceCapacity()).To(Equal(kapi.ResourceList{
+			types.DevicePoolResourcePrefix + "fast": *resource.NewQuantity(1, resource.DecimalSI),
+		}))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc())
+		netlinkOps.AssertExpectations(GinkgoT())
+	})
+
+	It("advertises the size of the pools as resources of the node", func() {
+		Expect(m.ResourceCapacity()).To(Equal(kapi.ResourceList{
+			types.DevicePoolResourcePrefix + "fast": *resource.NewQuantity(1, resource.DecimalSI),
+			types.DevicePoolResourcePrefix + "vms":  *resource.NewQuantity(2, resource.DecimalSI),
+		}))
+	})
+})
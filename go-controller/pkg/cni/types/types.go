@@ -30,6 +30,9 @@ type NetConf struct {
 
 	// PciAddrs in case of using sriov or Auxiliry device name in case of SF
 	DeviceID string `json:"deviceID,omitempty"`
+	// DevicePool is the name of the device pool of the node the SF or vDPA device of the pod interface is created
+	// from on demand, instead of using a DeviceID provisioned by a device plugin
+	DevicePool string `json:"devicePool,omitempty"`
 	// LogFile to log all the messages from cni shim binary to
 	LogFile string `json:"logFile,omitempty"`
 	// Level is the logging verbosity level
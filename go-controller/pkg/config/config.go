@@ -120,11 +120,17 @@ var (
 	}
 
 	// Metrics holds Prometheus metrics-related parameters.
-	Metrics MetricsConfig
+	Metrics = MetricsConfig{
+		ACLHitMetricsMaxObjects:     1000,
+		ACLVerdictMetricsMaxObjects: 1000,
+		PodMetricsLabels:            "namespace,pod,nad",
+	}
 
 	// OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
 	OVNKubernetesFeature = OVNKubernetesFeatureConfig{
 		EgressIPReachabiltyTotalTimeout: 1,
+		NBDriftAuditMode:                NBDriftAuditModeDryRun,
+		NBTxnCoalescingMaxOperations:    1000,
 	}
 
 	// OvnNorth holds northbound OVN database client and server authentication and location details
@@ -382,6 +388,30 @@ type MetricsConfig struct {
 	// configuration duration and optionally, its application to all nodes
 	EnableConfigDuration bool `gcfg:"enable-config-duration"`
 	EnableScaleMetrics   bool `gcfg:"enable-scale-metrics"`
+	// ACLVerdictsLogFile is the ovn-controller log file ovnkube-node decodes the logged ACL verdicts from
+	ACLVerdictsLogFile string `gcfg:"acl-verdicts-log-file"`
+	// EnableACLVerdictsStream enables streaming the decoded ACL verdicts from the OVN metrics server. The stream is
+	// not authenticated, it exposes the flows of all the pods of the node.
+	EnableACLVerdictsStream bool `gcfg:"enable-acl-verdicts-stream"`
+	// EnableFlowSamplingObservations enables serving the mapping of the flow sampling observation IDs to the
+	// Kubernetes objects at /flow-sampling on the metrics server
+	EnableFlowSamplingObservations bool `gcfg:"enable-flow-sampling-observations"`
+	// EnableACLVerdictMetrics enables counting the decoded ACL verdicts per owner of the ACL
+	EnableACLVerdictMetrics bool `gcfg:"enable-acl-verdict-metrics"`
+	// ACLVerdictMetricsMaxObjects is the maximum number of objects ACL verdicts are counted for, the verdicts of the
+	// other objects are counted for an overflow object of their kind. 0 means no limit.
+	ACLVerdictMetricsMaxObjects int `gcfg:"acl-verdict-metrics-max-objects"`
+	// EnableACLHitMetrics enables counting the traffic matched by the ACLs of every object on the nodes running
+	// ovnkube-controller for their zone
+	EnableACLHitMetrics bool `gcfg:"enable-acl-hit-metrics"`
+	// ACLHitMetricsMaxObjects is the maximum number of objects ACL hits are counted for, the hits of the other objects
+	// are counted for an overflow object of their kind. 0 means no limit.
+	ACLHitMetricsMaxObjects int `gcfg:"acl-hit-metrics-max-objects"`
+	// EnablePodMetrics enables the per pod interface and conntrack metrics on every node
+	EnablePodMetrics bool `gcfg:"enable-pod-metrics"`
+	// PodMetricsLabels is the comma separated allowlist of the labels of the per pod metrics among namespace, pod and
+	// nad. The metrics of the pods only differing by the other labels are aggregated, empty aggregates all the pods.
+	PodMetricsLabels string `gcfg:"pod-metrics-labels"`
 }
 
 // OVNKubernetesFeatureConfig holds OVN-Kubernetes feature enhancement config file parameters and command-line overrides
@@ -401,8 +431,37 @@ type OVNKubernetesFeatureConfig struct {
 	EnableStatelessNetPol           bool `gcfg:"enable-stateless-netpol"`
 	EnableInterconnect              bool `gcfg:"enable-interconnect"`
 	EnableMultiExternalGateway      bool `gcfg:"enable-multi-external-gateway"`
+
+	// EgressIPNodeHealthChecks is a comma separated list of checks (icmp, bfd) that egress nodes run in addition
+	// to serving the gRPC health check, and that must pass for the node to be reported as healthy
+	EgressIPNodeHealthChecks string `gcfg:"egressip-node-healthchecks"`
+	// EgressIPNodeHealthCheckICMPTargets is a comma separated list of upstream IP addresses probed by the icmp check
+	EgressIPNodeHealthCheckICMPTargets string `gcfg:"egressip-node-healthcheck-icmp-targets"`
+
+	// NBDriftAuditInterval is the interval in seconds between two audits of the OVN NB objects owned by the
+	// controller against the Kubernetes objects they are derived from, the auditor is disabled if 0
+	NBDriftAuditInterval int `gcfg:"nb-drift-audit-interval"`
+	// NBDriftAuditMode is either dry-run, to only report the drift, or repair, to also fix it
+	NBDriftAuditMode string `gcfg:"nb-drift-audit-mode"`
+
+	// EnableShadowMode makes ovnkube-controller record the transactions it would send to the OVN databases instead
+	// of sending them, to validate a new version or configuration against a live cluster
+	EnableShadowMode bool `gcfg:"enable-shadow-mode"`
+
+	// NBTxnCoalescingWindow is the window in milliseconds within which the OVN NB transactions of concurrent
+	// callers are merged into a single transaction, coalescing is disabled if 0
+	NBTxnCoalescingWindow int `gcfg:"nb-txn-coalescing-window"`
+	// NBTxnCoalescingMaxOperations is the maximum number of operations of a merged OVN NB transaction
+	NBTxnCoalescingMaxOperations int `gcfg:"nb-txn-coalescing-max-operations"`
 }
 
+const (
+	// NBDriftAuditModeDryRun only reports the drift between the Kubernetes and the OVN NB state
+	NBDriftAuditModeDryRun = "dry-run"
+	// NBDriftAuditModeRepair reports and repairs the drift between the Kubernetes and the OVN NB state
+	NBDriftAuditModeRepair = "repair"
+)
+
 // GatewayMode holds the node gateway mode
 type GatewayMode string
 
@@ -469,7 +528,11 @@ type OvnAuthConfig struct {
 	CertCommonName string `gcfg:"cert-common-name"`
 	Scheme         OvnDBScheme
 	ElectionTimer  uint `gcfg:"election-timer"`
-	northbound     bool
+	// CompactionLogSize is the size in MiB the Raft log can grow to before the DB checker compacts the database
+	CompactionLogSize uint `gcfg:"raft-compaction-log-size"`
+	// CompactionMaxAge is the time in seconds after which the DB checker compacts the database
+	CompactionMaxAge uint `gcfg:"raft-compaction-max-age"`
+	northbound       bool
 
 	exec kexec.Interface
 }
@@ -503,6 +566,11 @@ type OvnKubeNodeConfig struct {
 	DPResourceDeviceIdsMap map[string][]string
 	MgmtPortNetdev         string `gcfg:"mgmt-port-netdev"`
 	MgmtPortDPResourceName string `gcfg:"mgmt-port-dp-resource-name"`
+	// RawDevicePools holds the unparsed pools of devices created on demand for the pods. Should only be
+	// used inside config module.
+	RawDevicePools string `gcfg:"device-pools"`
+	// DevicePools holds the parsed pools of devices created on demand for the pods
+	DevicePools []DevicePoolConfig
 }
 
 // ClusterManagerConfig holds configuration for ovnkube-cluster-manager
@@ -1003,6 +1071,18 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Usage:       "Configure EgressIP node reachability using gRPC on this TCP port.",
 		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckPort,
 	},
+	&cli.StringFlag{
+		Name: "egressip-node-healthchecks",
+		Usage: "Comma separated list of checks that egress nodes run in addition to serving the gRPC health check " +
+			"(icmp: upstream targets reply to ICMP echo requests, bfd: a BFD session of the node's gateway router is up). " +
+			"Requires egressip-node-healthcheck-port.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthChecks,
+	},
+	&cli.StringFlag{
+		Name:        "egressip-node-healthcheck-icmp-targets",
+		Usage:       "Comma separated list of upstream IP addresses that egress nodes probe with the icmp health check.",
+		Destination: &cliConfig.OVNKubernetesFeature.EgressIPNodeHealthCheckICMPTargets,
+	},
 	&cli.BoolFlag{
 		Name:        "enable-multi-network",
 		Usage:       "Configure to use multiple NetworkAttachmentDefinition CRD feature with ovn-kubernetes.",
@@ -1039,6 +1119,39 @@ var OVNK8sFeatureFlags = []cli.Flag{
 		Destination: &cliConfig.OVNKubernetesFeature.EnableMultiExternalGateway,
 		Value:       OVNKubernetesFeature.EnableMultiExternalGateway,
 	},
+	&cli.IntFlag{
+		Name: "nb-drift-audit-interval",
+		Usage: "Interval in seconds between two audits of the OVN NB objects owned by ovnkube-controller against " +
+			"the Kubernetes objects they are derived from. The auditor is disabled if 0 (default: 0)",
+		Destination: &cliConfig.OVNKubernetesFeature.NBDriftAuditInterval,
+	},
+	&cli.StringFlag{
+		Name: "nb-drift-audit-mode",
+		Usage: "Mode of the OVN NB drift auditor: dry-run only reports the drift as metrics, events and logs, " +
+			"repair also fixes it",
+		Destination: &cliConfig.OVNKubernetesFeature.NBDriftAuditMode,
+		Value:       OVNKubernetesFeature.NBDriftAuditMode,
+	},
+	&cli.BoolFlag{
+		Name: "enable-shadow-mode",
+		Usage: "Record the transactions ovnkube-controller would send to the OVN databases instead of sending them. " +
+			"The recorded changes are exposed as metrics on the metrics server, and at /debug/shadow when pprof is " +
+			"enabled. Changes to Kubernetes objects are sent as server-side dry runs and no leader election lease " +
+			"is taken. Only supported by ovnkube-controller running alone",
+		Destination: &cliConfig.OVNKubernetesFeature.EnableShadowMode,
+	},
+	&cli.IntFlag{
+		Name: "nb-txn-coalescing-window",
+		Usage: "Window in milliseconds within which the OVN NB transactions of concurrent callers are merged " +
+			"into a single transaction. Coalescing is disabled if 0 (default: 0)",
+		Destination: &cliConfig.OVNKubernetesFeature.NBTxnCoalescingWindow,
+	},
+	&cli.IntFlag{
+		Name:        "nb-txn-coalescing-max-operations",
+		Usage:       "Maximum number of operations of a merged OVN NB transaction",
+		Destination: &cliConfig.OVNKubernetesFeature.NBTxnCoalescingMaxOperations,
+		Value:       OVNKubernetesFeature.NBTxnCoalescingMaxOperations,
+	},
 }
 
 // K8sFlags capture Kubernetes-related options
@@ -1202,6 +1315,62 @@ var MetricsFlags = []cli.Flag{
 		Usage:       "Enables metrics related to scaling",
 		Destination: &cliConfig.Metrics.EnableScaleMetrics,
 	},
+	&cli.StringFlag{
+		Name: "acl-verdicts-log-file",
+		Usage: "The ovn-controller log file to decode the logged ACL verdicts from " +
+			"(eg, /var/log/ovn/ovn-controller.log).",
+		Destination: &cliConfig.Metrics.ACLVerdictsLogFile,
+	},
+	&cli.BoolFlag{
+		Name: "metrics-enable-acl-verdicts-stream",
+		Usage: "Enables streaming the ACL verdicts decoded from the acl-verdicts-log-file at /acl-verdicts on the OVN " +
+			"metrics server. The stream is not authenticated and exposes the flows of all the pods of the node.",
+		Destination: &cliConfig.Metrics.EnableACLVerdictsStream,
+	},
+	&cli.BoolFlag{
+		Name: "metrics-enable-flow-sampling-observations",
+		Usage: "Enables serving the mapping of the flow sampling observation IDs to the Kubernetes objects at " +
+			"/flow-sampling on the metrics server. The mapping is not authenticated.",
+		Destination: &cliConfig.Metrics.EnableFlowSamplingObservations,
+	},
+	&cli.BoolFlag{
+		Name:        "metrics-enable-acl-verdicts",
+		Usage:       "Enables counting the ACL verdicts decoded from the acl-verdicts-log-file per owner of the ACL",
+		Destination: &cliConfig.Metrics.EnableACLVerdictMetrics,
+	},
+	&cli.IntFlag{
+		Name: "metrics-acl-verdicts-max-objects",
+		Usage: "The maximum number of objects ACL verdicts are counted for, the verdicts of the other objects are " +
+			"counted for an overflow object of their kind. 0 means no limit.",
+		Destination: &cliConfig.Metrics.ACLVerdictMetricsMaxObjects,
+		Value:       Metrics.ACLVerdictMetricsMaxObjects,
+	},
+	&cli.BoolFlag{
+		Name: "metrics-enable-acl-hits",
+		Usage: "Enables counting the packets and bytes matched by the ACLs of every object in the OpenFlow flows of " +
+			"the node. Counted by the nodes running ovnkube-controller for their zone, from the zone databases.",
+		Destination: &cliConfig.Metrics.EnableACLHitMetrics,
+	},
+	&cli.IntFlag{
+		Name: "metrics-acl-hits-max-objects",
+		Usage: "The maximum number of objects ACL hits are counted for, the hits of the other objects are counted " +
+			"for an overflow object of their kind. 0 means no limit.",
+		Destination: &cliConfig.Metrics.ACLHitMetricsMaxObjects,
+		Value:       Metrics.ACLHitMetricsMaxObjects,
+	},
+	&cli.BoolFlag{
+		Name: "metrics-enable-pod",
+		Usage: "Enables the per pod metrics of the OVS interfaces and conntrack entries of the pods of the node, " +
+			"exported with the OVS metrics.",
+		Destination: &cliConfig.Metrics.EnablePodMetrics,
+	},
+	&cli.StringFlag{
+		Name: "metrics-pod-labels",
+		Usage: "The comma separated allowlist of the labels of the per pod metrics among namespace, pod and nad. " +
+			"The metrics of the pods only differing by the other labels are aggregated.",
+		Destination: &cliConfig.Metrics.PodMetricsLabels,
+		Value:       Metrics.PodMetricsLabels,
+	},
 }
 
 // OvnNBFlags capture OVN northbound database options
@@ -1244,6 +1413,18 @@ var OvnNBFlags = []cli.Flag{
 		Usage:       "The desired northbound database election timer.",
 		Destination: &cliConfig.OvnNorth.ElectionTimer,
 	},
+	&cli.UintFlag{
+		Name: "nb-raft-compaction-log-size",
+		Usage: "The size in MiB the northbound database Raft log can grow to since the last compaction " +
+			"before the DB checker compacts the database. Disabled if 0.",
+		Destination: &cliConfig.OvnNorth.CompactionLogSize,
+	},
+	&cli.UintFlag{
+		Name: "nb-raft-compaction-max-age",
+		Usage: "The time in seconds since the last compaction after which the DB checker compacts the " +
+			"northbound database. Disabled if 0.",
+		Destination: &cliConfig.OvnNorth.CompactionMaxAge,
+	},
 }
 
 // OvnSBFlags capture OVN southbound database options
@@ -1286,6 +1467,18 @@ var OvnSBFlags = []cli.Flag{
 		Usage:       "The desired southbound database election timer.",
 		Destination: &cliConfig.OvnSouth.ElectionTimer,
 	},
+	&cli.UintFlag{
+		Name: "sb-raft-compaction-log-size",
+		Usage: "The size in MiB the southbound database Raft log can grow to since the last compaction " +
+			"before the DB checker compacts the database. Disabled if 0.",
+		Destination: &cliConfig.OvnSouth.CompactionLogSize,
+	},
+	&cli.UintFlag{
+		Name: "sb-raft-compaction-max-age",
+		Usage: "The time in seconds since the last compaction after which the DB checker compacts the " +
+			"southbound database. Disabled if 0.",
+		Destination: &cliConfig.OvnSouth.CompactionMaxAge,
+	},
 }
 
 // OVNGatewayFlags capture L3 Gateway related flags
@@ -1493,6 +1686,15 @@ var OvnKubeNodeFlags = []cli.Flag{
 		Value:       OvnKubeNode.MgmtPortDPResourceName,
 		Destination: &cliConfig.OvnKubeNode.MgmtPortDPResourceName,
 	},
+	&cli.StringFlag{
+		Name: "ovnkube-node-device-pools",
+		Usage: "A comma separated list of pools of scalable functions (sf) or vDPA devices (vdpa) created on demand " +
+			"for the pods whose network configuration sets the devicePool, in the " +
+			"<name>=<type>/<pf-pci-address>/<size> format, e.g. fast=sf/0000:03:00.0/8,vms=vdpa/0000:03:00.1/4. " +
+			"Only supported in the full ovnkube-node mode.",
+		Value:       OvnKubeNode.RawDevicePools,
+		Destination: &cliConfig.OvnKubeNode.RawDevicePools,
+	},
 	&cli.BoolFlag{
 		Name:        "disable-ovn-iface-id-ver",
 		Usage:       "Deprecated; iface-id-ver is always enabled",
@@ -1759,6 +1961,26 @@ func buildMetricsConfig(cli, file *config) error {
 		return err
 	}
 
+	if (Metrics.EnableACLVerdictsStream || Metrics.EnableACLVerdictMetrics) && Metrics.ACLVerdictsLogFile == "" {
+		return fmt.Errorf("ACL verdicts stream and metrics require an ACL verdicts log file")
+	}
+	if Metrics.ACLVerdictMetricsMaxObjects < 0 {
+		return fmt.Errorf("invalid ACL verdict metrics max objects %d, must not be negative",
+			Metrics.ACLVerdictMetricsMaxObjects)
+	}
+	if Metrics.ACLHitMetricsMaxObjects < 0 {
+		return fmt.Errorf("invalid ACL hit metrics max objects %d, must not be negative", Metrics.ACLHitMetricsMaxObjects)
+	}
+	if Metrics.PodMetricsLabels != "" {
+		for _, label := range strings.Split(Metrics.PodMetricsLabels, ",") {
+			switch label {
+			case "namespace", "pod", "nad":
+			default:
+				return fmt.Errorf("invalid pod metrics label %q, must be one of namespace, pod or nad", label)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -1860,6 +2082,26 @@ func buildOVNKubernetesFeatureConfig(ctx *cli.Context, cli, file *config) error
 	if err := overrideFields(&OVNKubernetesFeature, &cli.OVNKubernetesFeature, &savedOVNKubernetesFeature); err != nil {
 		return err
 	}
+	if OVNKubernetesFeature.EgressIPNodeHealthChecks != "" && OVNKubernetesFeature.EgressIPNodeHealthCheckPort == 0 {
+		return fmt.Errorf("egress IP node health checks %q require an egress IP node health check port",
+			OVNKubernetesFeature.EgressIPNodeHealthChecks)
+	}
+	if OVNKubernetesFeature.NBTxnCoalescingWindow < 0 {
+		return fmt.Errorf("invalid NB transaction coalescing window %d", OVNKubernetesFeature.NBTxnCoalescingWindow)
+	}
+	if OVNKubernetesFeature.NBTxnCoalescingMaxOperations <= 0 {
+		return fmt.Errorf("invalid NB transaction coalescing max operations %d",
+			OVNKubernetesFeature.NBTxnCoalescingMaxOperations)
+	}
+	if OVNKubernetesFeature.NBDriftAuditInterval < 0 {
+		return fmt.Errorf("invalid NB drift audit interval %d", OVNKubernetesFeature.NBDriftAuditInterval)
+	}
+	switch OVNKubernetesFeature.NBDriftAuditMode {
+	case NBDriftAuditModeDryRun, NBDriftAuditModeRepair:
+	default:
+		return fmt.Errorf("invalid NB drift audit mode %q, expected %s or %s", OVNKubernetesFeature.NBDriftAuditMode,
+			NBDriftAuditModeDryRun, NBDriftAuditModeRepair)
+	}
 	return nil
 }
 
@@ -2118,6 +2360,7 @@ func initConfigWithPath(ctx *cli.Context, exec kexec.Interface, saPath string, d
 		CNI:                  savedCNI,
 		OVNKubernetesFeature: savedOVNKubernetesFeature,
 		Kubernetes:           savedKubernetes,
+		Metrics:              savedMetrics,
 		OvnNorth:             savedOvnNorth,
 		OvnSouth:             savedOvnSouth,
 		Gateway:              savedGateway,
@@ -2605,5 +2848,21 @@ func buildOvnKubeNodeConfig(ctx *cli.Context, cli, file *config) error {
 	if OvnKubeNode.Mode == types.NodeModeDPUHost && OvnKubeNode.MgmtPortNetdev == "" && OvnKubeNode.MgmtPortDPResourceName == "" {
 		return fmt.Errorf("ovnkube-node-mgmt-port-netdev or ovnkube-node-mgmt-port-dp-resource-name must be provided")
 	}
+
+	OvnKubeNode.DevicePools = nil
+	if OvnKubeNode.RawDevicePools != "" {
+		// the devices are created on the eswitch of the node and plugged by the CNI server
+		if OvnKubeNode.Mode != types.NodeModeFull {
+			return fmt.Errorf("ovnkube-node-device-pools is not supported with ovnkube-node mode %s", OvnKubeNode.Mode)
+		}
+		if UnprivilegedMode {
+			return fmt.Errorf("ovnkube-node-device-pools is not supported in unprivileged mode")
+		}
+		pools, err := ParseDevicePools(OvnKubeNode.RawDevicePools)
+		if err != nil {
+			return fmt.Errorf("ovnkube-node-device-pools invalid: %v", err)
+		}
+		OvnKubeNode.DevicePools = pools
+	}
 	return nil
 }
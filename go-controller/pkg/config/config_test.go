@@ -222,6 +222,8 @@ mode=full
 [ovnkubernetesfeature]
 egressip-reachability-total-timeout=3
 egressip-node-healthcheck-port=1234
+egressip-node-healthchecks=icmp
+egressip-node-healthcheck-icmp-targets=10.0.0.1
 enable-multi-network=false
 enable-multi-networkpolicy=false
 enable-interconnect=false
@@ -315,6 +317,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Kubernetes.DNSServiceName).To(gomega.Equal("kube-dns"))
 			gomega.Expect(Metrics.NodeServerPrivKey).To(gomega.Equal(""))
 			gomega.Expect(Metrics.NodeServerCert).To(gomega.Equal(""))
+			gomega.Expect(Metrics.ACLHitMetricsMaxObjects).To(gomega.Equal(1000))
+			gomega.Expect(Metrics.PodMetricsLabels).To(gomega.Equal("namespace,pod,nad"))
 			gomega.Expect(Default.ClusterSubnets).To(gomega.Equal([]CIDRNetworkEntry{
 				{ovntest.MustParseIPNet("10.128.0.0/14"), 23},
 			}))
@@ -331,6 +335,7 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(Gateway.AllowNoUplink).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(1))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(0))
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthChecks).To(gomega.Equal(""))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetworkPolicy).To(gomega.BeFalse())
 			gomega.Expect(OVNKubernetesFeature.EnableInterconnect).To(gomega.BeFalse())
@@ -674,6 +679,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(HybridOverlay.Enabled).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(3))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(1234))
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthChecks).To(gomega.Equal("icmp"))
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckICMPTargets).To(gomega.Equal("10.0.0.1"))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EnableInterconnect).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EnableMultiExternalGateway).To(gomega.BeTrue())
@@ -777,6 +784,8 @@ var _ = Describe("Config Operations", func() {
 			gomega.Expect(HybridOverlay.Enabled).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout).To(gomega.Equal(5))
 			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckPort).To(gomega.Equal(4321))
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthChecks).To(gomega.Equal("icmp,bfd"))
+			gomega.Expect(OVNKubernetesFeature.EgressIPNodeHealthCheckICMPTargets).To(gomega.Equal("10.0.0.2,fd00::2"))
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetwork).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EnableMultiNetworkPolicy).To(gomega.BeTrue())
 			gomega.Expect(OVNKubernetesFeature.EnableInterconnect).To(gomega.BeTrue())
@@ -848,6 +857,8 @@ var _ = Describe("Config Operations", func() {
 			"-metrics-enable-config-duration=true",
 			"-egressip-reachability-total-timeout=5",
 			"-egressip-node-healthcheck-port=4321",
+			"-egressip-node-healthchecks=icmp,bfd",
+			"-egressip-node-healthcheck-icmp-targets=10.0.0.2,fd00::2",
 			"-enable-multi-network=true",
 			"-enable-multi-networkpolicy=true",
 			"-enable-interconnect=true",
@@ -1083,6 +1094,20 @@ enable-pprof=true
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	It("returns an error when a pod metrics label is invalid", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("invalid pod metrics label \"node\", must be one of namespace, pod or nad"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-metrics-pod-labels=namespace,node",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	It("returns an error when the gateway mode is invalid", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -1097,6 +1122,20 @@ enable-pprof=true
 		gomega.Expect(err).NotTo(gomega.HaveOccurred())
 	})
 
+	It("returns an error when egress IP node health checks are specified without a health check port", func() {
+		app.Action = func(ctx *cli.Context) error {
+			_, err := InitConfig(ctx, kexec.New(), nil)
+			gomega.Expect(err).To(gomega.MatchError("egress IP node health checks \"bfd\" require an egress IP node health check port"))
+			return nil
+		}
+		cliArgs := []string{
+			app.Name,
+			"-egressip-node-healthchecks=bfd",
+		}
+		err := app.Run(cliArgs)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	})
+
 	It("returns an error when the vlan-id is specified for mode other than shared gateway mode", func() {
 		app.Action = func(ctx *cli.Context) error {
 			_, err := InitConfig(ctx, kexec.New(), nil)
@@ -1970,5 +2009,38 @@ foo=bar
 			err := buildOvnKubeNodeConfig(nil, &cliConfig, &file)
 			gomega.Expect(err).ToNot(gomega.HaveOccurred())
 		})
+
+		It("Parses the device pools in the full mode", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:           types.NodeModeFull,
+					RawDevicePools: "fast=sf/0000:03:00.0/8,vms=vdpa/0000:03:00.1/4",
+				},
+			}
+			file := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode: types.NodeModeFull,
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &file)
+			gomega.Expect(err).ToNot(gomega.HaveOccurred())
+			gomega.Expect(OvnKubeNode.DevicePools).To(gomega.Equal([]DevicePoolConfig{
+				{Name: "fast", Type: DevicePoolTypeSF, PFPciAddress: "0000:03:00.0", Size: 8},
+				{Name: "vms", Type: DevicePoolTypeVdpa, PFPciAddress: "0000:03:00.1", Size: 4},
+			}))
+		})
+
+		It("Fails if device pools are provided and ovnkube node mode is dpu-host", func() {
+			cliConfig := config{
+				OvnKubeNode: OvnKubeNodeConfig{
+					Mode:           types.NodeModeDPUHost,
+					MgmtPortNetdev: "enp1s0f0v0",
+					RawDevicePools: "fast=sf/0000:03:00.0/8",
+				},
+			}
+			err := buildOvnKubeNodeConfig(nil, &cliConfig, &config{})
+			gomega.Expect(err).To(gomega.HaveOccurred())
+			gomega.Expect(err.Error()).To(gomega.ContainSubstring("ovnkube-node-device-pools is not supported with ovnkube-node mode"))
+		})
 	})
 })
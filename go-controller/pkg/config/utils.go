@@ -4,10 +4,12 @@ import (
 	"fmt"
 	"net"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 
 	iputils "github.com/containernetworking/plugins/pkg/ip"
+	"k8s.io/apimachinery/pkg/util/validation"
 	utilnet "k8s.io/utils/net"
 )
 
@@ -158,6 +160,65 @@ func ParseFlowCollectors(flowCollectors string) ([]HostPort, error) {
 	return parsedFlowsCollectors, nil
 }
 
+const (
+	// DevicePoolTypeSF is the type of the device pools of scalable functions
+	DevicePoolTypeSF = "sf"
+	// DevicePoolTypeVdpa is the type of the device pools of vDPA devices, backed by scalable functions
+	DevicePoolTypeVdpa = "vdpa"
+)
+
+// pciAddressRe matches a PCI address in the <domain>:<bus>:<device>.<function> format
+var pciAddressRe = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+
+// DevicePoolConfig is the object that holds the definition of a pool of devices created on demand on a PF
+type DevicePoolConfig struct {
+	// Name of the pool, referenced by the devicePool of the network configurations
+	Name string
+	// Type of the devices of the pool, DevicePoolTypeSF or DevicePoolTypeVdpa
+	Type string
+	// PFPciAddress is the PCI address of the PF the devices are created on
+	PFPciAddress string
+	// Size is the maximum number of devices of the pool
+	Size int
+}
+
+// ParseDevicePools returns the parsed set of DevicePoolConfigs passed by the user on the command line in the
+// <name>=<type>/<pf-pci-address>/<size> format, separated by commas.
+func ParseDevicePools(devicePools string) ([]DevicePoolConfig, error) {
+	var pools []DevicePoolConfig
+	names := map[string]bool{}
+	for _, entry := range strings.Split(devicePools, ",") {
+		entry = strings.TrimSpace(entry)
+		name, value, found := strings.Cut(entry, "=")
+		fields := strings.Split(value, "/")
+		if !found || len(fields) != 3 {
+			return nil, fmt.Errorf("device pool %q is not in the <name>=<type>/<pf-pci-address>/<size> format", entry)
+		}
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("device pool name %q is invalid: %s", name, strings.Join(errs, ", "))
+		}
+		if names[name] {
+			return nil, fmt.Errorf("device pool %s is defined more than once", name)
+		}
+		names[name] = true
+		pool := DevicePoolConfig{Name: name, Type: fields[0], PFPciAddress: fields[1]}
+		if pool.Type != DevicePoolTypeSF && pool.Type != DevicePoolTypeVdpa {
+			return nil, fmt.Errorf("device pool %s has unsupported type %q, supported types: %s, %s",
+				name, pool.Type, DevicePoolTypeSF, DevicePoolTypeVdpa)
+		}
+		if !pciAddressRe.MatchString(pool.PFPciAddress) {
+			return nil, fmt.Errorf("device pool %s PF %q is not a valid PCI address", name, pool.PFPciAddress)
+		}
+		size, err := strconv.Atoi(fields[2])
+		if err != nil || size <= 0 {
+			return nil, fmt.Errorf("device pool %s size %q is not a positive number", name, fields[2])
+		}
+		pool.Size = size
+		pools = append(pools, pool)
+	}
+	return pools, nil
+}
+
 type configSubnetType string
 
 const (
@@ -2,6 +2,8 @@ package config
 
 import (
 	"net"
+	"reflect"
+	"strings"
 	"testing"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
@@ -311,6 +313,71 @@ func Test_checkForOverlap(t *testing.T) {
 	}
 }
 
+func TestParseDevicePools(t *testing.T) {
+	tests := []struct {
+		name        string
+		devicePools string
+		expected    []DevicePoolConfig
+		expectedErr string
+	}{
+		{
+			name:        "pools of both types",
+			devicePools: "fast=sf/0000:03:00.0/8, vms=vdpa/0000:03:00.1/4",
+			expected: []DevicePoolConfig{
+				{Name: "fast", Type: DevicePoolTypeSF, PFPciAddress: "0000:03:00.0", Size: 8},
+				{Name: "vms", Type: DevicePoolTypeVdpa, PFPciAddress: "0000:03:00.1", Size: 4},
+			},
+		},
+		{
+			name:        "missing size",
+			devicePools: "fast=sf/0000:03:00.0",
+			expectedErr: "format",
+		},
+		{
+			name:        "invalid name",
+			devicePools: "Fast_Pool=sf/0000:03:00.0/8",
+			expectedErr: "name",
+		},
+		{
+			name:        "duplicate name",
+			devicePools: "fast=sf/0000:03:00.0/8,fast=sf/0000:03:00.1/8",
+			expectedErr: "more than once",
+		},
+		{
+			name:        "unsupported type",
+			devicePools: "fast=vf/0000:03:00.0/8",
+			expectedErr: "unsupported type",
+		},
+		{
+			name:        "invalid PF",
+			devicePools: "fast=sf/ens1f0/8",
+			expectedErr: "not a valid PCI address",
+		},
+		{
+			name:        "invalid size",
+			devicePools: "fast=sf/0000:03:00.0/0",
+			expectedErr: "not a positive number",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pools, err := ParseDevicePools(tc.devicePools)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(pools, tc.expected) {
+				t.Errorf("parsed device pools returned unexpected results: %+v", pools)
+			}
+		})
+	}
+}
+
 func TestParseFlowCollectors(t *testing.T) {
 	hp, err := ParseFlowCollectors("10.0.0.2:3030,:8888,[2020:1111:f::1:0933]:3333,10.0.0.3:3031")
 	if err != nil {
@@ -28,6 +28,7 @@ type AdminPolicyBasedRouteStatusApplyConfiguration struct {
 	LastTransitionTime *v1.Time                            `json:"lastTransitionTime,omitempty"`
 	Messages           []string                            `json:"messages,omitempty"`
 	Status             *adminpolicybasedroutev1.StatusType `json:"status,omitempty"`
+	NextHops           []NextHopStatusApplyConfiguration   `json:"nextHops,omitempty"`
 }
 
 // AdminPolicyBasedRouteStatusApplyConfiguration constructs an declarative configuration of the AdminPolicyBasedRouteStatus type for use with
@@ -61,3 +62,16 @@ func (b *AdminPolicyBasedRouteStatusApplyConfiguration) WithStatus(value adminpo
 	b.Status = &value
 	return b
 }
+
+// WithNextHops adds the given value to the NextHops field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NextHops field.
+func (b *AdminPolicyBasedRouteStatusApplyConfiguration) WithNextHops(values ...*NextHopStatusApplyConfiguration) *AdminPolicyBasedRouteStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNextHops")
+		}
+		b.NextHops = append(b.NextHops, *values[i])
+	}
+	return b
+}
@@ -24,10 +24,12 @@ import (
 // DynamicHopApplyConfiguration represents an declarative configuration of the DynamicHop type for use
 // with apply.
 type DynamicHopApplyConfiguration struct {
-	PodSelector           *v1.LabelSelector `json:"podSelector,omitempty"`
-	NamespaceSelector     *v1.LabelSelector `json:"namespaceSelector,omitempty"`
-	NetworkAttachmentName *string           `json:"networkAttachmentName,omitempty"`
-	BFDEnabled            *bool             `json:"bfdEnabled,omitempty"`
+	PodSelector           *v1.LabelSelector            `json:"podSelector,omitempty"`
+	NamespaceSelector     *v1.LabelSelector            `json:"namespaceSelector,omitempty"`
+	NetworkAttachmentName *string                      `json:"networkAttachmentName,omitempty"`
+	BFDEnabled            *bool                        `json:"bfdEnabled,omitempty"`
+	BFD                   *BFDConfigApplyConfiguration `json:"bfd,omitempty"`
+	Weight                *int32                       `json:"weight,omitempty"`
 }
 
 // DynamicHopApplyConfiguration constructs an declarative configuration of the DynamicHop type for use with
@@ -67,3 +69,19 @@ func (b *DynamicHopApplyConfiguration) WithBFDEnabled(value bool) *DynamicHopApp
 	b.BFDEnabled = &value
 	return b
 }
+
+// WithBFD sets the BFD field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BFD field is set to the value of the last call.
+func (b *DynamicHopApplyConfiguration) WithBFD(value *BFDConfigApplyConfiguration) *DynamicHopApplyConfiguration {
+	b.BFD = value
+	return b
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *DynamicHopApplyConfiguration) WithWeight(value int32) *DynamicHopApplyConfiguration {
+	b.Weight = &value
+	return b
+}
@@ -25,6 +25,8 @@ import (
 // with apply.
 type ExternalNetworkSourceApplyConfiguration struct {
 	NamespaceSelector *v1.LabelSelector `json:"namespaceSelector,omitempty"`
+	PodSelector       *v1.LabelSelector `json:"podSelector,omitempty"`
+	DestinationCIDRs  []string          `json:"destinationCIDRs,omitempty"`
 }
 
 // ExternalNetworkSourceApplyConfiguration constructs an declarative configuration of the ExternalNetworkSource type for use with
@@ -40,3 +42,21 @@ func (b *ExternalNetworkSourceApplyConfiguration) WithNamespaceSelector(value v1
 	b.NamespaceSelector = &value
 	return b
 }
+
+// WithPodSelector sets the PodSelector field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PodSelector field is set to the value of the last call.
+func (b *ExternalNetworkSourceApplyConfiguration) WithPodSelector(value v1.LabelSelector) *ExternalNetworkSourceApplyConfiguration {
+	b.PodSelector = &value
+	return b
+}
+
+// WithDestinationCIDRs adds the given value to the DestinationCIDRs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the DestinationCIDRs field.
+func (b *ExternalNetworkSourceApplyConfiguration) WithDestinationCIDRs(values ...string) *ExternalNetworkSourceApplyConfiguration {
+	for i := range values {
+		b.DestinationCIDRs = append(b.DestinationCIDRs, values[i])
+	}
+	return b
+}
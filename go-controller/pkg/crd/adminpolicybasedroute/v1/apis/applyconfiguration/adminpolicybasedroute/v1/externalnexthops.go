@@ -24,8 +24,9 @@ import (
 // ExternalNextHopsApplyConfiguration represents an declarative configuration of the ExternalNextHops type for use
 // with apply.
 type ExternalNextHopsApplyConfiguration struct {
-	StaticHops  []*v1.StaticHop  `json:"static,omitempty"`
-	DynamicHops []*v1.DynamicHop `json:"dynamic,omitempty"`
+	StaticHops        []*v1.StaticHop  `json:"static,omitempty"`
+	DynamicHops       []*v1.DynamicHop `json:"dynamic,omitempty"`
+	ConsistentHashing *bool            `json:"consistentHashing,omitempty"`
 }
 
 // ExternalNextHopsApplyConfiguration constructs an declarative configuration of the ExternalNextHops type for use with
@@ -59,3 +60,11 @@ func (b *ExternalNextHopsApplyConfiguration) WithDynamicHops(values ...**v1.Dyna
 	}
 	return b
 }
+
+// WithConsistentHashing sets the ConsistentHashing field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ConsistentHashing field is set to the value of the last call.
+func (b *ExternalNextHopsApplyConfiguration) WithConsistentHashing(value bool) *ExternalNextHopsApplyConfiguration {
+	b.ConsistentHashing = &value
+	return b
+}
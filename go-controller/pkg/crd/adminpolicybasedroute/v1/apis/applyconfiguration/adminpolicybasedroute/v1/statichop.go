@@ -20,8 +20,10 @@ package v1
 // StaticHopApplyConfiguration represents an declarative configuration of the StaticHop type for use
 // with apply.
 type StaticHopApplyConfiguration struct {
-	IP         *string `json:"ip,omitempty"`
-	BFDEnabled *bool   `json:"bfdEnabled,omitempty"`
+	IP         *string                      `json:"ip,omitempty"`
+	BFDEnabled *bool                        `json:"bfdEnabled,omitempty"`
+	BFD        *BFDConfigApplyConfiguration `json:"bfd,omitempty"`
+	Weight     *int32                       `json:"weight,omitempty"`
 }
 
 // StaticHopApplyConfiguration constructs an declarative configuration of the StaticHop type for use with
@@ -45,3 +47,19 @@ func (b *StaticHopApplyConfiguration) WithBFDEnabled(value bool) *StaticHopApply
 	b.BFDEnabled = &value
 	return b
 }
+
+// WithBFD sets the BFD field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BFD field is set to the value of the last call.
+func (b *StaticHopApplyConfiguration) WithBFD(value *BFDConfigApplyConfiguration) *StaticHopApplyConfiguration {
+	b.BFD = value
+	return b
+}
+
+// WithWeight sets the Weight field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Weight field is set to the value of the last call.
+func (b *StaticHopApplyConfiguration) WithWeight(value int32) *StaticHopApplyConfiguration {
+	b.Weight = &value
+	return b
+}
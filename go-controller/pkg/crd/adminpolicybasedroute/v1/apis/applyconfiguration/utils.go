@@ -34,12 +34,16 @@ func ForKind(kind schema.GroupVersionKind) interface{} {
 		return &adminpolicybasedroutev1.AdminPolicyBasedExternalRouteSpecApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("AdminPolicyBasedRouteStatus"):
 		return &adminpolicybasedroutev1.AdminPolicyBasedRouteStatusApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("BFDConfig"):
+		return &adminpolicybasedroutev1.BFDConfigApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("DynamicHop"):
 		return &adminpolicybasedroutev1.DynamicHopApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ExternalNetworkSource"):
 		return &adminpolicybasedroutev1.ExternalNetworkSourceApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("ExternalNextHops"):
 		return &adminpolicybasedroutev1.ExternalNextHopsApplyConfiguration{}
+	case v1.SchemeGroupVersion.WithKind("NextHopStatus"):
+		return &adminpolicybasedroutev1.NextHopStatusApplyConfiguration{}
 	case v1.SchemeGroupVersion.WithKind("StaticHop"):
 		return &adminpolicybasedroutev1.StaticHopApplyConfiguration{}
 
@@ -42,16 +42,26 @@ type AdminPolicyBasedExternalRoute struct {
 
 // AdminPolicyBasedExternalRouteSpec defines the desired state of AdminPolicyBasedExternalRoute
 type AdminPolicyBasedExternalRouteSpec struct {
-	// From defines the selectors that will determine the target namespaces to this CR.
+	// From defines the selectors that will determine the target namespaces and pods to this CR, and the destinations it applies to.
 	From ExternalNetworkSource `json:"from"`
 	// NextHops defines two types of hops: Static and Dynamic. Each hop defines at least one external gateway IP.
 	NextHops ExternalNextHops `json:"nextHops"`
 }
 
-// ExternalNetworkSource contains the selectors used to determine the namespaces where the policy will be applied to
+// ExternalNetworkSource contains the selectors used to determine the namespaces and pods where the policy will be applied to,
+// and the destinations the policy applies to
 type ExternalNetworkSource struct {
 	// NamespaceSelector defines a selector to be used to determine which namespaces will be targeted by this CR
 	NamespaceSelector metav1.LabelSelector `json:"namespaceSelector"`
+	// PodSelector defines a selector to filter the pods in the selected namespaces that will be targeted by this CR.
+	// When empty, all the pods in the selected namespaces are targeted.
+	// +optional
+	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// DestinationCIDRs restricts the traffic routed through the external gateways to the given destination prefixes.
+	// When empty, all the traffic leaving the cluster from the targeted pods is routed through the external gateways.
+	// Destination CIDRs can't be combined with BFD enabled hops.
+	// +optional
+	DestinationCIDRs []string `json:"destinationCIDRs,omitempty"`
 }
 
 // +kubebuilder:validation:MinProperties:=1
@@ -61,6 +71,12 @@ type ExternalNextHops struct {
 	StaticHops []*StaticHop `json:"static,omitempty"`
 	//DynamicHops defines a slices of DynamicHop. This field is optional.
 	DynamicHops []*DynamicHop `json:"dynamic,omitempty"`
+	// ConsistentHashing distributes the traffic over a fixed number of ECMP slots shared by the next hops, so that
+	// adding or removing a next hop only moves the flows of the slots it takes or releases. Defaults to false.
+	// +optional
+	// +kubebuilder:default:=false
+	// +default=false
+	ConsistentHashing bool `json:"consistentHashing,omitempty"`
 }
 
 // StaticHop defines the configuration of a static IP that acts as an external Gateway Interface. IP field is mandatory.
@@ -76,6 +92,15 @@ type StaticHop struct {
 	// +kubebuilder:default:=false
 	// +default=false
 	BFDEnabled bool `json:"bfdEnabled,omitempty"`
+	// BFD defines the timers of the BFD session established with the next hop, it is only used when BFDEnabled is true.
+	// +optional
+	BFD *BFDConfig `json:"bfd,omitempty"`
+	// Weight defines the share of the traffic routed through the next hop, relative to the weights of the other next hops.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	Weight int32 `json:"weight,omitempty"`
 	// SkipHostSNAT determines whether to disable Source NAT to the host IP. Defaults to false.
 	// +optional
 	// +kubebuilder:default:=false
@@ -107,6 +132,15 @@ type DynamicHop struct {
 	// +kubebuilder:default:=false
 	// +default=false
 	BFDEnabled bool `json:"bfdEnabled,omitempty"`
+	// BFD defines the timers of the BFD sessions established with the next hops, it is only used when BFDEnabled is true.
+	// +optional
+	BFD *BFDConfig `json:"bfd,omitempty"`
+	// Weight defines the share of the traffic routed through every next hop selected by this hop, relative to the
+	// weights of the other next hops. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=16
+	Weight int32 `json:"weight,omitempty"`
 	// SkipHostSNAT determines whether to disable Source NAT to the host IP. Defaults to false
 	// +optional
 	// +kubebuilder:default:=false
@@ -114,6 +148,22 @@ type DynamicHop struct {
 	// SkipHostSNAT bool `json:"skipHostSNAT,omitempty"`
 }
 
+// BFDConfig defines the timers of a BFD session. The OVN defaults are used for the fields that are not set.
+type BFDConfig struct {
+	// MinTx is the minimum interval, in milliseconds, between the BFD control packets sent to the next hop.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinTx int32 `json:"minTx,omitempty"`
+	// MinRx is the minimum interval, in milliseconds, between the BFD control packets received from the next hop.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MinRx int32 `json:"minRx,omitempty"`
+	// DetectMultiplier is the number of BFD control packets that can be missed before the next hop is declared down.
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	DetectMultiplier int32 `json:"detectMultiplier,omitempty"`
+}
+
 // AdminPolicyBasedExternalRouteList contains a list of AdminPolicyBasedExternalRoutes
 // +kubebuilder:object:root=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -136,6 +186,23 @@ type AdminPolicyBasedRouteStatus struct {
 	// A concise indication of whether the AdminPolicyBasedRoute resource is applied with success
 	// +optional
 	Status StatusType `json:"status,omitempty"`
+	// NextHops reports the state of the BFD sessions established by the gateway routers of the nodes with the
+	// BFD enabled next hops.
+	// +listType=map
+	// +listMapKey=ip
+	// +listMapKey=node
+	// +optional
+	NextHops []NextHopStatus `json:"nextHops,omitempty"`
+}
+
+// NextHopStatus contains the state of the BFD session between the gateway router of a node and a next hop.
+type NextHopStatus struct {
+	// IP is the IP of the next hop.
+	IP string `json:"ip"`
+	// Node is the name of the node whose gateway router established the BFD session.
+	Node string `json:"node"`
+	// BFDStatus is the state of the BFD session as reported by OVN.
+	BFDStatus BFDStatusType `json:"bfdStatus"`
 }
 
 // StatusType defines the types of status used in the Status field. The value determines if the
@@ -146,3 +213,16 @@ const (
 	SuccessStatus StatusType = "Success"
 	FailStatus    StatusType = "Fail"
 )
+
+// BFDStatusType defines the states of a BFD session reported in the NextHops status field.
+// +kubebuilder:validation:Enum=Up;Down;Init;AdminDown;Unknown
+type BFDStatusType string
+
+const (
+	BFDStatusUp        BFDStatusType = "Up"
+	BFDStatusDown      BFDStatusType = "Down"
+	BFDStatusInit      BFDStatusType = "Init"
+	BFDStatusAdminDown BFDStatusType = "AdminDown"
+	// BFDStatusUnknown is reported until OVN establishes the BFD session.
+	BFDStatusUnknown BFDStatusType = "Unknown"
+)
@@ -112,6 +112,11 @@ func (in *AdminPolicyBasedRouteStatus) DeepCopyInto(out *AdminPolicyBasedRouteSt
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.NextHops != nil {
+		in, out := &in.NextHops, &out.NextHops
+		*out = make([]NextHopStatus, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -125,11 +130,32 @@ func (in *AdminPolicyBasedRouteStatus) DeepCopy() *AdminPolicyBasedRouteStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BFDConfig) DeepCopyInto(out *BFDConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BFDConfig.
+func (in *BFDConfig) DeepCopy() *BFDConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(BFDConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DynamicHop) DeepCopyInto(out *DynamicHop) {
 	*out = *in
 	in.PodSelector.DeepCopyInto(&out.PodSelector)
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -147,6 +173,12 @@ func (in *DynamicHop) DeepCopy() *DynamicHop {
 func (in *ExternalNetworkSource) DeepCopyInto(out *ExternalNetworkSource) {
 	*out = *in
 	in.NamespaceSelector.DeepCopyInto(&out.NamespaceSelector)
+	in.PodSelector.DeepCopyInto(&out.PodSelector)
+	if in.DestinationCIDRs != nil {
+		in, out := &in.DestinationCIDRs, &out.DestinationCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -170,7 +202,7 @@ func (in *ExternalNextHops) DeepCopyInto(out *ExternalNextHops) {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
 				*out = new(StaticHop)
-				**out = **in
+				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
@@ -198,9 +230,30 @@ func (in *ExternalNextHops) DeepCopy() *ExternalNextHops {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NextHopStatus) DeepCopyInto(out *NextHopStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NextHopStatus.
+func (in *NextHopStatus) DeepCopy() *NextHopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(NextHopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *StaticHop) DeepCopyInto(out *StaticHop) {
 	*out = *in
+	if in.BFD != nil {
+		in, out := &in.BFD, &out.BFD
+		*out = new(BFDConfig)
+		**out = **in
+	}
 	return
 }
 
@@ -60,6 +60,11 @@ type EgressIPSpec struct {
 	// match this pod selector.
 	// +optional
 	PodSelector metav1.LabelSelector `json:"podSelector,omitempty"`
+	// Network is the network attachment definition, in the namespace/name format, of the
+	// secondary layer3 network whose pod IPs the egress IP applies to. This field is optional,
+	// and in case it is not set the egress IP applies to the pods' default network IPs.
+	// +optional
+	Network string `json:"network,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -21,7 +21,7 @@ func GetACLName(acl *nbdb.ACL) string {
 }
 
 func getACLMutableFields(acl *nbdb.ACL) []interface{} {
-	return []interface{}{&acl.Action, &acl.Direction, &acl.ExternalIDs, &acl.Log, &acl.Match, &acl.Meter,
+	return []interface{}{&acl.Action, &acl.Direction, &acl.ExternalIDs, &acl.Label, &acl.Log, &acl.Match, &acl.Meter,
 		&acl.Name, &acl.Options, &acl.Priority, &acl.Severity, &acl.Tier}
 }
 
@@ -32,7 +32,7 @@ func FindACLsWithPredicate(nbClient libovsdbclient.Client, p aclPredicate) ([]*n
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	acls := []*nbdb.ACL{}
-	err := nbClient.WhereCache(p).List(ctx, &acls)
+	err := whereCacheList(ctx, nbClient, p, &acls)
 	return acls, err
 }
 
@@ -159,7 +159,7 @@ func CreateOrUpdateACLs(nbClient libovsdbclient.Client, acls ...*nbdb.ACL) error
 	return err
 }
 
-// UpdateACLsLoggingOps updates the log and severity on the provided ACLs and
+// UpdateACLsLoggingOps updates the log, severity and label on the provided ACLs and
 // returns the corresponding ops
 func UpdateACLsLoggingOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, acls ...*nbdb.ACL) ([]libovsdb.Operation, error) {
 	opModels := make([]operationModel, 0, len(acls))
@@ -168,7 +168,7 @@ func UpdateACLsLoggingOps(nbClient libovsdbclient.Client, ops []libovsdb.Operati
 		acl := acls[i]
 		opModel := operationModel{
 			Model:          acl,
-			OnModelUpdates: []interface{}{&acl.Severity, &acl.Log},
+			OnModelUpdates: []interface{}{&acl.Severity, &acl.Log, &acl.Label},
 			ErrNotFound:    true,
 			BulkOp:         false,
 		}
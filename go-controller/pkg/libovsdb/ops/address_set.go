@@ -36,7 +36,7 @@ func FindAddressSetsWithPredicate(nbClient libovsdbclient.Client, p addressSetPr
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.AddressSet{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
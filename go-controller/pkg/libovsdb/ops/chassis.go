@@ -15,7 +15,7 @@ func ListChassis(sbClient libovsdbclient.Client) ([]*sbdb.Chassis, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	searchedChassis := []*sbdb.Chassis{}
-	err := sbClient.List(ctx, &searchedChassis)
+	err := cacheList(ctx, sbClient, &searchedChassis)
 	return searchedChassis, err
 }
 
@@ -24,7 +24,7 @@ func ListChassisPrivate(sbClient libovsdbclient.Client) ([]*sbdb.ChassisPrivate,
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*sbdb.ChassisPrivate{}
-	err := sbClient.List(ctx, &found)
+	err := cacheList(ctx, sbClient, &found)
 	return found, err
 }
 
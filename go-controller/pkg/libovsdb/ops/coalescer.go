@@ -0,0 +1,312 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+// minCoalescedTimeout is the minimum time a request must have left before its deadline, on top of the window, to be
+// merged with others. A merged transaction is sent with the earliest deadline of its requests, so requests with a
+// shorter deadline are sent in their own transaction not to bound the transactions of the others.
+const minCoalescedTimeout = types.OVSDBTimeout / 2
+
+var (
+	coalescersLock sync.RWMutex
+	// coalescers are the transaction coalescers of the clients with coalescing enabled
+	coalescers = map[client.Client]*transactionCoalescer{}
+)
+
+// TransactionBatchStats describes a transaction sent by a coalescer on behalf of one or more callers
+type TransactionBatchStats struct {
+	DBName string
+	// Requests is the number of callers whose operations were merged into the transaction
+	Requests int
+	// Operations is the number of operations of the transaction
+	Operations int
+	// QueueDurations are the durations each request waited before the transaction was sent
+	QueueDurations []time.Duration
+	// Duration is the duration of the transaction
+	Duration time.Duration
+	// Fallback is true if an operation of the transaction failed, and the requests were sent again separately so
+	// that the failure is only reported to the caller whose operations failed
+	Fallback bool
+}
+
+// TransactionBatchObserver is called for every transaction sent by a coalescer
+type TransactionBatchObserver func(stats TransactionBatchStats)
+
+// coalescedRequest are the operations of a single caller of TransactWithRetry
+type coalescedRequest struct {
+	ops        []ovsdb.Operation
+	namedUUIDs map[string]bool
+	deadline   time.Time
+	enqueued   time.Time
+	// result receives the results of the operations of the request
+	result chan coalescedResult
+}
+
+type coalescedResult struct {
+	results []ovsdb.OperationResult
+	err     error
+}
+
+// transactionCoalescer merges the operations of concurrent callers of TransactWithRetry sent within a window into a
+// single transaction. OVSDB transactions are atomic, so when an operation of the merged transaction fails nothing is
+// committed, and the requests are sent again separately to report the failure only to the caller it belongs to.
+type transactionCoalescer struct {
+	client        client.Client
+	window        time.Duration
+	maxOperations int
+	observer      TransactionBatchObserver
+	requests      chan *coalescedRequest
+	// stopped is closed when the coalescer stops collecting requests
+	stopped chan struct{}
+}
+
+// EnableTransactionCoalescing makes the transactions of the client through TransactWithRetry, and the functions
+// built on it, be merged with the transactions of concurrent callers sent within window, up to maxOperations
+// operations per transaction. The observer, if any, is called for every transaction sent. Coalescing stops when
+// stopCh is closed.
+func EnableTransactionCoalescing(c client.Client, window time.Duration, maxOperations int,
+	observer TransactionBatchObserver, stopCh <-chan struct{}) {
+	coalescersLock.Lock()
+	defer coalescersLock.Unlock()
+	if _, ok := coalescers[c]; ok {
+		return
+	}
+	tc := &transactionCoalescer{
+		client:        c,
+		window:        window,
+		maxOperations: maxOperations,
+		observer:      observer,
+		requests:      make(chan *coalescedRequest),
+		stopped:       make(chan struct{}),
+	}
+	coalescers[c] = tc
+	go tc.run(stopCh)
+	klog.Infof("Enabled transaction coalescing for %s with a window of %v and up to %d operations per transaction",
+		c.Schema().Name, window, maxOperations)
+}
+
+// DisableTransactionCoalescing makes the transactions of the client be sent separately again
+func DisableTransactionCoalescing(c client.Client) {
+	coalescersLock.Lock()
+	defer coalescersLock.Unlock()
+	delete(coalescers, c)
+}
+
+func getTransactionCoalescer(c client.Client) *transactionCoalescer {
+	coalescersLock.RLock()
+	defer coalescersLock.RUnlock()
+	return coalescers[c]
+}
+
+// transact queues the operations to be sent with the ones of concurrent callers and returns their results
+func (tc *transactionCoalescer) transact(ctx context.Context, ops []ovsdb.Operation) ([]ovsdb.OperationResult, error) {
+	// invalid operations fail before being sent, and would fail the whole merged transaction
+	if !tc.client.Schema().ValidateOperations(ops...) {
+		return transactWithRetry(ctx, tc.client, ops)
+	}
+	req := &coalescedRequest{
+		ops:        ops,
+		namedUUIDs: namedUUIDsOf(ops),
+		enqueued:   time.Now(),
+		result:     make(chan coalescedResult, 1),
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if time.Until(deadline) < tc.window+minCoalescedTimeout {
+			return transactWithRetry(ctx, tc.client, ops)
+		}
+		req.deadline = deadline
+	}
+	select {
+	case tc.requests <- req:
+	case <-tc.stopped:
+		return transactWithRetry(ctx, tc.client, ops)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// once queued, the operations may be committed with the others even if the context is done, so the caller
+	// gets the actual result. Transactions are sent with a deadline no later than the one of the request.
+	res := <-req.result
+	return res.results, res.err
+}
+
+// run collects the requests sent within a window into batches and sends them
+func (tc *transactionCoalescer) run(stopCh <-chan struct{}) {
+	var pending *coalescedRequest
+	for {
+		batch := []*coalescedRequest{}
+		if pending == nil {
+			select {
+			case pending = <-tc.requests:
+			case <-stopCh:
+				tc.stop()
+				return
+			}
+		}
+		batch = append(batch, pending)
+		operations := len(pending.ops)
+		namedUUIDs := pending.namedUUIDs
+		pending = nil
+
+		timer := time.NewTimer(tc.window)
+	collect:
+		for operations < tc.maxOperations {
+			select {
+			case req := <-tc.requests:
+				// requests that don't fit into the batch or use the same named UUIDs as another request are sent
+				// with the next batch
+				if operations+len(req.ops) > tc.maxOperations || sharesNamedUUIDs(namedUUIDs, req.namedUUIDs) {
+					pending = req
+					break collect
+				}
+				batch = append(batch, req)
+				operations += len(req.ops)
+				namedUUIDs = mergeNamedUUIDs(namedUUIDs, req.namedUUIDs)
+			case <-timer.C:
+				break collect
+			case <-stopCh:
+				timer.Stop()
+				tc.stop()
+				tc.send(batch)
+				return
+			}
+		}
+		timer.Stop()
+		go tc.send(batch)
+	}
+}
+
+// stop makes the transactions of the client be sent separately, including the ones of the requests sent
+// concurrently with the stop
+func (tc *transactionCoalescer) stop() {
+	coalescersLock.Lock()
+	defer coalescersLock.Unlock()
+	if coalescers[tc.client] == tc {
+		delete(coalescers, tc.client)
+	}
+	close(tc.stopped)
+}
+
+// send sends the operations of the batch in a single transaction and dispatches the results to the requests
+func (tc *transactionCoalescer) send(batch []*coalescedRequest) {
+	start := time.Now()
+	stats := TransactionBatchStats{
+		DBName:         tc.client.Schema().Name,
+		Requests:       len(batch),
+		QueueDurations: make([]time.Duration, 0, len(batch)),
+	}
+	ops := make([]ovsdb.Operation, 0, len(batch[0].ops))
+	var deadline time.Time
+	for _, req := range batch {
+		ops = append(ops, req.ops...)
+		stats.QueueDurations = append(stats.QueueDurations, start.Sub(req.enqueued))
+		if !req.deadline.IsZero() && (deadline.IsZero() || req.deadline.Before(deadline)) {
+			deadline = req.deadline
+		}
+	}
+	stats.Operations = len(ops)
+	defer func() {
+		stats.Duration = time.Since(start)
+		if tc.observer != nil {
+			tc.observer(stats)
+		}
+	}()
+
+	ctx, cancel := contextWithDeadline(deadline)
+	defer cancel()
+
+	results, err := transactWithRetry(ctx, tc.client, ops)
+	if len(batch) == 1 {
+		batch[0].result <- coalescedResult{results: results, err: err}
+		return
+	}
+	if err != nil {
+		// the transaction might have been committed, so it can't be sent again
+		for _, req := range batch {
+			req.result <- coalescedResult{err: fmt.Errorf("failed to send transaction of %d coalesced requests: %w",
+				len(batch), err)}
+		}
+		return
+	}
+	if len(results) == len(ops) && !hasOperationErrors(results) {
+		offset := 0
+		for _, req := range batch {
+			req.result <- coalescedResult{results: results[offset : offset+len(req.ops)]}
+			offset += len(req.ops)
+		}
+		return
+	}
+
+	// nothing was committed, send the requests separately to report the failure to its caller only
+	klog.V(5).Infof("Transaction of %d coalesced requests failed, sending them separately: %+v", len(batch), results)
+	stats.Fallback = true
+	var wg sync.WaitGroup
+	for _, req := range batch {
+		wg.Add(1)
+		go func(req *coalescedRequest) {
+			defer wg.Done()
+			ctx, cancel := contextWithDeadline(req.deadline)
+			defer cancel()
+			results, err := transactWithRetry(ctx, tc.client, req.ops)
+			req.result <- coalescedResult{results: results, err: err}
+		}(req)
+	}
+	wg.Wait()
+}
+
+// contextWithDeadline returns a context with the deadline, if not zero
+func contextWithDeadline(deadline time.Time) (context.Context, context.CancelFunc) {
+	if deadline.IsZero() {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithDeadline(context.Background(), deadline)
+}
+
+func hasOperationErrors(results []ovsdb.OperationResult) bool {
+	for _, result := range results {
+		if result.Error != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func namedUUIDsOf(ops []ovsdb.Operation) map[string]bool {
+	namedUUIDs := map[string]bool{}
+	for _, op := range ops {
+		if op.UUIDName != "" {
+			namedUUIDs[op.UUIDName] = true
+		}
+	}
+	return namedUUIDs
+}
+
+func sharesNamedUUIDs(a, b map[string]bool) bool {
+	for namedUUID := range b {
+		if a[namedUUID] {
+			return true
+		}
+	}
+	return false
+}
+
+func mergeNamedUUIDs(a, b map[string]bool) map[string]bool {
+	merged := make(map[string]bool, len(a)+len(b))
+	for namedUUID := range a {
+		merged[namedUUID] = true
+	}
+	for namedUUID := range b {
+		merged[namedUUID] = true
+	}
+	return merged
+}
@@ -0,0 +1,209 @@
+package ops
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	libovsdb "github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+func TestTransactionCoalescing(t *testing.T) {
+	const requests = 10
+
+	fakeSwitch := &nbdb.LogicalSwitch{
+		UUID: buildNamedUUID(),
+		Name: "sw",
+	}
+
+	// failingWaitOps returns operations that fail, waiting for the switch to have another name
+	failingWaitOps := func() []libovsdb.Operation {
+		timeout := 0
+		return []libovsdb.Operation{{
+			Op:      libovsdb.OperationWait,
+			Table:   nbdb.LogicalSwitchTable,
+			Timeout: &timeout,
+			Where:   []libovsdb.Condition{{Column: "name", Function: libovsdb.ConditionEqual, Value: fakeSwitch.Name}},
+			Columns: []string{"name"},
+			Until:   string(libovsdb.WaitConditionEqual),
+			Rows:    []libovsdb.Row{{"name": "other"}},
+		}}
+	}
+
+	tests := []struct {
+		desc string
+		// failing are the indexes of the requests whose operations fail
+		failing map[int]bool
+	}{
+		{
+			desc: "merges concurrent transactions",
+		},
+		{
+			desc:    "reports failed operations to their caller only",
+			failing: map[int]bool{3: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{fakeSwitch},
+			}, nil)
+			if err != nil {
+				t.Fatalf("test: \"%s\" failed to set up test harness: %v", tt.desc, err)
+			}
+			t.Cleanup(cleanup.Cleanup)
+
+			var lock sync.Mutex
+			var batches []TransactionBatchStats
+			stopCh := make(chan struct{})
+			t.Cleanup(func() { close(stopCh) })
+			EnableTransactionCoalescing(nbClient, 100*time.Millisecond, 1000, func(stats TransactionBatchStats) {
+				lock.Lock()
+				defer lock.Unlock()
+				batches = append(batches, stats)
+			}, stopCh)
+			t.Cleanup(func() { DisableTransactionCoalescing(nbClient) })
+
+			expectedData := []libovsdbtest.TestData{fakeSwitch}
+			errs := make([]error, requests)
+			var wg sync.WaitGroup
+			for i := 0; i < requests; i++ {
+				var ops []libovsdb.Operation
+				if tt.failing[i] {
+					ops = failingWaitOps()
+				} else {
+					sw := &nbdb.LogicalSwitch{UUID: buildNamedUUID(), Name: fmt.Sprintf("sw%d", i)}
+					expectedData = append(expectedData, sw)
+					ops, err = nbClient.Create(sw)
+					if err != nil {
+						t.Fatalf("test: \"%s\" failed to build operations: %v", tt.desc, err)
+					}
+				}
+				wg.Add(1)
+				go func(i int, c libovsdbclient.Client, ops []libovsdb.Operation) {
+					defer wg.Done()
+					_, errs[i] = TransactAndCheck(c, ops)
+				}(i, nbClient, ops)
+			}
+			wg.Wait()
+
+			for i, err := range errs {
+				if tt.failing[i] && err == nil {
+					t.Fatalf("test: \"%s\" expected request %d to fail", tt.desc, i)
+				}
+				if !tt.failing[i] && err != nil {
+					t.Fatalf("test: \"%s\" expected request %d to succeed, got: %v", tt.desc, i, err)
+				}
+			}
+
+			lock.Lock()
+			merged, total, fallback := false, 0, false
+			for _, batch := range batches {
+				merged = merged || batch.Requests > 1
+				fallback = fallback || batch.Fallback
+				total += batch.Requests
+			}
+			lock.Unlock()
+			if !merged || total != requests {
+				t.Fatalf("test: \"%s\" expected %d requests to be merged, got batches %+v", tt.desc, requests, batches)
+			}
+			if fallback != (len(tt.failing) > 0) {
+				t.Fatalf("test: \"%s\" expected fallback %v, got batches %+v", tt.desc, len(tt.failing) > 0, batches)
+			}
+
+			matcher := libovsdbtest.HaveDataIgnoringUUIDs(expectedData)
+			success, err := matcher.Match(nbClient)
+			if !success {
+				t.Fatalf("test: \"%s\" didn't match expected with actual, err: %v", tt.desc, matcher.FailureMessage(nbClient))
+			}
+			if err != nil {
+				t.Fatalf("test: \"%s\" encountered error: %v", tt.desc, err)
+			}
+		})
+	}
+}
+
+func TestTransactionCoalescingDeadlines(t *testing.T) {
+	tests := []struct {
+		desc string
+		// timeout of the context of the request, none if 0
+		timeout time.Duration
+		// cancelAfter cancels the context of the request after it is queued
+		cancelAfter time.Duration
+		// expectBatch is true if the request is expected to be sent by the coalescer
+		expectBatch bool
+	}{
+		{
+			desc:        "sends a request with a short deadline in its own transaction",
+			timeout:     time.Second,
+			expectBatch: false,
+		},
+		{
+			desc:        "coalesces a request with a long deadline",
+			timeout:     types.OVSDBTimeout,
+			expectBatch: true,
+		},
+		{
+			desc:        "returns the result of a queued request whose context is done",
+			cancelAfter: 20 * time.Millisecond,
+			expectBatch: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{}, nil)
+			if err != nil {
+				t.Fatalf("test: \"%s\" failed to set up test harness: %v", tt.desc, err)
+			}
+			t.Cleanup(cleanup.Cleanup)
+
+			var lock sync.Mutex
+			var batches []TransactionBatchStats
+			stopCh := make(chan struct{})
+			t.Cleanup(func() { close(stopCh) })
+			EnableTransactionCoalescing(nbClient, 100*time.Millisecond, 1000, func(stats TransactionBatchStats) {
+				lock.Lock()
+				defer lock.Unlock()
+				batches = append(batches, stats)
+			}, stopCh)
+			t.Cleanup(func() { DisableTransactionCoalescing(nbClient) })
+
+			sw := &nbdb.LogicalSwitch{UUID: buildNamedUUID(), Name: "sw"}
+			ops, err := nbClient.Create(sw)
+			if err != nil {
+				t.Fatalf("test: \"%s\" failed to build operations: %v", tt.desc, err)
+			}
+			ctx, cancel := context.WithCancel(context.Background())
+			if tt.timeout > 0 {
+				ctx, cancel = context.WithTimeout(context.Background(), tt.timeout)
+			}
+			defer cancel()
+			if tt.cancelAfter > 0 {
+				time.AfterFunc(tt.cancelAfter, cancel)
+			}
+			if _, err := TransactWithRetry(ctx, nbClient, ops); err != nil {
+				t.Fatalf("test: \"%s\" expected the request to succeed, got: %v", tt.desc, err)
+			}
+
+			lock.Lock()
+			sent := len(batches) > 0
+			lock.Unlock()
+			if sent != tt.expectBatch {
+				t.Fatalf("test: \"%s\" expected the request to be sent by the coalescer: %v, got batches %+v",
+					tt.desc, tt.expectBatch, batches)
+			}
+			matcher := libovsdbtest.HaveDataIgnoringUUIDs([]libovsdbtest.TestData{sw})
+			if success, err := matcher.Match(nbClient); !success || err != nil {
+				t.Fatalf("test: \"%s\" didn't match expected with actual, err: %v: %v", tt.desc, err,
+					matcher.FailureMessage(nbClient))
+			}
+		})
+	}
+}
@@ -34,6 +34,10 @@ func (it ObjectIDsType) GetExternalIDKeys() []ExternalIDKey {
 	return it.externalIDKeys
 }
 
+func (it ObjectIDsType) GetOwnerType() string {
+	return string(it.ownerObjectType)
+}
+
 func (it ObjectIDsType) HasKey(key ExternalIDKey) bool {
 	return it.externalIDsMap[key]
 }
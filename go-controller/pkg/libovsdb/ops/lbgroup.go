@@ -79,6 +79,6 @@ func FindLoadBalancerGroupsWithPredicate(nbClient libovsdbclient.Client, p loadB
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	groups := []*nbdb.LoadBalancerGroup{}
-	err := nbClient.WhereCache(p).List(ctx, &groups)
+	err := whereCacheList(ctx, nbClient, p, &groups)
 	return groups, err
 }
@@ -135,6 +135,6 @@ func ListLoadBalancers(nbClient libovsdbclient.Client) ([]*nbdb.LoadBalancer, er
 	lbs := []*nbdb.LoadBalancer{}
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
-	err := nbClient.List(ctx, &lbs)
+	err := cacheList(ctx, nbClient, &lbs)
 	return lbs, err
 }
@@ -470,6 +470,11 @@ func (m *modelClient) where(opModel *operationModel) error {
 	if err = m.client.Where(copyModel).List(ctx, opModel.ExistingResult); err != nil {
 		return err
 	}
+	if r := getShadowRecorder(m.client); r != nil {
+		if err = r.appendOverlayRowsByModel(opModel.ExistingResult, copyModel); err != nil {
+			return err
+		}
+	}
 	if opModel.Model == nil || opModel.BulkOp {
 		return nil
 	}
@@ -487,7 +492,7 @@ func (m *modelClient) whereCache(opModel *operationModel) error {
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	var err error
-	if err = m.client.WhereCache(opModel.ModelPredicate).List(ctx, opModel.ExistingResult); err != nil {
+	if err = whereCacheList(ctx, m.client, opModel.ModelPredicate, opModel.ExistingResult); err != nil {
 		return err
 	}
 
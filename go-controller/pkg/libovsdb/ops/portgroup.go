@@ -17,7 +17,7 @@ func FindPortGroupsWithPredicate(nbClient libovsdbclient.Client, p portGroupPred
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.PortGroup{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
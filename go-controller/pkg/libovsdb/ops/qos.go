@@ -18,7 +18,7 @@ func FindQoSesWithPredicate(nbClient libovsdbclient.Client, p QoSPredicate) ([]*
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.QoS{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
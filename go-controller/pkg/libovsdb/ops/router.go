@@ -42,7 +42,7 @@ func FindLogicalRoutersWithPredicate(nbClient libovsdbclient.Client, p logicalRo
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.LogicalRouter{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
@@ -145,7 +145,7 @@ func FindLogicalRouterPortWithPredicate(nbClient libovsdbclient.Client, p logica
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.LogicalRouterPort{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
@@ -252,7 +252,7 @@ func FindLogicalRouterPoliciesWithPredicate(nbClient libovsdbclient.Client, p lo
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.LogicalRouterPolicy{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
@@ -560,7 +560,7 @@ func FindLogicalRouterStaticRoutesWithPredicate(nbClient libovsdbclient.Client,
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
 	found := []*nbdb.LogicalRouterStaticRoute{}
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
@@ -764,6 +764,17 @@ func DeleteLogicalRouterStaticRoutes(nbClient libovsdbclient.Client, routerName
 
 // BFD ops
 
+type bfdPredicate func(*nbdb.BFD) bool
+
+// FindBFDsWithPredicate looks up BFDs from the cache based on a given predicate
+func FindBFDsWithPredicate(nbClient libovsdbclient.Client, p bfdPredicate) ([]*nbdb.BFD, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+	defer cancel()
+	found := []*nbdb.BFD{}
+	err := whereCacheList(ctx, nbClient, p, &found)
+	return found, err
+}
+
 // CreateOrUpdateBFDOps creates or updates the provided BFDs and returns
 // the corresponding ops
 func CreateOrUpdateBFDOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, bfds ...*nbdb.BFD) ([]libovsdb.Operation, error) {
@@ -783,6 +794,26 @@ func CreateOrUpdateBFDOps(nbClient libovsdbclient.Client, ops []libovsdb.Operati
 	return m.CreateOrUpdateOps(ops, opModels...)
 }
 
+// CreateOrUpdateBFDWithTimersOps creates or updates the provided BFDs and returns
+// the corresponding ops. Unlike CreateOrUpdateBFDOps, the BFD timers are always
+// updated so that unset timers are reset to the OVN defaults.
+func CreateOrUpdateBFDWithTimersOps(nbClient libovsdbclient.Client, ops []libovsdb.Operation, bfds ...*nbdb.BFD) ([]libovsdb.Operation, error) {
+	opModels := make([]operationModel, 0, len(bfds))
+	for i := range bfds {
+		bfd := bfds[i]
+		opModel := operationModel{
+			Model:          bfd,
+			OnModelUpdates: []interface{}{&bfd.MinTx, &bfd.MinRx, &bfd.DetectMult},
+			ErrNotFound:    false,
+			BulkOp:         false,
+		}
+		opModels = append(opModels, opModel)
+	}
+
+	m := newModelClient(nbClient)
+	return m.CreateOrUpdateOps(ops, opModels...)
+}
+
 // DeleteBFDs deletes the provided BFDs
 func DeleteBFDs(nbClient libovsdbclient.Client, bfds ...*nbdb.BFD) error {
 	opModels := make([]operationModel, 0, len(bfds))
@@ -1003,7 +1034,7 @@ func FindNATsWithPredicate(nbClient libovsdbclient.Client, predicate natPredicat
 	nats := []*nbdb.NAT{}
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
-	err := nbClient.WhereCache(predicate).List(ctx, &nats)
+	err := whereCacheList(ctx, nbClient, predicate, &nats)
 	return nats, err
 }
 
@@ -0,0 +1,638 @@
+package ops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/libovsdb/cache"
+	"github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/mapper"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/libovsdb/updates"
+)
+
+// DefaultShadowMaxOperations is the default number of distinct operations a shadow recorder keeps
+const DefaultShadowMaxOperations = 10000
+
+var (
+	shadowRecordersLock sync.RWMutex
+	// shadowRecorders are the recorders of the clients in shadow mode
+	shadowRecorders = map[client.Client]*ShadowRecorder{}
+
+	namedUUIDRegexp = regexp.MustCompile(`\["named-uuid","[^"]*"\]`)
+)
+
+// ShadowOperation is an operation a client in shadow mode would have sent to the database, reduced to the
+// changes it would have made to the database
+type ShadowOperation struct {
+	Table string `json:"table"`
+	Op    string `json:"op"`
+	// UUIDs of the existing rows the operation changes, empty for inserts
+	UUIDs []string `json:"uuids,omitempty"`
+	// Row holds the columns an insert sets, or the columns an update changes with their new values
+	Row ovsdb.Row `json:"row,omitempty"`
+	// Mutations are the mutations that change the rows
+	Mutations []ovsdb.Mutation `json:"mutations,omitempty"`
+	// Count is the number of times the operation was recorded
+	Count     int       `json:"count"`
+	FirstSeen time.Time `json:"firstSeen"`
+	LastSeen  time.Time `json:"lastSeen"`
+}
+
+// ShadowStats are the statistics of a shadow recorder
+type ShadowStats struct {
+	DBName string `json:"dbName"`
+	// Transactions is the number of transactions that were recorded instead of being sent to the database
+	Transactions int `json:"transactions"`
+	// UnchangedOperations is the number of operations that were not recorded since they wouldn't have changed the
+	// database
+	UnchangedOperations int `json:"unchangedOperations"`
+	// Operations is the number of distinct recorded operations by table and operation
+	Operations map[string]map[string]int `json:"operations"`
+}
+
+// ShadowRecorder records the operations a client in shadow mode would have sent to the database instead of sending
+// them. Operations are compared with the client cache, so that only the ones that would have changed the database
+// are recorded: the recorded operations are the diff between the state the client wants and the current database.
+// Identical operations are recorded once, and the least recently seen operations are dropped beyond maxOperations.
+// The rows the recorded operations insert are kept in an overlay of the client cache, along with the later changes
+// to them, so that the lookups of this package find them and the client doesn't insert them again. Changes to rows
+// that are in the client cache are not overlaid: lookups keep returning the cached rows as they are in the database.
+type ShadowRecorder struct {
+	sync.Mutex
+	dbName        string
+	dbModel       model.DatabaseModel
+	maxOperations int
+	transactions  int
+	unchanged     int
+	operations    map[string]*ShadowOperation
+	// overlay holds the rows inserted by the recorded operations
+	overlay *cache.TableCache
+}
+
+// EnableShadowMode makes the transactions of the client, through TransactWithRetry and the functions built on it,
+// be recorded instead of being sent to the database, and returns the recorder.
+func EnableShadowMode(c client.Client, maxOperations int) *ShadowRecorder {
+	shadowRecordersLock.Lock()
+	defer shadowRecordersLock.Unlock()
+	if r, ok := shadowRecorders[c]; ok {
+		return r
+	}
+	r := &ShadowRecorder{
+		dbName:        c.Schema().Name,
+		dbModel:       c.Cache().DatabaseModel(),
+		maxOperations: maxOperations,
+		operations:    map[string]*ShadowOperation{},
+	}
+	r.overlay = r.newOverlay()
+	shadowRecorders[c] = r
+	klog.Infof("Enabled shadow mode for %s: transactions are recorded instead of being sent to the database", r.dbName)
+	return r
+}
+
+// DisableShadowMode makes the transactions of the client be sent to the database again
+func DisableShadowMode(c client.Client) {
+	shadowRecordersLock.Lock()
+	defer shadowRecordersLock.Unlock()
+	delete(shadowRecorders, c)
+}
+
+func getShadowRecorder(c client.Client) *ShadowRecorder {
+	shadowRecordersLock.RLock()
+	defer shadowRecordersLock.RUnlock()
+	return shadowRecorders[c]
+}
+
+// GetShadowRecorders returns the recorders of the clients in shadow mode, sorted by database name
+func GetShadowRecorders() []*ShadowRecorder {
+	shadowRecordersLock.RLock()
+	defer shadowRecordersLock.RUnlock()
+	recorders := make([]*ShadowRecorder, 0, len(shadowRecorders))
+	for _, r := range shadowRecorders {
+		recorders = append(recorders, r)
+	}
+	sort.Slice(recorders, func(i, j int) bool { return recorders[i].dbName < recorders[j].dbName })
+	return recorders
+}
+
+// DBName returns the name of the database of the recorder
+func (r *ShadowRecorder) DBName() string {
+	return r.dbName
+}
+
+// Operations returns the recorded operations, from the least to the most recently seen
+func (r *ShadowRecorder) Operations() []ShadowOperation {
+	r.Lock()
+	defer r.Unlock()
+	operations := make([]ShadowOperation, 0, len(r.operations))
+	for _, op := range r.operations {
+		operations = append(operations, *op)
+	}
+	sort.SliceStable(operations, func(i, j int) bool { return operations[i].LastSeen.Before(operations[j].LastSeen) })
+	return operations
+}
+
+// Stats returns the statistics of the recorder
+func (r *ShadowRecorder) Stats() ShadowStats {
+	r.Lock()
+	defer r.Unlock()
+	stats := ShadowStats{
+		DBName:              r.dbName,
+		Transactions:        r.transactions,
+		UnchangedOperations: r.unchanged,
+		Operations:          map[string]map[string]int{},
+	}
+	for _, op := range r.operations {
+		if stats.Operations[op.Table] == nil {
+			stats.Operations[op.Table] = map[string]int{}
+		}
+		stats.Operations[op.Table][op.Op]++
+	}
+	return stats
+}
+
+// Reset drops the recorded operations and statistics
+func (r *ShadowRecorder) Reset() {
+	r.Lock()
+	defer r.Unlock()
+	r.transactions = 0
+	r.unchanged = 0
+	r.operations = map[string]*ShadowOperation{}
+	r.overlay = r.newOverlay()
+}
+
+// newOverlay returns an empty overlay, nil if it can't be created in which case inserted rows are not overlaid
+func (r *ShadowRecorder) newOverlay() *cache.TableCache {
+	overlay, err := cache.NewTableCache(r.dbModel, nil, nil)
+	if err != nil {
+		klog.Warningf("Failed to create the shadow overlay of %s, inserted rows won't be found by lookups: %v",
+			r.dbName, err)
+		return nil
+	}
+	return overlay
+}
+
+// record records the operations of a transaction and returns the results the database would have returned
+func (r *ShadowRecorder) record(c client.Client, ops []ovsdb.Operation) []ovsdb.OperationResult {
+	r.Lock()
+	defer r.Unlock()
+	results := make([]ovsdb.OperationResult, len(ops))
+	for i := range ops {
+		if ops[i].Op == ovsdb.OperationInsert {
+			results[i].UUID = ovsdb.UUID{GoUUID: uuid.NewString()}
+		}
+	}
+	// the overlay is changed with the operations where the named UUIDs are replaced with the UUIDs returned for
+	// the inserts, the operations are recorded with the named UUIDs so that identical operations are recorded once
+	expanded, err := expandShadowNamedUUIDs(c, ops, results)
+	if err != nil {
+		klog.Warningf("Failed to expand the named UUIDs of shadow transaction %+v: %v", ops, err)
+		expanded = nil
+	}
+
+	changes := make([]*ShadowOperation, 0, len(ops))
+	unchanged := 0
+	for i, op := range ops {
+		where := op.Where
+		if expanded != nil {
+			where = expanded[i].Where
+		}
+		change, count, err := shadowChange(c, r.overlay, &op, where)
+		if err != nil {
+			// record the whole operation when its changes can't be computed
+			klog.Warningf("Failed to compute the changes of shadow operation %+v: %v", op, err)
+			change = &ShadowOperation{Table: op.Table, Op: op.Op, Row: op.Row, Mutations: op.Mutations}
+		}
+		results[i].Count = count
+		if expanded != nil {
+			if err := r.applyToOverlay(&expanded[i], results[i].UUID.GoUUID); err != nil {
+				klog.Warningf("Failed to apply shadow operation %+v to the overlay: %v", op, err)
+			}
+		}
+		if change == nil {
+			if op.Op == ovsdb.OperationUpdate || op.Op == ovsdb.OperationMutate || op.Op == ovsdb.OperationDelete {
+				unchanged++
+			}
+			continue
+		}
+		changes = append(changes, change)
+	}
+
+	now := time.Now()
+	r.transactions++
+	r.unchanged += unchanged
+	for _, change := range changes {
+		key := change.key()
+		if existing, ok := r.operations[key]; ok {
+			existing.Count++
+			existing.LastSeen = now
+			continue
+		}
+		change.Count = 1
+		change.FirstSeen = now
+		change.LastSeen = now
+		r.operations[key] = change
+	}
+	r.evict()
+	klog.V(5).Infof("Recorded shadow transaction on %s: %+v", r.dbName, ops)
+	return results
+}
+
+// evict drops the least recently seen operations beyond maxOperations
+func (r *ShadowRecorder) evict() {
+	if r.maxOperations <= 0 || len(r.operations) <= r.maxOperations {
+		return
+	}
+	keys := make([]string, 0, len(r.operations))
+	for key := range r.operations {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return r.operations[keys[i]].LastSeen.Before(r.operations[keys[j]].LastSeen) })
+	for _, key := range keys[:len(keys)-r.maxOperations] {
+		delete(r.operations, key)
+	}
+}
+
+// expandShadowNamedUUIDs returns a copy of the operations where the named UUIDs are replaced with the UUIDs of
+// the results of the inserts
+func expandShadowNamedUUIDs(c client.Client, ops []ovsdb.Operation, results []ovsdb.OperationResult) ([]ovsdb.Operation, error) {
+	expanded := make([]ovsdb.Operation, len(ops))
+	for i, op := range ops {
+		if op.Op == ovsdb.OperationInsert {
+			op.UUID = results[i].UUID.GoUUID
+		}
+		if op.Row != nil {
+			op.Row = copyOvsRow(op.Row)
+		}
+		if op.Rows != nil {
+			rows := make([]ovsdb.Row, len(op.Rows))
+			for j := range op.Rows {
+				rows[j] = copyOvsRow(op.Rows[j])
+			}
+			op.Rows = rows
+		}
+		op.Where = append([]ovsdb.Condition(nil), op.Where...)
+		op.Mutations = append([]ovsdb.Mutation(nil), op.Mutations...)
+		expanded[i] = op
+	}
+	schema := c.Schema()
+	return ovsdb.ExpandNamedUUIDs(expanded, &schema)
+}
+
+func copyOvsRow(row ovsdb.Row) ovsdb.Row {
+	copied := make(ovsdb.Row, len(row))
+	for column, value := range row {
+		copied[column] = value
+	}
+	return copied
+}
+
+// applyToOverlay inserts the row of an insert into the overlay, or applies an update, mutate or delete to the
+// overlay rows it matches
+func (r *ShadowRecorder) applyToOverlay(op *ovsdb.Operation, insertUUID string) error {
+	if r.overlay == nil {
+		return nil
+	}
+	tableCache := r.overlay.Table(op.Table)
+	if tableCache == nil {
+		return fmt.Errorf("table %s not found in overlay", op.Table)
+	}
+	var rows map[string]model.Model
+	switch op.Op {
+	case ovsdb.OperationInsert:
+		rows = map[string]model.Model{insertUUID: nil}
+	case ovsdb.OperationUpdate, ovsdb.OperationMutate, ovsdb.OperationDelete:
+		var err error
+		rows, err = tableCache.RowsByCondition(op.Where)
+		if err != nil {
+			return err
+		}
+	default:
+		return nil
+	}
+	for rowUUID, current := range rows {
+		if op.Op == ovsdb.OperationDelete {
+			if err := tableCache.Delete(rowUUID); err != nil {
+				return err
+			}
+			continue
+		}
+		modelUpdates := updates.ModelUpdates{}
+		if err := modelUpdates.AddOperation(r.dbModel, op.Table, rowUUID, current, op); err != nil {
+			return err
+		}
+		m := modelUpdates.GetModel(op.Table, rowUUID)
+		if m == nil {
+			// the operation doesn't change the row
+			continue
+		}
+		var err error
+		if op.Op == ovsdb.OperationInsert {
+			err = tableCache.Create(rowUUID, m, false)
+		} else {
+			_, err = tableCache.Update(rowUUID, m, false)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendOverlayRows appends to result, a pointer to a slice of models, the overlay rows that match
+func (r *ShadowRecorder) appendOverlayRows(result interface{}, match func(model.Model) (bool, error)) error {
+	r.Lock()
+	defer r.Unlock()
+	if r.overlay == nil {
+		return nil
+	}
+	resultVal := reflect.Indirect(reflect.ValueOf(result))
+	elemType := resultVal.Type().Elem()
+	modelType := elemType
+	if elemType.Kind() != reflect.Ptr {
+		modelType = reflect.PtrTo(elemType)
+	}
+	tableCache := r.overlay.Table(r.dbModel.FindTable(modelType))
+	if tableCache == nil {
+		return nil
+	}
+	for _, row := range tableCache.Rows() {
+		matches, err := match(row)
+		if err != nil {
+			return err
+		}
+		if !matches {
+			continue
+		}
+		rowVal := reflect.ValueOf(row)
+		if elemType.Kind() != reflect.Ptr {
+			rowVal = rowVal.Elem()
+		}
+		resultVal.Set(reflect.Append(resultVal, rowVal))
+	}
+	return nil
+}
+
+// appendOverlayRowsByModel appends to result, a pointer to a slice of models, the overlay rows that have the
+// UUID or an index of the model
+func (r *ShadowRecorder) appendOverlayRowsByModel(result interface{}, m model.Model) error {
+	r.Lock()
+	overlay := r.overlay
+	r.Unlock()
+	if overlay == nil {
+		return nil
+	}
+	tableCache := overlay.Table(r.dbModel.FindTable(reflect.TypeOf(m)))
+	if tableCache == nil {
+		return nil
+	}
+	rows, err := tableCache.RowsByModels([]model.Model{m})
+	if err != nil {
+		return err
+	}
+	uuids := make(map[string]bool, len(rows))
+	for rowUUID := range rows {
+		uuids[rowUUID] = true
+	}
+	return r.appendOverlayRows(result, func(row model.Model) (bool, error) {
+		return uuids[getUUID(row)], nil
+	})
+}
+
+// whereCacheList lists into result the cached rows that match the predicate, along with the rows inserted by the
+// client in shadow mode that match it
+func whereCacheList(ctx context.Context, c client.Client, predicate interface{}, result interface{}) error {
+	if err := c.WhereCache(predicate).List(ctx, result); err != nil {
+		return err
+	}
+	r := getShadowRecorder(c)
+	if r == nil {
+		return nil
+	}
+	return r.appendOverlayRows(result, func(row model.Model) (bool, error) {
+		return reflect.ValueOf(predicate).Call([]reflect.Value{reflect.ValueOf(row)})[0].Bool(), nil
+	})
+}
+
+// cacheList lists into result the cached rows, along with the rows inserted by the client in shadow mode
+func cacheList(ctx context.Context, c client.Client, result interface{}) error {
+	if err := c.List(ctx, result); err != nil {
+		return err
+	}
+	r := getShadowRecorder(c)
+	if r == nil {
+		return nil
+	}
+	return r.appendOverlayRows(result, func(model.Model) (bool, error) { return true, nil })
+}
+
+// key identifies identical operations, named UUIDs are generated per transaction and therefore ignored
+func (op *ShadowOperation) key() string {
+	row, _ := json.Marshal(op.Row)
+	mutations, _ := json.Marshal(op.Mutations)
+	return namedUUIDRegexp.ReplaceAllString(
+		strings.Join([]string{op.Table, op.Op, strings.Join(op.UUIDs, ","), string(row), string(mutations)}, "|"),
+		`["named-uuid"]`)
+}
+
+// shadowChange returns the changes the operation would make to the database given the client cache, nil if it
+// wouldn't change it, and the number of rows it would apply to
+func shadowChange(c client.Client, overlay *cache.TableCache, op *ovsdb.Operation, where []ovsdb.Condition) (*ShadowOperation, int, error) {
+	switch op.Op {
+	case ovsdb.OperationInsert:
+		return &ShadowOperation{Table: op.Table, Op: op.Op, Row: op.Row}, 1, nil
+	case ovsdb.OperationUpdate, ovsdb.OperationMutate, ovsdb.OperationDelete:
+	default:
+		// other operations don't change the database
+		return nil, 0, nil
+	}
+
+	tableCache := c.Cache().Table(op.Table)
+	if tableCache == nil {
+		return nil, 0, fmt.Errorf("table %s not found in cache", op.Table)
+	}
+	rows, err := tableCache.RowsByCondition(where)
+	if err != nil {
+		return nil, 0, err
+	}
+	if overlay != nil && overlay.Table(op.Table) != nil {
+		overlayRows, err := overlay.Table(op.Table).RowsByCondition(where)
+		if err != nil {
+			return nil, 0, err
+		}
+		if len(overlayRows) > 0 && rows == nil {
+			rows = map[string]model.Model{}
+		}
+		for rowUUID, row := range overlayRows {
+			rows[rowUUID] = row
+		}
+	}
+	if len(rows) == 0 {
+		return nil, 0, nil
+	}
+
+	change := &ShadowOperation{Table: op.Table, Op: op.Op}
+	for rowUUID, row := range rows {
+		info, err := c.Cache().DatabaseModel().NewModelInfo(row)
+		if err != nil {
+			return nil, 0, err
+		}
+		changed := false
+		switch op.Op {
+		case ovsdb.OperationDelete:
+			changed = true
+		case ovsdb.OperationUpdate:
+			for column, value := range op.Row {
+				current, err := getOvsColumn(info, column)
+				if err != nil {
+					return nil, 0, err
+				}
+				if !ovsValuesEqual(current, value) {
+					if change.Row == nil {
+						change.Row = ovsdb.Row{}
+					}
+					change.Row[column] = value
+					changed = true
+				}
+			}
+		case ovsdb.OperationMutate:
+			for _, mutation := range op.Mutations {
+				current, err := getOvsColumn(info, mutation.Column)
+				if err != nil {
+					return nil, 0, err
+				}
+				if mutationChanges(current, mutation) {
+					change.Mutations = appendMutation(change.Mutations, mutation)
+					changed = true
+				}
+			}
+		}
+		if changed {
+			change.UUIDs = append(change.UUIDs, rowUUID)
+		}
+	}
+	if len(change.UUIDs) == 0 {
+		return nil, len(rows), nil
+	}
+	sort.Strings(change.UUIDs)
+	return change, len(rows), nil
+}
+
+// getOvsColumn returns the value of a column of a cached row in its OVSDB notation
+func getOvsColumn(info *mapper.Info, column string) (interface{}, error) {
+	native, err := info.FieldByColumn(column)
+	if err != nil {
+		return nil, err
+	}
+	columnSchema := info.Metadata.TableSchema.Column(column)
+	if columnSchema == nil {
+		return nil, fmt.Errorf("column %s not found in table %s", column, info.Metadata.TableName)
+	}
+	return ovsdb.NativeToOvs(columnSchema, native)
+}
+
+func appendMutation(mutations []ovsdb.Mutation, mutation ovsdb.Mutation) []ovsdb.Mutation {
+	for _, m := range mutations {
+		if m.Column == mutation.Column && m.Mutator == mutation.Mutator {
+			return mutations
+		}
+	}
+	return append(mutations, mutation)
+}
+
+// mutationChanges returns whether the mutation changes the current value of the column
+func mutationChanges(current interface{}, mutation ovsdb.Mutation) bool {
+	currentElems := ovsElements(current)
+	switch mutation.Mutator {
+	case ovsdb.MutateOperationInsert:
+		// inserting an existing key into a map doesn't change its value
+		if m, ok := mutation.Value.(ovsdb.OvsMap); ok {
+			currentKeys := ovsKeys(current)
+			for key := range m.GoMap {
+				if !currentKeys[fmt.Sprint(key)] {
+					return true
+				}
+			}
+			return false
+		}
+		for elem := range ovsElements(mutation.Value) {
+			if !currentElems[elem] {
+				return true
+			}
+		}
+		return false
+	case ovsdb.MutateOperationDelete:
+		// a set of keys can be deleted from a map
+		if _, isMap := current.(ovsdb.OvsMap); isMap {
+			if _, isSet := mutation.Value.(ovsdb.OvsSet); isSet {
+				currentKeys := ovsKeys(current)
+				for elem := range ovsElements(mutation.Value) {
+					if currentKeys[elem] {
+						return true
+					}
+				}
+				return false
+			}
+		}
+		for elem := range ovsElements(mutation.Value) {
+			if currentElems[elem] {
+				return true
+			}
+		}
+		return false
+	}
+	return true
+}
+
+// ovsValuesEqual compares two values of a column, regardless of the order of the elements of sets
+func ovsValuesEqual(a, b interface{}) bool {
+	elemsA, elemsB := ovsElements(a), ovsElements(b)
+	if len(elemsA) != len(elemsB) {
+		return false
+	}
+	for elem := range elemsA {
+		if !elemsB[elem] {
+			return false
+		}
+	}
+	return true
+}
+
+// ovsElements returns the elements of a set, the key-value pairs of a map, or the value of an atomic column
+func ovsElements(value interface{}) map[string]bool {
+	elems := map[string]bool{}
+	switch v := value.(type) {
+	case ovsdb.OvsSet:
+		for _, elem := range v.GoSet {
+			elems[fmt.Sprint(elem)] = true
+		}
+	case ovsdb.OvsMap:
+		for key, val := range v.GoMap {
+			elems[fmt.Sprintf("%v=%v", key, val)] = true
+		}
+	default:
+		elems[fmt.Sprint(v)] = true
+	}
+	return elems
+}
+
+func ovsKeys(value interface{}) map[string]bool {
+	keys := map[string]bool{}
+	if m, ok := value.(ovsdb.OvsMap); ok {
+		for key := range m.GoMap {
+			keys[fmt.Sprint(key)] = true
+		}
+	}
+	return keys
+}
@@ -0,0 +1,208 @@
+package ops
+
+import (
+	"fmt"
+	"testing"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	libovsdb "github.com/ovn-org/libovsdb/ovsdb"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+)
+
+func TestShadowMode(t *testing.T) {
+	fakeLB := &nbdb.LoadBalancer{
+		UUID: "lb-uuid",
+		Name: "lb1",
+	}
+	fakeSwitch := &nbdb.LogicalSwitch{
+		UUID:         "sw1-uuid",
+		Name:         "sw1",
+		ExternalIDs:  map[string]string{"key": "a"},
+		LoadBalancer: []string{fakeLB.UUID},
+	}
+	initialNbdb := []libovsdbtest.TestData{fakeLB, fakeSwitch}
+
+	tests := []struct {
+		desc string
+		// transact runs the transactions in shadow mode
+		transact func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error
+		// expectedOps are the table and operation of the expected recorded operations
+		expectedOps [][2]string
+		// expectedRow are the columns of the update expected to be recorded
+		expectedRow []string
+		// expectedUnchanged is the expected number of operations that wouldn't have changed the database
+		expectedUnchanged int
+	}{
+		{
+			desc: "records the creation of a switch",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				return CreateOrUpdateLogicalSwitch(nbClient, &nbdb.LogicalSwitch{Name: "sw2"})
+			},
+			expectedOps: [][2]string{{nbdb.LogicalSwitchTable, libovsdb.OperationInsert}},
+		},
+		{
+			desc: "finds the switch it inserted instead of inserting it again",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				for i := 0; i < 2; i++ {
+					if err := CreateOrUpdateLogicalSwitch(nbClient, &nbdb.LogicalSwitch{Name: "sw2"}); err != nil {
+						return err
+					}
+				}
+				_, err := GetLogicalSwitch(nbClient, &nbdb.LogicalSwitch{Name: "sw2"})
+				return err
+			},
+			expectedOps:       [][2]string{{nbdb.LogicalSwitchTable, libovsdb.OperationInsert}},
+			expectedUnchanged: 1,
+		},
+		{
+			desc: "records the changes to the switch it inserted once",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				if err := CreateOrUpdateLogicalSwitch(nbClient, &nbdb.LogicalSwitch{Name: "sw2"}); err != nil {
+					return err
+				}
+				for i := 0; i < 2; i++ {
+					err := UpdateLogicalSwitchSetExternalIDs(nbClient, &nbdb.LogicalSwitch{
+						Name:        "sw2",
+						ExternalIDs: map[string]string{"key": "b"},
+					})
+					if err != nil {
+						return err
+					}
+				}
+				sws, err := FindLogicalSwitchesWithPredicate(nbClient, func(sw *nbdb.LogicalSwitch) bool {
+					return sw.ExternalIDs["key"] == "b"
+				})
+				if err != nil {
+					return err
+				}
+				if len(sws) != 1 || sws[0].Name != "sw2" {
+					return fmt.Errorf("expected to find the updated switch sw2, found %+v", sws)
+				}
+				return nil
+			},
+			expectedOps: [][2]string{
+				{nbdb.LogicalSwitchTable, libovsdb.OperationInsert},
+				{nbdb.LogicalSwitchTable, libovsdb.OperationUpdate},
+			},
+			expectedUnchanged: 1,
+		},
+		{
+			desc: "records only the changed columns of an update",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				return CreateOrUpdateLogicalSwitch(nbClient, &nbdb.LogicalSwitch{
+					Name:         "sw1",
+					ExternalIDs:  map[string]string{"key": "b"},
+					LoadBalancer: []string{lb.UUID},
+				})
+			},
+			expectedOps: [][2]string{{nbdb.LogicalSwitchTable, libovsdb.OperationUpdate}},
+			expectedRow: []string{"external_ids"},
+		},
+		{
+			desc: "skips an update that doesn't change the database",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				return UpdateLogicalSwitchSetExternalIDs(nbClient, &nbdb.LogicalSwitch{
+					Name:        "sw1",
+					ExternalIDs: map[string]string{"key": "a"},
+				})
+			},
+			expectedUnchanged: 1,
+		},
+		{
+			desc: "skips a mutation that doesn't change the database",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				ops, err := AddLoadBalancersToLogicalSwitchOps(nbClient, nil, &nbdb.LogicalSwitch{Name: "sw1"}, lb)
+				if err != nil {
+					return err
+				}
+				_, err = TransactAndCheck(nbClient, ops)
+				return err
+			},
+			expectedUnchanged: 1,
+		},
+		{
+			desc: "records a mutation that changes the database",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				ops, err := RemoveLoadBalancersFromLogicalSwitchOps(nbClient, nil, &nbdb.LogicalSwitch{Name: "sw1"}, lb)
+				if err != nil {
+					return err
+				}
+				_, err = TransactAndCheck(nbClient, ops)
+				return err
+			},
+			expectedOps: [][2]string{{nbdb.LogicalSwitchTable, libovsdb.OperationMutate}},
+		},
+		{
+			desc: "records identical transactions once",
+			transact: func(nbClient libovsdbclient.Client, lb *nbdb.LoadBalancer) error {
+				for i := 0; i < 2; i++ {
+					if err := DeleteLogicalSwitch(nbClient, "sw1"); err != nil {
+						return err
+					}
+				}
+				return nil
+			},
+			expectedOps: [][2]string{{nbdb.LogicalSwitchTable, libovsdb.OperationDelete}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.desc, func(t *testing.T) {
+			nbClient, cleanup, err := libovsdbtest.NewNBTestHarness(libovsdbtest.TestSetup{NBData: initialNbdb}, nil)
+			if err != nil {
+				t.Fatalf("test: \"%s\" failed to set up test harness: %v", tt.desc, err)
+			}
+			t.Cleanup(cleanup.Cleanup)
+
+			// test data named UUIDs are replaced when creating the database
+			lbs, err := ListLoadBalancers(nbClient)
+			if err != nil || len(lbs) != 1 {
+				t.Fatalf("test: \"%s\" failed to list load balancers: %v", tt.desc, err)
+			}
+			lb := lbs[0]
+
+			recorder := EnableShadowMode(nbClient, DefaultShadowMaxOperations)
+			t.Cleanup(func() { DisableShadowMode(nbClient) })
+
+			if err = tt.transact(nbClient, lb); err != nil {
+				t.Fatalf("test: \"%s\" failed to transact: %v", tt.desc, err)
+			}
+
+			operations := recorder.Operations()
+			if len(operations) != len(tt.expectedOps) {
+				t.Fatalf("test: \"%s\" expected operations %v, recorded %+v", tt.desc, tt.expectedOps, operations)
+			}
+			for i, op := range operations {
+				if op.Table != tt.expectedOps[i][0] || op.Op != tt.expectedOps[i][1] {
+					t.Fatalf("test: \"%s\" expected operation %v, recorded %+v", tt.desc, tt.expectedOps[i], op)
+				}
+				if op.Op == libovsdb.OperationDelete && op.Count != 2 {
+					t.Fatalf("test: \"%s\" expected identical operations to be recorded once, recorded %+v", tt.desc, op)
+				}
+			}
+			if tt.expectedRow != nil {
+				if len(operations[0].Row) != len(tt.expectedRow) {
+					t.Fatalf("test: \"%s\" expected updated columns %v, recorded %v", tt.desc, tt.expectedRow, operations[0].Row)
+				}
+				for _, column := range tt.expectedRow {
+					if _, ok := operations[0].Row[column]; !ok {
+						t.Fatalf("test: \"%s\" expected updated columns %v, recorded %v", tt.desc, tt.expectedRow, operations[0].Row)
+					}
+				}
+			}
+			if stats := recorder.Stats(); stats.UnchangedOperations != tt.expectedUnchanged {
+				t.Fatalf("test: \"%s\" expected %d unchanged operations, got %d", tt.desc, tt.expectedUnchanged, stats.UnchangedOperations)
+			}
+
+			matcher := libovsdbtest.HaveData(initialNbdb)
+			success, err := matcher.Match(nbClient)
+			if !success {
+				t.Fatalf("test: \"%s\" expected the database to be unchanged: %v", tt.desc, matcher.FailureMessage(nbClient))
+			}
+			if err != nil {
+				t.Fatalf("test: \"%s\" encountered error: %v", tt.desc, err)
+			}
+		})
+	}
+}
@@ -22,7 +22,7 @@ func FindLogicalSwitchesWithPredicate(nbClient libovsdbclient.Client, p switchPr
 	found := []*nbdb.LogicalSwitch{}
 	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
 	defer cancel()
-	err := nbClient.WhereCache(p).List(ctx, &found)
+	err := whereCacheList(ctx, nbClient, p, &found)
 	return found, err
 }
 
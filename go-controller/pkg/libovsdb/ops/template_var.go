@@ -17,7 +17,7 @@ func ListTemplateVar(nbClient libovsdbclient.Client) ([]*nbdb.ChassisTemplateVar
 	defer cancel()
 
 	templatesList := []*nbdb.ChassisTemplateVar{}
-	err := nbClient.List(ctx, &templatesList)
+	err := cacheList(ctx, nbClient, &templatesList)
 	return templatesList, err
 }
 
@@ -18,6 +18,16 @@ import (
 // TransactWithRetry will attempt a transaction several times if it receives an error indicating that the client
 // was not connected when the transaction occurred.
 func TransactWithRetry(ctx context.Context, c client.Client, ops []ovsdb.Operation) ([]ovsdb.OperationResult, error) {
+	if recorder := getShadowRecorder(c); recorder != nil {
+		return recorder.record(c, ops), nil
+	}
+	if coalescer := getTransactionCoalescer(c); coalescer != nil {
+		return coalescer.transact(ctx, ops)
+	}
+	return transactWithRetry(ctx, c, ops)
+}
+
+func transactWithRetry(ctx context.Context, c client.Client, ops []ovsdb.Operation) ([]ovsdb.OperationResult, error) {
 	var results []ovsdb.OperationResult
 	resultErr := wait.PollUntilContextCancel(ctx, 200*time.Millisecond, true, func(ctx context.Context) (bool, error) {
 		var err error
@@ -65,7 +65,7 @@ func JoinACLName(substrings ...string) string {
 
 // acl.Name is cropped to 64 symbols and is used for logging.
 // currently only egress firewall, gress network policy and default deny network policy ACLs are logged.
-// Other ACLs don't need a name.
+// Other ACLs don't need a name, namespace multicast ACLs are only named while they are logged, see GetMulticastACLName.
 // Just a namespace name may be 63 symbols long, therefore some information may be cropped.
 // Therefore, "feature" as "EF" for EgressFirewall and "NP" for network policy goes first, then namespace,
 // then acl-related info.
@@ -90,6 +90,90 @@ func GetACLName(dbIDs *libovsdbops.DbObjectIDs) string {
 	return fmt.Sprintf("%.63s", aclName)
 }
 
+// GetMulticastACLName returns the name of a namespace multicast ACL. These ACLs had no name before their allowed
+// traffic could be logged, they are only named while it is, so that the names of the unlogged ACLs don't change.
+func GetMulticastACLName(dbIDs *libovsdbops.DbObjectIDs) string {
+	return fmt.Sprintf("%.63s", "MC:"+dbIDs.GetObjectID(libovsdbops.ObjectNameKey)+":"+
+		dbIDs.GetObjectID(libovsdbops.PolicyDirectionKey))
+}
+
+// ParseACLName returns the external IDs that GetACLName and GetMulticastACLName encode in an ACL name: the owner type
+// and the IDs of the owner object, but not the owner controller. It returns nil if the name was not built by them.
+// The names are cropped to 63 characters, so that the last IDs of a name of 63 characters may be cropped: only the
+// owner type is returned for such names, since a namespace alone may be 63 characters long.
+func ParseACLName(name string) map[string]string {
+	parts := strings.Split(name, ":")
+	if len(name) >= 63 {
+		ownerTypes := map[string]*libovsdbops.ObjectIDsType{
+			"EF":   libovsdbops.ACLEgressFirewall,
+			"ANP":  libovsdbops.ACLAdminNetworkPolicy,
+			"BANP": libovsdbops.ACLBaselineAdminNetworkPolicy,
+			"MC":   libovsdbops.ACLMulticastNamespace,
+		}
+		idsType, ok := ownerTypes[parts[0]]
+		if !ok || len(parts) < 2 {
+			// a cropped NP name may belong to a network policy or to the default deny ACLs of a namespace
+			return nil
+		}
+		return map[string]string{libovsdbops.OwnerTypeKey.String(): idsType.GetOwnerType()}
+	}
+	var idsType *libovsdbops.ObjectIDsType
+	var keys []libovsdbops.ExternalIDKey
+	switch {
+	case parts[0] == "NP" && len(parts) == 5:
+		// the network policy object name is namespace:name
+		parts = append([]string{parts[0], parts[1] + ":" + parts[2]}, parts[3:]...)
+		idsType = libovsdbops.ACLNetworkPolicy
+		keys = []libovsdbops.ExternalIDKey{libovsdbops.ObjectNameKey, libovsdbops.PolicyDirectionKey, libovsdbops.GressIdxKey}
+	case parts[0] == "NP" && len(parts) == 3:
+		idsType = libovsdbops.ACLNetpolNamespace
+		keys = []libovsdbops.ExternalIDKey{libovsdbops.ObjectNameKey, libovsdbops.PolicyDirectionKey}
+	case parts[0] == "EF" && len(parts) == 3:
+		idsType = libovsdbops.ACLEgressFirewall
+		keys = []libovsdbops.ExternalIDKey{libovsdbops.ObjectNameKey, libovsdbops.RuleIndex}
+	case parts[0] == "ANP" && len(parts) == 4:
+		idsType = libovsdbops.ACLAdminNetworkPolicy
+		keys = []libovsdbops.ExternalIDKey{libovsdbops.ObjectNameKey, libovsdbops.PolicyDirectionKey, libovsdbops.GressIdxKey}
+	case parts[0] == "BANP" && len(parts) == 4:
+		idsType = libovsdbops.ACLBaselineAdminNetworkPolicy
+		keys = []libovsdbops.ExternalIDKey{libovsdbops.ObjectNameKey, libovsdbops.PolicyDirectionKey, libovsdbops.GressIdxKey}
+	case parts[0] == "MC" && len(parts) == 3:
+		idsType = libovsdbops.ACLMulticastNamespace
+		keys = []libovsdbops.ExternalIDKey{libovsdbops.ObjectNameKey, libovsdbops.PolicyDirectionKey}
+	default:
+		return nil
+	}
+	externalIDs := map[string]string{
+		libovsdbops.OwnerTypeKey.String(): idsType.GetOwnerType(),
+	}
+	for i, key := range keys {
+		if parts[i+1] == "" {
+			return nil
+		}
+		externalIDs[key.String()] = parts[i+1]
+	}
+	return externalIDs
+}
+
+// GetACLOwner returns the type, namespace and name of the object owning an ACL with the given external IDs. The
+// namespace is empty for cluster scoped objects and the name for namespaces.
+func GetACLOwner(externalIDs map[string]string) (ownerType, namespace, name string) {
+	ownerType = externalIDs[libovsdbops.OwnerTypeKey.String()]
+	objectName := externalIDs[libovsdbops.ObjectNameKey.String()]
+	switch ownerType {
+	case libovsdbops.ACLNetworkPolicy.GetOwnerType(), libovsdbops.ACLFlowSampling.GetOwnerType():
+		// network policy and pod object names are namespace:name
+		namespace, name, _ = strings.Cut(objectName, ":")
+	case libovsdbops.ACLNetpolNamespace.GetOwnerType(), libovsdbops.ACLEgressFirewall.GetOwnerType(),
+		libovsdbops.ACLMulticastNamespace.GetOwnerType():
+		namespace = objectName
+	case libovsdbops.ACLAdminNetworkPolicy.GetOwnerType(), libovsdbops.ACLBaselineAdminNetworkPolicy.GetOwnerType(),
+		libovsdbops.ACLNetpolNode.GetOwnerType():
+		name = objectName
+	}
+	return
+}
+
 // BuildACL should be used to build ACL instead of directly calling libovsdbops.BuildACL.
 // It can properly set and reset log settings for ACL based on ACLLoggingLevels, and
 // set acl.Name and acl.ExternalIDs based on given DbIDs
@@ -122,8 +206,9 @@ func BuildACL(dbIDs *libovsdbops.DbObjectIDs, priority int, match, action string
 		log,
 		externalIDs,
 		options,
-		types.DefaultACLTier,
+		GetACLTier(dbIDs),
 	)
+	ACL.Label = getFlowSamplingLabel(ACL, logLevels)
 	return ACL
 }
 
@@ -139,7 +224,8 @@ func GetACLTier(dbIDs *libovsdbops.DbObjectIDs) int {
 	switch {
 	case t.IsSameType(libovsdbops.ACLAdminNetworkPolicy):
 		return types.DefaultANPACLTier
-	case t.IsSameType(libovsdbops.ACLBaselineAdminNetworkPolicy):
+	case t.IsSameType(libovsdbops.ACLBaselineAdminNetworkPolicy), t.IsSameType(libovsdbops.ACLFlowSampling):
+		// flow sampling ACLs only match the traffic that no other ACL matches
 		return types.DefaultBANPACLTier
 	default:
 		return types.DefaultACLTier
@@ -166,6 +252,8 @@ func GetACLMatch(portGroupName, match string, aclDir ACLDirection) string {
 type ACLLoggingLevels struct {
 	Allow string `json:"allow,omitempty"`
 	Deny  string `json:"deny,omitempty"`
+	// FlowSampling is set from the flow sampling annotation, ACLs are labeled with their observation ID when set
+	FlowSampling *util.FlowSampling `json:"-"`
 }
 
 func getLogSeverity(action string, aclLogging *ACLLoggingLevels) (log bool, severity string) {
@@ -198,6 +286,7 @@ func UpdateACLLogging(nbClient libovsdbclient.Client, ACLs []*nbdb.ACL, aclLoggi
 	for i := range ACLs {
 		log, severity := getLogSeverity(ACLs[i].Action, aclLogging)
 		libovsdbops.SetACLLogging(ACLs[i], severity, log)
+		ACLs[i].Label = getFlowSamplingLabel(ACLs[i], aclLogging)
 	}
 	ops, err := libovsdbops.UpdateACLsLoggingOps(nbClient, nil, ACLs...)
 	if err != nil {
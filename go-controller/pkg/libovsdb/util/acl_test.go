@@ -1,8 +1,11 @@
 package util
 
 import (
+	"strings"
 	"testing"
 
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/api/core/v1"
 )
@@ -170,3 +173,81 @@ func TestGetL4Match(t *testing.T) {
 		assert.Equal(t, tc.expected, l4Match)
 	}
 }
+
+func TestParseACLName(t *testing.T) {
+	const controller = "default-network-controller"
+	testcases := []struct {
+		desc  string
+		dbIDs *libovsdbops.DbObjectIDs
+	}{
+		{
+			"network policy",
+			libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, controller, map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:         "namespace1:policy1",
+				libovsdbops.PolicyDirectionKey:    string(ACLIngress),
+				libovsdbops.GressIdxKey:           "0",
+				libovsdbops.IpBlockIndexKey:       "-1",
+				libovsdbops.PortPolicyProtocolKey: "tcp",
+			}),
+		},
+		{
+			"network policy namespace",
+			libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetpolNamespace, controller, map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:      "namespace1",
+				libovsdbops.PolicyDirectionKey: string(ACLEgress),
+				libovsdbops.TypeKey:            "defaultDeny",
+			}),
+		},
+		{
+			"egress firewall",
+			libovsdbops.NewDbObjectIDs(libovsdbops.ACLEgressFirewall, controller, map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey: "namespace1",
+				libovsdbops.RuleIndex:     "3",
+			}),
+		},
+		{
+			"admin network policy",
+			libovsdbops.NewDbObjectIDs(libovsdbops.ACLAdminNetworkPolicy, controller, map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:         "anp1",
+				libovsdbops.PolicyDirectionKey:    string(ACLIngress),
+				libovsdbops.GressIdxKey:           "2",
+				libovsdbops.PortPolicyProtocolKey: "tcp",
+			}),
+		},
+		{
+			"namespace multicast",
+			libovsdbops.NewDbObjectIDs(libovsdbops.ACLMulticastNamespace, controller, map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:      "namespace1",
+				libovsdbops.PolicyDirectionKey: string(ACLIngress),
+			}),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			name := GetACLName(tc.dbIDs)
+			if tc.dbIDs.GetIDsType().IsSameType(libovsdbops.ACLMulticastNamespace) {
+				name = GetMulticastACLName(tc.dbIDs)
+			}
+			externalIDs := ParseACLName(name)
+			assert.NotNil(t, externalIDs, name)
+			aclExternalIDs := tc.dbIDs.GetExternalIDs()
+			for key, value := range externalIDs {
+				assert.Equal(t, aclExternalIDs[key], value, "%s: %s", name, key)
+			}
+		})
+	}
+
+	for _, name := range []string{"", "NP:namespace1", "EF:namespace1:1:2", "MC:namespace1:",
+		"NP:" + strings.Repeat("a", 60), "MC:cluster:DefaultDeny:Egress"} {
+		assert.Nil(t, ParseACLName(name), name)
+	}
+
+	// only the owner type of the possibly cropped names is known
+	croppedName := GetACLName(libovsdbops.NewDbObjectIDs(libovsdbops.ACLEgressFirewall, controller,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey: strings.Repeat("a", 63),
+			libovsdbops.RuleIndex:     "3",
+		}))
+	assert.Equal(t, map[string]string{libovsdbops.OwnerTypeKey.String(): libovsdbops.ACLEgressFirewall.GetOwnerType()},
+		ParseACLName(croppedName))
+}
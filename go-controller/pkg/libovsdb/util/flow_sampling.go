@@ -0,0 +1,112 @@
+package util
+
+import (
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+)
+
+// observationIDs are the observation IDs of the sampled ACLs of all the network controllers
+var observationIDs = newObservationIDAllocator()
+
+// observationIDAllocator allocates an observation ID per ACL primary ID, so that no two ACLs share an observation ID
+type observationIDAllocator struct {
+	sync.Mutex
+	// ids are the observation IDs by ACL primary ID
+	ids map[string]int
+	// owners are the ACL primary IDs by observation ID
+	owners map[int]string
+	// allocated is when the observation ID of an ACL primary ID was allocated
+	allocated map[string]time.Time
+}
+
+func newObservationIDAllocator() *observationIDAllocator {
+	return &observationIDAllocator{
+		ids:       map[string]int{},
+		owners:    map[int]string{},
+		allocated: map[string]time.Time{},
+	}
+}
+
+// allocate returns the observation ID of the primary ID, allocating the preferred ID if it is free, or the next free
+// one otherwise
+func (a *observationIDAllocator) allocate(primaryID string, preferred int) int {
+	a.Lock()
+	defer a.Unlock()
+	if id, ok := a.ids[primaryID]; ok {
+		return id
+	}
+	id := preferred
+	if id <= 0 || int64(id) > math.MaxUint32 {
+		// label 0 means no label
+		id = 1
+	}
+	for {
+		if _, ok := a.owners[id]; !ok {
+			break
+		}
+		// the IDs are 32 bits
+		if int64(id) == math.MaxUint32 {
+			id = 1
+		} else {
+			id++
+		}
+	}
+	a.ids[primaryID] = id
+	a.owners[id] = primaryID
+	a.allocated[primaryID] = time.Now()
+	return id
+}
+
+// release releases the observation IDs of the primary IDs that are not in use and were allocated before the given
+// time, the ACLs labeled since then may not be committed yet
+func (a *observationIDAllocator) release(inUse sets.Set[string], allocatedBefore time.Time) {
+	a.Lock()
+	defer a.Unlock()
+	for primaryID, id := range a.ids {
+		if inUse.Has(primaryID) || a.allocated[primaryID].After(allocatedBefore) {
+			continue
+		}
+		delete(a.ids, primaryID)
+		delete(a.owners, id)
+		delete(a.allocated, primaryID)
+	}
+}
+
+// GetFlowSamplingObservationID returns the observation ID of an ACL. The preferred ID is derived from its primary ID,
+// so that it is stable across restarts and controllers, and the next free ID is allocated if another ACL already has
+// it. OVN writes the label of an ACL to the connection tracking label of the connections it allows, which is how flow
+// records are mapped back to the ACL and its Kubernetes owner.
+func GetFlowSamplingObservationID(acl *nbdb.ACL) int {
+	primaryID := acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()]
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(primaryID))
+	return observationIDs.allocate(primaryID, int(h.Sum32()))
+}
+
+// ReserveFlowSamplingObservationID reserves the label of a labeled ACL as its observation ID, and returns the
+// observation ID of the ACL: it differs from the label if the ACL already has another observation ID, or if another
+// ACL has the label as observation ID.
+func ReserveFlowSamplingObservationID(acl *nbdb.ACL) int {
+	return observationIDs.allocate(acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()], acl.Label)
+}
+
+// ReleaseFlowSamplingObservationIDs releases the observation IDs of the ACLs whose primary IDs are not in use and that
+// were allocated before the given time
+func ReleaseFlowSamplingObservationIDs(inUse sets.Set[string], allocatedBefore time.Time) {
+	observationIDs.release(inUse, allocatedBefore)
+}
+
+// getFlowSamplingLabel returns the label of an ACL given the ACL settings of its namespace
+func getFlowSamplingLabel(acl *nbdb.ACL, aclLogging *ACLLoggingLevels) int {
+	if aclLogging == nil || aclLogging.FlowSampling == nil {
+		return 0
+	}
+	return GetFlowSamplingObservationID(acl)
+}
@@ -0,0 +1,29 @@
+package util
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestObservationIDAllocator(t *testing.T) {
+	a := newObservationIDAllocator()
+
+	assert.Equal(t, 10, a.allocate("acl1", 10))
+	assert.Equal(t, 10, a.allocate("acl1", 20), "an allocated ID should be kept")
+	assert.Equal(t, 11, a.allocate("acl2", 10), "a colliding ID should be moved to the next free ID")
+	assert.Equal(t, 12, a.allocate("acl3", 10))
+	assert.Equal(t, 1, a.allocate("acl4", 0), "label 0 should never be allocated")
+	assert.Equal(t, math.MaxUint32, a.allocate("acl5", math.MaxUint32))
+	assert.Equal(t, 2, a.allocate("acl6", math.MaxUint32), "the IDs should wrap around")
+
+	a.release(sets.New("acl1", "acl3"), time.Now().Add(-time.Minute))
+	assert.Len(t, a.ids, 6, "recently allocated IDs should not be released")
+	a.release(sets.New("acl1", "acl3"), time.Now())
+	assert.Equal(t, map[string]int{"acl1": 10, "acl3": 12}, a.ids)
+	assert.Equal(t, map[int]string{10: "acl1", 12: "acl3"}, a.owners)
+	assert.Equal(t, 11, a.allocate("acl7", 10), "released IDs should be reused")
+}
@@ -0,0 +1,421 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/libovsdb/cache"
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+)
+
+// aclHitsOverflowName is the name of the object the hits of the objects above the cardinality limit are counted for
+const aclHitsOverflowName = "_overflow"
+
+// aclHitsStageNames are the stages of the logical flows matching the traffic of the ACLs, the other ACL stages
+// implement the action of the ACLs and would count their traffic again
+var aclHitsStageNames = []string{
+	"ls_in_acl",
+	"ls_out_acl",
+	"ls_in_acl_eval",
+	"ls_out_acl_eval",
+	"ls_in_acl_after_lb",
+	"ls_in_acl_after_lb_eval",
+	"ls_out_acl_after_lb",
+	"ls_out_acl_after_lb_eval",
+}
+
+// aclHitsLogicalFlowRefreshes is the number of updates selecting the logical flows again after the ACLs change, so
+// that the logical flows northd writes after the update following the change are selected too
+const aclHitsLogicalFlowRefreshes = 2
+
+// aclHitsLogicalFlowResync is the number of updates after which the logical flows are selected again regardless of
+// the ACL changes, e.g. in case northd recomputed them
+const aclHitsLogicalFlowResync = 10
+
+var (
+	aclHitsPacketsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnNamespace, MetricOvnSubsystemController, "acl_hit_packets_total"),
+		"The total number of packets matched by the ACLs of an object in the OpenFlow flows of this node.",
+		[]string{"kind", "namespace", "name"}, nil)
+	aclHitsBytesDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnNamespace, MetricOvnSubsystemController, "acl_hit_bytes_total"),
+		"The total number of bytes matched by the ACLs of an object in the OpenFlow flows of this node.",
+		[]string{"kind", "namespace", "name"}, nil)
+	aclHitsDropsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnNamespace, MetricOvnSubsystemController, "acl_hit_dropped_packets_total"),
+		"The total number of packets dropped or rejected by the ACLs of an object in the OpenFlow flows of this node.",
+		[]string{"kind", "namespace", "name"}, nil)
+)
+
+// aclHitsObject is the object owning ACLs, its hits are the sum of the hits of the OpenFlow flows of its ACLs
+type aclHitsObject struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+type aclHits struct {
+	packets float64
+	bytes   float64
+	drops   float64
+}
+
+// aclHitsOpenFlow identifies an OpenFlow flow across dumps
+type aclHitsOpenFlow struct {
+	cookie string
+	flow   string
+}
+
+type aclHitsOpenFlowStats struct {
+	packets float64
+	bytes   float64
+}
+
+// aclHitsCollector maps the OpenFlow flows of br-int to the ACLs they are derived from, through their cookie which is
+// the beginning of the UUID of their logical flow, whose stage-hint is the beginning of the UUID of its ACL. Flows are
+// reinstalled with zero counters, so the counters of the objects accumulate the increments of their flows.
+// The ACLs are read from the cache of the NB client of the zone controller and the logical flows of the ACL stages are
+// selected from the SB database of the zone, whose client doesn't monitor the logical flows. The selected logical
+// flows are kept in an index and only selected again after the ACLs change, or every aclHitsLogicalFlowResync updates.
+type aclHitsCollector struct {
+	ovsOfctl           ovsClient
+	nbClient, sbClient libovsdbclient.Client
+	maxObjects         int
+
+	sync.Mutex
+	objects map[aclHitsObject]*aclHits
+	// overflowObjects are the objects whose hits are counted for the overflow object of their kind
+	overflowObjects map[aclHitsObject]bool
+	// flows holds the last stats of the flows of the ACLs
+	flows map[aclHitsOpenFlow]aclHitsOpenFlowStats
+	// lflowStageHints maps the cookies of the logical flows of the ACL stages to their stage hint, nil until selected
+	lflowStageHints map[string]string
+	// lflowRefreshes is the number of next updates that select the logical flows again
+	lflowRefreshes int
+	// lflowUpdates is the number of updates since the logical flows were last selected
+	lflowUpdates int
+}
+
+func newACLHitsCollector(ovsOfctl ovsClient, nbClient, sbClient libovsdbclient.Client, maxObjects int) *aclHitsCollector {
+	c := &aclHitsCollector{
+		ovsOfctl:        ovsOfctl,
+		nbClient:        nbClient,
+		sbClient:        sbClient,
+		maxObjects:      maxObjects,
+		objects:         map[aclHitsObject]*aclHits{},
+		overflowObjects: map[aclHitsObject]bool{},
+		flows:           map[aclHitsOpenFlow]aclHitsOpenFlowStats{},
+	}
+	nbClient.Cache().AddEventHandler(&cache.EventHandlerFuncs{
+		AddFunc: func(table string, _ model.Model) {
+			c.aclsChanged(table)
+		},
+		UpdateFunc: func(table string, _, _ model.Model) {
+			c.aclsChanged(table)
+		},
+		DeleteFunc: func(table string, _ model.Model) {
+			c.aclsChanged(table)
+		},
+	})
+	return c
+}
+
+// aclsChanged makes the next updates select the logical flows again when the ACLs change
+func (c *aclHitsCollector) aclsChanged(table string) {
+	if table != nbdb.ACLTable {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.lflowRefreshes = aclHitsLogicalFlowRefreshes
+}
+
+func (c *aclHitsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- aclHitsPacketsDesc
+	ch <- aclHitsBytesDesc
+	ch <- aclHitsDropsDesc
+}
+
+func (c *aclHitsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	defer c.Unlock()
+	for object, hits := range c.objects {
+		ch <- prometheus.MustNewConstMetric(aclHitsPacketsDesc, prometheus.CounterValue, hits.packets,
+			object.kind, object.namespace, object.name)
+		ch <- prometheus.MustNewConstMetric(aclHitsBytesDesc, prometheus.CounterValue, hits.bytes,
+			object.kind, object.namespace, object.name)
+		ch <- prometheus.MustNewConstMetric(aclHitsDropsDesc, prometheus.CounterValue, hits.drops,
+			object.kind, object.namespace, object.name)
+	}
+}
+
+type aclHitsACL struct {
+	object aclHitsObject
+	drop   bool
+}
+
+// update adds the increments of the counters of the OpenFlow flows of the ACLs since the last update
+func (c *aclHitsCollector) update() error {
+	acls, owners, err := c.getACLs()
+	if err != nil {
+		return err
+	}
+	cookies, err := c.getLogicalFlowACLs(acls)
+	if err != nil {
+		return err
+	}
+	stdout, stderr, err := c.ovsOfctl("-t", "5", "dump-flows", "br-int")
+	if err != nil {
+		return fmt.Errorf("failed to dump the OpenFlow flows of br-int, stderr(%s): %v", stderr, err)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.evictObjects(owners)
+	flows := map[aclHitsOpenFlow]aclHitsOpenFlowStats{}
+	for _, line := range strings.Split(stdout, "\n") {
+		flow, stats, ok := parseACLHitsOpenFlow(line)
+		if !ok {
+			continue
+		}
+		acl, ok := cookies[flow.cookie]
+		if !ok {
+			continue
+		}
+		flows[flow] = stats
+		increment := stats
+		if last, ok := c.flows[flow]; ok && stats.packets >= last.packets && stats.bytes >= last.bytes {
+			increment.packets -= last.packets
+			increment.bytes -= last.bytes
+		}
+		if increment.packets == 0 && increment.bytes == 0 {
+			continue
+		}
+		hits := c.getObjectHits(acl.object)
+		hits.packets += increment.packets
+		hits.bytes += increment.bytes
+		if acl.drop {
+			hits.drops += increment.packets
+		}
+	}
+	c.flows = flows
+	return nil
+}
+
+// getObjectHits returns the hits of an object, the hits of the objects above the cardinality limit are counted for the
+// overflow object of their kind
+func (c *aclHitsCollector) getObjectHits(object aclHitsObject) *aclHits {
+	if hits, ok := c.objects[object]; ok {
+		return hits
+	}
+	if c.overflowObjects[object] || (c.maxObjects > 0 && len(c.objects) >= c.maxObjects) {
+		c.overflowObjects[object] = true
+		object = aclHitsObject{kind: object.kind, name: aclHitsOverflowName}
+		if hits, ok := c.objects[object]; ok {
+			return hits
+		}
+	}
+	hits := &aclHits{}
+	c.objects[object] = hits
+	return hits
+}
+
+// evictObjects deletes the hits of the objects that don't own ACLs anymore, so that their series are deleted and the
+// objects above the cardinality limit can be counted instead. The overflow object of a kind is deleted with the last
+// object counted for it.
+func (c *aclHitsCollector) evictObjects(owners map[aclHitsObject]bool) {
+	overflowKinds := map[string]bool{}
+	for object := range c.overflowObjects {
+		if owners[object] {
+			overflowKinds[object.kind] = true
+		} else {
+			delete(c.overflowObjects, object)
+		}
+	}
+	for object := range c.objects {
+		if object.name == aclHitsOverflowName && object.namespace == "" {
+			if !overflowKinds[object.kind] {
+				delete(c.objects, object)
+			}
+			continue
+		}
+		if !owners[object] {
+			delete(c.objects, object)
+		}
+	}
+}
+
+// getACLs returns the ACLs owned by objects, by the beginning of their UUID, and the objects owning them
+func (c *aclHitsCollector) getACLs() (map[string]aclHitsACL, map[aclHitsObject]bool, error) {
+	nbACLs, err := libovsdbops.FindACLsWithPredicate(c.nbClient, func(acl *nbdb.ACL) bool {
+		return acl.ExternalIDs[libovsdbops.OwnerTypeKey.String()] != ""
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to find the ACLs: %w", err)
+	}
+	acls := map[string]aclHitsACL{}
+	owners := map[aclHitsObject]bool{}
+	for _, acl := range nbACLs {
+		kind, namespace, name := libovsdbutil.GetACLOwner(acl.ExternalIDs)
+		if kind == "" || len(acl.UUID) < 8 {
+			continue
+		}
+		object := aclHitsObject{kind: kind, namespace: namespace, name: name}
+		acls[acl.UUID[:8]] = aclHitsACL{
+			object: object,
+			drop:   acl.Action == nbdb.ACLActionDrop || acl.Action == nbdb.ACLActionReject,
+		}
+		owners[object] = true
+	}
+	return acls, owners, nil
+}
+
+// getLogicalFlowACLs returns the ACLs of the logical flows matching their traffic, by OpenFlow cookie, from the index
+// of the logical flows, which is selected again first if the ACLs changed
+func (c *aclHitsCollector) getLogicalFlowACLs(acls map[string]aclHitsACL) (map[string]aclHitsACL, error) {
+	c.Lock()
+	stageHints := c.lflowStageHints
+	refresh := stageHints == nil || c.lflowRefreshes > 0 || c.lflowUpdates >= aclHitsLogicalFlowResync
+	c.lflowUpdates++
+	c.Unlock()
+
+	if refresh {
+		var err error
+		if stageHints, err = c.selectLogicalFlowStageHints(); err != nil {
+			return nil, err
+		}
+		c.Lock()
+		c.lflowStageHints = stageHints
+		if c.lflowRefreshes > 0 {
+			c.lflowRefreshes--
+		}
+		c.lflowUpdates = 0
+		c.Unlock()
+	}
+
+	cookies := map[string]aclHitsACL{}
+	for cookie, stageHint := range stageHints {
+		if acl, ok := acls[stageHint]; ok {
+			cookies[cookie] = acl
+		}
+	}
+	return cookies, nil
+}
+
+// selectLogicalFlowStageHints returns the stage hints of the logical flows of the ACL evaluation stages by OpenFlow
+// cookie
+func (c *aclHitsCollector) selectLogicalFlowStageHints() (map[string]string, error) {
+	ops := make([]ovsdb.Operation, 0, len(aclHitsStageNames))
+	for _, stageName := range aclHitsStageNames {
+		ops = append(ops, ovsdb.Operation{
+			Op:    ovsdb.OperationSelect,
+			Table: sbdb.LogicalFlowTable,
+			Where: []ovsdb.Condition{ovsdb.NewCondition("external_ids", ovsdb.ConditionIncludes,
+				ovsdb.OvsMap{GoMap: map[interface{}]interface{}{"stage-name": stageName}})},
+			Columns: []string{"_uuid", "external_ids"},
+		})
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+	defer cancel()
+	results, err := c.sbClient.Transact(ctx, ops...)
+	if err == nil {
+		_, err = ovsdb.CheckOperationResults(results, ops)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to select the logical flows of the ACLs: %w", err)
+	}
+	stageHints := map[string]string{}
+	for _, result := range results {
+		for _, row := range result.Rows {
+			uuid, _ := row["_uuid"].(ovsdb.UUID)
+			externalIDs, _ := row["external_ids"].(ovsdb.OvsMap)
+			stageHint, _ := externalIDs.GoMap["stage-hint"].(string)
+			if len(uuid.GoUUID) < 8 || stageHint == "" {
+				continue
+			}
+			stageHints[strings.TrimLeft(uuid.GoUUID[:8], "0")] = stageHint
+		}
+	}
+	return stageHints, nil
+}
+
+// parseACLHitsOpenFlow parses a flow of ovs-ofctl dump-flows, e.g.
+// cookie=0x9d4e1f3a, duration=12.3s, table=44, n_packets=10, n_bytes=980, idle_age=5, priority=2001,ip,metadata=0x2 actions=drop
+// flows are identified by their cookie and by their table, priority and match which can't change for a cookie
+func parseACLHitsOpenFlow(line string) (aclHitsOpenFlow, aclHitsOpenFlowStats, bool) {
+	var flow aclHitsOpenFlow
+	var stats aclHitsOpenFlowStats
+	var identity []string
+	var err error
+	for _, field := range strings.Split(strings.TrimSpace(line), ", ") {
+		key, value, _ := strings.Cut(field, "=")
+		switch key {
+		case "cookie":
+			flow.cookie = strings.TrimLeft(strings.TrimPrefix(value, "0x"), "0")
+		case "n_packets":
+			if stats.packets, err = strconv.ParseFloat(value, 64); err != nil {
+				return flow, stats, false
+			}
+		case "n_bytes":
+			if stats.bytes, err = strconv.ParseFloat(value, 64); err != nil {
+				return flow, stats, false
+			}
+		case "duration", "idle_age", "hard_age", "idle_timeout", "hard_timeout":
+		default:
+			// table and the priority,match actions field
+			identity = append(identity, field)
+		}
+	}
+	if flow.cookie == "" || len(identity) == 0 {
+		return flow, stats, false
+	}
+	flow.flow = strings.Join(identity, ", ")
+	return flow, stats, true
+}
+
+func aclHitsUpdater(collector *aclHitsCollector, tickPeriod time.Duration, stopChan <-chan struct{}) {
+	ticker := time.NewTicker(tickPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := collector.update(); err != nil {
+				klog.Errorf("Failed to update the ACL hit metrics: %v", err)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+var registerACLHitMetricsOnce sync.Once
+
+// RegisterACLHitMetrics registers the per object ACL hit counters with the OVN metrics server, counting the hits of
+// the ACLs of up to maxObjects objects, 0 meaning no limit. It runs with the ovnkube-controller of the zone of the node,
+// whose NB and SB clients are given.
+func RegisterACLHitMetrics(nbClient, sbClient libovsdbclient.Client, maxObjects int, stopChan <-chan struct{}) {
+	registerACLHitMetricsOnce.Do(func() {
+		collector := newACLHitsCollector(util.RunOVSOfctl, nbClient, sbClient, maxObjects)
+		ovnRegistry.MustRegister(collector)
+		go aclHitsUpdater(collector, 30*time.Second, stopChan)
+	})
+}
@@ -0,0 +1,267 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/sbdb"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+)
+
+// aclHitsTestData returns the ACLs and the logical flows of their stages, the ACL UUIDs are the stage hints of the
+// logical flows, whose UUIDs are the cookies of the OpenFlow flows
+func aclHitsTestData() libovsdbtest.TestSetup {
+	acls := []libovsdbtest.TestData{
+		&nbdb.ACL{
+			UUID:   "1a2b3c4d-0000-0000-0000-000000000001",
+			Action: nbdb.ACLActionAllowRelated,
+			ExternalIDs: map[string]string{
+				"direction":                        "Ingress",
+				"gress-index":                      "0",
+				libovsdbops.ObjectNameKey.String(): "namespace1:policy1",
+				libovsdbops.OwnerTypeKey.String():  string(libovsdbops.NetworkPolicyOwnerType),
+			},
+		},
+		&nbdb.ACL{
+			UUID:   "0e2b3c4d-0000-0000-0000-000000000002",
+			Action: nbdb.ACLActionDrop,
+			ExternalIDs: map[string]string{
+				"direction":                        "Ingress",
+				"type":                             "defaultDeny",
+				libovsdbops.ObjectNameKey.String(): "namespace1",
+				libovsdbops.OwnerTypeKey.String():  string(libovsdbops.NetpolNamespaceOwnerType),
+			},
+		},
+		&nbdb.ACL{
+			UUID:   "6a2b3c4d-0000-0000-0000-000000000004",
+			Action: nbdb.ACLActionAllow,
+		},
+		&nbdb.ACL{
+			UUID:   "5a2b3c4d-0000-0000-0000-000000000003",
+			Action: nbdb.ACLActionDrop,
+			ExternalIDs: map[string]string{
+				"rule-index":                       "0",
+				libovsdbops.ObjectNameKey.String(): "namespace2",
+				libovsdbops.OwnerTypeKey.String():  string(libovsdbops.EgressFirewallOwnerType),
+			},
+		},
+	}
+	// ACLs are not root, they are kept by the port group referencing them
+	portGroup := &nbdb.PortGroup{UUID: "portgroup-UUID", Name: "portgroup"}
+	for _, acl := range acls {
+		portGroup.ACLs = append(portGroup.ACLs, acl.(*nbdb.ACL).UUID)
+	}
+	logicalFlow := func(uuid, stageHint, stageName string) *sbdb.LogicalFlow {
+		return &sbdb.LogicalFlow{
+			UUID:     uuid,
+			Pipeline: sbdb.LogicalFlowPipelineEgress,
+			ExternalIDs: map[string]string{
+				"source":     "northd.c:6700",
+				"stage-hint": stageHint,
+				"stage-name": stageName,
+			},
+		}
+	}
+	return libovsdbtest.TestSetup{
+		NBData: append(acls, portGroup),
+		SBData: []libovsdbtest.TestData{
+			logicalFlow("9d4e1f3a-0000-0000-0000-000000000001", "1a2b3c4d", "ls_out_acl_eval"),
+			logicalFlow("0f4e1f3a-0000-0000-0000-000000000002", "0e2b3c4d", "ls_out_acl_eval"),
+			logicalFlow("7d4e1f3a-0000-0000-0000-000000000003", "1a2b3c4d", "ls_out_acl_action"),
+			logicalFlow("8d4e1f3a-0000-0000-0000-000000000004", "5a2b3c4d", "ls_in_acl_after_lb_eval"),
+		},
+	}
+}
+
+func aclHitsOfctlDumpFlowsOutput(policyPackets, denyPackets, actionPackets int) string {
+	return fmt.Sprintf(`NXST_FLOW reply (xid=0x4):
+ cookie=0x9d4e1f3a, duration=12.3s, table=44, n_packets=%d, n_bytes=%d, idle_age=5, priority=2001,ip,reg15=0x3,metadata=0x2 actions=resubmit(,45)
+ cookie=0x9d4e1f3a, duration=12.3s, table=44, n_packets=1, n_bytes=100, idle_age=5, priority=2001,ip,reg15=0x4,metadata=0x2 actions=resubmit(,45)
+ cookie=0xf4e1f3a, duration=12.3s, table=44, n_packets=%d, n_bytes=%d, idle_age=5, priority=1000,ip,reg15=0x3,metadata=0x2 actions=drop
+ cookie=0x7d4e1f3a, duration=12.3s, table=45, n_packets=%d, n_bytes=%d, idle_age=5, priority=1000,metadata=0x2 actions=resubmit(,46)
+ cookie=0x0, duration=12.3s, table=0, n_packets=1000, n_bytes=100000, idle_age=0, priority=0 actions=drop
+`, policyPackets, policyPackets*100, denyPackets, denyPackets*100, actionPackets, actionPackets*100)
+}
+
+var _ = ginkgo.Describe("ACL hit metrics", func() {
+	var (
+		collector *aclHitsCollector
+		testCtx   *libovsdbtest.Context
+	)
+
+	ginkgo.AfterEach(func() {
+		if testCtx != nil {
+			testCtx.Cleanup()
+			testCtx = nil
+		}
+	})
+
+	newCollector := func(maxObjects int, dumpFlowsOutputs ...string) {
+		nbClient, sbClient, ctx, err := libovsdbtest.NewNBSBTestHarness(aclHitsTestData())
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		testCtx = ctx
+		ofctlOutput := []clientOutput{}
+		for _, output := range dumpFlowsOutputs {
+			ofctlOutput = append(ofctlOutput, clientOutput{stdout: output})
+		}
+		ovsOfctl := NewFakeOVSClient(ofctlOutput)
+		collector = newACLHitsCollector(ovsOfctl.FakeCall, nbClient, sbClient, maxObjects)
+	}
+
+	update := func(maxObjects int, dumpFlowsOutputs ...string) {
+		newCollector(maxObjects, dumpFlowsOutputs...)
+		for range dumpFlowsOutputs {
+			gomega.Expect(collector.update()).To(gomega.Succeed())
+		}
+	}
+
+	// gather returns the values of the metrics by kind/namespace/name of the object and metric name
+	gather := func() map[string]map[string]float64 {
+		registry := prometheus.NewPedanticRegistry()
+		gomega.Expect(registry.Register(collector)).To(gomega.Succeed())
+		metricFamilies, err := registry.Gather()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		values := map[string]map[string]float64{}
+		for _, metricFamily := range metricFamilies {
+			for _, metric := range metricFamily.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				object := labels["kind"] + "/" + labels["namespace"] + "/" + labels["name"]
+				if values[object] == nil {
+					values[object] = map[string]float64{}
+				}
+				values[object][metricFamily.GetName()] = metric.GetCounter().GetValue()
+			}
+		}
+		return values
+	}
+
+	hits := func(packets, bytes, drops float64) map[string]float64 {
+		return map[string]float64{
+			"ovn_controller_acl_hit_packets_total":         packets,
+			"ovn_controller_acl_hit_bytes_total":           bytes,
+			"ovn_controller_acl_hit_dropped_packets_total": drops,
+		}
+	}
+
+	ginkgo.It("counts the hits of the flows of the ACL evaluation stages per object", func() {
+		update(0, aclHitsOfctlDumpFlowsOutput(10, 5, 10))
+		// objects whose ACLs have no hits are not reported
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetworkPolicy/namespace1/policy1": hits(11, 1100, 0),
+			"NetpolNamespace/namespace1/":      hits(5, 500, 5),
+		}))
+	})
+
+	ginkgo.It("accumulates the increments of the flows across dumps and flow reinstalls", func() {
+		update(0,
+			aclHitsOfctlDumpFlowsOutput(10, 5, 10),
+			aclHitsOfctlDumpFlowsOutput(15, 5, 20),
+			// the flows were reinstalled with zero counters
+			aclHitsOfctlDumpFlowsOutput(2, 1, 0),
+		)
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetworkPolicy/namespace1/policy1": hits(18, 1800, 0),
+			"NetpolNamespace/namespace1/":      hits(6, 600, 6),
+		}))
+	})
+
+	ginkgo.It("counts the hits of the objects above the limit for an overflow object of their kind", func() {
+		update(1, aclHitsOfctlDumpFlowsOutput(10, 5, 10))
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetworkPolicy/namespace1/policy1":        hits(11, 1100, 0),
+			"NetpolNamespace//" + aclHitsOverflowName: hits(5, 500, 5),
+		}))
+	})
+
+	ginkgo.It("deletes the series of the objects that don't own ACLs anymore", func() {
+		newCollector(1,
+			aclHitsOfctlDumpFlowsOutput(10, 5, 10),
+			aclHitsOfctlDumpFlowsOutput(10, 5, 10),
+			aclHitsOfctlDumpFlowsOutput(10, 8, 10),
+		)
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetworkPolicy/namespace1/policy1":        hits(11, 1100, 0),
+			"NetpolNamespace//" + aclHitsOverflowName: hits(5, 500, 5),
+		}))
+
+		ginkgo.By("deleting the network policy")
+		policyACL := &nbdb.ACL{UUID: "1a2b3c4d-0000-0000-0000-000000000001"}
+		err := libovsdbops.DeleteACLsFromPortGroups(collector.nbClient, []string{"portgroup"}, policyACL)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(func() error {
+			return collector.nbClient.Get(context.TODO(), policyACL)
+		}).Should(gomega.HaveOccurred())
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+		// the overflowed objects stay counted for the overflow object
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetpolNamespace//" + aclHitsOverflowName: hits(5, 500, 5),
+		}))
+
+		ginkgo.By("deleting the namespace")
+		denyACL := &nbdb.ACL{UUID: "0e2b3c4d-0000-0000-0000-000000000002"}
+		err = libovsdbops.DeleteACLsFromPortGroups(collector.nbClient, []string{"portgroup"}, denyACL)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(func() error {
+			return collector.nbClient.Get(context.TODO(), denyACL)
+		}).Should(gomega.HaveOccurred())
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+		gomega.Expect(gather()).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("selects the logical flows again only after the ACLs change", func() {
+		firewallFlow := " cookie=0xad4e1f3a, duration=12.3s, table=20, n_packets=3, n_bytes=300, idle_age=5, priority=2000,ip,metadata=0x2 actions=drop\n"
+		newCollector(0,
+			aclHitsOfctlDumpFlowsOutput(10, 5, 10),
+			aclHitsOfctlDumpFlowsOutput(10, 5, 10)+firewallFlow,
+			aclHitsOfctlDumpFlowsOutput(10, 5, 10)+firewallFlow,
+		)
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+
+		ginkgo.By("adding a logical flow for the egress firewall ACL")
+		ops, err := collector.sbClient.Create(&sbdb.LogicalFlow{
+			UUID:     "ad4e1f3a-0000-0000-0000-000000000005",
+			Pipeline: sbdb.LogicalFlowPipelineIngress,
+			ExternalIDs: map[string]string{
+				"stage-hint": "5a2b3c4d",
+				"stage-name": "ls_in_acl_eval",
+			},
+		})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(collector.sbClient, ops)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		// the ACLs didn't change, the logical flows are not selected again
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetworkPolicy/namespace1/policy1": hits(11, 1100, 0),
+			"NetpolNamespace/namespace1/":      hits(5, 500, 5),
+		}))
+
+		ginkgo.By("changing the egress firewall ACL")
+		firewallACL := &nbdb.ACL{UUID: "5a2b3c4d-0000-0000-0000-000000000003", Action: nbdb.ACLActionReject}
+		ops, err = collector.nbClient.Where(firewallACL).Update(firewallACL, &firewallACL.Action)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(collector.nbClient, ops)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(func() int {
+			collector.Lock()
+			defer collector.Unlock()
+			return collector.lflowRefreshes
+		}).Should(gomega.Equal(aclHitsLogicalFlowRefreshes))
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+		gomega.Expect(gather()).To(gomega.Equal(map[string]map[string]float64{
+			"NetworkPolicy/namespace1/policy1": hits(11, 1100, 0),
+			"NetpolNamespace/namespace1/":      hits(5, 500, 5),
+			"EgressFirewall/namespace2/":       hits(3, 300, 3),
+		}))
+	})
+})
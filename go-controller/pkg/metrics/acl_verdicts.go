@@ -0,0 +1,206 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+)
+
+// ACLVerdict is the verdict of an ACL on a flow, mapped to the Kubernetes object the ACL is derived from
+type ACLVerdict struct {
+	Time time.Time `json:"time"`
+	// ACLName is the name of the ACL, as set by libovsdbutil.GetACLName
+	ACLName string `json:"aclName"`
+	// Verdict is one of allow, drop or reject
+	Verdict  string `json:"verdict"`
+	Severity string `json:"severity,omitempty"`
+	// Direction is the OVN direction of the ACL, from-lport or to-lport
+	Direction string `json:"direction,omitempty"`
+	// ExternalIDs are the external IDs of the ACL encoded in its name, as described by libovsdbutil.ParseACLName.
+	// Nil if the ACL is not owned by a known object or its name was cropped.
+	ExternalIDs map[string]string `json:"externalIDs,omitempty"`
+	// OwnerType is the type of the Kubernetes object the ACL is derived from
+	OwnerType string `json:"ownerType,omitempty"`
+	// Namespace and Name of the Kubernetes object, Name is empty for namespace scoped ACLs and Namespace for cluster
+	// scoped ones
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Flow is the description of the flow the verdict applies to, as logged by ovn-controller
+	Flow string `json:"flow"`
+}
+
+// ACLVerdictsSubscribeFunc subscribes to the ACL verdicts, the verdicts are sent to the returned channel until the
+// returned cancel function is called. The channel is closed when the source stops.
+type ACLVerdictsSubscribeFunc func() (<-chan ACLVerdict, func())
+
+var (
+	aclVerdictsSourceLock sync.RWMutex
+	aclVerdictsSource     ACLVerdictsSubscribeFunc
+)
+
+const (
+	// aclVerdictsOverflowName is the name of the object the verdicts of the objects above the cardinality limit are
+	// counted for
+	aclVerdictsOverflowName = "_overflow"
+	// aclVerdictsIdleTimeout is the time after which the series of an object without verdicts are deleted, so that
+	// the objects above the cardinality limit can be counted instead
+	aclVerdictsIdleTimeout = time.Hour
+)
+
+var metricACLVerdicts = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnNamespace,
+	Subsystem: MetricOvnSubsystemController,
+	Name:      "acl_verdicts_total",
+	Help:      "The total number of logged ACL verdicts, by owner of the ACL.",
+}, []string{
+	"owner_type",
+	"namespace",
+	"name",
+	"verdict",
+})
+
+// aclVerdictsObject is the object owning ACLs the verdicts are counted for
+type aclVerdictsObject struct {
+	ownerType string
+	namespace string
+	name      string
+}
+
+// aclVerdictsCounter counts the verdicts of up to maxObjects objects, the verdicts of the other objects are counted for
+// the overflow object of their owner type
+type aclVerdictsCounter struct {
+	maxObjects int
+
+	sync.Mutex
+	// lastVerdicts is the time of the last verdict of the counted objects
+	lastVerdicts map[aclVerdictsObject]time.Time
+}
+
+var aclVerdicts = &aclVerdictsCounter{lastVerdicts: map[aclVerdictsObject]time.Time{}}
+
+var registerACLVerdictMetricsOnce sync.Once
+
+// RegisterACLVerdictMetrics registers the ACL verdict counters with the OVN metrics server, verdicts are counted for
+// up to maxObjects objects, 0 meaning no limit
+func RegisterACLVerdictMetrics(maxObjects int) {
+	registerACLVerdictMetricsOnce.Do(func() {
+		aclVerdicts.Lock()
+		aclVerdicts.maxObjects = maxObjects
+		aclVerdicts.Unlock()
+		ovnRegistry.MustRegister(metricACLVerdicts)
+	})
+}
+
+// RecordACLVerdict counts the verdict of an ACL
+func RecordACLVerdict(verdict ACLVerdict) {
+	object := aclVerdicts.getObject(aclVerdictsObject{
+		ownerType: verdict.OwnerType,
+		namespace: verdict.Namespace,
+		name:      verdict.Name,
+	}, time.Now())
+	metricACLVerdicts.WithLabelValues(object.ownerType, object.namespace, object.name, verdict.Verdict).Inc()
+}
+
+// getObject returns the object a verdict of the given object is counted for: the object itself, or the overflow object
+// of its owner type if the cardinality limit is reached even after deleting the series of the idle objects
+func (c *aclVerdictsCounter) getObject(object aclVerdictsObject, now time.Time) aclVerdictsObject {
+	c.Lock()
+	defer c.Unlock()
+	if _, ok := c.lastVerdicts[object]; !ok && c.maxObjects > 0 && len(c.lastVerdicts) >= c.maxObjects {
+		c.evictIdleObjects(now)
+		if len(c.lastVerdicts) >= c.maxObjects {
+			object = aclVerdictsObject{ownerType: object.ownerType, name: aclVerdictsOverflowName}
+		}
+	}
+	c.lastVerdicts[object] = now
+	return object
+}
+
+// evictIdleObjects deletes the series of the objects without verdicts for aclVerdictsIdleTimeout
+func (c *aclVerdictsCounter) evictIdleObjects(now time.Time) {
+	for object, lastVerdict := range c.lastVerdicts {
+		if now.Sub(lastVerdict) < aclVerdictsIdleTimeout {
+			continue
+		}
+		metricACLVerdicts.DeletePartialMatch(prometheus.Labels{
+			"owner_type": object.ownerType,
+			"namespace":  object.namespace,
+			"name":       object.name,
+		})
+		delete(c.lastVerdicts, object)
+	}
+}
+
+// SetACLVerdictsSource sets the function subscribing to the verdicts streamed at /acl-verdicts, nil disables the
+// endpoint
+func SetACLVerdictsSource(subscribe ACLVerdictsSubscribeFunc) {
+	aclVerdictsSourceLock.Lock()
+	defer aclVerdictsSourceLock.Unlock()
+	aclVerdictsSource = subscribe
+}
+
+// aclVerdictsHandler streams the ACL verdicts as newline delimited JSON until the client goes away. The verdicts can
+// be filtered with the ownerType, namespace, name and verdict query parameters.
+func aclVerdictsHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writePlainText(http.StatusNotAcceptable, "unsupported http method", w)
+		return
+	}
+	aclVerdictsSourceLock.RLock()
+	subscribe := aclVerdictsSource
+	aclVerdictsSourceLock.RUnlock()
+	if subscribe == nil {
+		writePlainText(http.StatusServiceUnavailable, "ACL verdicts are not available", w)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writePlainText(http.StatusInternalServerError, "streaming is not supported", w)
+		return
+	}
+
+	query := req.URL.Query()
+	filter := func(verdict *ACLVerdict) bool {
+		for param, value := range map[string]string{
+			"ownerType": verdict.OwnerType,
+			"namespace": verdict.Namespace,
+			"name":      verdict.Name,
+			"verdict":   verdict.Verdict,
+		} {
+			if query.Has(param) && query.Get(param) != value {
+				return false
+			}
+		}
+		return true
+	}
+
+	verdicts, cancel := subscribe()
+	defer cancel()
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case verdict, ok := <-verdicts:
+			if !ok {
+				return
+			}
+			if !filter(&verdict) {
+				continue
+			}
+			if err := encoder.Encode(verdict); err != nil {
+				klog.V(5).Infof("Stopped streaming ACL verdicts to %s: %v", req.RemoteAddr, err)
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
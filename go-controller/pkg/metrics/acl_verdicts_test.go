@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var _ = ginkgo.Describe("ACL verdict metrics", func() {
+	ginkgo.BeforeEach(func() {
+		metricACLVerdicts.Reset()
+		aclVerdicts = &aclVerdictsCounter{maxObjects: 2, lastVerdicts: map[aclVerdictsObject]time.Time{}}
+	})
+
+	// gather returns the verdict counts by owner type/namespace/name of the object and verdict
+	gather := func() map[string]float64 {
+		registry := prometheus.NewPedanticRegistry()
+		gomega.Expect(registry.Register(metricACLVerdicts)).To(gomega.Succeed())
+		metricFamilies, err := registry.Gather()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		values := map[string]float64{}
+		for _, metricFamily := range metricFamilies {
+			for _, metric := range metricFamily.GetMetric() {
+				labels := map[string]string{}
+				for _, label := range metric.GetLabel() {
+					labels[label.GetName()] = label.GetValue()
+				}
+				values[labels["owner_type"]+"/"+labels["namespace"]+"/"+labels["name"]+"/"+labels["verdict"]] =
+					metric.GetCounter().GetValue()
+			}
+		}
+		return values
+	}
+
+	verdict := func(namespace, name, action string) ACLVerdict {
+		return ACLVerdict{OwnerType: "NetworkPolicy", Namespace: namespace, Name: name, Verdict: action}
+	}
+
+	ginkgo.It("counts the verdicts of the objects above the cardinality limit for the overflow object", func() {
+		RecordACLVerdict(verdict("ns1", "policy1", "allow"))
+		RecordACLVerdict(verdict("ns1", "policy1", "drop"))
+		RecordACLVerdict(verdict("ns2", "policy2", "allow"))
+		RecordACLVerdict(verdict("ns3", "policy3", "allow"))
+		RecordACLVerdict(verdict("ns4", "policy4", "drop"))
+		RecordACLVerdict(verdict("ns1", "policy1", "allow"))
+		gomega.Expect(gather()).To(gomega.Equal(map[string]float64{
+			"NetworkPolicy/ns1/policy1/allow": 2,
+			"NetworkPolicy/ns1/policy1/drop":  1,
+			"NetworkPolicy/ns2/policy2/allow": 1,
+			"NetworkPolicy//_overflow/allow":  1,
+			"NetworkPolicy//_overflow/drop":   1,
+		}))
+	})
+
+	ginkgo.It("deletes the series of the idle objects to count new objects", func() {
+		now := time.Now()
+		object1 := aclVerdicts.getObject(aclVerdictsObject{ownerType: "NetworkPolicy", namespace: "ns1", name: "policy1"},
+			now.Add(-2*aclVerdictsIdleTimeout))
+		metricACLVerdicts.WithLabelValues(object1.ownerType, object1.namespace, object1.name, "allow").Inc()
+		RecordACLVerdict(verdict("ns2", "policy2", "allow"))
+		RecordACLVerdict(verdict("ns3", "policy3", "allow"))
+		gomega.Expect(gather()).To(gomega.Equal(map[string]float64{
+			"NetworkPolicy/ns2/policy2/allow": 1,
+			"NetworkPolicy/ns3/policy3/allow": 1,
+		}))
+	})
+})
@@ -78,6 +78,32 @@ var metricEgressIPRebalanceCount = prometheus.NewCounter(prometheus.CounterOpts{
 
 /** EgressIP metrics recorded from cluster-manager ends**/
 
+var metricClusterNetworkProgramming = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "network_programming_duration_seconds",
+	Help: "The duration to apply the network configuration of an object of a kind (e.g. service, networkpolicy) " +
+		"in all the zones, from the first zone observing the change to the last zone applying it.",
+	Buckets: merge(
+		prometheus.LinearBuckets(0.25, 0.25, 2), // 0.25s, 0.50s
+		prometheus.LinearBuckets(1, 1, 59),      // 1s, 2s, 3s, ... 59s
+		prometheus.LinearBuckets(60, 5, 12),     // 60s, 65s, 70s, ... 115s
+		prometheus.LinearBuckets(120, 30, 11))}, // 2min, 2.5min, 3min, ..., 7min
+	[]string{
+		"kind",
+	})
+
+var metricClusterNetworkProgrammingIncomplete = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemClusterManager,
+	Name:      "network_programming_incomplete_total",
+	Help: "The total number of network configuration changes of a kind that were not reported as applied by " +
+		"all the zones in time.",
+},
+	[]string{
+		"kind",
+	})
+
 // RegisterClusterManagerBase registers ovnkube cluster manager base metrics with the Prometheus registry.
 // This function should only be called once.
 func RegisterClusterManagerBase() {
@@ -117,6 +143,10 @@ func RegisterClusterManagerFunctional() {
 		prometheus.MustRegister(metricEgressIPRebalanceCount)
 		prometheus.MustRegister(metricEgressIPCount)
 	}
+	if config.Metrics.EnableConfigDuration {
+		prometheus.MustRegister(metricClusterNetworkProgramming)
+		prometheus.MustRegister(metricClusterNetworkProgrammingIncomplete)
+	}
 	if err := prometheus.Register(MetricResourceRetryFailuresCount); err != nil {
 		if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
 			panic(err)
@@ -152,3 +182,15 @@ func RecordEgressIPRebalance(count int) {
 func RecordEgressIPCount(count float64) {
 	metricEgressIPCount.Set(count)
 }
+
+// RecordNetworkProgrammingDuration records the duration to apply the network configuration of an object of the given
+// kind in all the zones.
+func RecordNetworkProgrammingDuration(kind string, duration float64) {
+	metricClusterNetworkProgramming.WithLabelValues(kind).Observe(duration)
+}
+
+// RecordNetworkProgrammingIncomplete records a network configuration change of the given kind that was not reported
+// as applied by all the zones.
+func RecordNetworkProgrammingIncomplete(kind string) {
+	metricClusterNetworkProgrammingIncomplete.WithLabelValues(kind).Inc()
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"k8s.io/klog/v2"
+)
+
+// FlowSamplingObservation maps the observation ID of a sampled ACL to the Kubernetes object the ACL is derived from
+type FlowSamplingObservation struct {
+	ObservationID int `json:"observationID"`
+	// Controller is the name of the network controller that owns the ACL
+	Controller string `json:"controller"`
+	// OwnerType is the type of the Kubernetes object the ACL is derived from
+	OwnerType string `json:"ownerType"`
+	Namespace string `json:"namespace"`
+	// Name is the name of the Kubernetes object, empty for namespace ACLs
+	Name      string `json:"name,omitempty"`
+	Direction string `json:"direction,omitempty"`
+	Action    string `json:"action"`
+	// Probability with which the flows of the namespace are sampled, out of 65535
+	Probability int `json:"probability"`
+}
+
+var (
+	flowSamplingSourceLock sync.RWMutex
+	flowSamplingSource     func() ([]FlowSamplingObservation, error)
+)
+
+// SetFlowSamplingObservationsSource sets the function listing the observations served at /flow-sampling,
+// nil disables the endpoint
+func SetFlowSamplingObservationsSource(source func() ([]FlowSamplingObservation, error)) {
+	flowSamplingSourceLock.Lock()
+	defer flowSamplingSourceLock.Unlock()
+	flowSamplingSource = source
+}
+
+// flowSamplingHandler serves the observations of the sampled ACLs, optionally for the observation ID given by the id
+// query parameter
+func flowSamplingHandler(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		writePlainText(http.StatusNotAcceptable, "unsupported http method", w)
+		return
+	}
+	flowSamplingSourceLock.RLock()
+	source := flowSamplingSource
+	flowSamplingSourceLock.RUnlock()
+	if source == nil {
+		writePlainText(http.StatusServiceUnavailable, "flow sampling observations are not available", w)
+		return
+	}
+
+	id := -1
+	if idParam := req.URL.Query().Get("id"); idParam != "" {
+		var err error
+		if id, err = strconv.Atoi(idParam); err != nil {
+			writePlainText(http.StatusBadRequest, "invalid observation ID "+idParam, w)
+			return
+		}
+	}
+	observations, err := source()
+	if err != nil {
+		writePlainText(http.StatusInternalServerError, err.Error(), w)
+		return
+	}
+	if id >= 0 {
+		filtered := []FlowSamplingObservation{}
+		for _, observation := range observations {
+			if observation.ObservationID == id {
+				filtered = append(filtered, observation)
+			}
+		}
+		observations = filtered
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	if err := json.NewEncoder(w).Encode(observations); err != nil {
+		klog.Errorf("Failed to encode the flow sampling observations: %v", err)
+	}
+}
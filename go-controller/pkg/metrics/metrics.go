@@ -477,6 +477,13 @@ func StartMetricsServer(bindAddress string, enablePprof bool, certFile string, k
 
 		// Allow changes to log level at runtime
 		mux.HandleFunc("/debug/flags/v", stringFlagPutHandler(klogSetter))
+
+		if config.OVNKubernetesFeature.EnableShadowMode {
+			mux.HandleFunc("/debug/shadow", shadowModeHandler)
+		}
+	}
+	if config.Metrics.EnableFlowSamplingObservations {
+		mux.HandleFunc("/flow-sampling", flowSamplingHandler)
 	}
 
 	startMetricsServer(bindAddress, certFile, keyFile, mux, stopChan, wg)
@@ -490,6 +497,9 @@ func StartOVNMetricsServer(bindAddress, certFile, keyFile string, stopChan <-cha
 		promhttp.HandlerFor(ovnRegistry, promhttp.HandlerOpts{}))
 	mux := http.NewServeMux()
 	mux.Handle("/metrics", handler)
+	if config.Metrics.EnableACLVerdictsStream {
+		mux.HandleFunc("/acl-verdicts", aclVerdictsHandler)
+	}
 
 	startMetricsServer(bindAddress, certFile, keyFile, mux, stopChan, wg)
 }
@@ -0,0 +1,80 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var registerNBDriftMetricsOnce sync.Once
+
+var metricNBDriftObjects = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_drift_objects",
+	Help: "The number of OVN NB objects found by the last drift audit that are stale, i.e. not expected for " +
+		"their Kubernetes owner, missing, i.e. expected for an existing Kubernetes owner but not in the NB database, " +
+		"or mismatched, i.e. whose content differs from the expected one",
+},
+	[]string{
+		"owner_type",
+		"object_type",
+		"kind",
+	},
+)
+
+var metricNBDriftRepairs = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_drift_repairs_total",
+	Help:      "The total number of repairs of drifted OVN NB objects by owner type, object type and result",
+},
+	[]string{
+		"owner_type",
+		"object_type",
+		"result",
+	},
+)
+
+var metricNBDriftAuditDuration = prometheus.NewGauge(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_drift_audit_duration_seconds",
+	Help:      "The duration of the last OVN NB drift audit",
+})
+
+// NBDriftKey identifies a type of drift of OVN NB objects
+type NBDriftKey struct {
+	OwnerType  string
+	ObjectType string
+	Kind       string
+}
+
+// RegisterNBDriftMetrics registers the metrics of the OVN NB drift auditor
+func RegisterNBDriftMetrics() {
+	registerNBDriftMetricsOnce.Do(func() {
+		prometheus.MustRegister(metricNBDriftObjects)
+		prometheus.MustRegister(metricNBDriftRepairs)
+		prometheus.MustRegister(metricNBDriftAuditDuration)
+	})
+}
+
+// RecordNBDriftAudit records the number of drifted objects found by an audit, by type of drift, and its duration.
+// Types of drift that are not found anymore are reset.
+func RecordNBDriftAudit(drift map[NBDriftKey]int, duration time.Duration) {
+	metricNBDriftObjects.Reset()
+	for key, count := range drift {
+		metricNBDriftObjects.WithLabelValues(key.OwnerType, key.ObjectType, key.Kind).Set(float64(count))
+	}
+	metricNBDriftAuditDuration.Set(duration.Seconds())
+}
+
+// RecordNBDriftRepair records a repair of drifted objects of the given owner and object type
+func RecordNBDriftRepair(ownerType, objectType string, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	metricNBDriftRepairs.WithLabelValues(ownerType, objectType, result).Inc()
+}
@@ -0,0 +1,76 @@
+package metrics
+
+import (
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+)
+
+var registerNBTxnCoalescingMetricsOnce sync.Once
+
+var metricNBTxnBatchRequests = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_txn_batch_requests",
+	Help:      "The number of callers whose operations were merged into a single OVN NB transaction",
+	Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+})
+
+var metricNBTxnBatchOperations = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_txn_batch_operations",
+	Help:      "The number of operations of a merged OVN NB transaction",
+	Buckets:   prometheus.ExponentialBuckets(1, 2, 12),
+})
+
+var metricNBTxnBatchDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_txn_batch_duration_seconds",
+	Help: "The duration of a merged OVN NB transaction, including sending the requests again separately when an " +
+		"operation failed",
+	Buckets: prometheus.ExponentialBuckets(.001, 2, 15),
+})
+
+var metricNBTxnQueueDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_txn_queue_duration_seconds",
+	Help:      "The duration a caller waited for its operations to be sent in a merged OVN NB transaction",
+	Buckets:   prometheus.ExponentialBuckets(.001, 2, 12),
+})
+
+var metricNBTxnBatchFallbacks = prometheus.NewCounter(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemController,
+	Name:      "nb_txn_batch_fallbacks_total",
+	Help: "The total number of merged OVN NB transactions with a failed operation, whose requests were sent again " +
+		"separately to report the failure to its caller only",
+})
+
+// RegisterNBTxnCoalescingMetrics registers the metrics of the OVN NB transaction coalescer
+func RegisterNBTxnCoalescingMetrics() {
+	registerNBTxnCoalescingMetricsOnce.Do(func() {
+		prometheus.MustRegister(metricNBTxnBatchRequests)
+		prometheus.MustRegister(metricNBTxnBatchOperations)
+		prometheus.MustRegister(metricNBTxnBatchDuration)
+		prometheus.MustRegister(metricNBTxnQueueDuration)
+		prometheus.MustRegister(metricNBTxnBatchFallbacks)
+	})
+}
+
+// RecordNBTxnBatch records a transaction sent by the OVN NB transaction coalescer
+func RecordNBTxnBatch(stats libovsdbops.TransactionBatchStats) {
+	metricNBTxnBatchRequests.Observe(float64(stats.Requests))
+	metricNBTxnBatchOperations.Observe(float64(stats.Operations))
+	metricNBTxnBatchDuration.Observe(stats.Duration.Seconds())
+	for _, queueDuration := range stats.QueueDurations {
+		metricNBTxnQueueDuration.Observe(queueDuration.Seconds())
+	}
+	if stats.Fallback {
+		metricNBTxnBatchFallbacks.Inc()
+	}
+}
@@ -34,6 +34,50 @@ var metricOvnNodePortEnabled = prometheus.NewGauge(prometheus.GaugeOpts{
 	Help:      "Specifies if the node port is enabled on this node(1) or not(0).",
 })
 
+// MetricDevicePoolSize is a prometheus metric that tracks the number of devices that can be created on demand
+// from each device pool of the node
+var MetricDevicePoolSize = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "device_pool_size",
+	Help:      "The maximum number of devices of the device pools created on demand for the pods.",
+},
+	[]string{"pool", "type"},
+)
+
+// MetricDevicePoolAllocated is a prometheus metric that tracks the number of devices created for the pods from each
+// device pool of the node
+var MetricDevicePoolAllocated = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "device_pool_allocated",
+	Help:      "The number of devices of the device pools currently created for the pods.",
+},
+	[]string{"pool", "type"},
+)
+
+// MetricDevicePoolAllocationFailures is a prometheus metric that tracks the number of devices that could not be
+// allocated from each device pool of the node
+var MetricDevicePoolAllocationFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "device_pool_allocation_failures_total",
+	Help:      "The total number of devices of the device pools that could not be created for the pods.",
+},
+	[]string{"pool", "type"},
+)
+
+// MetricUnhealthyRepresentors is a prometheus metric that tracks the number of unhealthy OVS interfaces of the VF
+// representors of the pods of the node
+var MetricUnhealthyRepresentors = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Namespace: MetricOvnkubeNamespace,
+	Subsystem: MetricOvnkubeSubsystemNode,
+	Name:      "unhealthy_representors",
+	Help:      "The number of OVS interfaces of the VF representors of the pods without OpenFlow port or with their link down.",
+},
+	[]string{"reason"},
+)
+
 var registerNodeMetricsOnce sync.Once
 
 func RegisterNodeMetrics() {
@@ -42,6 +86,10 @@ func RegisterNodeMetrics() {
 		prometheus.MustRegister(MetricCNIRequestDuration)
 		prometheus.MustRegister(MetricNodeReadyDuration)
 		prometheus.MustRegister(metricOvnNodePortEnabled)
+		prometheus.MustRegister(MetricDevicePoolSize)
+		prometheus.MustRegister(MetricDevicePoolAllocated)
+		prometheus.MustRegister(MetricDevicePoolAllocationFailures)
+		prometheus.MustRegister(MetricUnhealthyRepresentors)
 		prometheus.MustRegister(prometheus.NewGaugeFunc(
 			prometheus.GaugeOpts{
 				Namespace: MetricOvnkubeNamespace,
@@ -138,6 +138,16 @@ var metricDBClusterElectionTimer = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	},
 )
 
+var metricDBClusterLeaderChanges = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: MetricOvnNamespace,
+	Subsystem: MetricOvnSubsystemDB,
+	Name:      "cluster_leader_changes_total",
+	Help:      "The total number of changes of the cluster leader seen by the server labeled by database name"},
+	[]string{
+		"db_name",
+	},
+)
+
 var metricDBClusterLogIndexStart = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Namespace: MetricOvnNamespace,
 	Subsystem: MetricOvnSubsystemDB,
@@ -429,6 +439,7 @@ func RegisterOvnDBMetrics(clientset kubernetes.Interface, k8sNodeName string, st
 		ovnRegistry.MustRegister(metricDBClusterServerRole)
 		ovnRegistry.MustRegister(metricDBClusterServerVote)
 		ovnRegistry.MustRegister(metricDBClusterElectionTimer)
+		ovnRegistry.MustRegister(metricDBClusterLeaderChanges)
 		ovnRegistry.MustRegister(metricDBClusterLogIndexStart)
 		ovnRegistry.MustRegister(metricDBClusterLogIndexNext)
 		ovnRegistry.MustRegister(metricDBClusterLogNotCommitted)
@@ -484,6 +495,7 @@ type OVNDBClusterStatus struct {
 	status          string
 	role            string
 	vote            string
+	leader          string
 	term            float64
 	electionTimer   float64
 	logIndexStart   float64
@@ -496,7 +508,13 @@ type OVNDBClusterStatus struct {
 	connOutErr      float64
 }
 
-func getOVNDBClusterStatusInfo(timeout int, dbProperties *util.OvsDbProperties) (clusterStatus *OVNDBClusterStatus,
+// LogEntries returns the number of entries of the Raft log of the server, i.e. the entries since its last snapshot
+func (clusterStatus *OVNDBClusterStatus) LogEntries() float64 {
+	return clusterStatus.logIndexNext - clusterStatus.logIndexStart
+}
+
+// GetOVNDBClusterStatusInfo parses the cluster/status output of the given database
+func GetOVNDBClusterStatusInfo(timeout int, dbProperties *util.OvsDbProperties) (clusterStatus *OVNDBClusterStatus,
 	err error) {
 	var stdout, stderr string
 
@@ -536,6 +554,9 @@ func getOVNDBClusterStatusInfo(timeout int, dbProperties *util.OvsDbProperties)
 			}
 		case "Vote":
 			clusterStatus.vote = line[idx+2:]
+		case "Leader":
+			// the value is `self`, `unknown` or the 4 char prefix of the leader server ID
+			clusterStatus.leader = line[idx+2:]
 		case "Election timer":
 			if value, err := strconv.ParseFloat(line[idx+2:], 64); err == nil {
 				clusterStatus.electionTimer = value
@@ -585,12 +606,21 @@ func getOVNDBClusterStatusInfo(timeout int, dbProperties *util.OvsDbProperties)
 	return clusterStatus, nil
 }
 
+// dbClusterLeaders holds the last known cluster leader of each database, only accessed by the metrics updater
+var dbClusterLeaders = map[string]string{}
+
 func ovnDBClusterStatusMetricsUpdater(dbProperties *util.OvsDbProperties) {
-	clusterStatus, err := getOVNDBClusterStatusInfo(5, dbProperties)
+	clusterStatus, err := GetOVNDBClusterStatusInfo(5, dbProperties)
 	if err != nil {
 		klog.Errorf(err.Error())
 		return
 	}
+	if clusterStatus.leader != "" && clusterStatus.leader != "unknown" {
+		if leader, ok := dbClusterLeaders[dbProperties.DbName]; ok && leader != clusterStatus.leader {
+			metricDBClusterLeaderChanges.WithLabelValues(dbProperties.DbName).Inc()
+		}
+		dbClusterLeaders[dbProperties.DbName] = clusterStatus.leader
+	}
 	metricDBClusterCID.WithLabelValues(dbProperties.DbName, clusterStatus.cid).Set(1)
 	metricDBClusterSID.WithLabelValues(dbProperties.DbName, clusterStatus.cid, clusterStatus.sid).Set(1)
 	metricDBClusterServerStatus.WithLabelValues(dbProperties.DbName, clusterStatus.cid, clusterStatus.sid,
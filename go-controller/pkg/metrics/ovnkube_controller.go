@@ -1,12 +1,14 @@
 package metrics
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"hash/fnv"
 	"math"
 	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,8 +25,11 @@ import (
 
 	"github.com/prometheus/client_golang/prometheus"
 	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kapimtypes "k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/util/workqueue"
 	klog "k8s.io/klog/v2"
 )
@@ -824,8 +829,19 @@ const (
 	//fixme: remove when bug is fixed in OVN (Red Hat bugzilla bug number 2074019). Also, handle overflow event.
 	maxNbCfg               = math.MaxUint32 - 1000
 	maxMeasurementLifetime = 20 * time.Minute
+	// maxZoneReports is the number of the last measurements a zone reports to ovnkube-cluster-manager
+	maxZoneReports = 100
 )
 
+// zoneReportKinds are the kinds whose measurements are reported to ovnkube-cluster-manager, which aggregates their
+// network programming duration across zones
+var zoneReportKinds = map[string]bool{
+	"service":            true,
+	"endpointslice":      true,
+	"networkpolicy":      true,
+	"adminnetworkpolicy": true,
+}
+
 type ovnMeasurement struct {
 	// time just before ovsdb tx is called
 	startTimestamp time.Time
@@ -869,6 +885,14 @@ type ConfigDurationRecorder struct {
 	// channel to trigger processing a measurement following call to End func. Channel string is kind/namespace/name
 	triggerProcessCh chan string
 	enabled          bool
+
+	// zoneReports holds the last measurements to report to ovnkube-cluster-manager, nil unless the zone reporter runs
+	zoneReports *util.NetworkProgrammingReports
+	// zoneReportsChanged is set when measurements were added since the zone reports were last published
+	zoneReportsChanged bool
+	zoneReportSeq      uint64
+	// controls access to the zone reports
+	zoneReportsMu sync.Mutex
 }
 
 // global variable is needed because this functionality is accessed in many functions
@@ -1193,6 +1217,7 @@ func (cr *ConfigDurationRecorder) processMeasurements(period time.Duration, upda
 			metricNetworkProgramming.With(prometheus.Labels{"kind": m.kind}).Observe(ovnKDelta)
 			klog.V(5).Infof("Config duration recorder: kind/namespace/name %s. OVN-Kubernetes controller took %v"+
 				" seconds. No OVN measurement.", kindNamespaceName, ovnKDelta)
+			cr.addZoneReport(kindNamespaceName, m, ovnKDelta)
 			delete(cr.measurements, kindNamespaceName)
 			cr.measurementsMu.Unlock()
 		// used for processing measurements that require OVN measurement or do not or are expired.
@@ -1217,6 +1242,7 @@ func (cr *ConfigDurationRecorder) processMeasurements(period time.Duration, upda
 					metricNetworkProgramming.With(prometheus.Labels{"kind": m.kind}).Observe(ovnKDelta)
 					klog.V(5).Infof("Config duration recorder: kind/namespace/name %s. OVN-Kubernetes controller"+
 						" took %v seconds. No OVN measurement.", kindNamespaceName, ovnKDelta)
+					cr.addZoneReport(kindNamespaceName, m, ovnKDelta)
 					delete(cr.measurements, kindNamespaceName)
 					continue
 				}
@@ -1234,6 +1260,7 @@ func (cr *ConfigDurationRecorder) processMeasurements(period time.Duration, upda
 				klog.V(5).Infof("Config duration recorder: kind/namespace/name %s. OVN-Kubernetes controller took"+
 					" %v seconds. OVN took %v seconds. Total took %v seconds", kindNamespaceName, ovnKDelta,
 					ovnDelta, ovnDelta+ovnKDelta)
+				cr.addZoneReport(kindNamespaceName, m, ovnKDelta+ovnDelta)
 				delete(cr.measurements, kindNamespaceName)
 			}
 			cr.measurementsMu.Unlock()
@@ -1241,6 +1268,92 @@ func (cr *ConfigDurationRecorder) processMeasurements(period time.Duration, upda
 	}
 }
 
+// RunZoneReporter reports the measurements of the kinds whose network programming duration is aggregated across zones
+// by ovnkube-cluster-manager, with a ConfigMap of the zone in the ovn-kubernetes namespace. The ConfigMap is updated
+// at most once per period, and only when measurements were added. Must be called after Run.
+func (cr *ConfigDurationRecorder) RunZoneReporter(zone string, client kubernetes.Interface, period time.Duration,
+	stop <-chan struct{}) {
+	if !cr.enabled {
+		return
+	}
+	cr.zoneReportsMu.Lock()
+	cr.zoneReports = &util.NetworkProgrammingReports{Zone: zone, Epoch: time.Now().UnixNano()}
+	cr.zoneReportsMu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if err := cr.publishZoneReports(client); err != nil {
+					klog.Errorf("Config duration recorder: failed to report the measurements of zone %s: %v", zone, err)
+				}
+			case <-stop:
+				return
+			}
+		}
+	}()
+}
+
+// addZoneReport adds a processed measurement to the zone reports, if its kind is reported. Must be called with
+// measurementsMu.
+func (cr *ConfigDurationRecorder) addZoneReport(kindNamespaceName string, m measurement, duration float64) {
+	if !zoneReportKinds[m.kind] {
+		return
+	}
+	// kind/namespace/name, the namespace is empty for cluster scoped kinds
+	parts := strings.SplitN(kindNamespaceName, "/", 3)
+	if len(parts) != 3 {
+		return
+	}
+	cr.zoneReportsMu.Lock()
+	defer cr.zoneReportsMu.Unlock()
+	if cr.zoneReports == nil {
+		return
+	}
+	cr.zoneReportSeq++
+	cr.zoneReports.Reports = append(cr.zoneReports.Reports, util.NetworkProgrammingReport{
+		Seq:       cr.zoneReportSeq,
+		Kind:      m.kind,
+		Namespace: parts[1],
+		Name:      parts[2],
+		Start:     m.startTimestamp,
+		End:       m.startTimestamp.Add(time.Duration(duration * float64(time.Second))),
+	})
+	if len(cr.zoneReports.Reports) > maxZoneReports {
+		cr.zoneReports.Reports = cr.zoneReports.Reports[len(cr.zoneReports.Reports)-maxZoneReports:]
+	}
+	cr.zoneReportsChanged = true
+}
+
+// publishZoneReports creates or updates the ConfigMap of the zone reports if they changed
+func (cr *ConfigDurationRecorder) publishZoneReports(client kubernetes.Interface) error {
+	cr.zoneReportsMu.Lock()
+	if !cr.zoneReportsChanged {
+		cr.zoneReportsMu.Unlock()
+		return nil
+	}
+	configMap, err := util.NewNetworkProgrammingReportsConfigMap(config.Kubernetes.OVNConfigNamespace, cr.zoneReports)
+	cr.zoneReportsChanged = false
+	cr.zoneReportsMu.Unlock()
+	if err != nil {
+		return err
+	}
+	configMaps := client.CoreV1().ConfigMaps(configMap.Namespace)
+	_, err = configMaps.Update(context.TODO(), configMap, metav1.UpdateOptions{})
+	if apierrors.IsNotFound(err) {
+		_, err = configMaps.Create(context.TODO(), configMap, metav1.CreateOptions{})
+	}
+	if err != nil {
+		cr.zoneReportsMu.Lock()
+		cr.zoneReportsChanged = true
+		cr.zoneReportsMu.Unlock()
+		return fmt.Errorf("failed to update ConfigMap %s/%s: %w", configMap.Namespace, configMap.Name, err)
+	}
+	return nil
+}
+
 func (cr *ConfigDurationRecorder) addHvCfg(hvCfg, hvCfgTimestamp int) {
 	var altered bool
 	for i, m := range cr.measurements {
@@ -1,16 +1,19 @@
 package metrics
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"time"
 
 	"github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics/mocks"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	fakeclientgo "k8s.io/client-go/kubernetes/fake"
@@ -232,5 +235,45 @@ var _ = ginkgo.Describe("Config Duration Operations", func() {
 			gomega.Expect(math.Round(histoValue)).Should(gomega.BeNumerically("==", math.Round(delta)))
 			histoMock.Cleanup()
 		})
+
+		ginkgo.It("reports the measurements of the aggregated kinds on a ConfigMap of the zone", func() {
+			instance.Run(nbClient, k, 0, time.Millisecond, stop)
+			instance.RunZoneReporter("zonea", k.KClient, time.Millisecond, stop)
+			histoMock := mocks.NewHistogramVecMock()
+			metricNetworkProgramming = histoMock
+			startTimestamp, ok := instance.Start("service", testNamespaceA, "testservicea")
+			gomega.Expect(ok).To(gomega.BeTrue())
+			endTimestamp := instance.End("service", testNamespaceA, "testservicea")
+			// pods are not aggregated across zones
+			_, ok = instance.Start("pod", testNamespaceB, testPodNameB)
+			gomega.Expect(ok).To(gomega.BeTrue())
+			instance.End("pod", testNamespaceB, testPodNameB)
+			gomega.Eventually(func() int { return len(histoMock.GetCh()) }).Should(gomega.Equal(2))
+
+			var reports *util.NetworkProgrammingReports
+			gomega.Eventually(func() error {
+				configMap, err := k.KClient.CoreV1().ConfigMaps(config.Kubernetes.OVNConfigNamespace).Get(
+					context.TODO(), util.GetNetworkProgrammingReportsConfigMapName("zonea"), metav1.GetOptions{})
+				if err != nil {
+					return err
+				}
+				gomega.Expect(configMap.Labels).To(gomega.HaveKey(util.NetworkProgrammingReportsLabel))
+				reports, err = util.ParseNetworkProgrammingReports(configMap)
+				if err == nil && reports == nil {
+					return fmt.Errorf("no reports on ConfigMap %s", configMap.Name)
+				}
+				return err
+			}).Should(gomega.Succeed())
+			gomega.Expect(reports.Zone).To(gomega.Equal("zonea"))
+			gomega.Expect(reports.Reports).To(gomega.HaveLen(1))
+			report := reports.Reports[0]
+			gomega.Expect(report.Kind).To(gomega.Equal("service"))
+			gomega.Expect(report.Namespace).To(gomega.Equal(testNamespaceA))
+			gomega.Expect(report.Name).To(gomega.Equal("testservicea"))
+			gomega.Expect(report.Start.Equal(startTimestamp)).To(gomega.BeTrue())
+			gomega.Expect(math.Round(report.End.Sub(report.Start).Seconds())).To(
+				gomega.BeNumerically("==", math.Round(endTimestamp.Sub(startTimestamp).Seconds())))
+			histoMock.Cleanup()
+		})
 	})
 })
@@ -0,0 +1,348 @@
+//go:build linux
+// +build linux
+
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+)
+
+// The labels of the per pod metrics
+const (
+	podMetricsLabelNamespace = "namespace"
+	podMetricsLabelPod       = "pod"
+	podMetricsLabelNAD       = "nad"
+)
+
+// podMetricsLabels are all the labels of the per pod metrics, in the order of their time series
+var podMetricsLabels = []string{podMetricsLabelNamespace, podMetricsLabelPod, podMetricsLabelNAD}
+
+// podInterfaceStatistics maps the OVS interface statistics to the per pod metrics. Statistics are from the point of
+// view of OVS: rx is the traffic sent by the pod.
+var podInterfaceStatistics = []struct {
+	statistic string
+	name      string
+	help      string
+}{
+	{"rx_bytes", "pod_interface_rx_bytes_total", "The total number of bytes received by OVS from the interfaces of the pods."},
+	{"tx_bytes", "pod_interface_tx_bytes_total", "The total number of bytes sent by OVS to the interfaces of the pods."},
+	{"rx_dropped", "pod_interface_rx_dropped_total", "The total number of packets dropped by the interfaces of the pods on receive."},
+	{"tx_dropped", "pod_interface_tx_dropped_total", "The total number of packets dropped by the interfaces of the pods on transmit."},
+	{"rx_errors", "pod_interface_rx_errors_total", "The total number of receive errors of the interfaces of the pods."},
+	{"tx_errors", "pod_interface_tx_errors_total", "The total number of transmit errors of the interfaces of the pods."},
+}
+
+// conntrackAddressRegex matches the addresses of the original and reply directions of a conntrack entry dumped with
+// ovs-appctl dpctl/dump-conntrack, e.g.
+// tcp,orig=(src=10.244.0.5,dst=10.96.0.1,sport=43122,dport=443),reply=(src=172.18.0.3,dst=10.244.0.5,sport=6443,...
+var conntrackAddressRegex = regexp.MustCompile(`(?:src|dst)=([0-9a-fA-F.:]+)`)
+
+// conntrackZoneRegex matches the zone of a conntrack entry, entries of zone 0 have none
+var conntrackZoneRegex = regexp.MustCompile(`,zone=([0-9]+)`)
+
+// conntrackZonePrefix prefixes the logical ports in the external_ids of br-int where ovn-controller stores their
+// conntrack zone
+const conntrackZonePrefix = "ct-zone-"
+
+// podMetricsKey identifies the time series of a pod interface, the labels that are not allowlisted are left empty so
+// that the interfaces only differing by them are aggregated
+type podMetricsKey struct {
+	namespace string
+	pod       string
+	nad       string
+}
+
+type podMetricsValues struct {
+	statistics         []float64
+	conntrackEntries   float64
+	conntrackAddresses []podConntrackAddress
+}
+
+// podConntrackAddress is an IP address of the OVS interface of a pod, whose conntrack entries are in the zone of the
+// logical port of the interface
+type podConntrackAddress struct {
+	ifaceID string
+	ip      string
+}
+
+// podMetricsCollector computes the per pod interface and conntrack metrics from the OVS interfaces of the pods,
+// identified by their external_ids:iface-id, and from the conntrack entries of the IP addresses of the pods in the
+// conntrack zones of their logical ports
+type podMetricsCollector struct {
+	ovsVsctl, ovsAppctl ovsClient
+	labels              map[string]bool
+	statisticDescs      []*prometheus.Desc
+	conntrackDesc       *prometheus.Desc
+
+	sync.Mutex
+	values map[podMetricsKey]*podMetricsValues
+}
+
+func newPodMetricsCollector(ovsVsctl, ovsAppctl ovsClient, labels []string) *podMetricsCollector {
+	c := &podMetricsCollector{
+		ovsVsctl:  ovsVsctl,
+		ovsAppctl: ovsAppctl,
+		labels:    map[string]bool{},
+		values:    map[podMetricsKey]*podMetricsValues{},
+	}
+	// keep the labels in a stable order whatever the order of the allowlist
+	variableLabels := []string{}
+	for _, label := range podMetricsLabels {
+		if util.SliceHasStringItem(labels, label) {
+			c.labels[label] = true
+			variableLabels = append(variableLabels, label)
+		}
+	}
+	for _, statistic := range podInterfaceStatistics {
+		c.statisticDescs = append(c.statisticDescs, prometheus.NewDesc(
+			prometheus.BuildFQName(MetricOvsNamespace, MetricOvsSubsystemVswitchd, statistic.name),
+			statistic.help, variableLabels, nil))
+	}
+	c.conntrackDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvsNamespace, MetricOvsSubsystemVswitchd, "pod_conntrack_entries"),
+		"The number of conntrack entries with an IP address of the pods as source or destination, in the conntrack zones of their ports.",
+		variableLabels, nil)
+	return c
+}
+
+func (c *podMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	for _, desc := range c.statisticDescs {
+		ch <- desc
+	}
+	ch <- c.conntrackDesc
+}
+
+func (c *podMetricsCollector) Collect(ch chan<- prometheus.Metric) {
+	c.Lock()
+	defer c.Unlock()
+	for key, values := range c.values {
+		labelValues := c.labelValues(key)
+		for i, desc := range c.statisticDescs {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.CounterValue, values.statistics[i], labelValues...)
+		}
+		ch <- prometheus.MustNewConstMetric(c.conntrackDesc, prometheus.GaugeValue, values.conntrackEntries,
+			labelValues...)
+	}
+}
+
+func (c *podMetricsCollector) labelValues(key podMetricsKey) []string {
+	labelValues := []string{}
+	if c.labels[podMetricsLabelNamespace] {
+		labelValues = append(labelValues, key.namespace)
+	}
+	if c.labels[podMetricsLabelPod] {
+		labelValues = append(labelValues, key.pod)
+	}
+	if c.labels[podMetricsLabelNAD] {
+		labelValues = append(labelValues, key.nad)
+	}
+	return labelValues
+}
+
+// update replaces the metrics with the current statistics of the pod interfaces and conntrack entries
+func (c *podMetricsCollector) update() error {
+	values, err := c.getInterfaceStatistics()
+	if err != nil {
+		return err
+	}
+	if err = c.countConntrackEntries(values); err != nil {
+		return err
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.values = values
+	return nil
+}
+
+// getInterfaceStatistics sums the statistics of the OVS interfaces of the pods by allowlisted labels
+func (c *podMetricsCollector) getInterfaceStatistics() (map[podMetricsKey]*podMetricsValues, error) {
+	stdout, stderr, err := c.ovsVsctl("--no-headings", "--data=bare", "--format=csv",
+		"--columns=statistics,external_ids", "list", "Interface")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output for ovs-vsctl list Interface stderr(%s) :(%v)", stderr, err)
+	}
+	records, err := util.ReadOVSCSV(stdout, 2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the ovs-vsctl list Interface output: %v", err)
+	}
+	values := map[podMetricsKey]*podMetricsValues{}
+	for _, record := range records {
+		externalIDs := util.ParseBareMap(record[1])
+		key, ok := c.getPodMetricsKey(externalIDs)
+		if !ok {
+			continue
+		}
+		value := values[key]
+		if value == nil {
+			value = &podMetricsValues{statistics: make([]float64, len(podInterfaceStatistics))}
+			values[key] = value
+		}
+		statistics := util.ParseBareMap(record[0])
+		for i, statistic := range podInterfaceStatistics {
+			if statistics[statistic.statistic] == "" {
+				continue
+			}
+			statValue, err := strconv.ParseFloat(statistics[statistic.statistic], 64)
+			if err != nil {
+				return nil, fmt.Errorf("expected statistic %s=%q to contain an integer: %v", statistic.statistic,
+					statistics[statistic.statistic], err)
+			}
+			value.statistics[i] += statValue
+		}
+		for _, ipAddress := range strings.Split(externalIDs["ip_addresses"], ",") {
+			if ip, _, err := net.ParseCIDR(ipAddress); err == nil {
+				value.conntrackAddresses = append(value.conntrackAddresses,
+					podConntrackAddress{ifaceID: externalIDs["iface-id"], ip: ip.String()})
+			}
+		}
+	}
+	return values, nil
+}
+
+// getPodMetricsKey returns the pod of an OVS interface from its iface-id, which is <namespace>_<pod> for the default
+// network and prefixed by the NAD name for secondary networks. Interfaces without sandbox are not pod interfaces.
+func (c *podMetricsCollector) getPodMetricsKey(externalIDs map[string]string) (podMetricsKey, bool) {
+	ifaceID := externalIDs["iface-id"]
+	if ifaceID == "" || externalIDs["sandbox"] == "" {
+		return podMetricsKey{}, false
+	}
+	nad := externalIDs[types.NADExternalID]
+	if nad == "" {
+		nad = types.DefaultNetworkName
+	} else {
+		ifaceID = strings.TrimPrefix(ifaceID, util.GetSecondaryNetworkPrefix(nad))
+	}
+	// namespaces can't contain underscores
+	namespace, pod, ok := strings.Cut(ifaceID, "_")
+	if !ok {
+		return podMetricsKey{}, false
+	}
+	key := podMetricsKey{}
+	if c.labels[podMetricsLabelNamespace] {
+		key.namespace = namespace
+	}
+	if c.labels[podMetricsLabelPod] {
+		key.pod = pod
+	}
+	if c.labels[podMetricsLabelNAD] {
+		key.nad = nad
+	}
+	return key, true
+}
+
+// getConntrackZones returns the conntrack zones of the logical ports, by logical port
+func (c *podMetricsCollector) getConntrackZones() (map[string]string, error) {
+	stdout, stderr, err := c.ovsVsctl("--no-headings", "--data=bare", "--format=csv", "--columns=external_ids",
+		"list", "Bridge", "br-int")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output for ovs-vsctl list Bridge br-int stderr(%s) :(%v)", stderr, err)
+	}
+	records, err := util.ReadOVSCSV(stdout, 1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the ovs-vsctl list Bridge br-int output: %v", err)
+	}
+	zones := map[string]string{}
+	for _, record := range records {
+		for key, zone := range util.ParseBareMap(record[0]) {
+			if logicalPort, ok := strings.CutPrefix(key, conntrackZonePrefix); ok {
+				zones[logicalPort] = zone
+			}
+		}
+	}
+	return zones, nil
+}
+
+// countConntrackEntries counts the conntrack entries with an IP address of the pods in the conntrack zone of their
+// logical port, once per time series. The entries of the other zones, e.g. of the gateway routers, are not counted.
+func (c *podMetricsCollector) countConntrackEntries(values map[podMetricsKey]*podMetricsValues) error {
+	hasAddresses := false
+	for _, value := range values {
+		hasAddresses = hasAddresses || len(value.conntrackAddresses) > 0
+	}
+	if !hasAddresses {
+		return nil
+	}
+	zones, err := c.getConntrackZones()
+	if err != nil {
+		return err
+	}
+	// the keys of the pods by zone and address
+	keysByAddress := map[string][]podMetricsKey{}
+	for key, value := range values {
+		for _, address := range value.conntrackAddresses {
+			zone, ok := zones[address.ifaceID]
+			if !ok {
+				// ovn-controller didn't assign a zone to the logical port yet
+				continue
+			}
+			zoneAddress := zone + "/" + address.ip
+			keysByAddress[zoneAddress] = append(keysByAddress[zoneAddress], key)
+		}
+	}
+	if len(keysByAddress) == 0 {
+		return nil
+	}
+	stdout, stderr, err := c.ovsAppctl("dpctl/dump-conntrack")
+	if err != nil {
+		return fmt.Errorf("failed to get output of ovs-appctl dpctl/dump-conntrack stderr(%s) :(%v)", stderr, err)
+	}
+	for _, entry := range strings.Split(stdout, "\n") {
+		zone := "0"
+		if match := conntrackZoneRegex.FindStringSubmatch(entry); match != nil {
+			zone = match[1]
+		}
+		counted := map[podMetricsKey]bool{}
+		for _, match := range conntrackAddressRegex.FindAllStringSubmatch(entry, -1) {
+			ip := net.ParseIP(match[1])
+			if ip == nil {
+				continue
+			}
+			for _, key := range keysByAddress[zone+"/"+ip.String()] {
+				if !counted[key] {
+					counted[key] = true
+					values[key].conntrackEntries++
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func podMetricsUpdater(collector *podMetricsCollector, tickPeriod time.Duration, stopChan <-chan struct{}) {
+	ticker := time.NewTicker(tickPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := collector.update(); err != nil {
+				klog.Errorf("Updating the per pod metrics failed: %v", err)
+			}
+		case <-stopChan:
+			return
+		}
+	}
+}
+
+var registerPodMetricsOnce sync.Once
+
+// RegisterPodMetrics registers the per pod interface and conntrack metrics with the OVN metrics server, labelled
+// with the given subset of the namespace, pod and nad labels
+func RegisterPodMetrics(labels []string, stopChan <-chan struct{}) {
+	registerPodMetricsOnce.Do(func() {
+		collector := newPodMetricsCollector(util.RunOVSVsctl, util.RunOVSAppctl, labels)
+		ovnRegistry.MustRegister(collector)
+		go podMetricsUpdater(collector, 30*time.Second, stopChan)
+	})
+}
@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// the quotes around the output are trimmed by the client
+	podMetricsVsctlListInterfaceOutput = `collisions=0 rx_bytes=1000 rx_dropped=1 rx_errors=0 rx_packets=10 tx_bytes=2000 tx_dropped=2 tx_errors=0 tx_packets=20,"attached_mac=""0a:58:0a:f4:00:05"" iface-id=namespace1_pod1 iface-id-ver=5c3a ip_addresses=""10.244.0.5/24,fd00:10:244::5/64"" sandbox=1f3a"
+collisions=0 rx_bytes=100 rx_dropped=0 rx_errors=1 rx_packets=1 tx_bytes=200 tx_dropped=0 tx_errors=1 tx_packets=2,"attached_mac=""0a:58:0a:f5:00:05"" iface-id=namespace1.nad1_namespace1_pod1 iface-id-ver=5c3a ip_addresses=""10.245.0.5/24"" ""k8s.ovn.org/nad""=""namespace1/nad1"" ""k8s.ovn.org/network""=net1 sandbox=1f3a"
+collisions=0 rx_bytes=300 rx_dropped=0 rx_errors=0 rx_packets=3 tx_bytes=400 tx_dropped=0 tx_errors=0 tx_packets=4,"attached_mac=""0a:58:0a:f4:00:06"" iface-id=namespace1_pod2 iface-id-ver=7d4b ip_addresses=""10.244.0.6/24"" sandbox=2e4b"
+collisions=0 rx_bytes=5000 rx_dropped=0 rx_errors=0 rx_packets=50 tx_bytes=6000 tx_dropped=0 tx_errors=0 tx_packets=60,"iface-id=k8s-node1 ip_addresses=""10.244.0.2/24"""
+collisions=0 rx_bytes=7000 rx_dropped=0 rx_errors=0 rx_packets=70 tx_bytes=8000 tx_dropped=0 tx_errors=0 tx_packets=80,`
+	// ovn-controller stores the conntrack zones of the logical ports in the external_ids of br-int
+	podMetricsVsctlListBridgeOutput     = `ct-zone-namespace1_pod1=5 ct-zone-namespace1.nad1_namespace1_pod1=9 ct-zone-namespace1_pod2=6 ct-zone-k8s-node1=1 ct-zone-GR_node1_dnat=12 ct-zone-GR_node1_snat=13`
+	podMetricsAppctlDumpConntrackOutput = `tcp,orig=(src=10.244.0.5,dst=10.96.0.1,sport=43122,dport=443),reply=(src=172.18.0.3,dst=10.244.0.5,sport=6443,dport=43122),zone=5,protoinfo=(state=ESTABLISHED)
+tcp,orig=(src=10.244.0.5,dst=10.244.0.6,sport=43124,dport=8080),reply=(src=10.244.0.6,dst=10.244.0.5,sport=8080,dport=43124),zone=5,protoinfo=(state=ESTABLISHED)
+tcp,orig=(src=10.244.0.5,dst=10.244.0.6,sport=43124,dport=8080),reply=(src=10.244.0.6,dst=10.244.0.5,sport=8080,dport=43124),zone=6,protoinfo=(state=ESTABLISHED)
+tcp,orig=(src=10.244.0.5,dst=172.18.0.10,sport=43126,dport=80),reply=(src=172.18.0.10,dst=172.18.0.3,sport=80,dport=43126),zone=13,protoinfo=(state=ESTABLISHED)
+udp,orig=(src=fd00:10:244::5,dst=fd00:10:96::a,sport=53001,dport=53),reply=(src=fd00:10:244::2,dst=fd00:10:244::5,sport=53,dport=53001),zone=5
+udp,orig=(src=10.245.0.5,dst=10.245.0.9,sport=53001,dport=53),reply=(src=10.245.0.9,dst=10.245.0.5,sport=53,dport=53001),zone=9
+icmp,orig=(src=10.244.0.2,dst=10.244.0.7,id=1,type=8,code=0),reply=(src=10.244.0.7,dst=10.244.0.2,id=1,type=0,code=0),zone=0
+`
+)
+
+var _ = ginkgo.Describe("Per pod metrics", func() {
+	// gather returns the values of the metrics by label values and metric name
+	gather := func(labels ...string) map[string]map[string]float64 {
+		ovsVsctl := NewFakeOVSClient([]clientOutput{
+			{stdout: podMetricsVsctlListInterfaceOutput},
+			{stdout: podMetricsVsctlListBridgeOutput},
+		})
+		ovsAppctl := NewFakeOVSClient([]clientOutput{{stdout: podMetricsAppctlDumpConntrackOutput}})
+		collector := newPodMetricsCollector(ovsVsctl.FakeCall, ovsAppctl.FakeCall, labels)
+		gomega.Expect(collector.update()).To(gomega.Succeed())
+
+		registry := prometheus.NewPedanticRegistry()
+		gomega.Expect(registry.Register(collector)).To(gomega.Succeed())
+		metricFamilies, err := registry.Gather()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		values := map[string]map[string]float64{}
+		for _, metricFamily := range metricFamilies {
+			for _, metric := range metricFamily.GetMetric() {
+				series := ""
+				for _, label := range metric.GetLabel() {
+					series += label.GetName() + "=" + label.GetValue() + " "
+				}
+				if values[series] == nil {
+					values[series] = map[string]float64{}
+				}
+				if metric.GetCounter() != nil {
+					values[series][metricFamily.GetName()] = metric.GetCounter().GetValue()
+				} else {
+					values[series][metricFamily.GetName()] = metric.GetGauge().GetValue()
+				}
+			}
+		}
+		return values
+	}
+
+	podMetrics := func(rxBytes, txBytes, rxDropped, txDropped, rxErrors, txErrors, conntrackEntries float64) map[string]float64 {
+		return map[string]float64{
+			"ovs_vswitchd_pod_interface_rx_bytes_total":   rxBytes,
+			"ovs_vswitchd_pod_interface_tx_bytes_total":   txBytes,
+			"ovs_vswitchd_pod_interface_rx_dropped_total": rxDropped,
+			"ovs_vswitchd_pod_interface_tx_dropped_total": txDropped,
+			"ovs_vswitchd_pod_interface_rx_errors_total":  rxErrors,
+			"ovs_vswitchd_pod_interface_tx_errors_total":  txErrors,
+			"ovs_vswitchd_pod_conntrack_entries":          conntrackEntries,
+		}
+	}
+
+	ginkgo.It("reports the metrics of the interfaces of every pod and NAD", func() {
+		// interfaces without sandbox, like the management port, are not pod interfaces, and the conntrack entries are
+		// only counted in the zone of the logical port of the pods, not in the zones of the gateway router
+		gomega.Expect(gather("namespace", "pod", "nad")).To(gomega.Equal(map[string]map[string]float64{
+			"nad=default namespace=namespace1 pod=pod1 ":         podMetrics(1000, 2000, 1, 2, 0, 0, 3),
+			"nad=namespace1/nad1 namespace=namespace1 pod=pod1 ": podMetrics(100, 200, 0, 0, 1, 1, 1),
+			"nad=default namespace=namespace1 pod=pod2 ":         podMetrics(300, 400, 0, 0, 0, 0, 1),
+		}))
+	})
+
+	ginkgo.It("aggregates the metrics of the pods only differing by labels missing from the allowlist", func() {
+		// the conntrack entries between pod1 and pod2 are counted once in the zone of each pod for the namespace
+		gomega.Expect(gather("nad", "namespace")).To(gomega.Equal(map[string]map[string]float64{
+			"nad=default namespace=namespace1 ":         podMetrics(1300, 2400, 1, 2, 0, 0, 4),
+			"nad=namespace1/nad1 namespace=namespace1 ": podMetrics(100, 200, 0, 0, 1, 1, 1),
+		}))
+	})
+})
@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/klog/v2"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+)
+
+var registerShadowModeMetricsOnce sync.Once
+
+var (
+	shadowTransactionsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemController, "shadow_transactions_total"),
+		"The total number of transactions recorded in shadow mode instead of being sent to the OVN database",
+		[]string{"db_name"}, nil,
+	)
+	shadowOperationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemController, "shadow_operations"),
+		"The number of distinct operations recorded in shadow mode that would have changed the OVN database",
+		[]string{"db_name", "table", "op"}, nil,
+	)
+	shadowUnchangedOperationsDesc = prometheus.NewDesc(
+		prometheus.BuildFQName(MetricOvnkubeNamespace, MetricOvnkubeSubsystemController, "shadow_unchanged_operations_total"),
+		"The total number of operations recorded in shadow mode that wouldn't have changed the OVN database",
+		[]string{"db_name"}, nil,
+	)
+)
+
+// shadowModeCollector exposes the statistics of the shadow mode recorders
+type shadowModeCollector struct{}
+
+func (shadowModeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- shadowTransactionsDesc
+	ch <- shadowOperationsDesc
+	ch <- shadowUnchangedOperationsDesc
+}
+
+func (shadowModeCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, recorder := range libovsdbops.GetShadowRecorders() {
+		stats := recorder.Stats()
+		ch <- prometheus.MustNewConstMetric(shadowTransactionsDesc, prometheus.CounterValue,
+			float64(stats.Transactions), stats.DBName)
+		ch <- prometheus.MustNewConstMetric(shadowUnchangedOperationsDesc, prometheus.CounterValue,
+			float64(stats.UnchangedOperations), stats.DBName)
+		for table, ops := range stats.Operations {
+			for op, count := range ops {
+				ch <- prometheus.MustNewConstMetric(shadowOperationsDesc, prometheus.GaugeValue,
+					float64(count), stats.DBName, table, op)
+			}
+		}
+	}
+}
+
+// RegisterShadowModeMetrics registers the metrics of the shadow mode recorders
+func RegisterShadowModeMetrics() {
+	registerShadowModeMetricsOnce.Do(func() {
+		prometheus.MustRegister(shadowModeCollector{})
+	})
+}
+
+// shadowModeReport is the report of a shadow mode recorder served by the debug endpoint
+type shadowModeReport struct {
+	libovsdbops.ShadowStats
+	RecordedOperations []libovsdbops.ShadowOperation `json:"recordedOperations"`
+}
+
+// shadowModeHandler serves the operations recorded in shadow mode on GET, optionally for the database given by the
+// db query parameter, and resets them on DELETE.
+func shadowModeHandler(w http.ResponseWriter, req *http.Request) {
+	dbName := req.URL.Query().Get("db")
+	var recorders []*libovsdbops.ShadowRecorder
+	for _, recorder := range libovsdbops.GetShadowRecorders() {
+		if dbName == "" || recorder.DBName() == dbName {
+			recorders = append(recorders, recorder)
+		}
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		reports := make([]shadowModeReport, 0, len(recorders))
+		for _, recorder := range recorders {
+			reports = append(reports, shadowModeReport{
+				ShadowStats:        recorder.Stats(),
+				RecordedOperations: recorder.Operations(),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if err := json.NewEncoder(w).Encode(reports); err != nil {
+			klog.Errorf("Failed to encode the shadow mode report: %v", err)
+		}
+	case http.MethodDelete:
+		for _, recorder := range recorders {
+			recorder.Reset()
+		}
+		writePlainText(http.StatusOK, "reset the recorded operations", w)
+	default:
+		writePlainText(http.StatusNotAcceptable, "unsupported http method", w)
+	}
+}
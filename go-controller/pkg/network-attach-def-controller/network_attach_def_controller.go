@@ -9,6 +9,7 @@ import (
 
 	kapi "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -21,6 +22,7 @@ import (
 	nadclientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
 	nadinformers "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/informers/externalversions"
 	nadlisters "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/listers/k8s.cni.cncf.io/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/syncmap"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -44,6 +46,15 @@ const (
 
 var ErrNetworkControllerTopologyNotManaged = errors.New("no cluster network controller to manage topology")
 
+// ErrNetworkConflict is returned when a NAD does not share the same config as the existing network of the same name
+var ErrNetworkConflict = errors.New("conflicts with the existing network of the same name")
+
+var (
+	errDefaultNetworkNAD    = errors.New("NAD for default network, skip it")
+	errInvalidNAD           = errors.New("invalid NAD")
+	errUnsupportedNADUpdate = errors.New("NAD update can't be applied live")
+)
+
 type BaseNetworkController interface {
 	Start(ctx context.Context) error
 	Stop()
@@ -53,6 +64,9 @@ type BaseNetworkController interface {
 type NetworkController interface {
 	BaseNetworkController
 	CompareNetInfo(util.BasicNetInfo) bool
+	// Reconfigure applies to the network the changes of its configuration that
+	// can be applied live, as validated by util.ValidateNetInfoUpdate.
+	Reconfigure(util.BasicNetInfo) error
 	AddNAD(nadName string)
 	DeleteNAD(nadName string)
 	HasNAD(nadName string) bool
@@ -85,6 +99,13 @@ type NetAttachDefinitionController struct {
 	stopChan           chan struct{}
 	wg                 sync.WaitGroup
 
+	// nadClient is used to report the status of the NADs
+	nadClient nadclientset.Interface
+	// reportAccepted is set when the Accepted condition of the NADs must be reported
+	reportAccepted bool
+	// statusZone is the zone reporting the status of the network topology of the NADs, empty if not reported
+	statusZone string
+
 	// key is nadName, value is BasicNetInfo
 	perNADNetInfo *syncmap.SyncMap[util.BasicNetInfo]
 	// controller for all networks, key is netName of net-attach-def, value is networkNADInfo
@@ -108,6 +129,7 @@ func NewNetAttachDefinitionController(name string, ncm NetworkControllerManager,
 		name:               name,
 		recorder:           recorder,
 		ncm:                ncm,
+		nadClient:          networkAttchDefClient,
 		nadFactory:         nadFactory,
 		netAttachDefLister: netAttachDefInformer.Lister(),
 		netAttachDefSynced: netAttachDefInformer.Informer().HasSynced,
@@ -130,6 +152,19 @@ func NewNetAttachDefinitionController(name string, ncm NetworkControllerManager,
 
 }
 
+// ReportAcceptedStatus enables reporting whether the NADs are accepted, as an Accepted condition held by the
+// util.OvnNetworkAcceptedAnnotation annotation of the NAD and as events. Only one controller of the cluster
+// should report it.
+func (nadController *NetAttachDefinitionController) ReportAcceptedStatus() {
+	nadController.reportAccepted = true
+}
+
+// ReportZoneStatus enables reporting the status of the network topology of the NADs created for the given zone.
+// The status of all zones is aggregated by the cluster manager status manager.
+func (nadController *NetAttachDefinitionController) ReportZoneStatus(zone string) {
+	nadController.statusZone = zone
+}
+
 func (nadController *NetAttachDefinitionController) Start() error {
 	klog.Infof("Starting %s NAD controller", nadController.name)
 	g := errgroup.Group{}
@@ -300,20 +335,34 @@ func (nadController *NetAttachDefinitionController) onNetworkAttachDefinitionUpd
 		return
 	}
 
-	// don't process resync or objects that are marked for deletion
-	if oldNAD.ResourceVersion == newNAD.ResourceVersion ||
+	// don't process objects that are marked for deletion, nor resyncs and
+	// updates of the metadata - e.g. the status annotations set by the zones -
+	// that don't change the network configuration
+	if oldNAD.Spec.Config == newNAD.Spec.Config ||
 		!newNAD.GetDeletionTimestamp().IsZero() {
 		return
 	}
 
-	err := fmt.Sprintf("%s: Updating net-attach-def %s/%s is not supported", nadController.name, newNAD.Namespace, newNAD.Name)
+	klog.V(4).Infof("%s: Updating net-attach-def %s/%s", nadController.name, newNAD.Namespace, newNAD.Name)
+	nadController.queueNetworkAttachDefinition(newObj)
+}
+
+// recordNADEvent records an event on the given net-attach-def
+func (nadController *NetAttachDefinitionController) recordNADEvent(nadName, eventType, reason, messageFmt string, args ...interface{}) {
+	if nadController.recorder == nil {
+		return
+	}
+	namespace, name, err := cache.SplitMetaNamespaceKey(nadName)
+	if err != nil {
+		klog.Errorf("%s: Failed to record event on net-attach-def %s: %v", nadController.name, nadName, err)
+		return
+	}
 	nadRef := kapi.ObjectReference{
 		Kind:      "NetworkAttachmentDefinition",
-		Namespace: newNAD.Namespace,
-		Name:      newNAD.Name,
+		Namespace: namespace,
+		Name:      name,
 	}
-	nadController.recorder.Eventf(&nadRef, kapi.EventTypeWarning, "ErrorUpdatingResource", err)
-	klog.Warningf(err)
+	nadController.recorder.Eventf(&nadRef, eventType, reason, messageFmt, args...)
 }
 
 func (nadController *NetAttachDefinitionController) onNetworkAttachDefinitionDelete(obj interface{}) {
@@ -355,6 +404,22 @@ func (nadController *NetAttachDefinitionController) getAllNetworkControllers() [
 // is the first NAD of the network.
 // Non-retriable errors (configuration error etc.) are just logged, and the function immediately returns nil.
 func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkControllerManager,
+	netattachdef *nettypes.NetworkAttachmentDefinition, doStart bool) error {
+	err := nadController.addNetAttachDef(ncm, netattachdef, doStart)
+	statusErr := nadController.updateNADStatus(netattachdef, doStart, err)
+	if errors.Is(err, errDefaultNetworkNAD) || errors.Is(err, errInvalidNAD) || errors.Is(err, errUnsupportedNADUpdate) {
+		err = nil
+	}
+	if statusErr != nil {
+		if err == nil {
+			return statusErr
+		}
+		klog.Warningf("%s: %v", nadController.name, statusErr)
+	}
+	return err
+}
+
+func (nadController *NetAttachDefinitionController) addNetAttachDef(ncm NetworkControllerManager,
 	netattachdef *nettypes.NetworkAttachmentDefinition, doStart bool) error {
 	var nInfo util.NetInfo
 	var err, invalidNADErr error
@@ -367,7 +432,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 	if invalidNADErr == nil {
 		netName = nInfo.GetNetworkName()
 		if netName == types.DefaultNetworkName {
-			invalidNADErr = fmt.Errorf("NAD for default network, skip it")
+			invalidNADErr = errDefaultNetworkNAD
 		}
 	}
 
@@ -379,7 +444,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 				// invalid nad, nothing to do
 				klog.Warningf("%s: net-attach-def %s is first seen and is invalid: %v", nadController.name, nadName, invalidNADErr)
 				nadController.perNADNetInfo.Delete(nadName)
-				return nil
+				return wrapInvalidNADErr(invalidNADErr)
 			}
 			klog.V(5).Infof("%s: net-attach-def %s network %s first seen", nadController.name, nadName, netName)
 			err = nadController.addNADToController(ncm, nadName, nInfo, doStart)
@@ -411,6 +476,27 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 				}
 				return nil
 			}
+			if invalidNADErr == nil && nadNci.GetNetworkName() == netName {
+				// the net-attach-def still belongs to the same network, apply the
+				// changes live if possible
+				if err := util.ValidateNetInfoUpdate(nadNci, nInfo); err != nil {
+					klog.Warningf("%s: net-attach-def %s update can't be applied live: %v", nadController.name, nadName, err)
+					nadController.recordNADEvent(nadName, kapi.EventTypeWarning, "ErrorUpdatingResource",
+						"%s: update of net-attach-def %s can't be applied live to network %s: %v; delete the pods attached "+
+							"to the network and recreate the net-attach-def to apply it", nadController.name, nadName, netName, err)
+					return fmt.Errorf("%w: %v", errUnsupportedNADUpdate, err)
+				}
+				err = nadController.reconfigureNetworkController(netName, nadName, nInfo)
+				if err != nil {
+					klog.Errorf("%s: Failed to reconfigure network %s for net-attach-def %s: %v", nadController.name, netName, nadName, err)
+					return err
+				}
+				nadController.perNADNetInfo.Delete(nadName)
+				nadController.perNADNetInfo.LoadOrStore(nadName, nInfo)
+				nadController.recordNADEvent(nadName, kapi.EventTypeNormal, "NetworkReconfigured",
+					"%s: update of net-attach-def %s applied live to network %s", nadController.name, nadName, netName)
+				return nil
+			}
 			if nadUpdated {
 				klog.V(5).Infof("%s: net-attach-def %s network %s updated", nadController.name, nadName, netName)
 				// delete the NAD from the old network first
@@ -424,7 +510,7 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 			}
 			if invalidNADErr != nil {
 				klog.Warningf("%s: net-attach-def %s is invalid: %v", nadController.name, nadName, invalidNADErr)
-				return nil
+				return wrapInvalidNADErr(invalidNADErr)
 			}
 			klog.V(5).Infof("%s: Add updated net-attach-def %s to network %s", nadController.name, nadName, netName)
 			nadController.perNADNetInfo.LoadOrStore(nadName, nInfo)
@@ -440,6 +526,77 @@ func (nadController *NetAttachDefinitionController) AddNetAttachDef(ncm NetworkC
 	})
 }
 
+// wrapInvalidNADErr wraps the given parsing error of a NAD so that it is not retried
+func wrapInvalidNADErr(err error) error {
+	if errors.Is(err, errDefaultNetworkNAD) {
+		return err
+	}
+	return fmt.Errorf("%w: %w", errInvalidNAD, err)
+}
+
+// updateNADStatus reports the status of the given NAD according to the result of adding it.
+// Transient errors are not reported, the NAD is retried.
+func (nadController *NetAttachDefinitionController) updateNADStatus(nad *nettypes.NetworkAttachmentDefinition,
+	doStart bool, addErr error) error {
+	if errors.Is(addErr, errDefaultNetworkNAD) || errors.Is(addErr, config.ErrorAttachDefNotOvnManaged) {
+		// not a secondary network handled by ovn-kubernetes
+		return nil
+	}
+	if nadController.reportAccepted {
+		if err := nadController.updateNADAcceptedStatus(nad, addErr); err != nil {
+			return err
+		}
+	}
+	if nadController.statusZone != "" && doStart {
+		var message string
+		switch {
+		case addErr == nil:
+			message = "network topology created"
+		case errors.Is(addErr, errInvalidNAD) || errors.Is(addErr, errUnsupportedNADUpdate):
+			return nil
+		default:
+			message = fmt.Sprintf("%s: %v", types.NetworkTopologyErrorMsg, addErr)
+		}
+		return util.SetNADZoneStatus(nadController.nadClient, nad, nadController.statusZone, message)
+	}
+	return nil
+}
+
+// updateNADAcceptedStatus sets the Accepted condition of the given NAD and records an event when it changes
+func (nadController *NetAttachDefinitionController) updateNADAcceptedStatus(nad *nettypes.NetworkAttachmentDefinition, addErr error) error {
+	condition := metav1.Condition{
+		Type:    util.NetworkConditionAccepted,
+		Status:  metav1.ConditionFalse,
+		Message: fmt.Sprintf("%v", addErr),
+	}
+	switch {
+	case addErr == nil || errors.Is(addErr, ErrNetworkControllerTopologyNotManaged):
+		condition.Status = metav1.ConditionTrue
+		condition.Reason = util.NetworkReasonAccepted
+		condition.Message = "network accepted"
+	case errors.Is(addErr, errInvalidNAD):
+		condition.Reason = util.NetworkReasonInvalidConfig
+	case errors.Is(addErr, ErrNetworkConflict):
+		condition.Reason = util.NetworkReasonNetworkConflict
+	case errors.Is(addErr, errUnsupportedNADUpdate):
+		condition.Reason = util.NetworkReasonUnsupportedUpdate
+	default:
+		return nil
+	}
+	updated, err := util.SetNADCondition(nadController.nadClient, nad, util.OvnNetworkAcceptedAnnotation, condition)
+	if err != nil || !updated {
+		return err
+	}
+	nadName := util.GetNADName(nad.Namespace, nad.Name)
+	eventType := kapi.EventTypeNormal
+	if condition.Status != metav1.ConditionTrue {
+		eventType = kapi.EventTypeWarning
+	}
+	nadController.recordNADEvent(nadName, eventType, condition.Reason, "%s: net-attach-def %s: %s",
+		nadController.name, nadName, condition.Message)
+	return nil
+}
+
 // DeleteNetAttachDef deletes the given NAD from the associated controller. It delete the controller if this
 // is the last NAD of the network
 func (nadController *NetAttachDefinitionController) DeleteNetAttachDef(netAttachDefName string) error {
@@ -494,14 +651,11 @@ func (nadController *NetAttachDefinitionController) addNADToController(ncm Netwo
 			isStarted = nni.isStarted
 			_, nadExists = nni.nadNames[nadName]
 
-			if !oc.CompareNetInfo(nInfo) {
-				if nadExists {
-					// this should not happen, continue to start the existing controller if requested
-					return fmt.Errorf("%s: net-attach-def %s netconf spec changed, should not happen", nadController.name, networkName)
-				} else {
-					return fmt.Errorf("%s: NAD %s does not share the same CNI config with network %s",
-						nadController.name, nadName, networkName)
-				}
+			// the config of the network might have been updated live by another NAD
+			// of the network, that is fine for the NADs already in the network
+			if !nadExists && !oc.CompareNetInfo(nInfo) {
+				return fmt.Errorf("%s: NAD %s does not share the same CNI config with network %s: %w",
+					nadController.name, nadName, networkName, ErrNetworkConflict)
 			}
 		}
 		if !nadExists {
@@ -530,6 +684,27 @@ func (nadController *NetAttachDefinitionController) addNADToController(ncm Netwo
 	})
 }
 
+// reconfigureNetworkController applies live the updated config of the given NAD
+// to the controller of its network. The other NADs of the network are expected
+// to be updated with the same config.
+func (nadController *NetAttachDefinitionController) reconfigureNetworkController(netName, nadName string, nInfo util.NetInfo) error {
+	klog.V(5).Infof("%s: Reconfigure network %s with net-attach-def %s", nadController.name, netName, nadName)
+	return nadController.perNetworkNADInfo.DoWithLock(netName, func(networkName string) error {
+		nni, found := nadController.perNetworkNADInfo.Load(networkName)
+		if !found {
+			return fmt.Errorf("%s: network controller for network %s not found", nadController.name, networkName)
+		}
+		if _, nadExists := nni.nadNames[nadName]; !nadExists {
+			return fmt.Errorf("%s: NAD %s does not exist on network %s", nadController.name, nadName, networkName)
+		}
+		if nni.nc.CompareNetInfo(nInfo) {
+			// already reconfigured through another NAD of the network
+			return nil
+		}
+		return nni.nc.Reconfigure(nInfo)
+	})
+}
+
 func (nadController *NetAttachDefinitionController) deleteNADFromController(netName, nadName string) error {
 	klog.V(5).Infof("%s: Delete net-attach-def %s from network %s", nadController.name, nadName, netName)
 	return nadController.perNetworkNADInfo.DoWithLock(netName, func(networkName string) error {
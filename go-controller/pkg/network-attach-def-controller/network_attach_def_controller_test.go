@@ -0,0 +1,80 @@
+package networkAttachDefController
+
+import (
+	"testing"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	fakenadclient "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestOnNetworkAttachDefinitionUpdate(t *testing.T) {
+	const (
+		config        = `{"cniVersion": "0.4.0", "name": "blue", "type": "ovn-k8s-cni-overlay", "topology": "layer2", "netAttachDefName": "ns1/nad1", "subnets": "10.1.0.0/16"}`
+		updatedConfig = `{"cniVersion": "0.4.0", "name": "blue", "type": "ovn-k8s-cni-overlay", "topology": "layer2", "netAttachDefName": "ns1/nad1", "subnets": "10.1.0.0/16", "mtu": 1300}`
+	)
+	newNAD := func(resourceVersion, config string, annotations map[string]string) *nettypes.NetworkAttachmentDefinition {
+		return &nettypes.NetworkAttachmentDefinition{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            "nad1",
+				Namespace:       "ns1",
+				ResourceVersion: resourceVersion,
+				Annotations:     annotations,
+			},
+			Spec: nettypes.NetworkAttachmentDefinitionSpec{Config: config},
+		}
+	}
+	deletedNAD := newNAD("2", updatedConfig, nil)
+	now := metav1.Now()
+	deletedNAD.DeletionTimestamp = &now
+
+	tests := []struct {
+		name        string
+		oldNAD      *nettypes.NetworkAttachmentDefinition
+		newNAD      *nettypes.NetworkAttachmentDefinition
+		expectQueue bool
+	}{
+		{
+			name:   "resync is not queued",
+			oldNAD: newNAD("1", config, nil),
+			newNAD: newNAD("1", config, nil),
+		},
+		{
+			name:   "status only update is not queued",
+			oldNAD: newNAD("1", config, nil),
+			newNAD: newNAD("2", config, map[string]string{
+				util.OvnNetworkAcceptedAnnotation:            `{"type":"Accepted","status":"True"}`,
+				util.OvnNetworkTopologyReadyAnnotation:       `{"type":"TopologyReady","status":"True"}`,
+				util.GetNetworkZoneStatusAnnotation("zone1"): "network topology created",
+			}),
+		},
+		{
+			name:        "config update is queued",
+			oldNAD:      newNAD("1", config, nil),
+			newNAD:      newNAD("2", updatedConfig, nil),
+			expectQueue: true,
+		},
+		{
+			name:   "update of a deleted net-attach-def is not queued",
+			oldNAD: newNAD("1", config, nil),
+			newNAD: deletedNAD,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nadController, err := NewNetAttachDefinitionController("test", nil, fakenadclient.NewSimpleClientset(), nil)
+			if err != nil {
+				t.Fatalf("failed to create the net-attach-def controller: %v", err)
+			}
+			defer nadController.queue.ShutDown()
+
+			nadController.onNetworkAttachDefinitionUpdate(tt.oldNAD, tt.newNAD)
+
+			if queued := nadController.queue.Len() == 1; queued != tt.expectQueue {
+				t.Errorf("expected the net-attach-def to be queued: %v, got queued: %v", tt.expectQueue, queued)
+			}
+		})
+	}
+}
@@ -202,6 +202,7 @@ func NewNetworkControllerManager(ovnClient *util.OVNClientset, wf *factory.Watch
 		if err != nil {
 			return nil, err
 		}
+		cm.nadController.ReportZoneStatus(config.Default.Zone)
 	}
 	return cm, nil
 }
@@ -373,6 +374,9 @@ func (cm *NetworkControllerManager) Start(ctx context.Context) error {
 		//  for a cluster with 10 nodes, measurement of 1 in every 100 requests
 		//  for a cluster with 100 nodes, measurement of 1 in every 1000 requests
 		metrics.GetConfigDurationRecorder().Run(cm.nbClient, cm.kube, 10, time.Second*5, cm.stopChan)
+		// report the measurements of the zone for ovnkube-cluster-manager to aggregate them across zones
+		metrics.GetConfigDurationRecorder().RunZoneReporter(config.Default.Zone, cm.client, time.Second*10,
+			cm.stopChan)
 	}
 	cm.podRecorder.Run(cm.sbClient, cm.stopChan)
 
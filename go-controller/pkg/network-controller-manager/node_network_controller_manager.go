@@ -10,11 +10,14 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	nad "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/network-attach-def-controller"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
 	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/klog/v2"
@@ -29,6 +32,9 @@ type nodeNetworkControllerManager struct {
 	watchFactory  factory.NodeWatchFactory
 	stopChan      chan struct{}
 	recorder      record.EventRecorder
+	// unhealthyRepresentors are the reasons the OVS interfaces of the VF representors are unhealthy by interface
+	// name, as of the last health check
+	unhealthyRepresentors map[string]string
 
 	defaultNodeNetworkController nad.BaseNetworkController
 
@@ -49,7 +55,11 @@ func (ncm *nodeNetworkControllerManager) NewNetworkController(nInfo util.NetInfo
 
 // CleanupDeletedNetworks cleans up all stale entities giving list of all existing secondary network controllers
 func (ncm *nodeNetworkControllerManager) CleanupDeletedNetworks(allControllers []nad.NetworkController) error {
-	return nil
+	networks := sets.New[string]()
+	for _, nc := range allControllers {
+		networks.Insert(nc.GetNetworkName())
+	}
+	return node.CleanupDeletedSecondaryManagementPorts(networks)
 }
 
 // newCommonNetworkControllerInfo creates and returns the base node network controller info
@@ -67,11 +77,15 @@ func NewNodeNetworkControllerManager(ovnClient *util.OVNClientset, wf factory.No
 		watchFactory:  wf,
 		stopChan:      make(chan struct{}),
 		recorder:      eventRecorder,
+
+		unhealthyRepresentors: map[string]string{},
 	}
 
-	// need to configure OVS interfaces for Pods on secondary networks in the DPU mode
+	// need to configure OVS interfaces for Pods on secondary networks in the DPU mode, and the
+	// management ports of the secondary networks for their egress IPs in the full mode
 	var err error
-	if config.OVNKubernetesFeature.EnableMultiNetwork && config.OvnKubeNode.Mode == ovntypes.NodeModeDPU {
+	if config.OVNKubernetesFeature.EnableMultiNetwork && (config.OvnKubeNode.Mode == ovntypes.NodeModeDPU ||
+		config.OvnKubeNode.Mode == ovntypes.NodeModeFull && config.OVNKubernetesFeature.EnableEgressIP) {
 		ncm.nadController, err = nad.NewNetAttachDefinitionController("node-network-controller-manager", ncm, ovnClient.NetworkAttchDefClient, eventRecorder)
 	}
 	if err != nil {
@@ -121,6 +135,7 @@ func (ncm *nodeNetworkControllerManager) Start(ctx context.Context) (err error)
 		go wait.Until(func() {
 			checkForStaleOVSInternalPorts()
 			ncm.checkForStaleOVSRepresentorInterfaces()
+			ncm.checkOVSRepresentorInterfacesHealth()
 		}, time.Minute, ncm.stopChan)
 	}
 
@@ -174,36 +189,34 @@ func (ncm *nodeNetworkControllerManager) checkForStaleOVSRepresentorInterfaces()
 
 	// parse this data into local struct
 	type interfaceInfo struct {
-		Name   string
-		PodUID string
-	}
-
-	lines := strings.Split(out, "\n")
-	interfaceInfos := make([]*interfaceInfo, 0, len(lines))
-	for _, line := range lines {
-		cols := strings.Split(line, ",")
-		// Note: There are exactly 2 column entries as requested in the ovs query
-		// Col 0: interface name
-		// Col 1: space separated key=val pairs of external_ids attributes
-		if len(cols) < 2 {
-			// should never happen
-			klog.Errorf("Unexpected output: %s, expect \"<name>,<external_ids>\"", line)
-			continue
-		}
+		Name    string
+		PodUID  string
+		Sandbox string
+		NADName string
+	}
 
-		if cols[1] != "" {
-			for _, attr := range strings.Split(cols[1], " ") {
-				keyVal := strings.SplitN(attr, "=", 2)
-				if len(keyVal) != 2 {
-					// should never happen
-					klog.Errorf("Unexpected output: %s, expect \"<key>=<value>\"", attr)
-					continue
-				} else if keyVal[0] == "iface-id-ver" {
-					ifcInfo := interfaceInfo{Name: strings.TrimSpace(cols[0]), PodUID: keyVal[1]}
-					interfaceInfos = append(interfaceInfos, &ifcInfo)
-					break
-				}
-			}
+	// Note: There are exactly 2 column entries as requested in the ovs query
+	// Col 0: interface name
+	// Col 1: space separated key=val pairs of external_ids attributes
+	records, err := util.ReadOVSCSV(out, 2)
+	if err != nil {
+		klog.Errorf("Unexpected output: %s, expect \"<name>,<external_ids>\": %v", out, err)
+		return
+	}
+	interfaceInfos := make([]*interfaceInfo, 0, len(records))
+	for _, record := range records {
+		externalIDs := util.ParseBareMap(record[1])
+		ifcInfo := interfaceInfo{
+			Name:    strings.TrimSpace(record[0]),
+			PodUID:  externalIDs["iface-id-ver"],
+			Sandbox: externalIDs["sandbox"],
+			NADName: externalIDs[ovntypes.NADExternalID],
+		}
+		if ifcInfo.NADName == "" {
+			ifcInfo.NADName = ovntypes.DefaultNetworkName
+		}
+		if ifcInfo.PodUID != "" {
+			interfaceInfos = append(interfaceInfos, &ifcInfo)
 		}
 	}
 
@@ -219,25 +232,43 @@ func (ncm *nodeNetworkControllerManager) checkForStaleOVSRepresentorInterfaces()
 		klog.Errorf("Failed to list pods. %v", err)
 		return
 	}
-	expectedPodUIDs := make(map[string]struct{})
+	expectedPods := make(map[string]*kapi.Pod)
 	for _, pod := range pods {
 		if pod.Spec.NodeName == ncm.name && !util.PodWantsHostNetwork(pod) {
 			// Note: wf (WatchFactory) *usually* returns pods assigned to this node, however we dont rely on it
 			// and add this check to filter out pods assigned to other nodes. (e.g when ovnkube master and node
 			// share the same process)
-			expectedPodUIDs[string(pod.UID)] = struct{}{}
+			expectedPods[string(pod.UID)] = pod
 		}
 	}
 
 	// Remove any stale representor ports
 	for _, ifaceInfo := range interfaceInfos {
-		if _, ok := expectedPodUIDs[ifaceInfo.PodUID]; !ok {
+		pod, ok := expectedPods[ifaceInfo.PodUID]
+		if !ok {
 			klog.Warningf("Found stale OVS Interface %s with iface-id-ver %s, deleting it", ifaceInfo.Name, ifaceInfo.PodUID)
-			_, stderr, err := util.RunOVSVsctl("--if-exists", "--with-iface", "del-port", ifaceInfo.Name)
-			if err != nil {
-				klog.Errorf("Failed to delete interface %q . stderr: %q, error: %v",
-					ifaceInfo.Name, stderr, err)
+		} else if config.OvnKubeNode.Mode == ovntypes.NodeModeDPU {
+			// A pod has a VF representor for each of its NADs in DPU mode, the
+			// representor of a NAD is stale once the DPU connection details of
+			// the NAD are removed or are for another sandbox
+			dpuCD, err := util.UnmarshalPodDPUConnDetails(pod.Annotations, ifaceInfo.NADName)
+			if err == nil && dpuCD.SandboxId == ifaceInfo.Sandbox {
+				continue
+			}
+			if err != nil && !util.IsAnnotationNotSetError(err) {
+				klog.Errorf("Failed to get DPU connection details of pod %s/%s for NAD %s: %v",
+					pod.Namespace, pod.Name, ifaceInfo.NADName, err)
+				continue
 			}
+			klog.Warningf("Found stale OVS Interface %s of pod %s/%s for NAD %s with sandbox %s, deleting it",
+				ifaceInfo.Name, pod.Namespace, pod.Name, ifaceInfo.NADName, ifaceInfo.Sandbox)
+		} else {
+			continue
+		}
+		_, stderr, err := util.RunOVSVsctl("--if-exists", "--with-iface", "del-port", ifaceInfo.Name)
+		if err != nil {
+			klog.Errorf("Failed to delete interface %q . stderr: %q, error: %v",
+				ifaceInfo.Name, stderr, err)
 		}
 	}
 }
@@ -290,3 +321,97 @@ func checkForStaleOVSInternalPorts() {
 			stderr, err)
 	}
 }
+
+const (
+	representorNoOFPort = "no_ofport"
+	representorLinkDown = "link_down"
+)
+
+// checkOVSRepresentorInterfacesHealth checks the OVS interfaces backed by representor interfaces: an interface is
+// unhealthy when OVS couldn't assign it an OpenFlow port or its link is down. The unhealthy interfaces are reported
+// by the ovnkube_node_unhealthy_representors metric, and with an event on their pod when they become unhealthy.
+func (ncm *nodeNetworkControllerManager) checkOVSRepresentorInterfacesHealth() {
+	out, stderr, err := util.RunOVSVsctl("--columns=name,ofport,link_state,external_ids", "--data=bare",
+		"--no-headings", "--format=csv", "find", "Interface", "external_ids:sandbox!=\"\"",
+		"external_ids:vf-netdev-name!=\"\"")
+	if err != nil {
+		klog.Errorf("Failed to list ovn-k8s OVS interfaces:, stderr: %q, error: %v", stderr, err)
+		return
+	}
+
+	// Note: There are exactly 4 column entries as requested in the ovs query
+	// Col 0: interface name
+	// Col 1: OpenFlow port, -1 or empty if OVS failed to add the interface
+	// Col 2: link state
+	// Col 3: space separated key=val pairs of external_ids attributes
+	records, err := util.ReadOVSCSV(out, 4)
+	if err != nil {
+		klog.Errorf("Unexpected output: %s, expect \"<name>,<ofport>,<link_state>,<external_ids>\": %v", out, err)
+		return
+	}
+	unhealthyRepresentors := map[string]string{}
+	// pod UIDs and NADs of the interfaces that became unhealthy
+	podUIDs := map[string]string{}
+	nadNames := map[string]string{}
+	for _, record := range records {
+		name := strings.TrimSpace(record[0])
+		var reason string
+		switch {
+		case record[1] == "" || record[1] == "-1":
+			reason = representorNoOFPort
+		case record[2] == "down":
+			reason = representorLinkDown
+		default:
+			continue
+		}
+		unhealthyRepresentors[name] = reason
+		if ncm.unhealthyRepresentors[name] == reason {
+			continue
+		}
+		klog.Warningf("OVS Interface %s of a VF representor is unhealthy: %s", name, reason)
+		externalIDs := util.ParseBareMap(record[3])
+		nadNames[name] = ovntypes.DefaultNetworkName
+		if nadName := externalIDs[ovntypes.NADExternalID]; nadName != "" {
+			nadNames[name] = nadName
+		}
+		if podUID := externalIDs["iface-id-ver"]; podUID != "" {
+			podUIDs[name] = podUID
+		}
+	}
+	for name := range ncm.unhealthyRepresentors {
+		if _, ok := unhealthyRepresentors[name]; !ok {
+			klog.Infof("OVS Interface %s of a VF representor is healthy again", name)
+		}
+	}
+	ncm.unhealthyRepresentors = unhealthyRepresentors
+
+	for _, reason := range []string{representorNoOFPort, representorLinkDown} {
+		count := 0
+		for _, unhealthyReason := range unhealthyRepresentors {
+			if unhealthyReason == reason {
+				count++
+			}
+		}
+		metrics.MetricUnhealthyRepresentors.WithLabelValues(reason).Set(float64(count))
+	}
+
+	if len(podUIDs) == 0 || ncm.recorder == nil {
+		return
+	}
+	pods, err := ncm.watchFactory.GetPods("")
+	if err != nil {
+		klog.Errorf("Failed to list pods. %v", err)
+		return
+	}
+	podsByUID := make(map[string]*kapi.Pod, len(pods))
+	for _, pod := range pods {
+		podsByUID[string(pod.UID)] = pod
+	}
+	for name, podUID := range podUIDs {
+		if pod, ok := podsByUID[podUID]; ok {
+			ncm.recorder.Eventf(pod, kapi.EventTypeWarning, "UnhealthyRepresentor",
+				"OVS Interface %s of the VF representor for NAD %s is unhealthy: %s", name, nadNames[name],
+				unhealthyRepresentors[name])
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	factoryMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory/mocks"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
@@ -14,6 +15,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/tools/record"
 )
 
 func genListStalePortsCmd() string {
@@ -41,6 +43,27 @@ func genFindInterfaceWithSandboxCmd() string {
 		"--format=csv find Interface external_ids:sandbox!=\"\" external_ids:vf-netdev-name!=\"\"")
 }
 
+func genFindInterfaceHealthCmd() string {
+	return fmt.Sprintf("ovs-vsctl --timeout=15 --columns=name,ofport,link_state,external_ids --data=bare --no-headings " +
+		"--format=csv find Interface external_ids:sandbox!=\"\" external_ids:vf-netdev-name!=\"\"")
+}
+
+func genDPUConnDetailsAnnotations(sandbox string, nadNames ...string) map[string]string {
+	annotations := map[string]string{}
+	for i, nadName := range nadNames {
+		var err error
+		annotations, err = util.MarshalPodDPUConnDetails(annotations, &util.DPUConnectionDetails{
+			PfId:      "0",
+			VfId:      fmt.Sprint(i),
+			SandboxId: sandbox,
+		}, nadName)
+		if err != nil {
+			panic(err)
+		}
+	}
+	return annotations
+}
+
 var _ = Describe("Healthcheck tests", func() {
 	var execMock *ovntest.FakeExec
 	var factoryMock factoryMocks.NodeWatchFactory
@@ -101,7 +124,7 @@ var _ = Describe("Healthcheck tests", func() {
 				ObjectMeta: metav1.ObjectMeta{
 					Name:        "a-pod",
 					Namespace:   "a-ns",
-					Annotations: map[string]string{},
+					Annotations: genDPUConnDetailsAnnotations("123abcfaa", types.DefaultNetworkName, "a-ns/nad1"),
 					UID:         "pod-a-uuid-1",
 				},
 				Spec: v1.PodSpec{
@@ -150,6 +173,40 @@ var _ = Describe("Healthcheck tests", func() {
 			})
 		})
 
+		Context("in DPU mode", func() {
+			BeforeEach(func() {
+				config.OvnKubeNode.Mode = types.NodeModeDPU
+			})
+
+			AfterEach(func() {
+				config.OvnKubeNode.Mode = types.NodeModeFull
+			})
+
+			It("removes the VF rep ports of NADs without DPU connection details of their sandbox", func() {
+				execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: genFindInterfaceWithSandboxCmd(),
+					Output: "pod-a-ifc,sandbox=123abcfaa iface-id=a-ns_a-pod iface-id-ver=pod-a-uuid-1 vf-netdev-name=blah\n" +
+						// ovs-vsctl quotes the keys and values with a '/', and the fields with a quote
+						`pod-a-nad1-ifc,"sandbox=123abcfaa iface-id=a-ns_nad1_a-ns_a-pod iface-id-ver=pod-a-uuid-1 ` +
+						`""k8s.ovn.org/nad""=""a-ns/nad1"" ""k8s.ovn.org/network""=net1 vf-netdev-name=blah"` + "\n" +
+						`pod-a-nad2-ifc,"sandbox=123abcfaa iface-id=a-ns_nad2_a-ns_a-pod iface-id-ver=pod-a-uuid-1 ` +
+						`""k8s.ovn.org/nad""=""a-ns/nad2"" ""k8s.ovn.org/network""=net2 vf-netdev-name=blah"` + "\n" +
+						"pod-a-old-ifc,sandbox=456defaa iface-id=a-ns_a-pod iface-id-ver=pod-a-uuid-1 vf-netdev-name=blah\n" +
+						"pod-b-ifc,sandbox=123abcfaa iface-id=b-ns_b-pod iface-id-ver=pod-b-uuid-2 vf-netdev-name=blah\n",
+					Err: nil,
+				})
+				for _, iface := range []string{"pod-a-nad2-ifc", "pod-a-old-ifc", "pod-b-ifc"} {
+					execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+						Cmd:    genDeleteStaleRepPortCmd(iface),
+						Output: "",
+						Err:    nil,
+					})
+				}
+				ncm.checkForStaleOVSRepresentorInterfaces()
+				Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
+			})
+		})
+
 		Context("bridge does not have stale representor ports", func() {
 			It("does not remove any port from bridge", func() {
 				// ports in br-int
@@ -164,4 +221,58 @@ var _ = Describe("Healthcheck tests", func() {
 			})
 		})
 	})
+
+	Describe("checkOVSRepresentorInterfacesHealth", func() {
+		var ncm *nodeNetworkControllerManager
+		var recorder *record.FakeRecorder
+
+		BeforeEach(func() {
+			ncm, err = NewNodeNetworkControllerManager(fakeClient, &factoryMock, "localNode", nil)
+			Expect(err).NotTo(HaveOccurred())
+			recorder = record.NewFakeRecorder(10)
+			ncm.recorder = recorder
+			factoryMock.On("GetPods", "").Return([]*v1.Pod{
+				{ObjectMeta: metav1.ObjectMeta{Name: "a-pod", Namespace: "a-ns", UID: "pod-a-uuid-1"}},
+			}, nil)
+		})
+
+		It("reports the representors without OpenFlow port or with their link down once", func() {
+			output := "pod-a-ifc,5,up,sandbox=123abcfaa iface-id=a-ns_a-pod iface-id-ver=pod-a-uuid-1 vf-netdev-name=blah\n" +
+				// ovs-vsctl quotes the keys and values with a '/', and the fields with a quote
+				`pod-a-nad1-ifc,-1,,"sandbox=123abcfaa iface-id=a-ns_nad1_a-ns_a-pod iface-id-ver=pod-a-uuid-1 ` +
+				`""k8s.ovn.org/nad""=""a-ns/nad1"" ""k8s.ovn.org/network""=net1 vf-netdev-name=blah"` + "\n" +
+				`pod-a-nad2-ifc,7,down,"sandbox=123abcfaa iface-id=a-ns_nad2_a-ns_a-pod iface-id-ver=pod-a-uuid-1 ` +
+				`""k8s.ovn.org/nad""=""a-ns/nad2"" ""k8s.ovn.org/network""=net2 vf-netdev-name=blah"` + "\n"
+			for i := 0; i < 2; i++ {
+				execMock.AddFakeCmd(&ovntest.ExpectedCmd{Cmd: genFindInterfaceHealthCmd(), Output: output})
+			}
+			ncm.checkOVSRepresentorInterfacesHealth()
+			ncm.checkOVSRepresentorInterfacesHealth()
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
+			Expect(ncm.unhealthyRepresentors).To(Equal(map[string]string{
+				"pod-a-nad1-ifc": representorNoOFPort,
+				"pod-a-nad2-ifc": representorLinkDown,
+			}))
+			Expect(recorder.Events).To(HaveLen(2))
+			events := []string{<-recorder.Events, <-recorder.Events}
+			Expect(events).To(ConsistOf(
+				"Warning UnhealthyRepresentor OVS Interface pod-a-nad1-ifc of the VF representor for NAD a-ns/nad1 "+
+					"is unhealthy: "+representorNoOFPort,
+				"Warning UnhealthyRepresentor OVS Interface pod-a-nad2-ifc of the VF representor for NAD a-ns/nad2 "+
+					"is unhealthy: "+representorLinkDown,
+			))
+		})
+
+		It("forgets the representors that are healthy again", func() {
+			ncm.unhealthyRepresentors = map[string]string{"pod-a-ifc": representorLinkDown}
+			execMock.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    genFindInterfaceHealthCmd(),
+				Output: "pod-a-ifc,5,up,sandbox=123abcfaa iface-id=a-ns_a-pod iface-id-ver=pod-a-uuid-1 vf-netdev-name=blah\n",
+			})
+			ncm.checkOVSRepresentorInterfacesHealth()
+			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc)
+			Expect(ncm.unhealthyRepresentors).To(BeEmpty())
+			Expect(recorder.Events).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,49 @@
+package aclverdicts
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+)
+
+// ovn-controller logs the verdicts of the ACLs with logging enabled as e.g.
+// 2023-05-10T09:04:20.164Z|00004|acl_log(ovn_pinctrl0)|INFO|name="NP:default:allow-web:Ingress:0", verdict=allow,
+// severity=alert, direction=to-lport: tcp,vlan_tci=0x0000,dl_src=0a:58:0a:f4:01:01,...,tp_dst=8080,tcp_flags=syn
+var aclLogRegex = regexp.MustCompile(`^(\S+)\|\d+\|acl_log\([^)]*\)\|\w+\|name="((?:[^"\\]|\\.)*)", ` +
+	`verdict=(\w+), severity=(\w+)(?:, direction=([\w-]+))?: (.*)$`)
+
+const aclLogTimeLayout = "2006-01-02T15:04:05.000Z"
+
+// parseACLLogLine returns the ACL verdict logged by ovn-controller on the given log line, nil if the line is not an
+// ACL log
+func parseACLLogLine(line string) *metrics.ACLVerdict {
+	match := aclLogRegex.FindStringSubmatch(line)
+	if match == nil {
+		return nil
+	}
+	verdict := &metrics.ACLVerdict{
+		ACLName:   strings.ReplaceAll(match[2], `\"`, `"`),
+		Verdict:   match[3],
+		Severity:  match[4],
+		Direction: match[5],
+		Flow:      match[6],
+	}
+	var err error
+	if verdict.Time, err = time.Parse(aclLogTimeLayout, match[1]); err != nil {
+		verdict.Time = time.Now().UTC()
+	}
+	setOwner(verdict)
+	return verdict
+}
+
+// setOwner maps the verdict to the Kubernetes object owning the ACL, based on the external IDs encoded in the ACL name
+func setOwner(verdict *metrics.ACLVerdict) {
+	verdict.ExternalIDs = libovsdbutil.ParseACLName(verdict.ACLName)
+	if verdict.ExternalIDs == nil {
+		return
+	}
+	verdict.OwnerType, verdict.Namespace, verdict.Name = libovsdbutil.GetACLOwner(verdict.ExternalIDs)
+}
@@ -0,0 +1,198 @@
+package aclverdicts
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+
+	"k8s.io/klog/v2"
+)
+
+// These variables are meant to be used in unit tests
+var pollInterval = 1 * time.Second
+var subscriberBufferSize = 1000
+
+// Stream decodes the ACL verdicts logged by ovn-controller and sends them to its subscribers. Verdicts are only
+// decoded from the lines appended to the log file after the stream is started.
+type Stream struct {
+	logFile       string
+	recordMetrics bool
+
+	sync.Mutex
+	subscribers      map[int]chan metrics.ACLVerdict
+	nextSubscriberID int
+	stopped          bool
+}
+
+// NewStream returns a stream of the ACL verdicts logged to the given ovn-controller log file, verdicts are also
+// counted in the ACL verdict metrics if recordMetrics is set
+func NewStream(logFile string, recordMetrics bool) *Stream {
+	return &Stream{
+		logFile:       logFile,
+		recordMetrics: recordMetrics,
+		subscribers:   map[int]chan metrics.ACLVerdict{},
+	}
+}
+
+// Subscribe returns a channel receiving the verdicts decoded from now on and a function cancelling the subscription.
+// Verdicts are dropped for subscribers that don't keep up. The channel is closed when the stream stops.
+func (s *Stream) Subscribe() (<-chan metrics.ACLVerdict, func()) {
+	s.Lock()
+	defer s.Unlock()
+	ch := make(chan metrics.ACLVerdict, subscriberBufferSize)
+	if s.stopped {
+		close(ch)
+		return ch, func() {}
+	}
+	id := s.nextSubscriberID
+	s.nextSubscriberID++
+	s.subscribers[id] = ch
+	return ch, func() {
+		s.Lock()
+		defer s.Unlock()
+		if ch, ok := s.subscribers[id]; ok {
+			delete(s.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+// Run follows the log file and decodes its ACL verdicts until stopCh is closed. The log file is reopened when it is
+// rotated or truncated.
+func (s *Stream) Run(stopCh <-chan struct{}) {
+	klog.Infof("Decoding ACL verdicts from %s", s.logFile)
+	defer s.stop()
+
+	f := &follower{path: s.logFile}
+	defer f.close()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		if err := f.poll(s.publish); err != nil {
+			klog.V(5).Infof("Failed to read ACL verdicts from %s: %v", s.logFile, err)
+		}
+		select {
+		case <-stopCh:
+			klog.Infof("Stopped decoding ACL verdicts from %s", s.logFile)
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *Stream) publish(line string) {
+	verdict := parseACLLogLine(line)
+	if verdict == nil {
+		return
+	}
+	if s.recordMetrics {
+		metrics.RecordACLVerdict(*verdict)
+	}
+	s.Lock()
+	defer s.Unlock()
+	for id, ch := range s.subscribers {
+		select {
+		case ch <- *verdict:
+		default:
+			klog.V(5).Infof("Dropped ACL verdict for slow subscriber %d", id)
+		}
+	}
+}
+
+func (s *Stream) stop() {
+	s.Lock()
+	defer s.Unlock()
+	s.stopped = true
+	for id, ch := range s.subscribers {
+		delete(s.subscribers, id)
+		close(ch)
+	}
+}
+
+// follower reads the lines appended to a file, across rotations and truncations of the file
+type follower struct {
+	path string
+	// polled is set once the file was polled, the content the file had before is skipped
+	polled bool
+	file   *os.File
+	info   os.FileInfo
+	reader *bufio.Reader
+	// offset of the next byte to read
+	offset int64
+	// partial is the beginning of a line which has not been terminated yet
+	partial string
+}
+
+// poll calls handle for every line appended to the file since the last poll
+func (f *follower) poll(handle func(line string)) error {
+	skipContent := !f.polled
+	f.polled = true
+	info, err := os.Stat(f.path)
+	if err != nil {
+		return err
+	}
+	if f.file != nil && (!os.SameFile(info, f.info) || info.Size() < f.offset) {
+		// rotated or truncated: drain what is left in the old file first, then start over from the beginning
+		_ = f.read(handle)
+		f.close()
+		if err := f.open(info, false); err != nil {
+			return err
+		}
+	}
+	if f.file == nil {
+		if err := f.open(info, skipContent); err != nil {
+			return err
+		}
+	}
+	return f.read(handle)
+}
+
+func (f *follower) open(info os.FileInfo, seekEnd bool) error {
+	file, err := os.Open(f.path)
+	if err != nil {
+		return err
+	}
+	f.offset = 0
+	if seekEnd {
+		if f.offset, err = file.Seek(0, io.SeekEnd); err != nil {
+			file.Close()
+			return err
+		}
+	}
+	f.file = file
+	f.info = info
+	f.reader = bufio.NewReader(file)
+	f.partial = ""
+	return nil
+}
+
+func (f *follower) read(handle func(line string)) error {
+	if f.file == nil {
+		return nil
+	}
+	for {
+		line, err := f.reader.ReadString('\n')
+		f.offset += int64(len(line))
+		if errors.Is(err, io.EOF) {
+			f.partial += line
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		handle(f.partial + line[:len(line)-1])
+		f.partial = ""
+	}
+}
+
+func (f *follower) close() {
+	if f.file != nil {
+		f.file.Close()
+		f.file = nil
+	}
+}
@@ -0,0 +1,175 @@
+package aclverdicts
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func aclLogLine(name, verdict string) string {
+	return fmt.Sprintf(`2023-05-10T09:04:20.164Z|00004|acl_log(ovn_pinctrl0)|INFO|name="%s", verdict=%s, severity=alert, `+
+		`direction=to-lport: tcp,vlan_tci=0x0000,nw_src=10.244.1.3,nw_dst=10.244.2.4,tp_src=44530,tp_dst=8080,tcp_flags=syn`,
+		name, verdict)
+}
+
+func TestParseACLLogLine(t *testing.T) {
+	testcases := []struct {
+		desc      string
+		line      string
+		ownerType string
+		namespace string
+		name      string
+	}{
+		{
+			desc:      "network policy",
+			line:      aclLogLine("NP:namespace1:policy1:Ingress:0", "allow"),
+			ownerType: string(libovsdbops.NetworkPolicyOwnerType),
+			namespace: "namespace1",
+			name:      "policy1",
+		},
+		{
+			desc:      "network policy default deny",
+			line:      aclLogLine("NP:namespace1:Ingress", "drop"),
+			ownerType: string(libovsdbops.NetpolNamespaceOwnerType),
+			namespace: "namespace1",
+		},
+		{
+			desc:      "egress firewall",
+			line:      aclLogLine("EF:namespace1:2", "drop"),
+			ownerType: string(libovsdbops.EgressFirewallOwnerType),
+			namespace: "namespace1",
+		},
+		{
+			desc:      "baseline admin network policy",
+			line:      aclLogLine("BANP:default:Egress:1", "allow"),
+			ownerType: string(libovsdbops.BaselineAdminNetworkPolicyOwnerType),
+			name:      "default",
+		},
+		{
+			desc:      "namespace multicast",
+			line:      aclLogLine("MC:namespace1:Egress", "allow"),
+			ownerType: string(libovsdbops.MulticastNamespaceOwnerType),
+			namespace: "namespace1",
+		},
+		{
+			desc: "unnamed ACL",
+			line: aclLogLine("<unnamed>", "allow"),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.desc, func(t *testing.T) {
+			verdict := parseACLLogLine(tc.line)
+			if !assert.NotNil(t, verdict) {
+				return
+			}
+			assert.Equal(t, time.Date(2023, 5, 10, 9, 4, 20, 164000000, time.UTC), verdict.Time)
+			assert.Equal(t, "alert", verdict.Severity)
+			assert.Equal(t, "to-lport", verdict.Direction)
+			assert.Contains(t, verdict.Flow, "tp_dst=8080")
+			assert.Equal(t, tc.ownerType, verdict.OwnerType)
+			assert.Equal(t, tc.namespace, verdict.Namespace)
+			assert.Equal(t, tc.name, verdict.Name)
+		})
+	}
+
+	assert.Nil(t, parseACLLogLine("2023-05-10T09:04:20.164Z|00005|binding|INFO|Claiming lport pod1 for this chassis."))
+}
+
+func TestStream(t *testing.T) {
+	defer func(interval time.Duration) { pollInterval = interval }(pollInterval)
+	pollInterval = 10 * time.Millisecond
+
+	logFile := filepath.Join(t.TempDir(), "ovn-controller.log")
+	appendLines := func(lines ...string) {
+		f, err := os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer f.Close()
+		for _, line := range lines {
+			if _, err = f.WriteString(line + "\n"); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	// verdicts logged before the stream is started are not streamed
+	appendLines(aclLogLine("NP:namespace1:old:Ingress:0", "allow"))
+
+	stream := NewStream(logFile, false)
+	verdicts, cancel := stream.Subscribe()
+	defer cancel()
+	stopCh := make(chan struct{})
+	wg := sync.WaitGroup{}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		stream.Run(stopCh)
+	}()
+
+	expectVerdict := func(aclName string) {
+		select {
+		case verdict := <-verdicts:
+			assert.Equal(t, aclName, verdict.ACLName)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Timed out waiting for the verdict of %s", aclName)
+		}
+	}
+
+	// wait for the stream to skip the existing content before logging
+	time.Sleep(5 * pollInterval)
+	appendLines(
+		"2023-05-10T09:04:20.164Z|00005|binding|INFO|Claiming lport pod1 for this chassis.",
+		aclLogLine("NP:namespace1:policy1:Ingress:0", "allow"),
+	)
+	expectVerdict("NP:namespace1:policy1:Ingress:0")
+
+	// rotate the log file
+	if err := os.Rename(logFile, logFile+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendLines(aclLogLine("EF:namespace1:0", "drop"))
+	expectVerdict("EF:namespace1:0")
+
+	// truncate the log file
+	if err := os.Truncate(logFile, 0); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * pollInterval)
+	appendLines(aclLogLine("MC:namespace1:Egress", "allow"))
+	expectVerdict("MC:namespace1:Egress")
+
+	close(stopCh)
+	wg.Wait()
+	_, ok := <-verdicts
+	assert.False(t, ok, "the subscription should be closed when the stream stops")
+	closed, _ := stream.Subscribe()
+	_, ok = <-closed
+	assert.False(t, ok, "subscriptions to a stopped stream should be closed")
+}
+
+func TestStreamDropsVerdictsOfSlowSubscribers(t *testing.T) {
+	defer func(size int) { subscriberBufferSize = size }(subscriberBufferSize)
+	subscriberBufferSize = 1
+
+	stream := NewStream("", false)
+	verdicts, cancel := stream.Subscribe()
+	stream.publish(aclLogLine("EF:namespace1:0", "drop"))
+	stream.publish(aclLogLine("EF:namespace1:1", "drop"))
+	cancel()
+
+	received := []metrics.ACLVerdict{}
+	for verdict := range verdicts {
+		received = append(received, verdict)
+	}
+	if assert.Len(t, received, 1) {
+		assert.Equal(t, "EF:namespace1:0", received[0].ACLName)
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"k8s.io/klog/v2"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
-	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -47,7 +46,7 @@ func (bnnc *BaseNodeNetworkController) addDPUPodForNAD(pod *kapi.Pod, dpuCD *uti
 	podDesc := fmt.Sprintf("pod %s/%s for NAD %s", pod.Namespace, pod.Name, nadName)
 	klog.Infof("Adding %s on DPU", podDesc)
 	podInterfaceInfo, err := cni.PodAnnotation2PodInfo(pod.Annotations, nil,
-		string(pod.UID), "", nadName, netName, config.Default.MTU)
+		string(pod.UID), "", nadName, netName, bnnc.MTU())
 	if err != nil {
 		return fmt.Errorf("failed to get pod interface information of %s: %v. retrying", podDesc, err)
 	}
@@ -96,6 +95,31 @@ func dpuConnectionDetailChanged(oldDPUCD, newDPUCD *util.DPUConnectionDetails) b
 	return false
 }
 
+// getPodNADNamesDPU returns the NADs of the network of the controller the pod
+// is attached to, or none if it is not attached to the network.
+func (bnnc *BaseNodeNetworkController) getPodNADNamesDPU(pod *kapi.Pod) []string {
+	if !bnnc.IsSecondary() {
+		return []string{types.DefaultNetworkName}
+	}
+	on, networkMap, err := util.GetPodNADToNetworkMapping(pod, bnnc.NetInfo)
+	if err != nil {
+		// configuration error, no need to retry, do not return error
+		klog.Errorf("Error getting network-attachment for pod %s/%s network %s: %v",
+			pod.Namespace, pod.Name, bnnc.GetNetworkName(), err)
+		return nil
+	}
+	if !on {
+		klog.V(5).Infof("Skipping Pod %s/%s as it is not attached to network: %s",
+			pod.Namespace, pod.Name, bnnc.GetNetworkName())
+		return nil
+	}
+	nadNames := make([]string, 0, len(networkMap))
+	for nadName := range networkMap {
+		nadNames = append(nadNames, nadName)
+	}
+	return nadNames
+}
+
 // watchPodsDPU watch updates for pod DPU annotations
 func (bnnc *BaseNodeNetworkController) watchPodsDPU() (*factory.Handler, error) {
 	clientSet := cni.NewClientSet(bnnc.client, corev1listers.NewPodLister(bnnc.watchFactory.LocalPodInformer().GetIndexer()))
@@ -109,27 +133,16 @@ func (bnnc *BaseNodeNetworkController) watchPodsDPU() (*factory.Handler, error)
 				return
 			}
 
-			// add all the Pod's NADs into Pod's nadToDPUCDMap
+			// add all the Pod's NADs into Pod's nadToDPUCDMap, a Pod has a VF
+			// for each NAD of a secondary network it is attached to.
 			// For default network, NAD name is DefaultNetworkName.
-			var nadToDPUCDMap map[string]*util.DPUConnectionDetails
-			if bnnc.IsSecondary() {
-				on, networkMap, err := util.GetPodNADToNetworkMapping(pod, bnnc.NetInfo)
-				if err != nil || !on {
-					if err != nil {
-						// configuration error, no need to retry, do not return error
-						klog.Errorf("Error getting network-attachment for pod %s/%s network %s: %v",
-							pod.Namespace, pod.Name, bnnc.GetNetworkName(), err)
-					} else {
-						klog.V(5).Infof("Skipping Pod %s/%s as it is not attached to network: %s",
-							pod.Namespace, pod.Name, netName)
-					}
-					return
-				}
-				for nadName := range networkMap {
-					nadToDPUCDMap = map[string]*util.DPUConnectionDetails{nadName: nil}
-				}
-			} else {
-				nadToDPUCDMap = map[string]*util.DPUConnectionDetails{types.DefaultNetworkName: nil}
+			nadNames := bnnc.getPodNADNamesDPU(pod)
+			if len(nadNames) == 0 {
+				return
+			}
+			nadToDPUCDMap := make(map[string]*util.DPUConnectionDetails, len(nadNames))
+			for _, nadName := range nadNames {
+				nadToDPUCDMap[nadName] = nil
 			}
 
 			for nadName := range nadToDPUCDMap {
@@ -3,6 +3,8 @@ package node
 import (
 	"fmt"
 
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stretchr/testify/mock"
@@ -10,6 +12,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
 	adminpolicybasedrouteclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
 	factorymocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory/mocks"
 	kubemocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube/mocks"
@@ -435,4 +438,37 @@ var _ = Describe("Node DPU tests", func() {
 			Expect(execMock.CalledMatchesExpected()).To(BeTrue(), execMock.ErrorDesc())
 		})
 	})
+
+	Context("getPodNADNamesDPU", func() {
+		var snnc *SecondaryNodeNetworkController
+
+		BeforeEach(func() {
+			netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "l2-net", Type: "ovn-k8s-cni-overlay"},
+				Topology: types.Layer2Topology,
+				NADName:  "foo-ns/nad1",
+				Subnets:  "10.1.0.0/16",
+			})
+			Expect(err).NotTo(HaveOccurred())
+			netInfo.AddNAD("foo-ns/nad1")
+			netInfo.AddNAD("foo-ns/nad2")
+			cnnci := newCommonNodeNetworkControllerInfo(nil, &kubeMock, adminpolicybasedrouteclient.NewSimpleClientset(),
+				&factoryMock, nil, "")
+			snnc = NewSecondaryNodeNetworkController(cnnci, netInfo)
+		})
+
+		It("Returns the default network for the default network controller", func() {
+			Expect(dnnc.getPodNADNamesDPU(&pod)).To(Equal([]string{types.DefaultNetworkName}))
+		})
+
+		It("Returns every NAD of the secondary network the pod is attached to", func() {
+			pod.Annotations[nadv1.NetworkAttachmentAnnot] = `[{"name":"nad1"},{"name":"nad2"},{"name":"nad3"}]`
+			Expect(snnc.getPodNADNamesDPU(&pod)).To(ConsistOf("foo-ns/nad1", "foo-ns/nad2"))
+		})
+
+		It("Returns no NAD if the pod is not attached to the secondary network", func() {
+			pod.Annotations[nadv1.NetworkAttachmentAnnot] = `[{"name":"nad3"}]`
+			Expect(snnc.getPodNADNamesDPU(&pod)).To(BeEmpty())
+		})
+	})
 })
@@ -530,7 +530,9 @@ func (c *Controller) processEIP(eip *eipv1.EgressIP) (*eIPConfig, sets.Set[strin
 			return nil, selectedNamespaces, selectedPods, selectedNamespacesPodIPs,
 				fmt.Errorf("failed to generate mask for EgressIP %s IP %s: %v", eip.Name, status.EgressIP, err)
 		}
-		if util.IsOVNNetwork(parsedNodeEIPConfig, eIPNet.IP) {
+		// the egress IPs of the default network hosted by the OVN network are hosted by the gateway router, the
+		// secondary networks have no gateway router so the node SNATs their traffic on its gateway interface
+		if eip.Spec.Network == "" && util.IsOVNNetwork(parsedNodeEIPConfig, eIPNet.IP) {
 			continue
 		}
 		found, link, err := findLinkOnSameNetworkAsIP(eIPNet.IP, c.v4, c.v6)
@@ -559,7 +561,7 @@ func (c *Controller) processEIP(eip *eipv1.EgressIP) (*eIPConfig, sets.Set[strin
 				if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !util.PodScheduled(pod) {
 					continue
 				}
-				ips, err := util.DefaultNetworkPodIPs(pod)
+				ips, err := getPodIPs(pod, eip.Spec.Network)
 				if err != nil {
 					return nil, selectedNamespaces, selectedPods, selectedNamespacesPodIPs, fmt.Errorf("failed to get pod ips: %w", err)
 				}
@@ -941,7 +943,7 @@ func (c *Controller) repairNode() error {
 			if err != nil {
 				return err
 			}
-			if util.IsOVNNetwork(parsedNodeEIPConfig, eIPNet.IP) {
+			if egressIP.Spec.Network == "" && util.IsOVNNetwork(parsedNodeEIPConfig, eIPNet.IP) {
 				continue
 			}
 			isEIPV6 := utilnet.IsIPv6(eIPNet.IP)
@@ -1462,6 +1464,15 @@ func filterRuleByPriority(priority int) (*netlink.Rule, uint64) {
 		netlink.RT_FILTER_PRIORITY
 }
 
+// getPodIPs returns the IPs of the pod on the network referenced by an EgressIP: the default network if
+// network is empty, or the secondary network attached through the network attachment definition network.
+func getPodIPs(pod *corev1.Pod, network string) ([]net.IP, error) {
+	if network == "" {
+		return util.DefaultNetworkPodIPs(pod)
+	}
+	return util.PodNADIPs(pod, network), nil
+}
+
 func getPodNamespacedName(pod *corev1.Pod) ktypes.NamespacedName {
 	return ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
 }
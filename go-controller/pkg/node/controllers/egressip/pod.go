@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -168,9 +169,11 @@ func (c *Controller) onPodUpdate(oldObj, newObj interface{}) {
 		utilruntime.HandleError(errors.New("invalid Pod provided to onPodUpdate()"))
 		return
 	}
-	// if labels AND assigned Pod IPs AND the multus network status annotations are the same, skip processing changes to the pod.
+	// if labels AND assigned Pod IPs AND the OVN network annotations are the same, skip processing changes to the pod.
+	// The OVN network annotation holds the IPs of pods on secondary networks.
 	if reflect.DeepEqual(o.Labels, n.Labels) &&
-		reflect.DeepEqual(o.Status.PodIPs, n.Status.PodIPs) {
+		reflect.DeepEqual(o.Status.PodIPs, n.Status.PodIPs) &&
+		o.Annotations[util.OvnPodAnnotationName] == n.Annotations[util.OvnPodAnnotationName] {
 		return
 	}
 	c.podQueue.Add(newObj)
@@ -169,6 +169,18 @@ func clearOVSFlowTargets() error {
 	if err != nil {
 		return err
 	}
+	// the collector set of the flow sampling of the ACLs
+	stdout, stderr, err := util.RunOVSVsctl("--no-heading", "--data=bare", "--columns=_uuid", "find",
+		"flow_sample_collector_set", fmt.Sprintf("id=%d", types.FlowSamplingCollectorSetID))
+	if err != nil {
+		return fmt.Errorf("error finding the flow sampling collector set: %v\n  %q", err, stderr)
+	}
+	for _, uuid := range strings.Fields(stdout) {
+		_, stderr, err = util.RunOVSVsctl("--if-exists", "destroy", "flow_sample_collector_set", uuid)
+		if err != nil {
+			return fmt.Errorf("error destroying the flow sampling collector set: %v\n  %q", err, stderr)
+		}
+	}
 	return nil
 }
 
@@ -266,6 +278,21 @@ func setOVSFlowTargets(node *kapi.Node) error {
 		if err != nil {
 			return fmt.Errorf("error setting IPFIX: %v\n  %q", err, stderr)
 		}
+
+		// the samples of the ACLs of the namespaces with flow sampling are sent to the IPFIX targets too, with the
+		// probability of the sample collector of the namespace instead of the bridge wide sampling rate
+		_, stderr, err = util.RunOVSVsctl(
+			"--",
+			"--id=@br", "get", "bridge", "br-int",
+			"--",
+			"--id=@ipfix", "create", "ipfix", fmt.Sprintf("targets=[%s]", collectors),
+			"--",
+			"create", "flow_sample_collector_set", fmt.Sprintf("id=%d", types.FlowSamplingCollectorSetID),
+			"bridge=@br", "ipfix=@ipfix",
+		)
+		if err != nil {
+			return fmt.Errorf("error setting the flow sampling collector set: %v\n  %q", err, stderr)
+		}
 	}
 	return nil
 }
@@ -781,7 +808,11 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		if !ok {
 			return fmt.Errorf("cannot get kubeclient for starting CNI server")
 		}
-		cniServer, err = cni.NewCNIServer(nc.watchFactory, kclient.KClient)
+		devicePools, err := nc.initDevicePools(node)
+		if err != nil {
+			return err
+		}
+		cniServer, err = cni.NewCNIServer(nc.watchFactory, kclient.KClient, devicePools)
 		if err != nil {
 			return err
 		}
@@ -816,6 +847,15 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		return err
 	}
 
+	// Tear down the gateway mode the node was migrated from
+	var gatewayModeMigrator *gatewayModeMigrator
+	if config.OvnKubeNode.Mode == types.NodeModeFull {
+		gatewayModeMigrator = newGatewayModeMigrator(nc.name, nc.Kube, nc.watchFactory)
+		if err := gatewayModeMigrator.teardownPreviousMode(node, mgmtPortConfig); err != nil {
+			return err
+		}
+	}
+
 	// Initialize gateway
 	if config.OvnKubeNode.Mode == types.NodeModeDPUHost {
 		err = nc.initGatewayDPUHost(nodeAddr)
@@ -1123,6 +1163,32 @@ func (nc *DefaultNodeNetworkController) Start(ctx context.Context) error {
 		ovspinning.Run(nc.stopChan)
 	}()
 
+	if gatewayModeMigrator != nil {
+		if err := gatewayModeMigrator.Start(); err != nil {
+			return fmt.Errorf("failed to start the gateway mode migrator: %w", err)
+		}
+		nc.wg.Add(1)
+		go func() {
+			defer nc.wg.Done()
+			<-nc.stopChan
+			gatewayModeMigrator.Stop()
+		}()
+	}
+
+	if gw, ok := nc.gateway.(*gateway); ok && config.OvnKubeNode.Mode == types.NodeModeFull &&
+		config.Gateway.Mode != config.GatewayModeDisabled {
+		gatewayUplinkController := newGatewayUplinkController(nc.name, nc.watchFactory, gw)
+		if err := gatewayUplinkController.Start(); err != nil {
+			return fmt.Errorf("failed to start the gateway uplink controller: %w", err)
+		}
+		nc.wg.Add(1)
+		go func() {
+			defer nc.wg.Done()
+			<-nc.stopChan
+			gatewayUplinkController.Stop()
+		}()
+	}
+
 	klog.Infof("Default node network controller initialized and ready.")
 	return nil
 }
@@ -1161,7 +1227,13 @@ func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPo
 		return nil
 	}
 
-	healthServer, err := healthcheck.NewEgressIPHealthServer(nodeMgmtIP, healthCheckPort)
+	checks, err := healthcheck.NewEgressIPNodeChecks(nc.name, config.OVNKubernetesFeature.EgressIPNodeHealthChecks,
+		config.OVNKubernetesFeature.EgressIPNodeHealthCheckICMPTargets)
+	if err != nil {
+		return fmt.Errorf("invalid Egress IP node health checks: %w", err)
+	}
+
+	healthServer, err := healthcheck.NewEgressIPHealthServer(nodeMgmtIP, healthCheckPort, checks...)
 	if err != nil {
 		return fmt.Errorf("unable to allocate health checking server: %v", err)
 	}
@@ -1171,6 +1243,18 @@ func (nc *DefaultNodeNetworkController) startEgressIPHealthCheckingServer(mgmtPo
 		defer nc.wg.Done()
 		healthServer.Run(nc.stopChan)
 	}()
+
+	// the checks requested on the node replace the configured ones
+	checksController := newEgressIPHealthChecksController(nc.name, nc.watchFactory, healthServer)
+	if err := checksController.Start(); err != nil {
+		return fmt.Errorf("failed to start the Egress IP health checks controller: %w", err)
+	}
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		<-nc.stopChan
+		checksController.Stop()
+	}()
 	return nil
 }
 
@@ -266,6 +266,9 @@ var _ = Describe("Node", func() {
 						" -- " +
 						"clear bridge br-int ipfix",
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=_uuid find flow_sample_collector_set id=4242",
+				})
 				err := util.SetExec(fexec)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -372,6 +375,9 @@ var _ = Describe("Node", func() {
 						" -- " +
 						"clear bridge br-int ipfix",
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=_uuid find flow_sample_collector_set id=4242",
+				})
 				err := util.SetExec(fexec)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -439,6 +445,13 @@ var _ = Describe("Node", func() {
 						" -- " +
 						"clear bridge br-int ipfix",
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd:    "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=_uuid find flow_sample_collector_set id=4242",
+					Output: "0f6d6c4e-5d2b-4c3b-9c7c-2f3c1a9f8a11\n",
+				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=15 --if-exists destroy flow_sample_collector_set 0f6d6c4e-5d2b-4c3b-9c7c-2f3c1a9f8a11",
+				})
 				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 					Cmd: fmt.Sprintf("ovs-vsctl --timeout=15"+
 						" -- "+
@@ -447,6 +460,13 @@ var _ = Describe("Node", func() {
 						" -- "+
 						"set bridge br-int ipfix=@ipfix", ipfixIP, ipfixPort),
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: fmt.Sprintf("ovs-vsctl --timeout=15"+
+						" -- --id=@br get bridge br-int"+
+						" -- --id=@ipfix create ipfix "+
+						"targets=[\"%s:%d\"]"+
+						" -- create flow_sample_collector_set id=4242 bridge=@br ipfix=@ipfix", ipfixIP, ipfixPort),
+				})
 				err := util.SetExec(fexec)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -513,6 +533,9 @@ var _ = Describe("Node", func() {
 						" -- " +
 						"clear bridge br-int ipfix",
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=_uuid find flow_sample_collector_set id=4242",
+				})
 				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 					Cmd: fmt.Sprintf("ovs-vsctl --timeout=15"+
 						" -- "+
@@ -521,6 +544,13 @@ var _ = Describe("Node", func() {
 						" -- "+
 						"set bridge br-int ipfix=@ipfix", ipfixIP, ipfixPort),
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: fmt.Sprintf("ovs-vsctl --timeout=15"+
+						" -- --id=@br get bridge br-int"+
+						" -- --id=@ipfix create ipfix "+
+						"targets=[\"%s:%d\"]"+
+						" -- create flow_sample_collector_set id=4242 bridge=@br ipfix=@ipfix", ipfixIP, ipfixPort),
+				})
 				err := util.SetExec(fexec)
 				Expect(err).NotTo(HaveOccurred())
 
@@ -587,6 +617,9 @@ var _ = Describe("Node", func() {
 						" -- " +
 						"clear bridge br-int ipfix",
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=15 --no-heading --data=bare --columns=_uuid find flow_sample_collector_set id=4242",
+				})
 				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
 					Cmd: "ovs-vsctl --timeout=15" +
 						" -- " +
@@ -596,6 +629,13 @@ var _ = Describe("Node", func() {
 						" -- " +
 						"set bridge br-int ipfix=@ipfix",
 				})
+				fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+					Cmd: "ovs-vsctl --timeout=15" +
+						" -- --id=@br get bridge br-int" +
+						" -- --id=@ipfix create ipfix " +
+						`targets=["10.0.0.2:3030","1.2.5.6:8888","[2020:1111:f::1:933]:3333"]` +
+						" -- create flow_sample_collector_set id=4242 bridge=@br ipfix=@ipfix",
+				})
 				err := util.SetExec(fexec)
 				Expect(err).NotTo(HaveOccurred())
 
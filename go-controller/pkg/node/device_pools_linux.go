@@ -0,0 +1,112 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+)
+
+// initDevicePools returns the manager of the device pools of the node, after recovering the devices created before a
+// restart, or nil if the node has no device pools. The pools are advertised as extended resources of the node, and
+// re-advertised every minute as the kubelet resets the extended resources of the node when it restarts.
+func (nc *DefaultNodeNetworkController) initDevicePools(node *kapi.Node) (*cni.DevicePoolManager, error) {
+	var devicePools *cni.DevicePoolManager
+	capacity := kapi.ResourceList{}
+	if len(config.OvnKubeNode.DevicePools) > 0 {
+		var err error
+		devicePools, err = cni.NewDevicePoolManager(config.OvnKubeNode.DevicePools)
+		if err != nil {
+			return nil, fmt.Errorf("failed to initialize the device pools: %v", err)
+		}
+		if err := devicePools.Sync(); err != nil {
+			return nil, fmt.Errorf("failed to sync the device pools: %v", err)
+		}
+		capacity = devicePools.ResourceCapacity()
+	}
+	if err := updateDevicePoolResources(nc.Kube, node, capacity); err != nil {
+		return nil, fmt.Errorf("failed to advertise the device pools of node %s: %v", node.Name, err)
+	}
+	if devicePools != nil {
+		nc.wg.Add(1)
+		go func() {
+			defer nc.wg.Done()
+			wait.Until(func() {
+				node, err := nc.watchFactory.GetNode(nc.name)
+				if err != nil {
+					klog.Errorf("Failed to get node %s: %v", nc.name, err)
+					return
+				}
+				if err := updateDevicePoolResources(nc.Kube, node, capacity); err != nil {
+					klog.Errorf("Failed to advertise the device pools of node %s: %v", nc.name, err)
+				}
+			}, time.Minute, nc.stopChan)
+		}()
+	}
+	return devicePools, nil
+}
+
+// devicePoolResourcesChanged returns true if the capacity of the device pool resources of the node differs from the
+// given one
+func devicePoolResourcesChanged(node *kapi.Node, capacity kapi.ResourceList) bool {
+	for name, quantity := range node.Status.Capacity {
+		if !strings.HasPrefix(string(name), types.DevicePoolResourcePrefix) {
+			continue
+		}
+		if expected, ok := capacity[name]; !ok || !expected.Equal(quantity) {
+			return true
+		}
+	}
+	for name := range capacity {
+		if _, ok := node.Status.Capacity[name]; !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// updateDevicePoolResources sets the capacity of the device pool resources of the node to the given one, removing the
+// resources of the pools that are no longer configured. The kubelet computes their allocatable from it.
+func updateDevicePoolResources(kube kube.Interface, node *kapi.Node, capacity kapi.ResourceList) error {
+	if !devicePoolResourcesChanged(node, capacity) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := kube.GetNode(node.Name)
+		if err != nil {
+			return err
+		}
+		if !devicePoolResourcesChanged(node, capacity) {
+			return nil
+		}
+		node = node.DeepCopy()
+		if node.Status.Capacity == nil {
+			node.Status.Capacity = kapi.ResourceList{}
+		}
+		for name := range node.Status.Capacity {
+			if strings.HasPrefix(string(name), types.DevicePoolResourcePrefix) {
+				if _, ok := capacity[name]; !ok {
+					delete(node.Status.Capacity, name)
+					delete(node.Status.Allocatable, name)
+				}
+			}
+		}
+		for name, quantity := range capacity {
+			node.Status.Capacity[name] = quantity
+		}
+		klog.Infof("Updating the device pool resources of node %s to %v", node.Name, capacity)
+		return kube.UpdateNodeStatus(node)
+	})
+}
@@ -0,0 +1,55 @@
+package node
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Device pool resources", func() {
+	const nodeName = "node1"
+	fast := corev1.ResourceName(types.DevicePoolResourcePrefix + "fast")
+	vms := corev1.ResourceName(types.DevicePoolResourcePrefix + "vms")
+
+	newNode := func(capacity corev1.ResourceList) *corev1.Node {
+		capacity[corev1.ResourceCPU] = resource.MustParse("4")
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status:     corev1.NodeStatus{Capacity: capacity, Allocatable: capacity.DeepCopy()},
+		}
+	}
+
+	It("advertises the pools and removes the resources of the pools that are no longer configured", func() {
+		node := newNode(corev1.ResourceList{vms: resource.MustParse("2")})
+		fakeClient := fake.NewSimpleClientset(node)
+
+		capacity := corev1.ResourceList{fast: *resource.NewQuantity(4, resource.DecimalSI)}
+		Expect(updateDevicePoolResources(&kube.Kube{KClient: fakeClient}, node, capacity)).To(Succeed())
+
+		updated, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(updated.Status.Capacity).To(HaveKey(corev1.ResourceCPU))
+		Expect(updated.Status.Capacity).NotTo(HaveKey(vms))
+		Expect(updated.Status.Allocatable).NotTo(HaveKey(vms))
+		quantity := updated.Status.Capacity[fast]
+		Expect(quantity.Value()).To(BeEquivalentTo(4))
+	})
+
+	It("does not update a node already advertising the pools", func() {
+		node := newNode(corev1.ResourceList{fast: resource.MustParse("4")})
+		fakeClient := fake.NewSimpleClientset(node)
+
+		capacity := corev1.ResourceList{fast: *resource.NewQuantity(4, resource.DecimalSI)}
+		Expect(updateDevicePoolResources(&kube.Kube{KClient: fakeClient}, node, capacity)).To(Succeed())
+		Expect(fakeClient.Actions()).To(BeEmpty())
+	})
+})
@@ -0,0 +1,101 @@
+package node
+
+import (
+	"strings"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// egressIPHealthChecksController configures the egress IP health checks that the node runs in addition to serving
+// the gRPC health check, from the k8s.ovn.org/egressip-node-healthchecks annotation of the node or, without it,
+// from the configuration
+type egressIPHealthChecksController struct {
+	nodeName       string
+	watchFactory   factory.NodeWatchFactory
+	healthServer   healthcheck.EgressIPHealthServer
+	nodeController controller.Controller
+}
+
+func newEgressIPHealthChecksController(nodeName string, watchFactory factory.NodeWatchFactory,
+	healthServer healthcheck.EgressIPHealthServer) *egressIPHealthChecksController {
+	c := &egressIPHealthChecksController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		healthServer: healthServer,
+	}
+	controllerConfig := &controller.Config[kapi.Node]{
+		RateLimiter:    workqueue.NewItemFastSlowRateLimiter(time.Second, 5*time.Second, 5),
+		Informer:       watchFactory.NodeInformer(),
+		Lister:         watchFactory.ListNodes,
+		ObjNeedsUpdate: c.needsUpdate,
+		Reconcile:      c.reconcileNode,
+	}
+	c.nodeController = controller.NewController[kapi.Node]("egressip_healthchecks", controllerConfig)
+	return c
+}
+
+func (c *egressIPHealthChecksController) Start() error {
+	return c.nodeController.Start(1)
+}
+
+func (c *egressIPHealthChecksController) Stop() {
+	c.nodeController.Stop()
+}
+
+func (c *egressIPHealthChecksController) needsUpdate(oldNode, newNode *kapi.Node) bool {
+	if newNode == nil || newNode.Name != c.nodeName {
+		return false
+	}
+	return oldNode == nil ||
+		oldNode.Annotations[util.OvnNodeEgressIPHealthChecks] != newNode.Annotations[util.OvnNodeEgressIPHealthChecks]
+}
+
+func (c *egressIPHealthChecksController) reconcileNode(nodeName string) error {
+	if nodeName != c.nodeName {
+		return nil
+	}
+	node, err := c.watchFactory.GetNode(nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	checks, err := getEgressIPNodeHealthChecks(node)
+	if err != nil {
+		// retrying won't fix the annotation, run the configured checks until it is fixed
+		klog.Errorf("Ignoring the egress IP health checks requested on node %s: %v", nodeName, err)
+		checks, err = healthcheck.NewEgressIPNodeChecks(nodeName, config.OVNKubernetesFeature.EgressIPNodeHealthChecks,
+			config.OVNKubernetesFeature.EgressIPNodeHealthCheckICMPTargets)
+		if err != nil {
+			return err
+		}
+	}
+	klog.Infof("Running %d egress IP health checks on node %s", len(checks), nodeName)
+	c.healthServer.SetChecks(checks)
+	return nil
+}
+
+// getEgressIPNodeHealthChecks returns the egress IP health checks requested for the node, or the configured ones
+func getEgressIPNodeHealthChecks(node *kapi.Node) ([]healthcheck.EgressIPNodeCheck, error) {
+	nodeChecks, err := util.ParseNodeEgressIPHealthChecks(node)
+	if err != nil {
+		return nil, err
+	}
+	if nodeChecks == nil {
+		return healthcheck.NewEgressIPNodeChecks(node.Name, config.OVNKubernetesFeature.EgressIPNodeHealthChecks,
+			config.OVNKubernetesFeature.EgressIPNodeHealthCheckICMPTargets)
+	}
+	return healthcheck.NewEgressIPNodeChecks(node.Name, strings.Join(nodeChecks.Checks, ","),
+		strings.Join(nodeChecks.ICMPTargets, ","))
+}
@@ -0,0 +1,88 @@
+package node
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/healthcheck"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeEgressIPHealthServer struct {
+	checks []healthcheck.EgressIPNodeCheck
+}
+
+func (s *fakeEgressIPHealthServer) Run(_ <-chan struct{}) {}
+
+func (s *fakeEgressIPHealthServer) SetChecks(checks []healthcheck.EgressIPNodeCheck) {
+	s.checks = checks
+}
+
+var _ = Describe("Egress IP health checks", func() {
+	const nodeName = "node1"
+	var (
+		watchFactory *factory.WatchFactory
+		healthServer *fakeEgressIPHealthServer
+		c            *egressIPHealthChecksController
+	)
+
+	start := func(annotations map[string]string) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName, Annotations: annotations}}
+		var err error
+		watchFactory, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fake.NewSimpleClientset(node)}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFactory.Start()).To(Succeed())
+		healthServer = &fakeEgressIPHealthServer{}
+		c = newEgressIPHealthChecksController(nodeName, watchFactory, healthServer)
+	}
+
+	checkNames := func() []string {
+		var names []string
+		for _, check := range healthServer.checks {
+			names = append(names, check.Name())
+		}
+		return names
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EgressIPNodeHealthCheckPort = 9107
+		config.OVNKubernetesFeature.EgressIPNodeHealthChecks = healthcheck.EgressIPNodeCheckBFD
+	})
+
+	AfterEach(func() {
+		watchFactory.Shutdown()
+	})
+
+	It("runs the configured checks on a node without the annotation", func() {
+		start(nil)
+		Expect(c.reconcileNode(nodeName)).To(Succeed())
+		Expect(checkNames()).To(Equal([]string{healthcheck.EgressIPNodeCheckBFD}))
+	})
+
+	It("runs the checks requested on the node", func() {
+		start(map[string]string{
+			util.OvnNodeEgressIPHealthChecks: `{"checks": ["icmp", "bfd"], "icmp-targets": ["172.18.0.1"]}`,
+		})
+		Expect(c.reconcileNode(nodeName)).To(Succeed())
+		Expect(checkNames()).To(Equal([]string{healthcheck.EgressIPNodeCheckICMP, healthcheck.EgressIPNodeCheckBFD}))
+	})
+
+	It("runs no checks when the node requests none", func() {
+		start(map[string]string{util.OvnNodeEgressIPHealthChecks: `{"checks": []}`})
+		Expect(c.reconcileNode(nodeName)).To(Succeed())
+		Expect(healthServer.checks).To(BeEmpty())
+	})
+
+	It("runs the configured checks when the checks requested on the node are invalid", func() {
+		start(map[string]string{util.OvnNodeEgressIPHealthChecks: `{"checks": ["icmp"]}`})
+		Expect(c.reconcileNode(nodeName)).To(Succeed())
+		Expect(checkNames()).To(Equal([]string{healthcheck.EgressIPNodeCheckBFD}))
+	})
+})
@@ -19,6 +19,7 @@ import (
 	discovery "k8s.io/api/discovery/v1"
 	apierrors "k8s.io/apimachinery/pkg/util/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
 
@@ -54,6 +55,13 @@ type gateway struct {
 	watchFactory *factory.WatchFactory // used for retry
 	stopChan     <-chan struct{}
 	wg           *sync.WaitGroup
+
+	nodeName string
+	kube     kube.Interface
+	// uplinksLock protects the gateway uplinks applied to the gateway and their bridges, by uplink name
+	uplinksLock   sync.Mutex
+	uplinks       map[string]util.GatewayUplink
+	uplinkBridges map[string]*bridgeConfiguration
 }
 
 func (g *gateway) AddService(svc *kapi.Service) error {
@@ -261,18 +269,32 @@ func setupUDPAggregationUplink(ifname string) error {
 	return nil
 }
 
-func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops []net.IP, gwIPs []*net.IPNet, nodeAnnotator kube.Annotator) (
-	*bridgeConfiguration, *bridgeConfiguration, error) {
+// gatewayInitInternal sets up the bridges of the gateway and publishes them in the l3-gateway-config annotation. It
+// returns the bridge of the gateway interface, and the external bridges: the bridge of the egress gateway interface,
+// if any, followed by the bridges of the gateway uplinks.
+func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, uplinks map[string]util.GatewayUplink,
+	gwNextHops []net.IP, gwIPs []*net.IPNet, nodeAnnotator kube.Annotator) (*bridgeConfiguration, []*bridgeConfiguration, error) {
 	gatewayBridge, err := bridgeForInterface(gwIntf, nodeName, types.PhysicalNetworkName, gwIPs)
 	if err != nil {
 		return nil, nil, errors.Wrapf(err, "Bridge for interface failed for %s", gwIntf)
 	}
 	var egressGWBridge *bridgeConfiguration
+	var externalBridges []*bridgeConfiguration
 	if egressGatewayIntf != "" {
 		egressGWBridge, err = bridgeForInterface(egressGatewayIntf, nodeName, types.PhysicalNetworkExGwName, nil)
 		if err != nil {
 			return nil, nil, errors.Wrapf(err, "Bridge for interface failed for %s", egressGatewayIntf)
 		}
+		externalBridges = append(externalBridges, egressGWBridge)
+	}
+	var l3GwUplinks []util.L3GatewayUplink
+	for _, name := range sets.List(sets.KeySet(uplinks)) {
+		uplinkBridge, err := gatewayUplinkBridge(nodeName, name, uplinks[name])
+		if err != nil {
+			return nil, nil, err
+		}
+		l3GwUplinks = append(l3GwUplinks, l3GatewayUplink(name, uplinks[name], uplinkBridge))
+		externalBridges = append(externalBridges, uplinkBridge)
 	}
 
 	chassisID, err := util.GetNodeChassisID()
@@ -323,8 +345,11 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 
 	if config.Default.EnableUDPAggregation {
 		err = setupUDPAggregationUplink(gatewayBridge.uplinkName)
-		if err == nil && egressGWBridge != nil {
-			err = setupUDPAggregationUplink(egressGWBridge.uplinkName)
+		for _, bridge := range externalBridges {
+			if err != nil {
+				break
+			}
+			err = setupUDPAggregationUplink(bridge.uplinkName)
 		}
 		if err != nil {
 			klog.Warningf("Could not enable UDP packet aggregation on uplink interface (aggregation will be disabled): %v", err)
@@ -341,6 +366,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 		NextHops:       gwNextHops,
 		NodePortEnable: config.Gateway.NodeportEnable,
 		VLANID:         &config.Gateway.VLANID,
+		Uplinks:        l3GwUplinks,
 	}
 	if egressGWBridge != nil {
 		l3GwConfig.EgressGWInterfaceID = egressGWBridge.interfaceID
@@ -349,7 +375,7 @@ func gatewayInitInternal(nodeName, gwIntf, egressGatewayIntf string, gwNextHops
 	}
 
 	err = util.SetL3GatewayConfig(nodeAnnotator, &l3GwConfig)
-	return gatewayBridge, egressGWBridge, err
+	return gatewayBridge, externalBridges, err
 }
 
 func gatewayReady(patchPort string) (bool, error) {
@@ -432,6 +458,8 @@ type bridgeConfiguration struct {
 	ofPortPatch string
 	ofPortPhys  string
 	ofPortHost  string
+	// gatewayUplink is the name of the gateway uplink of the bridge, if any
+	gatewayUplink string
 }
 
 // updateInterfaceIPAddresses sets and returns the bridge's current ips
@@ -41,6 +41,16 @@ func bridgedGatewayNodeSetup(nodeName, bridgeName, physicalNetworkName string) (
 		}
 	}
 
+	if err := setBridgeMappings(bridgeName, physicalNetworkName); err != nil {
+		return "", err
+	}
+
+	ifaceID := bridgeName + "_" + nodeName
+	return ifaceID, nil
+}
+
+// setBridgeMappings maps the physical network names to the local ovs bridge in ovn-bridge-mappings
+func setBridgeMappings(bridgeName string, physicalNetworkNames ...string) error {
 	// ovn-bridge-mappings maps a physical network name to a local ovs bridge
 	// that provides connectivity to that network. It is in the form of physnet1:br1,physnet2:br2.
 	// Note that there may be multiple ovs bridge mappings, be sure not to override
@@ -48,34 +58,58 @@ func bridgedGatewayNodeSetup(nodeName, bridgeName, physicalNetworkName string) (
 	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
 		"external_ids:ovn-bridge-mappings")
 	if err != nil {
-		return "", fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
+		return fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
 	}
-	// skip the existing mapping setting for the specified physicalNetworkName
+	// skip the existing mapping setting for the specified physicalNetworkNames
 	mapString := ""
 	bridgeMappings := strings.Split(stdout, ",")
 	for _, bridgeMapping := range bridgeMappings {
 		m := strings.Split(bridgeMapping, ":")
-		if network := m[0]; network != physicalNetworkName {
+		if network := m[0]; !util.SliceHasStringItem(physicalNetworkNames, network) {
 			if len(mapString) != 0 {
 				mapString += ","
 			}
 			mapString += bridgeMapping
 		}
 	}
-	if len(mapString) != 0 {
-		mapString += ","
+	for _, physicalNetworkName := range physicalNetworkNames {
+		if len(mapString) != 0 {
+			mapString += ","
+		}
+		mapString += physicalNetworkName + ":" + bridgeName
 	}
-	mapString += physicalNetworkName + ":" + bridgeName
 
 	_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".",
 		fmt.Sprintf("external_ids:ovn-bridge-mappings=%s", mapString))
 	if err != nil {
-		return "", fmt.Errorf("failed to set ovn-bridge-mappings for ovs bridge %s"+
+		return fmt.Errorf("failed to set ovn-bridge-mappings for ovs bridge %s"+
 			", stderr:%s (%v)", bridgeName, stderr, err)
 	}
+	return nil
+}
 
-	ifaceID := bridgeName + "_" + nodeName
-	return ifaceID, nil
+// deleteBridgeMappings removes the physical network names from ovn-bridge-mappings
+func deleteBridgeMappings(physicalNetworkNames ...string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Open_vSwitch", ".",
+		"external_ids:ovn-bridge-mappings")
+	if err != nil {
+		return fmt.Errorf("failed to get ovn-bridge-mappings stderr:%s (%v)", stderr, err)
+	}
+	var bridgeMappings []string
+	for _, bridgeMapping := range strings.Split(stdout, ",") {
+		m := strings.Split(bridgeMapping, ":")
+		if network := m[0]; len(bridgeMapping) > 0 && !util.SliceHasStringItem(physicalNetworkNames, network) {
+			bridgeMappings = append(bridgeMappings, bridgeMapping)
+		}
+	}
+
+	_, stderr, err = util.RunOVSVsctl("set", "Open_vSwitch", ".",
+		fmt.Sprintf("external_ids:ovn-bridge-mappings=%s", strings.Join(bridgeMappings, ",")))
+	if err != nil {
+		return fmt.Errorf("failed to delete ovn-bridge-mappings of physical networks %v"+
+			", stderr:%s (%v)", physicalNetworkNames, stderr, err)
+	}
+	return nil
 }
 
 // getNetworkInterfaceIPAddresses returns the IP addresses for the network interface 'iface'.
@@ -346,6 +380,15 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 		egressGWInterface = interfaceForEXGW(config.Gateway.EgressGWInterface)
 	}
 
+	node, err := nc.watchFactory.GetNode(nc.name)
+	if err != nil {
+		return err
+	}
+	uplinks, err := util.ParseNodeGatewayUplinks(node)
+	if err != nil {
+		return err
+	}
+
 	ifAddrs, err = getNetworkInterfaceIPAddresses(gatewayIntf)
 	if err != nil {
 		return err
@@ -368,12 +411,12 @@ func (nc *DefaultNodeNetworkController) initGateway(subnets []*net.IPNet, nodeAn
 	switch config.Gateway.Mode {
 	case config.GatewayModeLocal:
 		klog.Info("Preparing Local Gateway")
-		gw, err = newLocalGateway(nc.name, subnets, gatewayNextHops, gatewayIntf, egressGWInterface, ifAddrs, nodeAnnotator,
-			managementPortConfig, nc.Kube, nc.watchFactory, nc.routeManager)
+		gw, err = newLocalGateway(nc.name, subnets, gatewayNextHops, gatewayIntf, egressGWInterface, uplinks, ifAddrs,
+			nodeAnnotator, managementPortConfig, nc.Kube, nc.watchFactory, nc.routeManager)
 	case config.GatewayModeShared:
 		klog.Info("Preparing Shared Gateway")
-		gw, err = newSharedGateway(nc.name, subnets, gatewayNextHops, gatewayIntf, egressGWInterface, ifAddrs, nodeAnnotator, nc.Kube,
-			managementPortConfig, nc.watchFactory, nc.routeManager)
+		gw, err = newSharedGateway(nc.name, subnets, gatewayNextHops, gatewayIntf, egressGWInterface, uplinks, ifAddrs,
+			nodeAnnotator, nc.Kube, managementPortConfig, nc.watchFactory, nc.routeManager)
 	case config.GatewayModeDisabled:
 		var chassisID string
 		klog.Info("Gateway Mode is disabled")
@@ -20,7 +20,8 @@ import (
 	utilnet "k8s.io/utils/net"
 )
 
-func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net.IP, gwIntf, egressGWIntf string, gwIPs []*net.IPNet,
+func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net.IP, gwIntf, egressGWIntf string,
+	uplinks map[string]util.GatewayUplink, gwIPs []*net.IPNet,
 	nodeAnnotator kube.Annotator, cfg *managementPortConfig, kube kube.Interface, watchFactory factory.NodeWatchFactory,
 	routeManager *routemanager.Controller) (*gateway, error) {
 	klog.Info("Creating new local gateway")
@@ -44,11 +45,12 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 		}
 	}
 
-	gwBridge, exGwBridge, err := gatewayInitInternal(
-		nodeName, gwIntf, egressGWIntf, gwNextHops, gwIPs, nodeAnnotator)
+	gwBridge, externalBridges, err := gatewayInitInternal(
+		nodeName, gwIntf, egressGWIntf, uplinks, gwNextHops, gwIPs, nodeAnnotator)
 	if err != nil {
 		return nil, err
 	}
+	gw.initGatewayUplinks(nodeName, kube, uplinks, externalBridges)
 
 	// OCP HACK -- block MCS ports https://github.com/openshift/ovn-kubernetes/pull/170
 	if err := insertMCSBlockIptRules(); err != nil {
@@ -56,22 +58,17 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 	}
 	// END OCP HACK
 
-	if exGwBridge != nil {
-		gw.readyFunc = func() (bool, error) {
-			ready, err := gatewayReady(gwBridge.patchPort)
-			if err != nil {
-				return false, err
-			}
-			exGWReady, err := gatewayReady(exGwBridge.patchPort)
-			if err != nil {
+	gw.readyFunc = func() (bool, error) {
+		ready, err := gatewayReady(gwBridge.patchPort)
+		if err != nil || !ready {
+			return false, err
+		}
+		for _, bridge := range externalBridges {
+			if ready, err = gatewayReady(bridge.patchPort); err != nil || !ready {
 				return false, err
 			}
-			return ready && exGWReady, nil
-		}
-	} else {
-		gw.readyFunc = func() (bool, error) {
-			return gatewayReady(gwBridge.patchPort)
 		}
+		return true, nil
 	}
 
 	gw.initFunc = func() error {
@@ -80,14 +77,14 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 		if err != nil {
 			return err
 		}
-		if exGwBridge != nil {
-			err = setBridgeOfPorts(exGwBridge)
+		for _, bridge := range externalBridges {
+			err = setBridgeOfPorts(bridge)
 			if err != nil {
 				return err
 			}
 			if config.Gateway.DisableForwarding {
-				if err := initExternalBridgeDropForwardingRules(exGwBridge.bridgeName); err != nil {
-					return fmt.Errorf("failed to add forwarding block rules for bridge %s: err %v", exGwBridge.bridgeName, err)
+				if err := initExternalBridgeDropForwardingRules(bridge.bridgeName); err != nil {
+					return fmt.Errorf("failed to add forwarding block rules for bridge %s: err %v", bridge.bridgeName, err)
 				}
 			}
 		}
@@ -102,7 +99,7 @@ func newLocalGateway(nodeName string, hostSubnets []*net.IPNet, gwNextHops []net
 			return fmt.Errorf("failed to set the node masquerade route to OVN: %v", err)
 		}
 
-		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, exGwBridge, hostSubnets, gw.nodeIPManager.ListAddresses())
+		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, externalBridges, hostSubnets, gw.nodeIPManager.ListAddresses())
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,281 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/client-go/util/workqueue"
+	nodeutil "k8s.io/component-helpers/node/util"
+	"k8s.io/klog/v2"
+)
+
+// The gateway of a node is migrated to another gateway mode by stopping ovnkube for its container to be restarted in
+// the new mode. When it starts, the state of the gateway mode the node was programmed in, as published in its l3-gateway-config
+// annotation, is torn down before the gateway is initialized in the new mode. The progress of the migration is
+// reported with the GatewayModeMigration node condition.
+
+// The reasons of the GatewayModeMigration node condition
+const (
+	gatewayModeMigrationRequested     = "MigrationRequested"
+	gatewayModeMigrationReprogramming = "Reprogramming"
+	gatewayModeMigrationCompleted     = "Completed"
+	gatewayModeMigrationFailed        = "Failed"
+	gatewayModeMigrationInvalidMode   = "InvalidMode"
+	gatewayModeMigrationUnsupported   = "Unsupported"
+)
+
+var (
+	// nodeGatewayModeEnabled is set when the gateway mode requested with the k8s.ovn.org/gateway-mode annotation
+	// of the node overrides the configured gateway mode
+	nodeGatewayModeEnabled bool
+	// configuredGatewayMode is the gateway mode configured before the override
+	configuredGatewayMode config.GatewayMode
+	// stopOvnkube stops ovnkube for it to be restarted in the requested gateway mode
+	stopOvnkube context.CancelFunc
+)
+
+// ApplyNodeGatewayMode overrides the configured gateway mode with the one requested with the
+// k8s.ovn.org/gateway-mode annotation of the node, if any. As the gateway mode is also used by ovnkube-controller,
+// it must only be called when the ovnkube-controller of the zone of the node runs in the same process, before any
+// controller is started. stop cancels the context ovnkube runs with, ovnkube is stopped when another gateway mode is
+// requested.
+func ApplyNodeGatewayMode(kubeClient kubernetes.Interface, nodeName string, stop context.CancelFunc) error {
+	if config.Gateway.Mode == config.GatewayModeDisabled {
+		return nil
+	}
+	stopOvnkube = stop
+	node, err := kubeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+	if !nodeGatewayModeEnabled {
+		nodeGatewayModeEnabled = true
+		configuredGatewayMode = config.Gateway.Mode
+	}
+	mode, err := util.ParseNodeGatewayMode(node)
+	if err != nil {
+		// reported on the node by the gateway mode migrator
+		klog.Errorf("Ignoring the requested gateway mode: %v", err)
+		return nil
+	}
+	if mode != "" && mode != config.Gateway.Mode {
+		klog.Infof("Using the %s gateway mode requested on node %s instead of the configured %s gateway mode",
+			mode, nodeName, config.Gateway.Mode)
+		config.Gateway.Mode = mode
+	}
+	return nil
+}
+
+// gatewayModeMigrator stops ovnkube when another gateway mode is requested for the node, and reports the progress of
+// the migration
+type gatewayModeMigrator struct {
+	nodeName       string
+	kube           kube.Interface
+	watchFactory   factory.NodeWatchFactory
+	nodeController controller.Controller
+	// restart stops ovnkube, for it to be restarted in the requested gateway mode
+	restart func()
+}
+
+func newGatewayModeMigrator(nodeName string, kube kube.Interface, watchFactory factory.NodeWatchFactory) *gatewayModeMigrator {
+	m := &gatewayModeMigrator{
+		nodeName:     nodeName,
+		kube:         kube,
+		watchFactory: watchFactory,
+		restart:      stopOvnkube,
+	}
+	controllerConfig := &controller.Config[kapi.Node]{
+		RateLimiter:    workqueue.NewItemFastSlowRateLimiter(time.Second, 5*time.Second, 5),
+		Informer:       watchFactory.NodeInformer(),
+		Lister:         watchFactory.ListNodes,
+		ObjNeedsUpdate: m.needsUpdate,
+		Reconcile:      m.reconcileNode,
+	}
+	m.nodeController = controller.NewController[kapi.Node]("gateway_mode_migrator", controllerConfig)
+	return m
+}
+
+// teardownPreviousMode tears down the gateway state of the gateway mode the node was programmed in, if it changed.
+// Must be called before the gateway is initialized.
+func (m *gatewayModeMigrator) teardownPreviousMode(node *kapi.Node, mgmtPortConfig *managementPortConfig) error {
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		// the gateway of the node was never initialized
+		return nil
+	}
+	previousMode := l3GatewayConfig.Mode
+	if previousMode == config.Gateway.Mode || previousMode == config.GatewayModeDisabled ||
+		config.Gateway.Mode == config.GatewayModeDisabled {
+		return nil
+	}
+	klog.Infof("Migrating the gateway of node %s from the %s to the %s gateway mode", m.nodeName, previousMode,
+		config.Gateway.Mode)
+	m.reportCondition(kapi.ConditionTrue, gatewayModeMigrationReprogramming,
+		fmt.Sprintf("Migrating from the %s to the %s gateway mode", previousMode, config.Gateway.Mode))
+	if err := cleanupGatewayMode(previousMode, mgmtPortConfig); err != nil {
+		m.reportCondition(kapi.ConditionFalse, gatewayModeMigrationFailed,
+			fmt.Sprintf("Failed to tear down the %s gateway mode: %v", previousMode, err))
+		return fmt.Errorf("failed to tear down the %s gateway mode of node %s: %w", previousMode, m.nodeName, err)
+	}
+	return nil
+}
+
+// Start completes the migration ovnkube-node restarted for, and watches the requested gateway mode. Must be called
+// once the gateway is initialized.
+func (m *gatewayModeMigrator) Start() error {
+	if err := m.completeMigration(); err != nil {
+		return err
+	}
+	return m.nodeController.Start(1)
+}
+
+func (m *gatewayModeMigrator) Stop() {
+	m.nodeController.Stop()
+}
+
+func (m *gatewayModeMigrator) needsUpdate(oldNode, newNode *kapi.Node) bool {
+	if newNode == nil || newNode.Name != m.nodeName {
+		return false
+	}
+	return oldNode == nil || oldNode.Annotations[util.OvnNodeGatewayMode] != newNode.Annotations[util.OvnNodeGatewayMode]
+}
+
+func (m *gatewayModeMigrator) reconcileNode(nodeName string) error {
+	if nodeName != m.nodeName {
+		return nil
+	}
+	node, err := m.watchFactory.GetNode(nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	mode, err := util.ParseNodeGatewayMode(node)
+	if err != nil {
+		return m.setCondition(kapi.ConditionFalse, gatewayModeMigrationInvalidMode, err.Error())
+	}
+	if mode == "" {
+		if !nodeGatewayModeEnabled {
+			return nil
+		}
+		// back to the configured gateway mode
+		mode = configuredGatewayMode
+	}
+	if mode == config.Gateway.Mode {
+		return m.completeMigration()
+	}
+	if !nodeGatewayModeEnabled {
+		return m.setCondition(kapi.ConditionFalse, gatewayModeMigrationUnsupported,
+			fmt.Sprintf("The gateway mode can only be changed when ovnkube-controller runs in the same process "+
+				"as ovnkube-node with interconnect enabled, the node runs the %s gateway mode", config.Gateway.Mode))
+	}
+	if err := m.setCondition(kapi.ConditionTrue, gatewayModeMigrationRequested,
+		fmt.Sprintf("Restarting ovnkube-node to migrate from the %s to the %s gateway mode", config.Gateway.Mode,
+			mode)); err != nil {
+		return err
+	}
+	klog.Infof("Stopping ovnkube to migrate the gateway of node %s from the %s to the %s gateway mode",
+		m.nodeName, config.Gateway.Mode, mode)
+	// ovnkube shuts down once the context it runs with is cancelled
+	m.restart()
+	return nil
+}
+
+// completeMigration reports that the node runs the current gateway mode, if a migration was reported
+func (m *gatewayModeMigrator) completeMigration() error {
+	node, err := m.kube.GetNode(m.nodeName)
+	if err != nil {
+		return err
+	}
+	if _, condition := nodeutil.GetNodeCondition(&node.Status, types.GatewayModeMigrationCondition); condition == nil {
+		return nil
+	}
+	return m.setCondition(kapi.ConditionFalse, gatewayModeMigrationCompleted,
+		fmt.Sprintf("The node runs the %s gateway mode", config.Gateway.Mode))
+}
+
+// reportCondition sets the GatewayModeMigration node condition, logging the failures
+func (m *gatewayModeMigrator) reportCondition(status kapi.ConditionStatus, reason, message string) {
+	if err := m.setCondition(status, reason, message); err != nil {
+		klog.Errorf("Failed to report the gateway mode migration of node %s: %v", m.nodeName, err)
+	}
+}
+
+// setCondition sets the GatewayModeMigration node condition, if it changed
+func (m *gatewayModeMigrator) setCondition(status kapi.ConditionStatus, reason, message string) error {
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		node, err := m.kube.GetNode(m.nodeName)
+		if err != nil {
+			return err
+		}
+		node = node.DeepCopy()
+		now := metav1.Now()
+		condition := kapi.NodeCondition{
+			Type:               types.GatewayModeMigrationCondition,
+			Status:             status,
+			Reason:             reason,
+			Message:            message,
+			LastHeartbeatTime:  now,
+			LastTransitionTime: now,
+		}
+		i, existing := nodeutil.GetNodeCondition(&node.Status, types.GatewayModeMigrationCondition)
+		if existing == nil {
+			node.Status.Conditions = append(node.Status.Conditions, condition)
+			return m.kube.UpdateNodeStatus(node)
+		}
+		if existing.Status == status && existing.Reason == reason && existing.Message == message {
+			return nil
+		}
+		if existing.Status == status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		node.Status.Conditions[i] = condition
+		return m.kube.UpdateNodeStatus(node)
+	})
+}
+
+// cleanupGatewayMode tears down the gateway state programmed in the given gateway mode, with the cleanup paths used
+// when ovnkube-node is removed from the node. Only the patch ports to br-int are deleted: the gateway bridge and the
+// bridge mappings are left in place. Unlike CleanupClusterNode, the bridge mapped to the locnet physical network isn't
+// deleted, neither gateway mode creates it and it may be used by other workloads.
+func cleanupGatewayMode(mode config.GatewayMode, mgmtPortConfig *managementPortConfig) error {
+	if mode == config.GatewayModeLocal && mgmtPortConfig != nil {
+		if err := delLocalGatewayNATRules(mgmtPortConfig); err != nil {
+			return err
+		}
+	}
+	return cleanupSharedGateway()
+}
+
+// delLocalGatewayNATRules deletes the iptables rules added by newLocalGateway for the management port
+func delLocalGatewayNATRules(cfg *managementPortConfig) error {
+	var rules []nodeipt.Rule
+	for _, familyConfig := range []*managementPortIPFamilyConfig{cfg.ipv4, cfg.ipv6} {
+		if familyConfig == nil {
+			continue
+		}
+		cidrNet := &net.IPNet{IP: familyConfig.ifAddr.IP.Mask(familyConfig.ifAddr.Mask), Mask: familyConfig.ifAddr.Mask}
+		rules = append(rules, getLocalGatewayFilterRules(cfg.ifName, cidrNet)...)
+		rules = append(rules, getLocalGatewayNATRules(cfg.ifName, cidrNet)...)
+	}
+	return nodeipt.DelRules(rules)
+}
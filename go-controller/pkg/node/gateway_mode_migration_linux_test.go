@@ -0,0 +1,253 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	nodeutil "k8s.io/component-helpers/node/util"
+)
+
+var _ = Describe("Gateway mode migration", func() {
+	const nodeName = "node1"
+	var (
+		fakeClient   *fake.Clientset
+		watchFactory *factory.WatchFactory
+		migrator     *gatewayModeMigrator
+		restarted    bool
+	)
+
+	start := func(node *corev1.Node) {
+		var err error
+		fakeClient = fake.NewSimpleClientset(node)
+		watchFactory, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFactory.Start()).To(Succeed())
+		migrator = newGatewayModeMigrator(nodeName, &kube.Kube{KClient: fakeClient}, watchFactory)
+		migrator.restart = func() { restarted = true }
+	}
+
+	getCondition := func() *corev1.NodeCondition {
+		node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		_, condition := nodeutil.GetNodeCondition(&node.Status, types.GatewayModeMigrationCondition)
+		return condition
+	}
+
+	newNode := func(mode string, conditions ...corev1.NodeCondition) *corev1.Node {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+			Status:     corev1.NodeStatus{Conditions: conditions},
+		}
+		if mode != "" {
+			node.Annotations = map[string]string{util.OvnNodeGatewayMode: mode}
+		}
+		return node
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Gateway.Mode = config.GatewayModeShared
+		restarted = false
+	})
+
+	AfterEach(func() {
+		watchFactory.Shutdown()
+		watchFactory = nil
+		nodeGatewayModeEnabled = false
+		configuredGatewayMode = ""
+		stopOvnkube = nil
+	})
+
+	It("restarts ovnkube-node when another gateway mode is requested", func() {
+		nodeGatewayModeEnabled = true
+		configuredGatewayMode = config.GatewayModeShared
+		start(newNode("local"))
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeTrue())
+		condition := getCondition()
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(corev1.ConditionTrue))
+		Expect(condition.Reason).To(Equal(gatewayModeMigrationRequested))
+	})
+
+	It("restarts ovnkube-node when the requested gateway mode is removed", func() {
+		nodeGatewayModeEnabled = true
+		configuredGatewayMode = config.GatewayModeLocal
+		start(newNode(""))
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeTrue())
+	})
+
+	It("reports the migration as unsupported when the gateway mode can't be overridden", func() {
+		start(newNode("local"))
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeFalse())
+		Expect(getCondition().Reason).To(Equal(gatewayModeMigrationUnsupported))
+	})
+
+	It("reports an invalid gateway mode", func() {
+		nodeGatewayModeEnabled = true
+		start(newNode("bogus"))
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeFalse())
+		condition := getCondition()
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(gatewayModeMigrationInvalidMode))
+	})
+
+	It("completes the migration once the requested gateway mode runs", func() {
+		nodeGatewayModeEnabled = true
+		config.Gateway.Mode = config.GatewayModeLocal
+		start(newNode("local", corev1.NodeCondition{
+			Type:   types.GatewayModeMigrationCondition,
+			Status: corev1.ConditionTrue,
+			Reason: gatewayModeMigrationReprogramming,
+		}))
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeFalse())
+		condition := getCondition()
+		Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		Expect(condition.Reason).To(Equal(gatewayModeMigrationCompleted))
+	})
+
+	It("does not report anything when no gateway mode is requested", func() {
+		start(newNode(""))
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeFalse())
+		Expect(getCondition()).To(BeNil())
+	})
+
+	It("migrates the gateway of a node from the shared to the local gateway mode and back", func() {
+		fexec := ovntest.NewLooseCompareFakeExec()
+		for i := 0; i < 2; i++ {
+			// only the patch port of the gateway bridge is deleted, the bridge mapped to locnet is left in place
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --columns=name --no-heading find port external_ids:ovn-localnet-port!=_",
+				Output: "patch-breth0_node1-to-br-int",
+			})
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-vsctl --timeout=15 --if-exists del-port patch-breth0_node1-to-br-int",
+			})
+			fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+				Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+				Output: "physnet:breth0,locnet:br-local",
+			})
+			fexec.AddFakeCmdsNoOutputNoError([]string{
+				"ovs-ofctl -O OpenFlow13 replace-flows breth0 -",
+			})
+		}
+		Expect(util.SetExec(fexec)).To(Succeed())
+		iptV4, _ := util.SetFakeIPTablesHelpers()
+
+		_, mgmtPortSubnet, err := net.ParseCIDR("10.1.1.0/24")
+		Expect(err).NotTo(HaveOccurred())
+		mgmtPortConfig := &managementPortConfig{
+			ifName: types.K8sMgmtIntfName,
+			ipv4: &managementPortIPFamilyConfig{
+				ifAddr: &net.IPNet{IP: net.ParseIP("10.1.1.2"), Mask: mgmtPortSubnet.Mask},
+			},
+		}
+		localGatewayRules := append(getLocalGatewayFilterRules(types.K8sMgmtIntfName, mgmtPortSubnet),
+			getLocalGatewayNATRules(types.K8sMgmtIntfName, mgmtPortSubnet)...)
+
+		// setL3GatewayMode publishes the gateway mode the node is programmed in, as the gateway initialization does
+		setL3GatewayMode := func(node *corev1.Node, mode config.GatewayMode) {
+			node.Annotations[util.OvnNodeChassisID] = "chassis1"
+			node.Annotations[util.OvnNodeL3GatewayConfig] = fmt.Sprintf(`{"default":{"mode":"%s",`+
+				`"interface-id":"breth0_node1","mac-address":"00:00:00:55:66:77","ip-addresses":["172.18.0.2/16"],`+
+				`"next-hops":["172.18.0.1"],"node-port-enable":"true"}}`, mode)
+		}
+		getNode := func() *corev1.Node {
+			node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return node
+		}
+		updateNode := func(node *corev1.Node) {
+			_, err := fakeClient.CoreV1().Nodes().Update(context.TODO(), node, metav1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(func() string {
+				node, err := watchFactory.GetNode(nodeName)
+				Expect(err).NotTo(HaveOccurred())
+				return node.Annotations[util.OvnNodeGatewayMode]
+			}).Should(Equal(node.Annotations[util.OvnNodeGatewayMode]))
+		}
+		// restartNode starts ovnkube-node with the configured shared gateway mode, tearing down the gateway mode
+		// the node was programmed in and initializing the gateway in the requested mode
+		restartNode := func(node *corev1.Node) {
+			if watchFactory != nil {
+				watchFactory.Shutdown()
+			}
+			config.Gateway.Mode = config.GatewayModeShared
+			nodeGatewayModeEnabled = false
+			start(node)
+			Expect(ApplyNodeGatewayMode(fakeClient, nodeName, func() { restarted = true })).To(Succeed())
+			migrator.restart = stopOvnkube
+			restarted = false
+			Expect(migrator.teardownPreviousMode(node, mgmtPortConfig)).To(Succeed())
+			node = getNode()
+			setL3GatewayMode(node, config.Gateway.Mode)
+			updateNode(node)
+			Expect(migrator.completeMigration()).To(Succeed())
+			if config.Gateway.Mode == config.GatewayModeLocal {
+				Expect(nodeipt.AddRules(localGatewayRules, true)).To(Succeed())
+			}
+		}
+
+		node := newNode("")
+		node.Annotations = map[string]string{}
+		setL3GatewayMode(node, config.GatewayModeShared)
+		restartNode(node)
+		Expect(getCondition()).To(BeNil())
+
+		By("requesting the local gateway mode")
+		node = getNode()
+		node.Annotations[util.OvnNodeGatewayMode] = string(config.GatewayModeLocal)
+		updateNode(node)
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeTrue())
+		Expect(getCondition().Reason).To(Equal(gatewayModeMigrationRequested))
+
+		restartNode(getNode())
+		Expect(config.Gateway.Mode).To(Equal(config.GatewayModeLocal))
+		Expect(getCondition().Reason).To(Equal(gatewayModeMigrationCompleted))
+		for _, rule := range localGatewayRules {
+			exists, err := iptV4.Exists(rule.Table, rule.Chain, rule.Args...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeTrue())
+		}
+
+		By("removing the requested gateway mode")
+		node = getNode()
+		delete(node.Annotations, util.OvnNodeGatewayMode)
+		updateNode(node)
+		Expect(migrator.reconcileNode(nodeName)).To(Succeed())
+		Expect(restarted).To(BeTrue())
+		Expect(getCondition().Reason).To(Equal(gatewayModeMigrationRequested))
+
+		restartNode(getNode())
+		Expect(config.Gateway.Mode).To(Equal(config.GatewayModeShared))
+		Expect(getCondition().Reason).To(Equal(gatewayModeMigrationCompleted))
+		// the rules of the management port are deleted with the local gateway mode
+		for _, rule := range localGatewayRules {
+			exists, err := iptV4.Exists(rule.Table, rule.Chain, rule.Args...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(exists).To(BeFalse())
+		}
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+})
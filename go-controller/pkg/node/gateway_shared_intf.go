@@ -1330,6 +1330,15 @@ func flowsForDefaultBridge(bridge *bridgeConfiguration, extraIPs []net.IP) ([]st
 	return dftFlows, nil
 }
 
+// uplinkSNAT returns the conntrack action SNATing the traffic of pods to the IP of the bridge of a gateway uplink, as
+// the gateway router SNATs it to the node IP whatever the gateway port it egresses through
+func uplinkSNAT(bridge *bridgeConfiguration, bridgeIP *net.IPNet) string {
+	if bridge.gatewayUplink == "" {
+		return ""
+	}
+	return fmt.Sprintf("nat(src=%s), ", bridgeIP.IP)
+}
+
 func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, error) {
 	// CAUTION: when adding new flows where the in_port is ofPortPatch and the out_port is ofPortPhys, ensure
 	// that dl_src is included in match criteria!
@@ -1378,9 +1387,9 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 			// so that reverse direction goes back to the pods.
 			dftFlows = append(dftFlows,
 				fmt.Sprintf("cookie=%s, priority=100, in_port=%s, dl_src=%s, ip, "+
-					"actions=ct(commit, zone=%d, exec(set_field:%s->ct_mark)), output:%s",
+					"actions=ct(commit, zone=%d, %sexec(set_field:%s->ct_mark)), output:%s",
 					defaultOpenFlowCookie, ofPortPatch, bridgeMacAddress, config.Default.ConntrackZone,
-					ctMarkOVN, ofPortPhys))
+					uplinkSNAT(bridge, physicalIP), ctMarkOVN, ofPortPhys))
 
 			// table 0, packets coming from host Commit connections with ct_mark ctMarkHost
 			// so that reverse direction goes back to the host.
@@ -1441,8 +1450,9 @@ func commonFlows(subnets []*net.IPNet, bridge *bridgeConfiguration) ([]string, e
 			// so that reverse direction goes back to the pods.
 			dftFlows = append(dftFlows,
 				fmt.Sprintf("cookie=%s, priority=100, in_port=%s, dl_src=%s, ipv6, "+
-					"actions=ct(commit, zone=%d, exec(set_field:%s->ct_mark)), output:%s",
-					defaultOpenFlowCookie, ofPortPatch, bridgeMacAddress, config.Default.ConntrackZone, ctMarkOVN, ofPortPhys))
+					"actions=ct(commit, zone=%d, %sexec(set_field:%s->ct_mark)), output:%s",
+					defaultOpenFlowCookie, ofPortPatch, bridgeMacAddress, config.Default.ConntrackZone,
+					uplinkSNAT(bridge, physicalIP), ctMarkOVN, ofPortPhys))
 
 			// table 0, packets coming from host. Commit connections with ct_mark ctMarkHost
 			// so that reverse direction goes back to the host.
@@ -1681,33 +1691,29 @@ func initSvcViaMgmPortRoutingRules(hostSubnets []*net.IPNet) error {
 }
 
 func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP, gwIntf, egressGWIntf string,
-	gwIPs []*net.IPNet, nodeAnnotator kube.Annotator, kube kube.Interface, cfg *managementPortConfig,
+	uplinks map[string]util.GatewayUplink, gwIPs []*net.IPNet, nodeAnnotator kube.Annotator, kube kube.Interface, cfg *managementPortConfig,
 	watchFactory factory.NodeWatchFactory, routeManager *routemanager.Controller) (*gateway, error) {
 	klog.Info("Creating new shared gateway")
 	gw := &gateway{}
 
-	gwBridge, exGwBridge, err := gatewayInitInternal(
-		nodeName, gwIntf, egressGWIntf, gwNextHops, gwIPs, nodeAnnotator)
+	gwBridge, externalBridges, err := gatewayInitInternal(
+		nodeName, gwIntf, egressGWIntf, uplinks, gwNextHops, gwIPs, nodeAnnotator)
 	if err != nil {
 		return nil, err
 	}
+	gw.initGatewayUplinks(nodeName, kube, uplinks, externalBridges)
 
-	if exGwBridge != nil {
-		gw.readyFunc = func() (bool, error) {
-			ready, err := gatewayReady(gwBridge.patchPort)
-			if err != nil {
-				return false, err
-			}
-			exGWReady, err := gatewayReady(exGwBridge.patchPort)
-			if err != nil {
+	gw.readyFunc = func() (bool, error) {
+		ready, err := gatewayReady(gwBridge.patchPort)
+		if err != nil || !ready {
+			return false, err
+		}
+		for _, bridge := range externalBridges {
+			if ready, err = gatewayReady(bridge.patchPort); err != nil || !ready {
 				return false, err
 			}
-			return ready && exGWReady, nil
-		}
-	} else {
-		gw.readyFunc = func() (bool, error) {
-			return gatewayReady(gwBridge.patchPort)
 		}
+		return true, nil
 	}
 
 	// OCP HACK -- block MCS ports https://github.com/openshift/ovn-kubernetes/pull/170
@@ -1724,14 +1730,14 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 		if err != nil {
 			return err
 		}
-		if exGwBridge != nil {
-			err = setBridgeOfPorts(exGwBridge)
+		for _, bridge := range externalBridges {
+			err = setBridgeOfPorts(bridge)
 			if err != nil {
 				return err
 			}
 			if config.Gateway.DisableForwarding {
-				if err := initExternalBridgeDropForwardingRules(exGwBridge.bridgeName); err != nil {
-					return fmt.Errorf("failed to add forwarding block rules for bridge %s: err %v", exGwBridge.bridgeName, err)
+				if err := initExternalBridgeDropForwardingRules(bridge.bridgeName); err != nil {
+					return fmt.Errorf("failed to add forwarding block rules for bridge %s: err %v", bridge.bridgeName, err)
 				}
 			}
 		}
@@ -1748,7 +1754,7 @@ func newSharedGateway(nodeName string, subnets []*net.IPNet, gwNextHops []net.IP
 			}
 		}
 
-		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, exGwBridge, subnets, nodeIPs)
+		gw.openflowManager, err = newGatewayOpenFlowManager(gwBridge, externalBridges, subnets, nodeIPs)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,300 @@
+package node
+
+import (
+	"fmt"
+	"net"
+	"reflect"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/controller"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"github.com/pkg/errors"
+
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+)
+
+// The gateway uplinks requested with the k8s.ovn.org/gateway-uplinks annotation of the node are set up when the
+// gateway is initialized. The changes of the annotation are then applied by the gateway uplink controller: the bridges
+// of the removed uplinks are torn down, the bridges of the new uplinks are set up and the uplinks are published in the
+// l3-gateway-config annotation for ovnkube-controller to connect them to the gateway router. The flows of the bridge
+// of a new uplink are programmed once ovn-controller created its patch port.
+
+// gatewayUplinkBridge sets up the bridge of a gateway uplink and maps the localnet secondary networks of the uplink
+// to it
+func gatewayUplinkBridge(nodeName, name string, uplink util.GatewayUplink) (*bridgeConfiguration, error) {
+	uplinkBridge, err := bridgeForInterface(uplink.Interface, nodeName, types.GatewayUplinkPrefix+name, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Bridge for interface failed for uplink %s %s", name, uplink.Interface)
+	}
+	uplinkBridge.gatewayUplink = name
+	if len(uplink.Networks) > 0 {
+		if err := setBridgeMappings(uplinkBridge.bridgeName, uplink.Networks...); err != nil {
+			return nil, err
+		}
+	}
+	return uplinkBridge, nil
+}
+
+// l3GatewayUplink returns the gateway uplink as published in the l3-gateway-config annotation
+func l3GatewayUplink(name string, uplink util.GatewayUplink, bridge *bridgeConfiguration) util.L3GatewayUplink {
+	l3GwUplink := util.L3GatewayUplink{
+		Name:        name,
+		InterfaceID: bridge.interfaceID,
+		MACAddress:  bridge.macAddress,
+		IPAddresses: bridge.ips,
+		Namespaces:  uplink.Namespaces,
+	}
+	for _, nextHop := range uplink.NextHops {
+		l3GwUplink.NextHops = append(l3GwUplink.NextHops, net.ParseIP(nextHop))
+	}
+	return l3GwUplink
+}
+
+// teardownGatewayUplinkBridge removes the bridge mappings of a gateway uplink, for ovn-controller to delete its patch
+// port, and moves its interface back out of the bridge if the bridge was created for it. A bridge that existed before
+// is left with the NORMAL action only.
+func teardownGatewayUplinkBridge(name string, uplink util.GatewayUplink, bridge *bridgeConfiguration) error {
+	if err := deleteBridgeMappings(append([]string{types.GatewayUplinkPrefix + name}, uplink.Networks...)...); err != nil {
+		return err
+	}
+	if config.Gateway.DisableForwarding {
+		if err := nodeipt.DelRules(getGatewayDropRules(bridge.bridgeName)); err != nil {
+			return fmt.Errorf("failed to delete forwarding block rules for bridge %s: %w", bridge.bridgeName, err)
+		}
+	}
+	if bridge.uplinkName == uplink.Interface && bridge.bridgeName == util.GetBridgeName(uplink.Interface) {
+		// the bridge was created by NicToBridge for the interface of the uplink
+		if err := util.BridgeToNic(bridge.bridgeName); err != nil {
+			return fmt.Errorf("failed to move bridge %s back to %s: %w", bridge.bridgeName, uplink.Interface, err)
+		}
+		return nil
+	}
+	_, stderr, err := util.ReplaceOFFlows(bridge.bridgeName,
+		[]string{fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)})
+	if err != nil {
+		return fmt.Errorf("failed to reset the flows of bridge %s, stderr: %s: %w", bridge.bridgeName, stderr, err)
+	}
+	return nil
+}
+
+// initGatewayUplinks records the gateway uplinks set up when the gateway was initialized
+func (g *gateway) initGatewayUplinks(nodeName string, kube kube.Interface, uplinks map[string]util.GatewayUplink,
+	externalBridges []*bridgeConfiguration) {
+	g.nodeName = nodeName
+	g.kube = kube
+	g.uplinks = make(map[string]util.GatewayUplink, len(uplinks))
+	for name, uplink := range uplinks {
+		g.uplinks[name] = uplink
+	}
+	g.uplinkBridges = make(map[string]*bridgeConfiguration, len(uplinks))
+	for _, bridge := range externalBridges {
+		if bridge.gatewayUplink != "" {
+			g.uplinkBridges[bridge.gatewayUplink] = bridge
+		}
+	}
+}
+
+// reconcileGatewayUplinks applies the gateway uplinks requested for the node: the uplinks that were removed or moved
+// to another interface are torn down, the new uplinks are set up and published in the l3-gateway-config annotation,
+// and the flows of their bridges are programmed once ovn-controller created their patch port. An error is returned
+// while a patch port is missing for the uplinks to be reconciled again.
+func (g *gateway) reconcileGatewayUplinks(uplinks map[string]util.GatewayUplink) error {
+	g.uplinksLock.Lock()
+	defer g.uplinksLock.Unlock()
+
+	changed := false
+	for _, name := range sets.List(sets.KeySet(g.uplinks)) {
+		applied := g.uplinks[name]
+		if uplink, ok := uplinks[name]; ok && uplink.Interface == applied.Interface {
+			continue
+		}
+		klog.Infof("Removing gateway uplink %s of interface %s", name, applied.Interface)
+		bridge := g.uplinkBridges[name]
+		g.openflowManager.deleteExternalBridge(bridge)
+		if err := teardownGatewayUplinkBridge(name, applied, bridge); err != nil {
+			return fmt.Errorf("failed to remove gateway uplink %s: %w", name, err)
+		}
+		delete(g.uplinks, name)
+		delete(g.uplinkBridges, name)
+		changed = true
+	}
+
+	for _, name := range sets.List(sets.KeySet(uplinks)) {
+		uplink := uplinks[name]
+		applied, ok := g.uplinks[name]
+		if ok && reflect.DeepEqual(uplink, applied) {
+			continue
+		}
+		if ok {
+			// same interface, the localnet secondary networks mapped to the bridge may have changed
+			if stale := sets.New(applied.Networks...).Delete(uplink.Networks...); stale.Len() > 0 {
+				if err := deleteBridgeMappings(sets.List(stale)...); err != nil {
+					return err
+				}
+			}
+			if len(uplink.Networks) > 0 {
+				if err := setBridgeMappings(g.uplinkBridges[name].bridgeName, uplink.Networks...); err != nil {
+					return err
+				}
+			}
+		} else {
+			klog.Infof("Adding gateway uplink %s of interface %s", name, uplink.Interface)
+			bridge, err := gatewayUplinkBridge(g.nodeName, name, uplink)
+			if err != nil {
+				return err
+			}
+			g.uplinkBridges[name] = bridge
+		}
+		g.uplinks[name] = uplink
+		changed = true
+	}
+
+	if changed {
+		if err := g.publishGatewayUplinks(); err != nil {
+			return err
+		}
+	}
+
+	var pending []string
+	for _, name := range sets.List(sets.KeySet(g.uplinkBridges)) {
+		bridge := g.uplinkBridges[name]
+		if g.openflowManager.hasExternalBridge(bridge) {
+			continue
+		}
+		ready, err := gatewayReady(bridge.patchPort)
+		if err != nil {
+			return err
+		}
+		if !ready {
+			pending = append(pending, name)
+			continue
+		}
+		if err := g.addGatewayUplinkFlows(bridge); err != nil {
+			return fmt.Errorf("failed to program the flows of gateway uplink %s: %w", name, err)
+		}
+		changed = true
+	}
+	if changed {
+		g.openflowManager.requestFlowSync()
+	}
+	if len(pending) > 0 {
+		return fmt.Errorf("waiting for ovn-controller to create the patch ports of gateway uplinks %v", pending)
+	}
+	return nil
+}
+
+// addGatewayUplinkFlows starts managing the flows of the bridge of a gateway uplink set up after the gateway was
+// initialized
+func (g *gateway) addGatewayUplinkFlows(bridge *bridgeConfiguration) error {
+	node, err := g.watchFactory.GetNode(g.nodeName)
+	if err != nil {
+		return err
+	}
+	subnets, err := util.ParseNodeHostSubnetAnnotation(node, types.DefaultNetworkName)
+	if err != nil {
+		return fmt.Errorf("failed to get subnets for node: %s for OpenFlow cache update", node.Name)
+	}
+	if err := setBridgeOfPorts(bridge); err != nil {
+		return err
+	}
+	if config.Gateway.DisableForwarding {
+		if err := initExternalBridgeDropForwardingRules(bridge.bridgeName); err != nil {
+			return fmt.Errorf("failed to add forwarding block rules for bridge %s: err %v", bridge.bridgeName, err)
+		}
+	}
+	return g.openflowManager.addExternalBridge(bridge, subnets)
+}
+
+// publishGatewayUplinks sets the gateway uplinks in the l3-gateway-config annotation of the node
+func (g *gateway) publishGatewayUplinks() error {
+	node, err := g.watchFactory.GetNode(g.nodeName)
+	if err != nil {
+		return err
+	}
+	l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+	if err != nil {
+		return err
+	}
+	l3GatewayConfig.Uplinks = nil
+	for _, name := range sets.List(sets.KeySet(g.uplinks)) {
+		l3GatewayConfig.Uplinks = append(l3GatewayConfig.Uplinks,
+			l3GatewayUplink(name, g.uplinks[name], g.uplinkBridges[name]))
+	}
+	nodeAnnotator := kube.NewNodeAnnotator(g.kube, g.nodeName)
+	if err := util.SetL3GatewayConfig(nodeAnnotator, l3GatewayConfig); err != nil {
+		return err
+	}
+	return nodeAnnotator.Run()
+}
+
+// gatewayUplinkController applies the changes of the gateway uplinks annotation of the node to its gateway
+type gatewayUplinkController struct {
+	nodeName       string
+	watchFactory   factory.NodeWatchFactory
+	gateway        *gateway
+	nodeController controller.Controller
+}
+
+func newGatewayUplinkController(nodeName string, watchFactory factory.NodeWatchFactory,
+	gw *gateway) *gatewayUplinkController {
+	c := &gatewayUplinkController{
+		nodeName:     nodeName,
+		watchFactory: watchFactory,
+		gateway:      gw,
+	}
+	controllerConfig := &controller.Config[kapi.Node]{
+		RateLimiter:    workqueue.NewItemFastSlowRateLimiter(time.Second, 5*time.Second, 5),
+		Informer:       watchFactory.NodeInformer(),
+		Lister:         watchFactory.ListNodes,
+		ObjNeedsUpdate: c.needsUpdate,
+		Reconcile:      c.reconcileNode,
+	}
+	c.nodeController = controller.NewController[kapi.Node]("gateway_uplinks", controllerConfig)
+	return c
+}
+
+// Start watches the gateway uplinks of the node. Must be called once the gateway is initialized.
+func (c *gatewayUplinkController) Start() error {
+	return c.nodeController.Start(1)
+}
+
+func (c *gatewayUplinkController) Stop() {
+	c.nodeController.Stop()
+}
+
+func (c *gatewayUplinkController) needsUpdate(oldNode, newNode *kapi.Node) bool {
+	if newNode == nil || newNode.Name != c.nodeName {
+		return false
+	}
+	return oldNode == nil ||
+		oldNode.Annotations[util.OvnNodeGatewayUplinks] != newNode.Annotations[util.OvnNodeGatewayUplinks]
+}
+
+func (c *gatewayUplinkController) reconcileNode(nodeName string) error {
+	if nodeName != c.nodeName {
+		return nil
+	}
+	node, err := c.watchFactory.GetNode(nodeName)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	uplinks, err := util.ParseNodeGatewayUplinks(node)
+	if err != nil {
+		// retrying won't help until the annotation is fixed
+		klog.Errorf("Ignoring the gateway uplinks of node %s: %v", nodeName, err)
+		return nil
+	}
+	return c.gateway.reconcileGatewayUplinks(uplinks)
+}
@@ -0,0 +1,104 @@
+package node
+
+import (
+	"context"
+	"sync"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Gateway uplinks", func() {
+	const nodeName = "node1"
+	var (
+		fakeClient   *fake.Clientset
+		watchFactory *factory.WatchFactory
+		fexec        *ovntest.FakeExec
+	)
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.Gateway.Mode = config.GatewayModeShared
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		watchFactory.Shutdown()
+		watchFactory = nil
+	})
+
+	It("tears down a removed gateway uplink and unpublishes it", func() {
+		uplink := util.GatewayUplink{Interface: "breth2", Namespaces: []string{"database"}}
+		bridge := &bridgeConfiguration{
+			bridgeName:    "breth2",
+			uplinkName:    "eth2",
+			interfaceID:   "breth2_" + nodeName,
+			macAddress:    ovntest.MustParseMAC("11:22:33:44:55:77"),
+			ips:           ovntest.MustParseIPNets("192.168.100.10/24"),
+			patchPort:     "patch-breth2_" + nodeName + "-to-br-int",
+			gatewayUplink: "storage",
+		}
+
+		fakeClient = fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+		nodeAnnotator := kube.NewNodeAnnotator(&kube.Kube{KClient: fakeClient}, nodeName)
+		Expect(util.SetL3GatewayConfig(nodeAnnotator, &util.L3GatewayConfig{
+			Mode:        config.GatewayModeShared,
+			ChassisID:   "SYSTEM-ID",
+			InterfaceID: "breth0_" + nodeName,
+			MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:66"),
+			IPAddresses: ovntest.MustParseIPNets("169.254.33.2/24"),
+			NextHops:    ovntest.MustParseIPs("169.254.33.1"),
+			Uplinks:     []util.L3GatewayUplink{l3GatewayUplink("storage", uplink, bridge)},
+		})).To(Succeed())
+		Expect(nodeAnnotator.Run()).To(Succeed())
+		var err error
+		watchFactory, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{KubeClient: fakeClient}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFactory.Start()).To(Succeed())
+
+		gw := &gateway{
+			watchFactory: watchFactory,
+			openflowManager: &openflowManager{
+				externalBridges:    []*bridgeConfiguration{bridge},
+				exBridgeFlowCaches: map[string]map[string][]string{"breth2": {}},
+				exBridgeFlowMutex:  sync.Mutex{},
+				flowChan:           make(chan struct{}, 1),
+			},
+		}
+		gw.initGatewayUplinks(nodeName, &kube.Kube{KClient: fakeClient},
+			map[string]util.GatewayUplink{"storage": uplink}, []*bridgeConfiguration{bridge})
+
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --if-exists get Open_vSwitch . external_ids:ovn-bridge-mappings",
+			Output: "physnet:breth0,uplink-storage:breth2",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 set Open_vSwitch . external_ids:ovn-bridge-mappings=physnet:breth0",
+			// breth2 existed before, it is left with the NORMAL action only
+			"ovs-ofctl -O OpenFlow13 --bundle replace-flows breth2 -",
+		})
+
+		Expect(gw.reconcileGatewayUplinks(map[string]util.GatewayUplink{})).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		Expect(gw.uplinks).To(BeEmpty())
+		Expect(gw.openflowManager.getExternalBridges()).To(BeEmpty())
+		Expect(gw.openflowManager.exBridgeFlowCaches).NotTo(HaveKey("breth2"))
+
+		node, err := fakeClient.CoreV1().Nodes().Get(context.TODO(), nodeName, metav1.GetOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		l3GatewayConfig, err := util.ParseNodeL3GatewayAnnotation(node)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(l3GatewayConfig.Uplinks).To(BeEmpty())
+	})
+})
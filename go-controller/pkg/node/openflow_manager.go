@@ -18,13 +18,15 @@ import (
 )
 
 type openflowManager struct {
-	defaultBridge         *bridgeConfiguration
-	externalGatewayBridge *bridgeConfiguration
+	defaultBridge *bridgeConfiguration
+	// externalBridges are the bridges of the egress gateway interface and of the gateway uplinks
+	externalBridges []*bridgeConfiguration
 	// flow cache, use map instead of array for readability when debugging
-	flowCache     map[string][]string
-	flowMutex     sync.Mutex
-	exGWFlowCache map[string][]string
-	exGWFlowMutex sync.Mutex
+	flowCache map[string][]string
+	flowMutex sync.Mutex
+	// flow caches of the external bridges, by bridge name
+	exBridgeFlowCaches map[string]map[string][]string
+	exBridgeFlowMutex  sync.Mutex
 	// channel to indicate we need to update flows immediately
 	flowChan chan struct{}
 }
@@ -36,11 +38,10 @@ func (c *openflowManager) getDefaultBridgePorts() (string, string, string, strin
 		c.defaultBridge.uplinkName, c.defaultBridge.ofPortPhys
 }
 
-func (c *openflowManager) getExGwBridgePorts() (string, string, string, string) {
-	c.externalGatewayBridge.Lock()
-	defer c.externalGatewayBridge.Unlock()
-	return c.externalGatewayBridge.patchPort, c.externalGatewayBridge.ofPortPatch,
-		c.externalGatewayBridge.uplinkName, c.externalGatewayBridge.ofPortPhys
+func getExternalBridgePorts(bridge *bridgeConfiguration) (string, string, string, string) {
+	bridge.Lock()
+	defer bridge.Unlock()
+	return bridge.patchPort, bridge.ofPortPatch, bridge.uplinkName, bridge.ofPortPhys
 }
 
 func (c *openflowManager) getDefaultBridgeName() string {
@@ -73,10 +74,56 @@ func (c *openflowManager) deleteFlowsByKey(key string) {
 	delete(c.flowCache, key)
 }
 
-func (c *openflowManager) updateExBridgeFlowCacheEntry(key string, flows []string) {
-	c.exGWFlowMutex.Lock()
-	defer c.exGWFlowMutex.Unlock()
-	c.exGWFlowCache[key] = flows
+func (c *openflowManager) updateExBridgeFlowCacheEntry(bridgeName, key string, flows []string) {
+	c.exBridgeFlowMutex.Lock()
+	defer c.exBridgeFlowMutex.Unlock()
+	if c.exBridgeFlowCaches[bridgeName] == nil {
+		c.exBridgeFlowCaches[bridgeName] = map[string][]string{}
+	}
+	c.exBridgeFlowCaches[bridgeName][key] = flows
+}
+
+// getExternalBridges returns the external bridges whose flows are managed
+func (c *openflowManager) getExternalBridges() []*bridgeConfiguration {
+	c.exBridgeFlowMutex.Lock()
+	defer c.exBridgeFlowMutex.Unlock()
+	return append([]*bridgeConfiguration{}, c.externalBridges...)
+}
+
+// hasExternalBridge tells if the flows of the external bridge are managed
+func (c *openflowManager) hasExternalBridge(bridge *bridgeConfiguration) bool {
+	c.exBridgeFlowMutex.Lock()
+	defer c.exBridgeFlowMutex.Unlock()
+	for _, exBridge := range c.externalBridges {
+		if exBridge == bridge {
+			return true
+		}
+	}
+	return false
+}
+
+// addExternalBridge starts managing the flows of an external bridge set up after the gateway was initialized
+func (c *openflowManager) addExternalBridge(bridge *bridgeConfiguration, subnets []*net.IPNet) error {
+	if err := c.updateExBridgeFlowCache(bridge, subnets); err != nil {
+		return err
+	}
+	c.exBridgeFlowMutex.Lock()
+	defer c.exBridgeFlowMutex.Unlock()
+	c.externalBridges = append(c.externalBridges, bridge)
+	return nil
+}
+
+// deleteExternalBridge stops managing the flows of an external bridge and drops its flow cache
+func (c *openflowManager) deleteExternalBridge(bridge *bridgeConfiguration) {
+	c.exBridgeFlowMutex.Lock()
+	defer c.exBridgeFlowMutex.Unlock()
+	for i, exBridge := range c.externalBridges {
+		if exBridge == bridge {
+			c.externalBridges = append(c.externalBridges[:i], c.externalBridges[i+1:]...)
+			break
+		}
+	}
+	delete(c.exBridgeFlowCaches, bridge.bridgeName)
 }
 
 func (c *openflowManager) requestFlowSync() {
@@ -106,22 +153,28 @@ func (c *openflowManager) syncFlows() {
 		klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.flowCache)
 	}
 
-	if c.externalGatewayBridge != nil {
-		c.externalGatewayBridge.Lock()
-		defer c.externalGatewayBridge.Unlock()
+	c.exBridgeFlowMutex.Lock()
+	defer c.exBridgeFlowMutex.Unlock()
+	for _, bridge := range c.externalBridges {
+		c.syncExBridgeFlows(bridge)
+	}
+}
 
-		c.exGWFlowMutex.Lock()
-		defer c.exGWFlowMutex.Unlock()
+// syncExBridgeFlows replaces the flows of an external bridge with its flow cache, must be called with
+// exBridgeFlowMutex
+func (c *openflowManager) syncExBridgeFlows(bridge *bridgeConfiguration) {
+	bridge.Lock()
+	defer bridge.Unlock()
 
-		flows := []string{}
-		for _, entry := range c.exGWFlowCache {
-			flows = append(flows, entry...)
-		}
+	flowCache := c.exBridgeFlowCaches[bridge.bridgeName]
+	flows := []string{}
+	for _, entry := range flowCache {
+		flows = append(flows, entry...)
+	}
 
-		_, stderr, err := util.ReplaceOFFlows(c.externalGatewayBridge.bridgeName, flows)
-		if err != nil {
-			klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, c.exGWFlowCache)
-		}
+	_, stderr, err := util.ReplaceOFFlows(bridge.bridgeName, flows)
+	if err != nil {
+		klog.Errorf("Failed to add flows, error: %v, stderr, %s, flows: %s", err, stderr, flowCache)
 	}
 }
 
@@ -133,16 +186,17 @@ func (c *openflowManager) syncFlows() {
 //
 // -- to handle host -> service access, via masquerading from the host to OVN GR
 // -- to handle external -> service(ExternalTrafficPolicy: Local) -> host access without SNAT
-func newGatewayOpenFlowManager(gwBridge, exGWBridge *bridgeConfiguration, subnets []*net.IPNet, extraIPs []net.IP) (*openflowManager, error) {
+func newGatewayOpenFlowManager(gwBridge *bridgeConfiguration, externalBridges []*bridgeConfiguration, subnets []*net.IPNet,
+	extraIPs []net.IP) (*openflowManager, error) {
 	// add health check function to check default OpenFlow flows are on the shared gateway bridge
 	ofm := &openflowManager{
-		defaultBridge:         gwBridge,
-		externalGatewayBridge: exGWBridge,
-		flowCache:             make(map[string][]string),
-		flowMutex:             sync.Mutex{},
-		exGWFlowCache:         make(map[string][]string),
-		exGWFlowMutex:         sync.Mutex{},
-		flowChan:              make(chan struct{}, 1),
+		defaultBridge:      gwBridge,
+		externalBridges:    externalBridges,
+		flowCache:          make(map[string][]string),
+		flowMutex:          sync.Mutex{},
+		exBridgeFlowCaches: make(map[string]map[string][]string),
+		exBridgeFlowMutex:  sync.Mutex{},
+		flowChan:           make(chan struct{}, 1),
 	}
 
 	if err := ofm.updateBridgeFlowCache(subnets, extraIPs); err != nil {
@@ -170,11 +224,9 @@ func (c *openflowManager) Run(stopChan <-chan struct{}, doneWg *sync.WaitGroup)
 					continue
 				}
 
-				if c.externalGatewayBridge != nil {
-					if err := checkPorts(c.getExGwBridgePorts()); err != nil {
-						klog.Errorf("Checkports failed %v", err)
-						continue
-					}
+				if err := c.checkExternalBridgePorts(); err != nil {
+					klog.Errorf("Checkports failed %v", err)
+					continue
 				}
 				c.syncFlows()
 			case <-c.flowChan:
@@ -210,16 +262,34 @@ func (c *openflowManager) updateBridgeFlowCache(subnets []*net.IPNet, extraIPs [
 	c.updateFlowCacheEntry("NORMAL", []string{fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)})
 	c.updateFlowCacheEntry("DEFAULT", dftFlows)
 
-	// we consume ex gw bridge flows only if that is enabled
-	if c.externalGatewayBridge != nil {
-		c.externalGatewayBridge.Lock()
-		defer c.externalGatewayBridge.Unlock()
-		c.updateExBridgeFlowCacheEntry("NORMAL", []string{fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)})
-		exGWBridgeDftFlows, err := commonFlows(subnets, c.externalGatewayBridge)
-		if err != nil {
+	for _, bridge := range c.getExternalBridges() {
+		if err := c.updateExBridgeFlowCache(bridge, subnets); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateExBridgeFlowCache generates the "static" flows of an external bridge
+func (c *openflowManager) updateExBridgeFlowCache(bridge *bridgeConfiguration, subnets []*net.IPNet) error {
+	bridge.Lock()
+	defer bridge.Unlock()
+	c.updateExBridgeFlowCacheEntry(bridge.bridgeName, "NORMAL",
+		[]string{fmt.Sprintf("table=0,priority=0,actions=%s\n", util.NormalAction)})
+	exBridgeDftFlows, err := commonFlows(subnets, bridge)
+	if err != nil {
+		return err
+	}
+	c.updateExBridgeFlowCacheEntry(bridge.bridgeName, "DEFAULT", exBridgeDftFlows)
+	return nil
+}
+
+// checkExternalBridgePorts checks the ports of the external bridges
+func (c *openflowManager) checkExternalBridgePorts() error {
+	for _, bridge := range c.getExternalBridges() {
+		if err := checkPorts(getExternalBridgePorts(bridge)); err != nil {
 			return err
 		}
-		c.updateExBridgeFlowCacheEntry("DEFAULT", exGWBridgeDftFlows)
 	}
 	return nil
 }
@@ -0,0 +1,269 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/coreos/go-iptables/iptables"
+	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	nodeipt "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node/iptables"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+const (
+	// secondaryManagementPortNetworkKey is the key of the external ID of the OVS interface of a
+	// secondary network's management port holding the name of the network
+	secondaryManagementPortNetworkKey = "ovn-network"
+	// secondaryManagementPortTableStart is the start of the IDs of the routing tables holding the
+	// routes to the subnets of the secondary networks through their management port, past the
+	// routing tables of the egress IPs that are indexed by interface index
+	secondaryManagementPortTableStart = 100000
+	// secondaryManagementPortRulePriority is the priority of the ip rules selecting the routing
+	// tables of the secondary networks, ahead of the ip rules of the egress IPs
+	secondaryManagementPortRulePriority = 5900
+)
+
+// secondaryManagementPortName returns the name of the management port of the network with the given ID
+func secondaryManagementPortName(networkID int) string {
+	return types.K8sMgmtIntfNamePrefix + strconv.Itoa(networkID)
+}
+
+// secondaryManagementPortTable returns the ID of the routing table of the network with the given
+// ID, which is also the mark of the connections entering the node through its management port
+func secondaryManagementPortTable(networkID int) int {
+	return secondaryManagementPortTableStart + networkID
+}
+
+// getSecondaryManagementPortIptRules returns the rules marking the connections entering the node
+// through the management port, and restoring the mark on their packets so that the replies are
+// routed back through the routing table of the network
+func getSecondaryManagementPortIptRules(ifName string, mark int, proto iptables.Protocol) []nodeipt.Rule {
+	return []nodeipt.Rule{
+		{
+			Table:    "mangle",
+			Chain:    "PREROUTING",
+			Args:     []string{"-i", ifName, "-j", "CONNMARK", "--set-mark", strconv.Itoa(mark)},
+			Protocol: proto,
+		},
+		{
+			Table:    "mangle",
+			Chain:    "PREROUTING",
+			Args:     []string{"-m", "connmark", "--mark", strconv.Itoa(mark), "-j", "MARK", "--set-mark", strconv.Itoa(mark)},
+			Protocol: proto,
+		},
+	}
+}
+
+// createSecondaryManagementPort creates the management port of a layer3 secondary network on
+// the node, plugged to the port the network controller created on the node switch, and routes
+// the network's subnets through it. Egress IP traffic of the network's pods reaches the node
+// through it, to be SNATed to the egress IP hosted by the node. The routes are in a routing
+// table of the network, selected by the mark of the connections entering the node through the
+// management port, so that the subnets of the secondary networks may overlap with each other.
+func createSecondaryManagementPort(nodeName string, netInfo util.NetInfo, networkID int, hostSubnets []*net.IPNet) error {
+	ifName := secondaryManagementPortName(networkID)
+	// the network controller derives the MAC address the same way
+	macAddress := util.IPAddrToHWAddr(util.GetNodeManagementIfAddr(hostSubnets[0]).IP)
+	stdout, stderr, err := util.RunOVSVsctl(
+		"--", "--may-exist", "add-port", "br-int", ifName,
+		"--", "set", "interface", ifName,
+		"type=internal", "mtu_request="+fmt.Sprintf("%d", netInfo.MTU()),
+		"mac="+strings.ReplaceAll(macAddress.String(), ":", "\\:"),
+		"external-ids:iface-id="+netInfo.GetNetworkScopedName(types.K8sPrefix+nodeName),
+		fmt.Sprintf("external-ids:%s=\"%s\"", secondaryManagementPortNetworkKey, netInfo.GetNetworkName()))
+	if err != nil {
+		return fmt.Errorf("failed to add port %s to br-int, stdout: %q, stderr: %q, error: %v", ifName, stdout, stderr, err)
+	}
+
+	link, err := util.LinkSetUp(ifName)
+	if err != nil {
+		return err
+	}
+	table := secondaryManagementPortTable(networkID)
+	for _, hostSubnet := range hostSubnets {
+		isIPv6 := utilnet.IsIPv6CIDR(hostSubnet)
+		mgmtIfAddr := util.GetNodeManagementIfAddr(hostSubnet)
+		exists, err := util.LinkAddrExist(link, mgmtIfAddr)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			// the route to the node subnet goes to the routing table of the network
+			if err := util.LinkAddrAdd(link, mgmtIfAddr, unix.IFA_F_NOPREFIXROUTE, 0, 0); err != nil {
+				return err
+			}
+		}
+		// the routes use the MTU of the port, updated with the MTU of the network
+		routes := []*netlink.Route{{
+			LinkIndex: link.Attrs().Index,
+			Dst:       hostSubnet,
+			Scope:     netlink.SCOPE_LINK,
+			Table:     table,
+		}}
+		gwIP := util.GetNodeGatewayIfAddr(hostSubnet).IP
+		for _, subnet := range netInfo.Subnets() {
+			if utilnet.IsIPv6CIDR(subnet.CIDR) != isIPv6 {
+				continue
+			}
+			routes = append(routes, &netlink.Route{
+				LinkIndex: link.Attrs().Index,
+				Dst:       subnet.CIDR,
+				Gw:        gwIP,
+				Table:     table,
+			})
+		}
+		for _, route := range routes {
+			if err := util.GetNetLinkOps().RouteReplace(route); err != nil {
+				return fmt.Errorf("failed to add route to %s in table %d: %v", route.Dst, table, err)
+			}
+		}
+
+		family, proto := netlink.FAMILY_V4, iptables.ProtocolIPv4
+		if isIPv6 {
+			family, proto = netlink.FAMILY_V6, iptables.ProtocolIPv6
+		}
+		if err := ensureSecondaryManagementPortRule(table, family); err != nil {
+			return err
+		}
+		if err := appendIptRules(getSecondaryManagementPortIptRules(ifName, table, proto)); err != nil {
+			return fmt.Errorf("failed to mark the connections of management port %s: %v", ifName, err)
+		}
+
+		sysctlFamily := "ipv4"
+		if isIPv6 {
+			sysctlFamily = "ipv6"
+		}
+		stdout, stderr, err := util.RunSysctl("-w", fmt.Sprintf("net.%s.conf.%s.forwarding=1", sysctlFamily, ifName))
+		if err != nil || stdout != fmt.Sprintf("net.%s.conf.%s.forwarding = 1", sysctlFamily, ifName) {
+			return fmt.Errorf("could not set the correct forwarding value for interface %s: stdout: %v, stderr: %v, err: %v",
+				ifName, stdout, stderr, err)
+		}
+		if !isIPv6 {
+			// the reverse path filter looks the pod IPs up with the mark of their connections
+			stdout, stderr, err := util.RunSysctl("-w", fmt.Sprintf("net.ipv4.conf.%s.src_valid_mark=1", ifName))
+			if err != nil || stdout != fmt.Sprintf("net.ipv4.conf.%s.src_valid_mark = 1", ifName) {
+				return fmt.Errorf("could not set the correct src_valid_mark value for interface %s: stdout: %v, stderr: %v, err: %v",
+					ifName, stdout, stderr, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ensureSecondaryManagementPortRule ensures the ip rule selecting the routing table of a secondary
+// network for the connections marked with its ID
+func ensureSecondaryManagementPortRule(table, family int) error {
+	rules, err := util.GetNetLinkOps().RuleListFiltered(family, &netlink.Rule{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return fmt.Errorf("failed to list the ip rules of table %d: %v", table, err)
+	}
+	for _, rule := range rules {
+		if rule.Mark == table && rule.Priority == secondaryManagementPortRulePriority {
+			return nil
+		}
+	}
+	rule := netlink.NewRule()
+	rule.Family = family
+	rule.Table = table
+	rule.Mark = table
+	rule.Priority = secondaryManagementPortRulePriority
+	if err := netlink.RuleAdd(rule); err != nil {
+		return fmt.Errorf("failed to add ip rule %s: %v", rule, err)
+	}
+	return nil
+}
+
+// deleteSecondaryManagementPortRouting deletes the ip rules and iptables rules of the management
+// port, its routes are deleted along with the interface
+func deleteSecondaryManagementPortRouting(ifName string) error {
+	networkID, err := strconv.Atoi(strings.TrimPrefix(ifName, types.K8sMgmtIntfNamePrefix))
+	if err != nil {
+		return fmt.Errorf("failed to get the network ID of management port %s: %v", ifName, err)
+	}
+	table := secondaryManagementPortTable(networkID)
+	var errs []error
+	rules, err := util.GetNetLinkOps().RuleListFiltered(netlink.FAMILY_ALL, &netlink.Rule{Table: table}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("failed to list the ip rules of table %d: %v", table, err))
+	}
+	for i := range rules {
+		if err := netlink.RuleDel(&rules[i]); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete ip rule %s: %v", rules[i], err))
+		}
+	}
+	for _, proto := range clusterIPTablesProtocols() {
+		if err := nodeipt.DelRules(getSecondaryManagementPortIptRules(ifName, table, proto)); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// deleteSecondaryManagementPorts deletes the management ports of the network from the node
+func deleteSecondaryManagementPorts(netName string) error {
+	stdout, stderr, err := util.RunOVSVsctl("--no-headings", "--data=bare", "--columns=name", "find", "interface",
+		fmt.Sprintf("external-ids:%s=\"%s\"", secondaryManagementPortNetworkKey, netName))
+	if err != nil {
+		return fmt.Errorf("failed to find the management ports of network %s, stderr: %q, error: %v", netName, stderr, err)
+	}
+	for _, ifName := range strings.Fields(stdout) {
+		if _, stderr, err := util.RunOVSVsctl("--if-exists", "del-port", "br-int", ifName); err != nil {
+			return fmt.Errorf("failed to delete management port %s of network %s, stderr: %q, error: %v",
+				ifName, netName, stderr, err)
+		}
+		if err := deleteSecondaryManagementPortRouting(ifName); err != nil {
+			return fmt.Errorf("failed to delete the routing of management port %s of network %s: %v", ifName, netName, err)
+		}
+	}
+	return nil
+}
+
+// CleanupDeletedSecondaryManagementPorts deletes the management ports of the networks that
+// are not in networks, deleted while ovnkube-node was down
+func CleanupDeletedSecondaryManagementPorts(networks sets.Set[string]) error {
+	if !config.OVNKubernetesFeature.EnableEgressIP || config.OvnKubeNode.Mode != types.NodeModeFull {
+		return nil
+	}
+	stdout, stderr, err := util.RunOVSVsctl("--no-headings", "--data=bare", "--format=csv",
+		"--columns=name,external_ids", "list", "interface")
+	if err != nil {
+		return fmt.Errorf("failed to find the management ports of secondary networks, stderr: %q, error: %v", stderr, err)
+	}
+	records, err := util.ReadOVSCSV(stdout, 2)
+	if err != nil {
+		return fmt.Errorf("failed to parse the management ports of secondary networks %q: %v", stdout, err)
+	}
+	var errs []error
+	for _, record := range records {
+		ifName := record[0]
+		netName := util.ParseBareMap(record[1])[secondaryManagementPortNetworkKey]
+		if netName == "" || networks.Has(netName) {
+			continue
+		}
+		klog.Infof("Deleting management port %s of deleted network %s", ifName, netName)
+		if _, stderr, err := util.RunOVSVsctl("--if-exists", "del-port", "br-int", ifName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete management port %s of network %s, stderr: %q, error: %v",
+				ifName, netName, stderr, err))
+			continue
+		}
+		if err := deleteSecondaryManagementPortRouting(ifName); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete the routing of management port %s of network %s: %v",
+				ifName, netName, err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
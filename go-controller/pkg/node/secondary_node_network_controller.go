@@ -2,11 +2,19 @@ package node
 
 import (
 	"context"
+	"fmt"
 	"sync"
+	"time"
 
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 
+	"k8s.io/apimachinery/pkg/labels"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/cache"
 	"k8s.io/klog/v2"
 )
 
@@ -16,6 +24,15 @@ type SecondaryNodeNetworkController struct {
 	BaseNodeNetworkController
 	// pod events factory handler
 	podHandler *factory.Handler
+
+	// egress IP events handler, requesting a sync of the management port of the network
+	egressIPHandler cache.ResourceEventHandlerRegistration
+	// managementPortSync holds a pending request to sync the management port of the network
+	managementPortSync chan struct{}
+	// managementPortLock serializes the changes of the management port of the network
+	managementPortLock sync.Mutex
+	// hasManagementPort is whether the node has a management port for the network
+	hasManagementPort bool
 }
 
 // NewSecondaryNodeNetworkController creates a new OVN controller for creating logical network
@@ -28,20 +45,155 @@ func NewSecondaryNodeNetworkController(cnnci *CommonNodeNetworkControllerInfo, n
 			stopChan:                        make(chan struct{}),
 			wg:                              &sync.WaitGroup{},
 		},
+		managementPortSync: make(chan struct{}, 1),
 	}
 }
 
 // Start starts the default controller; handles all events and creates all needed logical entities
 func (nc *SecondaryNodeNetworkController) Start(ctx context.Context) error {
 	klog.Infof("Start secondary node network controller of network %s", nc.GetNetworkName())
-	handler, err := nc.watchPodsDPU()
+	if config.OvnKubeNode.Mode == types.NodeModeDPU {
+		handler, err := nc.watchPodsDPU()
+		if err != nil {
+			return err
+		}
+		nc.podHandler = handler
+	}
+	if mayNeedSecondaryManagementPort(nc.NetInfo) {
+		if err := nc.watchEgressIPs(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mayNeedSecondaryManagementPort returns whether the node may need a management port for the
+// network: the egress IPs of layer3 secondary networks hosted by the node are SNATed by the
+// node to the interfaces hosting them.
+func mayNeedSecondaryManagementPort(netInfo util.NetInfo) bool {
+	return config.OVNKubernetesFeature.EnableEgressIP && config.OvnKubeNode.Mode == types.NodeModeFull &&
+		netInfo.TopologyType() == types.Layer3Topology
+}
+
+// watchEgressIPs syncs the management port of the network on the egress IP events: the node
+// has one while an egress IP references the network.
+func (nc *SecondaryNodeNetworkController) watchEgressIPs(ctx context.Context) error {
+	var err error
+	nc.egressIPHandler, err = nc.watchFactory.EgressIPInformer().Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			nc.requestManagementPortSync()
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if oldObj.(*egressipv1.EgressIP).Spec.Network != newObj.(*egressipv1.EgressIP).Spec.Network {
+				nc.requestManagementPortSync()
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			nc.requestManagementPortSync()
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to watch the egress IPs of network %s: %w", nc.GetNetworkName(), err)
+	}
+
+	// a management port may be left over from a previous run while no egress IP references
+	// the network anymore
+	nc.hasManagementPort = true
+	nc.requestManagementPortSync()
+
+	ctx, cancel := context.WithCancel(ctx)
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		defer cancel()
+		select {
+		case <-nc.stopChan:
+		case <-ctx.Done():
+		}
+	}()
+	nc.wg.Add(1)
+	go func() {
+		defer nc.wg.Done()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-nc.managementPortSync:
+			}
+			// the node subnet and the ID of the network are allocated by the cluster manager
+			if err := nc.syncManagementPort(); err != nil {
+				klog.Warningf("Failed to sync the management port of network %s, will retry: %v", nc.GetNetworkName(), err)
+				time.AfterFunc(5*time.Second, nc.requestManagementPortSync)
+			}
+		}
+	}()
+	return nil
+}
+
+// requestManagementPortSync requests a sync of the management port of the network, unless
+// one is already pending
+func (nc *SecondaryNodeNetworkController) requestManagementPortSync() {
+	select {
+	case nc.managementPortSync <- struct{}{}:
+	default:
+	}
+}
+
+// syncManagementPort creates the management port of the network if an egress IP references
+// the network, and deletes it otherwise.
+func (nc *SecondaryNodeNetworkController) syncManagementPort() error {
+	egressIPs, err := nc.watchFactory.EgressIPInformer().Lister().List(labels.Everything())
 	if err != nil {
 		return err
 	}
-	nc.podHandler = handler
+	hasEgressIP := false
+	for _, egressIP := range egressIPs {
+		if egressIP.Spec.Network != "" && nc.HasNAD(egressIP.Spec.Network) {
+			hasEgressIP = true
+			break
+		}
+	}
+
+	nc.managementPortLock.Lock()
+	defer nc.managementPortLock.Unlock()
+	if hasEgressIP == nc.hasManagementPort {
+		return nil
+	}
+	if !hasEgressIP {
+		klog.Infof("Deleting the management port of network %s, which has no egress IP", nc.GetNetworkName())
+		if err := deleteSecondaryManagementPorts(nc.GetNetworkName()); err != nil {
+			return err
+		}
+		nc.hasManagementPort = false
+		return nil
+	}
+	klog.Infof("Creating the management port of network %s for its egress IPs", nc.GetNetworkName())
+	if err := nc.createManagementPort(); err != nil {
+		return err
+	}
+	nc.hasManagementPort = true
 	return nil
 }
 
+func (nc *SecondaryNodeNetworkController) createManagementPort() error {
+	node, err := nc.watchFactory.GetNode(nc.name)
+	if err != nil {
+		return err
+	}
+	networkID, err := util.ParseNetworkIDAnnotation(node, nc.GetNetworkName())
+	if err != nil {
+		return err
+	}
+	hostSubnets, err := util.ParseNodeHostSubnetAnnotation(node, nc.GetNetworkName())
+	if err != nil {
+		return err
+	}
+	if len(hostSubnets) == 0 {
+		return fmt.Errorf("node %s has no subnet for network %s", nc.name, nc.GetNetworkName())
+	}
+	return createSecondaryManagementPort(nc.name, nc.NetInfo, networkID, hostSubnets)
+}
+
 // Stop gracefully stops the controller
 func (nc *SecondaryNodeNetworkController) Stop() {
 	klog.Infof("Stop secondary node network controller of network %s", nc.GetNetworkName())
@@ -51,9 +203,47 @@ func (nc *SecondaryNodeNetworkController) Stop() {
 	if nc.podHandler != nil {
 		nc.watchFactory.RemovePodHandler(nc.podHandler)
 	}
+	if nc.egressIPHandler != nil {
+		if err := nc.watchFactory.EgressIPInformer().Informer().RemoveEventHandler(nc.egressIPHandler); err != nil {
+			klog.Errorf("Failed to remove the egress IP handler of network %s: %v", nc.GetNetworkName(), err)
+		}
+	}
+}
+
+// Reconfigure applies the changes of the network configuration that can be
+// applied live: the interfaces of the running pods and the management port of
+// the network get the new MTU and the routes to the subnets added to the
+// network.
+func (nc *SecondaryNodeNetworkController) Reconfigure(netInfo util.BasicNetInfo) error {
+	klog.Infof("Reconfigure secondary node network controller of network %s", nc.GetNetworkName())
+	interfacesChanged := nc.MTU() != netInfo.MTU() || len(nc.Subnets()) != len(netInfo.Subnets())
+	if err := util.UpdateNetInfo(nc.NetInfo, netInfo); err != nil {
+		return err
+	}
+	// the network may have gained or lost the network attachment definition of an egress IP
+	nc.requestManagementPortSync()
+	if !interfacesChanged || config.OvnKubeNode.Mode != types.NodeModeFull {
+		return nil
+	}
+
+	var errs []error
+	if err := reconfigurePodInterfaces(nc.NetInfo); err != nil {
+		errs = append(errs, err)
+	}
+	nc.managementPortLock.Lock()
+	defer nc.managementPortLock.Unlock()
+	if nc.hasManagementPort {
+		if err := nc.createManagementPort(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
 }
 
 // Cleanup cleans up node entities for the given secondary network
 func (nc *SecondaryNodeNetworkController) Cleanup(netName string) error {
+	if mayNeedSecondaryManagementPort(nc.NetInfo) {
+		return deleteSecondaryManagementPorts(netName)
+	}
 	return nil
 }
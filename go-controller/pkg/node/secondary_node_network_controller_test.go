@@ -0,0 +1,162 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/coreos/go-iptables/iptables"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/vishvananda/netlink"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	egressipv1fake "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1/apis/clientset/versioned/fake"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	utilMocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var _ = Describe("Secondary node network controller management port", func() {
+	const (
+		nodeName = "node1"
+		netName  = "l3-net"
+		nadName  = "blue/l3-network"
+	)
+	var (
+		watchFactory *factory.WatchFactory
+		fexec        *ovntest.FakeExec
+		nc           *SecondaryNodeNetworkController
+	)
+
+	start := func(egressIPs ...egressipv1.EgressIP) {
+		node := &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}}
+		var err error
+		watchFactory, err = factory.NewNodeWatchFactory(&util.OVNNodeClientset{
+			KubeClient:     fake.NewSimpleClientset(node),
+			EgressIPClient: egressipv1fake.NewSimpleClientset(&egressipv1.EgressIPList{Items: egressIPs}),
+		}, nodeName)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(watchFactory.Start()).To(Succeed())
+
+		netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: netName, Type: "ovn-k8s-cni-overlay"},
+			Topology: types.Layer3Topology,
+			NADName:  nadName,
+			Subnets:  "10.1.0.0/16/24",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		netInfo.AddNAD(nadName)
+		cnnci := newCommonNodeNetworkControllerInfo(nil, nil, nil, watchFactory, nil, nodeName)
+		nc = NewSecondaryNodeNetworkController(cnnci, netInfo)
+	}
+
+	newEgressIP := func(name, network string) egressipv1.EgressIP {
+		return egressipv1.EgressIP{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: egressipv1.EgressIPSpec{
+				EgressIPs: []string{"172.18.0.33"},
+				Network:   network,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		Expect(config.PrepareTestConfig()).To(Succeed())
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		fexec = ovntest.NewFakeExec()
+		Expect(util.SetExec(fexec)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		watchFactory.Shutdown()
+	})
+
+	It("deletes the management port of a network without egress IPs", func() {
+		start(newEgressIP("egressip-default", ""), newEgressIP("egressip-red", "red/l3-network"))
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd:    "ovs-vsctl --timeout=15 --no-headings --data=bare --columns=name find interface external-ids:ovn-network=\"" + netName + "\"",
+			Output: "ovn-k8s-mp3",
+		})
+		fexec.AddFakeCmdsNoOutputNoError([]string{
+			"ovs-vsctl --timeout=15 --if-exists del-port br-int ovn-k8s-mp3",
+		})
+		netlinkOpsMock := new(utilMocks.NetLinkOps)
+		netlinkOpsMock.On("RuleListFiltered", netlink.FAMILY_ALL, &netlink.Rule{Table: 100003}, uint64(netlink.RT_FILTER_TABLE)).
+			Return(nil, nil)
+		origNetlinkOps := util.GetNetLinkOps()
+		util.SetNetLinkOpMockInst(netlinkOpsMock)
+		defer util.SetNetLinkOpMockInst(origNetlinkOps)
+		iptV4, _ := util.SetFakeIPTablesHelpers()
+		Expect(iptV4.NewChain("mangle", "PREROUTING")).To(Succeed())
+		for _, rule := range getSecondaryManagementPortIptRules("ovn-k8s-mp3", 100003, iptables.ProtocolIPv4) {
+			Expect(iptV4.Append(rule.Table, rule.Chain, rule.Args...)).To(Succeed())
+		}
+
+		nc.hasManagementPort = true
+		Expect(nc.syncManagementPort()).To(Succeed())
+		Expect(nc.hasManagementPort).To(BeFalse())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+		rules, err := iptV4.List("mangle", "PREROUTING")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(rules).To(BeEmpty())
+
+		// nothing to do once the management port is deleted
+		Expect(nc.syncManagementPort()).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("creates the management port of a network with an egress IP", func() {
+		start(newEgressIP("egressip-blue", nadName))
+		// the cluster manager did not allocate the ID and the subnet of the network on the node yet
+		Expect(nc.syncManagementPort()).NotTo(Succeed())
+		Expect(nc.hasManagementPort).To(BeFalse())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+		// an existing management port is kept
+		nc.hasManagementPort = true
+		Expect(nc.syncManagementPort()).To(Succeed())
+		Expect(nc.hasManagementPort).To(BeTrue())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+
+	It("applies an MTU change to the pod interfaces of the network", func() {
+		start()
+		fexec.AddFakeCmd(&ovntest.ExpectedCmd{
+			Cmd: "ovs-vsctl --timeout=15 --no-headings --data=bare --columns=name find Interface external_ids:" +
+				types.NetworkExternalID + "=\"" + netName + "\"",
+		})
+		netInfo, err := util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: netName, Type: "ovn-k8s-cni-overlay"},
+			Topology: types.Layer3Topology,
+			NADName:  nadName,
+			Subnets:  "10.1.0.0/16/24",
+			MTU:      9000,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nc.Reconfigure(netInfo)).To(Succeed())
+		Expect(nc.MTU()).To(Equal(9000))
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+
+		// nothing to apply to the interfaces when only exclude subnets change
+		netInfo, err = util.NewNetInfo(&ovncnitypes.NetConf{
+			NetConf:        cnitypes.NetConf{Name: netName, Type: "ovn-k8s-cni-overlay"},
+			Topology:       types.Layer3Topology,
+			NADName:        nadName,
+			Subnets:        "10.1.0.0/16/24",
+			ExcludeSubnets: "10.1.0.1/32",
+			MTU:            9000,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(nc.Reconfigure(netInfo)).To(Succeed())
+		Expect(fexec.CalledMatchesExpected()).To(BeTrue(), fexec.ErrorDesc)
+	})
+})
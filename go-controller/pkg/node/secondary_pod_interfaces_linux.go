@@ -0,0 +1,156 @@
+//go:build linux
+// +build linux
+
+package node
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/containernetworking/plugins/pkg/ns"
+	"github.com/vishvananda/netlink"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// podNetnsDir is where the container runtimes bind mount the network namespaces of the pods
+const podNetnsDir = "/var/run/netns"
+
+// reconfigurePodInterfaces applies the MTU of the network to the veth pairs of the pods of the
+// network running on the node, and gives the pods of a layer3 network the routes to all the
+// subnets of the network. The pod interfaces backed by VFs or SFs keep their MTU until the pods
+// are recreated.
+func reconfigurePodInterfaces(netInfo util.NetInfo) error {
+	stdout, stderr, err := util.RunOVSVsctl("--no-headings", "--data=bare", "--columns=name", "find", "Interface",
+		fmt.Sprintf("external_ids:%s=\"%s\"", types.NetworkExternalID, netInfo.GetNetworkName()))
+	if err != nil {
+		return fmt.Errorf("failed to find the pod interfaces of network %s, stderr: %q, error: %v",
+			netInfo.GetNetworkName(), stderr, err)
+	}
+	hostIfaces := strings.Fields(stdout)
+	if len(hostIfaces) == 0 {
+		return nil
+	}
+	netnsPaths, err := getPodNetnsPaths()
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, hostIface := range hostIfaces {
+		if err := reconfigurePodInterface(netInfo, hostIface, netnsPaths); err != nil {
+			errs = append(errs, fmt.Errorf("failed to reconfigure pod interface %s of network %s: %w",
+				hostIface, netInfo.GetNetworkName(), err))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// getPodNetnsPaths returns the paths of the network namespaces of the pods by their ID in the
+// network namespace of ovnkube-node, the ID the veth pairs of the pods refer to their peer's
+// network namespace with
+func getPodNetnsPaths() (map[int]string, error) {
+	entries, err := os.ReadDir(podNetnsDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list the network namespaces in %s: %w", podNetnsDir, err)
+	}
+	netnsPaths := make(map[int]string, len(entries))
+	for _, entry := range entries {
+		path := filepath.Join(podNetnsDir, entry.Name())
+		netns, err := os.Open(path)
+		if err != nil {
+			klog.V(5).Infof("Failed to open network namespace %s: %v", path, err)
+			continue
+		}
+		id, err := netlink.GetNetNsIdByFd(int(netns.Fd()))
+		netns.Close()
+		if err != nil || id < 0 {
+			// no veth pair of the node is in this network namespace
+			continue
+		}
+		netnsPaths[id] = path
+	}
+	return netnsPaths, nil
+}
+
+func reconfigurePodInterface(netInfo util.NetInfo, hostIface string, netnsPaths map[int]string) error {
+	link, err := util.GetNetLinkOps().LinkByName(hostIface)
+	if err != nil {
+		if util.GetNetLinkOps().IsLinkNotFoundError(err) {
+			// the pod is being deleted
+			return nil
+		}
+		return err
+	}
+	veth, ok := link.(*netlink.Veth)
+	if !ok {
+		klog.Infof("Pod interface %s of network %s is not a veth pair, it keeps its MTU until its pod is recreated",
+			hostIface, netInfo.GetNetworkName())
+		return nil
+	}
+	peerIndex, err := netlink.VethPeerIndex(veth)
+	if err != nil {
+		return fmt.Errorf("failed to get the peer of the veth: %w", err)
+	}
+	netnsPath, ok := netnsPaths[link.Attrs().NetNsID]
+	if !ok {
+		return fmt.Errorf("failed to find the network namespace of the peer of the veth in %s", podNetnsDir)
+	}
+
+	var podIPs []*net.IPNet
+	if netInfo.TopologyType() == types.Layer3Topology {
+		stdout, stderr, err := util.RunOVSVsctl("--if-exists", "get", "Interface", hostIface, "external_ids:ip_addresses")
+		if err != nil {
+			return fmt.Errorf("failed to get the IPs of the pod, stderr: %q, error: %v", stderr, err)
+		}
+		if ipAddresses := strings.Trim(stdout, "\""); ipAddresses != "" {
+			podIPs, err = util.ParseIPNets(strings.Split(ipAddresses, ","))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	mtu := netInfo.MTU()
+	if link.Attrs().MTU != mtu {
+		if err := util.GetNetLinkOps().LinkSetMTU(link, mtu); err != nil {
+			return err
+		}
+	}
+	return ns.WithNetNSPath(netnsPath, func(ns.NetNS) error {
+		podLink, err := util.GetNetLinkOps().LinkByIndex(peerIndex)
+		if err != nil {
+			return err
+		}
+		if podLink.Attrs().MTU != mtu {
+			if err := util.GetNetLinkOps().LinkSetMTU(podLink, mtu); err != nil {
+				return err
+			}
+		}
+		// the pods of layer3 networks route the subnets of the network through the node switch
+		for _, podIP := range podIPs {
+			gwIP := util.GetNodeGatewayIfAddr(&net.IPNet{IP: podIP.IP.Mask(podIP.Mask), Mask: podIP.Mask}).IP
+			for _, subnet := range netInfo.Subnets() {
+				if utilnet.IsIPv6CIDR(subnet.CIDR) != utilnet.IsIPv6CIDR(podIP) {
+					continue
+				}
+				route := &netlink.Route{
+					LinkIndex: podLink.Attrs().Index,
+					Dst:       subnet.CIDR,
+					Gw:        gwIP,
+				}
+				if err := util.GetNetLinkOps().RouteReplace(route); err != nil {
+					return fmt.Errorf("failed to add route to %s via %s: %w", subnet.CIDR, gwIP, err)
+				}
+			}
+		}
+		return nil
+	})
+}
@@ -123,13 +123,17 @@ func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *
 	egressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLEgrMatch(), aclDir)
 	dbIDs := getNamespaceMcastACLDbIDs(ns, aclDir, bnc.controllerName)
 	aclPipeline := libovsdbutil.ACLDirectionToACLPipeline(aclDir)
-	egressACL := libovsdbutil.BuildACL(dbIDs, types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
+	egressACL := libovsdbutil.BuildACL(dbIDs, types.DefaultMcastAllowPriority, egressMatch, nbdb.ACLActionAllow,
+		&nsInfo.aclLogging, aclPipeline)
+	setNamespaceMcastACLName(egressACL, dbIDs)
 
 	aclDir = libovsdbutil.ACLIngress
 	ingressMatch := libovsdbutil.GetACLMatch(portGroupName, bnc.getMulticastACLIgrMatch(nsInfo), aclDir)
 	dbIDs = getNamespaceMcastACLDbIDs(ns, aclDir, bnc.controllerName)
 	aclPipeline = libovsdbutil.ACLDirectionToACLPipeline(aclDir)
-	ingressACL := libovsdbutil.BuildACL(dbIDs, types.DefaultMcastAllowPriority, ingressMatch, nbdb.ACLActionAllow, nil, aclPipeline)
+	ingressACL := libovsdbutil.BuildACL(dbIDs, types.DefaultMcastAllowPriority, ingressMatch, nbdb.ACLActionAllow,
+		&nsInfo.aclLogging, aclPipeline)
+	setNamespaceMcastACLName(ingressACL, dbIDs)
 
 	acls := []*nbdb.ACL{egressACL, ingressACL}
 	ops, err := libovsdbops.CreateOrUpdateACLsOps(bnc.nbClient, nil, acls...)
@@ -150,6 +154,28 @@ func (bnc *BaseNetworkController) createMulticastAllowPolicy(ns string, nsInfo *
 	return nil
 }
 
+// setNamespaceMcastACLName names a namespace multicast ACL while it is logged, for the logged verdicts to be mapped
+// back to the namespace
+func setNamespaceMcastACLName(acl *nbdb.ACL, dbIDs *libovsdbops.DbObjectIDs) {
+	if acl.Log {
+		aclName := libovsdbutil.GetMulticastACLName(dbIDs)
+		acl.Name = &aclName
+	}
+}
+
+// updateACLLoggingForMulticast updates the logging of the multicast ACLs of the namespace, if multicast is enabled for it.
+// The ACLs are rebuilt, since their name depends on their logging too.
+// Caller must hold the namespace's namespaceInfo object lock.
+func (bnc *BaseNetworkController) updateACLLoggingForMulticast(ns string, nsInfo *namespaceInfo) error {
+	if !bnc.multicastSupport || !nsInfo.multicastEnabled {
+		return nil
+	}
+	if err := bnc.createMulticastAllowPolicy(ns, nsInfo); err != nil {
+		return fmt.Errorf("unable to update multicast ACL logging in ns %s: %w", ns, err)
+	}
+	return nil
+}
+
 func (bnc *BaseNetworkController) deleteMulticastAllowPolicy(ns string) error {
 	portGroupName := bnc.getNamespacePortGroupName(ns)
 
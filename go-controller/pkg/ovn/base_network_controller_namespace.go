@@ -350,6 +350,10 @@ func (bnc *BaseNetworkController) needNamespacedPortGroup() bool {
 }
 
 func (bnc *BaseNetworkController) configureNamespaceCommon(nsInfo *namespaceInfo, ns *kapi.Namespace) error {
+	bnc.flowSamplingUpdateNsInfo(ns, nsInfo)
+	if nsInfo.aclLogging.FlowSampling != nil {
+		klog.Infof("Namespace %s: flow sampling is set to probability=%d", ns.Name, nsInfo.aclLogging.FlowSampling.Probability)
+	}
 	if annotation, ok := ns.Annotations[util.AclLoggingAnnotation]; ok {
 		if err := bnc.aclLoggingUpdateNsInfo(annotation, nsInfo); err == nil {
 			klog.Infof("Namespace %s: ACL logging is set to deny=%s allow=%s", ns.Name, nsInfo.aclLogging.Deny, nsInfo.aclLogging.Allow)
@@ -400,7 +404,7 @@ func (bnc *BaseNetworkController) updateNamespaceAclLogging(ns, aclAnnotation st
 		klog.Infof("Namespace %s: NetworkPolicy ACL logging setting updated to deny=%s allow=%s",
 			ns, nsInfo.aclLogging.Deny, nsInfo.aclLogging.Allow)
 	}
-	return nil
+	return bnc.updateACLLoggingForMulticast(ns, nsInfo)
 }
 
 func (bnc *BaseNetworkController) getAllNamespacePodAddresses(ns string) []net.IP {
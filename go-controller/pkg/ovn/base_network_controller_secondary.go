@@ -532,8 +532,13 @@ func (bsnc *BaseSecondaryNetworkController) updateNamespaceForSecondaryNetwork(o
 
 	aclAnnotation := newer.Annotations[util.AclLoggingAnnotation]
 	oldACLAnnotation := old.Annotations[util.AclLoggingAnnotation]
-	// support for ACL logging update, if new annotation is empty, make sure we propagate new setting
-	if aclAnnotation != oldACLAnnotation {
+	flowSamplingChanged := newer.Annotations[util.FlowSamplingAnnotation] != old.Annotations[util.FlowSamplingAnnotation]
+	if flowSamplingChanged {
+		bsnc.flowSamplingUpdateNsInfo(newer, nsInfo)
+	}
+	// support for ACL logging update, if new annotation is empty, make sure we propagate new setting. Flow sampling
+	// is set on the same ACLs as logging.
+	if aclAnnotation != oldACLAnnotation || flowSamplingChanged {
 		if err := bsnc.updateNamespaceAclLogging(old.Name, aclAnnotation, nsInfo); err != nil {
 			errors = append(errors, err)
 		}
@@ -1,6 +1,7 @@
 package ovn
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"reflect"
@@ -11,6 +12,7 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	lsm "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/logical_switch_manager"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/retry"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -322,6 +324,27 @@ func (oc *BaseSecondaryLayer2NetworkController) initializeLogicalSwitch(switchNa
 	return &logicalSwitch, nil
 }
 
+// reconfigure updates the network information live and adds the new subnets and
+// exclude subnets of the network to the IPAM of its logical switch, keeping the
+// IPs already allocated to the pods.
+func (oc *BaseSecondaryLayer2NetworkController) reconfigure(switchName string, netInfo util.BasicNetInfo) error {
+	if err := util.UpdateNetInfo(oc.NetInfo, netInfo); err != nil {
+		return err
+	}
+
+	hostSubnets := make([]*net.IPNet, 0, len(oc.Subnets()))
+	for _, clusterSubnet := range oc.Subnets() {
+		hostSubnets = append(hostSubnets, clusterSubnet.CIDR)
+	}
+	err := oc.lsManager.ExpandSwitch(switchName, hostSubnets, oc.ExcludeSubnets()...)
+	if errors.Is(err, lsm.SwitchNotFound) {
+		// the switch is initialized with the updated subnets when the
+		// controller starts
+		return nil
+	}
+	return err
+}
+
 func (oc *BaseSecondaryLayer2NetworkController) addUpdateNodeEvent(node *corev1.Node) error {
 	if oc.isLocalZoneNode(node) {
 		return oc.addUpdateLocalNodeEvent(node)
@@ -11,6 +11,7 @@ import (
 	"github.com/ovn-org/libovsdb/ovsdb"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/pkg/errors"
@@ -33,6 +34,7 @@ func (c *Controller) processNextANPWorkItem(wg *sync.WaitGroup) bool {
 
 	err := c.syncAdminNetworkPolicy(anpKey.(string))
 	if err == nil {
+		metrics.GetConfigDurationRecorder().End("adminnetworkpolicy", "", anpKey.(string))
 		c.anpQueue.Forget(anpKey)
 		return true
 	}
@@ -43,6 +45,7 @@ func (c *Controller) processNextANPWorkItem(wg *sync.WaitGroup) bool {
 		return true
 	}
 
+	metrics.GetConfigDurationRecorder().End("adminnetworkpolicy", "", anpKey.(string))
 	c.anpQueue.Forget(anpKey)
 	return true
 }
@@ -444,10 +447,12 @@ func (c *Controller) createNewANP(desiredANPState *adminNetworkPolicyState, desi
 	if err != nil {
 		return fmt.Errorf("failed to create ops to add port to a port group: %v", err)
 	}
+	ops, txOkCallBack := c.addConfigDurationOps(ops, desiredANPState.name, isBanp)
 	_, err = libovsdbops.TransactAndCheck(c.nbClient, ops)
 	if err != nil {
 		return fmt.Errorf("failed to run ovsdb txn to add ports to port group: %v", err)
 	}
+	txOkCallBack()
 	return nil
 }
 
@@ -550,13 +555,29 @@ func (c *Controller) updateExistingANP(currentANPState, desiredANPState *adminNe
 		return fmt.Errorf("failed to create ops for changes to ANP %s subject: %v", desiredANPState.name, err)
 	}
 	ops = append(ops, subjectOps...)
+	ops, txOkCallBack := c.addConfigDurationOps(ops, desiredANPState.name, isBanp)
 	_, err = libovsdbops.TransactAndCheck(c.nbClient, ops)
 	if err != nil {
 		return fmt.Errorf("failed to run ovsdb txn to update ANP %s: %v", desiredANPState.name, err)
 	}
+	txOkCallBack()
 	return nil
 }
 
+// addConfigDurationOps appends the ops recording the configuration duration of the ANP to the given ops and returns
+// the callback to run once they are transacted. BANPs are not measured.
+func (c *Controller) addConfigDurationOps(ops []ovsdb.Operation, anpName string, isBanp bool) ([]ovsdb.Operation, func()) {
+	if isBanp {
+		return ops, func() {}
+	}
+	recordOps, txOkCallBack, _, err := metrics.GetConfigDurationRecorder().AddOVN(c.nbClient, "adminnetworkpolicy",
+		"", anpName)
+	if err != nil {
+		klog.Errorf("Failed to record config duration: %v", err)
+	}
+	return append(ops, recordOps...), txOkCallBack
+}
+
 // constructOpsForRuleChanges takes the desired state of the anp and returns the corresponding ops for updating NBDB objects
 func (c *Controller) constructOpsForRuleChanges(desiredANPState *adminNetworkPolicyState, isBanp bool) ([]ovsdb.Operation, error) {
 	var ops []ovsdb.Operation
@@ -8,6 +8,7 @@ import (
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	v1 "k8s.io/api/core/v1"
@@ -306,6 +307,7 @@ func (c *Controller) onANPAdd(obj interface{}) {
 		return
 	}
 	klog.V(4).Infof("Adding Admin Network Policy %s", key)
+	metrics.GetConfigDurationRecorder().Start("adminnetworkpolicy", "", key)
 	c.anpQueue.Add(key)
 }
 
@@ -328,6 +330,7 @@ func (c *Controller) onANPUpdate(oldObj, newObj interface{}) {
 		klog.V(4).Infof("Updating Admin Network Policy %s: "+
 			"anpPriority: %v, anpSubject %v, anpIngress %v, anpEgress %v", key,
 			newANP.Spec.Priority, newANP.Spec.Subject, newANP.Spec.Ingress, newANP.Spec.Egress)
+		metrics.GetConfigDurationRecorder().Start("adminnetworkpolicy", "", key)
 		c.anpQueue.Add(key)
 	}
 }
@@ -340,6 +343,7 @@ func (c *Controller) onANPDelete(obj interface{}) {
 		return
 	}
 	klog.V(4).Infof("Deleting Admin Network Policy %s", key)
+	metrics.GetConfigDurationRecorder().Start("adminnetworkpolicy", "", key)
 	c.anpQueue.Add(key)
 }
 
@@ -9,6 +9,7 @@ import (
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/klog/v2"
@@ -167,3 +168,49 @@ func (c *Controller) repairBaselineAdminNetworkPolicy() error {
 	}
 	return nil
 }
+
+// DeleteStaleAdminNetworkPolicy deletes the port group, the ACLs and the address-sets of an admin network policy,
+// or of the baseline admin network policy if isBanp is set, that the controller doesn't handle, unless the policy
+// exists in the lister. It holds the controller lock, like the policy handlers do.
+func (c *Controller) DeleteStaleAdminNetworkPolicy(name string, isBanp bool) error {
+	c.Lock()
+	defer c.Unlock()
+	var err error
+	aclIDsType, asIDsType := libovsdbops.ACLAdminNetworkPolicy, libovsdbops.AddressSetAdminNetworkPolicy
+	if isBanp {
+		aclIDsType, asIDsType = libovsdbops.ACLBaselineAdminNetworkPolicy, libovsdbops.AddressSetBaselineAdminNetworkPolicy
+		_, err = c.banpLister.Get(name)
+		if c.banpCache.name == name {
+			klog.Infof("BANP %s is handled, not deleting its objects", name)
+			return nil
+		}
+	} else {
+		_, err = c.anpLister.Get(name)
+		if _, loaded := c.anpCache[name]; loaded {
+			klog.Infof("ANP %s is handled, not deleting its objects", name)
+			return nil
+		}
+	}
+	if err == nil {
+		klog.Infof("Policy %s exists, not deleting its objects", name)
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to get policy %s from the lister, err: %v", name, err)
+	}
+
+	portGroupName, readableGroupName := getAdminNetworkPolicyPGName(name, isBanp)
+	if err := libovsdbops.DeletePortGroups(c.nbClient, portGroupName); err != nil {
+		return fmt.Errorf("unable to delete PG %s of policy %s: %w", readableGroupName, name, err)
+	}
+	// the ACLs of the port group are gone with it, unless they are also referenced by other port groups
+	predicateIDs := libovsdbops.NewDbObjectIDs(aclIDsType, c.controllerName,
+		map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: name})
+	staleACLs, err := libovsdbops.FindACLsWithPredicate(c.nbClient, libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil))
+	if err != nil {
+		return fmt.Errorf("unable to find ACLs of policy %s: %w", name, err)
+	}
+	if err := libovsdbops.DeleteACLsFromAllPortGroups(c.nbClient, staleACLs...); err != nil {
+		return fmt.Errorf("unable to delete ACLs of policy %s: %w", name, err)
+	}
+	return c.clearASForPeers(name, asIDsType)
+}
@@ -58,6 +58,9 @@ type RouteInfo struct {
 	// external gateways are used. The first map key is the podIP (src-ip of the route),
 	// the second the GW IP (next hop), and the third the GR name
 	PodExternalRoutes map[string]map[string]string
+	// PodConsistentHashGateways keeps the gateways sharing the ECMP slots of the pod IPs routed with consistent
+	// hashing. The first map key is the podIP and the second the GW IP.
+	PodConsistentHashGateways map[string]map[string]*gateway_info.GatewayInfo
 }
 
 type ExternalGatewayRouteInfoCache struct {
@@ -79,8 +82,9 @@ func NewExternalGatewayRouteInfoCache() *ExternalGatewayRouteInfoCache {
 func (e *ExternalGatewayRouteInfoCache) CreateOrLoad(podName ktypes.NamespacedName, f func(routeInfo *RouteInfo) error) error {
 	return e.routeInfos.DoWithLock(podName, func(key ktypes.NamespacedName) error {
 		routeInfo := &RouteInfo{
-			PodExternalRoutes: make(map[string]map[string]string),
-			PodName:           podName,
+			PodExternalRoutes:         make(map[string]map[string]string),
+			PodConsistentHashGateways: make(map[string]map[string]*gateway_info.GatewayInfo),
+			PodName:                   podName,
 		}
 		routeInfo, _ = e.routeInfos.LoadOrStore(key, routeInfo)
 		return f(routeInfo)
@@ -447,6 +451,35 @@ func (m *externalPolicyManager) onPolicyDelete(obj interface{}) {
 	m.routeQueue.Add(key)
 }
 
+// enqueuePoliciesWithBFD adds the policies with BFD enabled hops to the route queue, so that their status reflects
+// the latest state of the BFD sessions.
+func (m *externalPolicyManager) enqueuePoliciesWithBFD() {
+	policies, err := m.routeLister.List(labels.Everything())
+	if err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to list Admin Policy Based External Routes: %v", err))
+		return
+	}
+	for _, policy := range policies {
+		if hasBFDEnabledHops(policy) {
+			m.routeQueue.Add(policy.Name)
+		}
+	}
+}
+
+func hasBFDEnabledHops(policy *adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute) bool {
+	for _, hop := range policy.Spec.NextHops.StaticHops {
+		if hop.BFDEnabled {
+			return true
+		}
+	}
+	for _, hop := range policy.Spec.NextHops.DynamicHops {
+		if hop.BFDEnabled {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *externalPolicyManager) onNamespaceAdd(obj interface{}) {
 	ns, ok := obj.(*v1.Namespace)
 	if !ok {
@@ -700,7 +733,11 @@ func (m *externalPolicyManager) getPoliciesForPodChange(pod *v1.Pod) (sets.Set[s
 		if err != nil {
 			return nil, err
 		}
-		if targetNsSel.Matches(labels.Set(podNs.Labels)) {
+		targetPodSel, err := metav1.LabelSelectorAsSelector(&informerPolicy.Spec.From.PodSelector)
+		if err != nil {
+			return nil, err
+		}
+		if targetNsSel.Matches(labels.Set(podNs.Labels)) && targetPodSel.Matches(labels.Set(pod.Labels)) {
 			policyNames.Insert(informerPolicy.Name)
 			continue
 		}
@@ -723,7 +760,7 @@ func (m *externalPolicyManager) getPoliciesForPodChange(pod *v1.Pod) (sets.Set[s
 	m.policyReferencedObjectsLock.RLock()
 	defer m.policyReferencedObjectsLock.RUnlock()
 	for policyName, policyRefs := range m.policyReferencedObjects {
-		// we don't store target pods, because all pods in the target namespace are affected, check namespace
+		// we don't store target pods, check the namespace to also catch pods that stopped matching the pod selector
 		if policyRefs.targetNamespaces.Has(podNs.Name) {
 			policyNames.Insert(policyName)
 			continue
@@ -330,7 +330,10 @@ func (m *externalPolicyManager) processStaticHopsGatewayInformation(hops []*admi
 		if ip == nil {
 			return nil, fmt.Errorf("could not parse routing static gw annotation value '%s'", h.IP)
 		}
-		gwList.InsertOverwrite(gateway_info.NewGatewayInfo(sets.New(ip.String()), h.BFDEnabled))
+		gwInfo := gateway_info.NewGatewayInfo(sets.New(ip.String()), h.BFDEnabled)
+		gwInfo.BFDParams = getBFDParams(h.BFDEnabled, h.BFD)
+		gwInfo.Weight = int(h.Weight)
+		gwList.InsertOverwrite(gwInfo)
 	}
 	return gwList, nil
 }
@@ -370,7 +373,10 @@ func (m *externalPolicyManager) processDynamicHopsGatewayInformation(hops []*adm
 					continue
 				}
 				key := ktypes.NamespacedName{Namespace: pod.Namespace, Name: pod.Name}
-				podsInfo.InsertOverwrite(gateway_info.NewGatewayInfo(foundGws, h.BFDEnabled))
+				gwInfo := gateway_info.NewGatewayInfo(foundGws, h.BFDEnabled)
+				gwInfo.BFDParams = getBFDParams(h.BFDEnabled, h.BFD)
+				gwInfo.Weight = int(h.Weight)
+				podsInfo.InsertOverwrite(gwInfo)
 				selectedPods.Insert(key)
 			}
 			selectedNamespaces.Insert(gwNamespace.Name)
@@ -379,6 +385,55 @@ func (m *externalPolicyManager) processDynamicHopsGatewayInformation(hops []*adm
 	return podsInfo, selectedNamespaces, selectedPods, nil
 }
 
+// getBFDParams returns the BFD timers of a hop, the timers are ignored when BFD is disabled.
+func getBFDParams(bfdEnabled bool, bfdConfig *adminpolicybasedrouteapi.BFDConfig) gateway_info.BFDParams {
+	if !bfdEnabled || bfdConfig == nil {
+		return gateway_info.BFDParams{}
+	}
+	return gateway_info.BFDParams{
+		MinTx:      int(bfdConfig.MinTx),
+		MinRx:      int(bfdConfig.MinRx),
+		DetectMult: int(bfdConfig.DetectMultiplier),
+	}
+}
+
+// processDestinationCIDRs validates the destination CIDRs of a policy and returns them in their canonical form.
+// Routes restricted to destinations are implemented with logical router policies, that can't be tracked with BFD and
+// have a single ECMP member per gateway.
+func processDestinationCIDRs(policy *adminpolicybasedrouteapi.AdminPolicyBasedExternalRoute) (sets.Set[string], error) {
+	destinations := sets.New[string]()
+	for _, cidr := range policy.Spec.From.DestinationCIDRs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid destination CIDR %q: %w", cidr, err)
+		}
+		destinations.Insert(ipNet.String())
+	}
+	if destinations.Len() == 0 {
+		return destinations, nil
+	}
+	if policy.Spec.NextHops.ConsistentHashing {
+		return nil, fmt.Errorf("consistent hashing can't be used with destination CIDRs")
+	}
+	for _, hop := range policy.Spec.NextHops.StaticHops {
+		if hop.BFDEnabled {
+			return nil, fmt.Errorf("static hop %s can't enable BFD with destination CIDRs", hop.IP)
+		}
+		if hop.Weight > 1 {
+			return nil, fmt.Errorf("static hop %s can't set a weight with destination CIDRs", hop.IP)
+		}
+	}
+	for _, hop := range policy.Spec.NextHops.DynamicHops {
+		if hop.BFDEnabled {
+			return nil, fmt.Errorf("dynamic hops can't enable BFD with destination CIDRs")
+		}
+		if hop.Weight > 1 {
+			return nil, fmt.Errorf("dynamic hops can't set a weight with destination CIDRs")
+		}
+	}
+	return destinations, nil
+}
+
 // getPolicyConfigAndUpdatePolicyRefs lists and updates all referenced objects for a given policy and returns
 // routePolicyConfig to perform an update.
 // This function should be the only one that lists referenced objects, and updates policyReferencedObjects atomically.
@@ -391,6 +446,10 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 	if staticGWInfo.Len() > 0 {
 		klog.V(5).Infof("Found static hops for policy %s:%+v", policy.Name, staticGWInfo)
 	}
+	destinations, err := processDestinationCIDRs(policy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to process destination CIDRs: %w", err)
+	}
 
 	// take a lock before listing any objects
 	m.policyReferencedObjectsLock.Lock()
@@ -403,19 +462,29 @@ func (m *externalPolicyManager) getPolicyConfigAndUpdatePolicyRefs(policy *admin
 	if dynamicGWInfo.Len() > 0 {
 		klog.V(5).Infof("Found dynamic hops for policy %s: %+v", policy.Name, dynamicGWInfo)
 	}
+	for _, gwInfo := range append(staticGWInfo.Elems(), dynamicGWInfo.Elems()...) {
+		gwInfo.ConsistentHashing = policy.Spec.NextHops.ConsistentHashing
+		if destinations.Len() > 0 {
+			gwInfo.Destinations = destinations
+		}
+	}
 
 	targetNs, err := m.listNamespacesBySelector(&policy.Spec.From.NamespaceSelector)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list target namespaces: %w", err)
 	}
+	targetPodSel, err := metav1.LabelSelectorAsSelector(&policy.Spec.From.PodSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert target pod selector: %w", err)
+	}
 
 	targetNsNames := sets.Set[string]{}
 	targetNamespaces := map[string]map[ktypes.NamespacedName]*v1.Pod{}
 	for _, ns := range targetNs {
 		targetNsNames.Insert(ns.Name)
-		targetPods, err := m.podLister.Pods(ns.Name).List(labels.Everything())
+		targetPods, err := m.podLister.Pods(ns.Name).List(targetPodSel)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get all ns %s pods: %v", ns.Name, err)
+			return nil, fmt.Errorf("failed to get ns %s target pods: %v", ns.Name, err)
 		}
 		podsMap := map[ktypes.NamespacedName]*v1.Pod{}
 		for _, pod := range targetPods {
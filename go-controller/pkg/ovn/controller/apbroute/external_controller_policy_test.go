@@ -16,6 +16,7 @@ import (
 	adminpolicybasedrouteclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned/fake"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/factory"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/kubernetes/fake"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 )
 
 func newPod(podName, namespace, podIP string, labels map[string]string) *corev1.Pod {
@@ -803,6 +805,326 @@ var _ = Describe("OVN External Gateway policy", func() {
 			eventuallyExpectConfig(policyName, expectedPolicy, expectedRefs)
 		})
 	})
+
+	var _ = Context("when the policy source selects pods and destinations", func() {
+
+		var (
+			targetPod3 = newPod("pod_target3", namespaceTarget.Name, "192.169.10.3",
+				map[string]string{"key": "other", "name": "pod_target3"})
+			destinationCIDRs = []string{"172.16.0.0/16", "10.0.0.0/8"}
+		)
+
+		findDestinationPolicies := func() []*nbdb.LogicalRouterPolicy {
+			policies, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(nbClient, func(item *nbdb.LogicalRouterPolicy) bool {
+				return item.Priority == types.ExternalGWDestinationReroutePriority
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return policies
+		}
+
+		It("only applies the policy to the pods matching the pod selector", func() {
+			podSelectorPolicy := newPolicy("podSelector",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				false,
+			)
+			podSelectorPolicy.Spec.From.PodSelector = v1.LabelSelector{MatchLabels: map[string]string{"key": "pod"}}
+			initController([]runtime.Object{namespaceTarget, targetPod1, targetPod3}, []runtime.Object{podSelectorPolicy})
+
+			expectedPolicy, expectedRefs := expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{namespaceTargetWithPod},
+				[]string{staticHopGWIP},
+				nil, false)
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyExpectConfig(podSelectorPolicy.Name, expectedPolicy, expectedRefs)
+
+			By("updating the labels of the second pod to match the pod selector")
+			updatePodLabels(targetPod3, map[string]string{"key": "pod", "name": "pod_target3"}, fakeClient)
+			expectedPolicy, expectedRefs = expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{newNamespaceWithPods(namespaceTarget.Name, targetPod1, targetPod3)},
+				[]string{staticHopGWIP},
+				nil, false)
+			eventuallyExpectConfig(podSelectorPolicy.Name, expectedPolicy, expectedRefs)
+
+			By("updating the labels of the first pod to no longer match the pod selector")
+			updatePodLabels(targetPod1, map[string]string{"key": "other", "name": "pod_target1"}, fakeClient)
+			expectedPolicy, expectedRefs = expectedPolicyStateAndRefs(
+				[]*namespaceWithPods{newNamespaceWithPods(namespaceTarget.Name, targetPod3)},
+				[]string{staticHopGWIP},
+				nil, false)
+			eventuallyExpectConfig(podSelectorPolicy.Name, expectedPolicy, expectedRefs)
+		})
+
+		It("reroutes only the traffic to the destination CIDRs through the gateways", func() {
+			destinationPolicy := newPolicy("destination",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP, "10.10.10.2"),
+				nil,
+				nil,
+				false,
+			)
+			destinationPolicy.Spec.From.DestinationCIDRs = destinationCIDRs
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{destinationPolicy})
+			eventuallyExpectNumberOfPolicies(1)
+			eventuallyCheckAPBRouteStatus(destinationPolicy.Name, false)
+
+			Eventually(func() []string {
+				policies := findDestinationPolicies()
+				if len(policies) != 1 {
+					return nil
+				}
+				Expect(policies[0].Match).To(Equal("ip4.src == 192.169.10.1 && ip4.dst == {10.0.0.0/8, 172.16.0.0/16}"))
+				Expect(policies[0].Action).To(Equal(nbdb.LogicalRouterPolicyActionReroute))
+				return policies[0].Nexthops
+			}, 5).Should(ConsistOf(staticHopGWIP, "10.10.10.2"))
+			routes, err := libovsdbops.FindLogicalRouterStaticRoutesWithPredicate(nbClient, func(item *nbdb.LogicalRouterStaticRoute) bool {
+				return true
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(routes).To(BeEmpty())
+
+			By("deleting the policy the reroute is removed")
+			deletePolicy(destinationPolicy.Name, fakeRouteClient)
+			eventuallyExpectNumberOfPolicies(0)
+			Eventually(findDestinationPolicies, 5).Should(BeEmpty())
+		})
+
+		It("reports an error when BFD is enabled together with destination CIDRs", func() {
+			destinationPolicy := newPolicy("destinationBFD",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				true,
+			)
+			destinationPolicy.Spec.From.DestinationCIDRs = destinationCIDRs
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{destinationPolicy})
+			eventuallyCheckAPBRouteStatus(destinationPolicy.Name, true)
+			Consistently(findDestinationPolicies).Should(BeEmpty())
+		})
+	})
+
+	var _ = Context("when BFD is enabled on the hops", func() {
+
+		getNextHopsStatus := func(policyName string) []adminpolicybasedrouteapi.NextHopStatus {
+			p, err := fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.TODO(), policyName, v1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			return p.Status.NextHops
+		}
+
+		It("configures the BFD timers and reports the state of the BFD sessions", func() {
+			bfdPolicy := newPolicy("bfd",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				true,
+			)
+			bfdPolicy.Spec.NextHops.StaticHops[0].BFD = &adminpolicybasedrouteapi.BFDConfig{
+				MinTx:            100,
+				MinRx:            200,
+				DetectMultiplier: 5,
+			}
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{bfdPolicy})
+			eventuallyExpectNumberOfPolicies(1)
+
+			var bfd *nbdb.BFD
+			Eventually(func() error {
+				bfds, err := libovsdbops.FindBFDsWithPredicate(nbClient, func(item *nbdb.BFD) bool {
+					return item.DstIP == staticHopGWIP
+				})
+				if err != nil {
+					return err
+				}
+				if len(bfds) != 1 {
+					return fmt.Errorf("expected 1 BFD entry, found %d", len(bfds))
+				}
+				bfd = bfds[0]
+				return nil
+			}, 5).Should(Succeed())
+			Expect(bfd.LogicalPort).To(Equal(types.GWRouterToExtSwitchPrefix + "GR_node"))
+			Expect(bfd.MinTx).To(Equal(pointer.Int(100)))
+			Expect(bfd.MinRx).To(Equal(pointer.Int(200)))
+			Expect(bfd.DetectMult).To(Equal(pointer.Int(5)))
+
+			Eventually(func() []adminpolicybasedrouteapi.NextHopStatus { return getNextHopsStatus(bfdPolicy.Name) }, 5).
+				Should(ConsistOf(adminpolicybasedrouteapi.NextHopStatus{
+					IP:        staticHopGWIP,
+					Node:      "node",
+					BFDStatus: adminpolicybasedrouteapi.BFDStatusUnknown,
+				}))
+
+			By("updating the state of the BFD session")
+			bfd.Status = &nbdb.BFDStatusUp
+			ops, err := nbClient.Where(bfd).Update(bfd, &bfd.Status)
+			Expect(err).NotTo(HaveOccurred())
+			_, err = libovsdbops.TransactAndCheck(nbClient, ops)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() []adminpolicybasedrouteapi.NextHopStatus { return getNextHopsStatus(bfdPolicy.Name) }, 5).
+				Should(ConsistOf(adminpolicybasedrouteapi.NextHopStatus{
+					IP:        staticHopGWIP,
+					Node:      "node",
+					BFDStatus: adminpolicybasedrouteapi.BFDStatusUp,
+				}))
+		})
+
+		It("updates the BFD timers when they are changed in the policy", func() {
+			bfdPolicy := newPolicy("bfd",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				true,
+			)
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{bfdPolicy})
+			eventuallyExpectNumberOfPolicies(1)
+
+			findBFD := func() *nbdb.BFD {
+				bfds, err := libovsdbops.FindBFDsWithPredicate(nbClient, func(item *nbdb.BFD) bool {
+					return item.DstIP == staticHopGWIP
+				})
+				Expect(err).NotTo(HaveOccurred())
+				if len(bfds) != 1 {
+					return nil
+				}
+				return bfds[0]
+			}
+			Eventually(findBFD, 5).ShouldNot(BeNil())
+			Expect(findBFD().MinTx).To(BeNil())
+
+			By("setting the BFD timers in the static hop")
+			p, err := fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.Background(), bfdPolicy.Name, v1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			p.Spec.NextHops.StaticHops[0].BFD = &adminpolicybasedrouteapi.BFDConfig{MinTx: 300}
+			p.Generation++
+			_, err = fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Update(context.Background(), p, v1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() *int {
+				bfd := findBFD()
+				if bfd == nil {
+					return nil
+				}
+				return bfd.MinTx
+			}, 5).Should(Equal(pointer.Int(300)))
+		})
+	})
+
+	var _ = Context("when the hops are weighted or use consistent hashing", func() {
+
+		findPodRoutes := func() []*nbdb.LogicalRouterStaticRoute {
+			routes, err := libovsdbops.FindLogicalRouterStaticRoutesWithPredicate(nbClient, func(item *nbdb.LogicalRouterStaticRoute) bool {
+				return item.IPPrefix == "192.169.10.1/32"
+			})
+			Expect(err).NotTo(HaveOccurred())
+			return routes
+		}
+
+		slotOwners := func() map[string]string {
+			owners := map[string]string{}
+			for _, route := range findPodRoutes() {
+				if slot := route.ExternalIDs[types.ExternalGWECMPSlotExternalID]; slot != "" {
+					owners[slot] = route.Nexthop
+				}
+			}
+			return owners
+		}
+
+		slotNextHops := func() sets.Set[string] {
+			nextHops := sets.New[string]()
+			for _, owner := range slotOwners() {
+				nextHops.Insert(owner)
+			}
+			return nextHops
+		}
+
+		It("creates an ECMP route per unit of weight of the hop", func() {
+			weightedPolicy := newPolicy("weighted",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				false,
+			)
+			weightedPolicy.Spec.NextHops.StaticHops[0].Weight = 3
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{weightedPolicy})
+			eventuallyExpectNumberOfPolicies(1)
+
+			Eventually(func() int { return len(findPodRoutes()) }, 5).Should(Equal(3))
+			members := sets.New[string]()
+			for _, route := range findPodRoutes() {
+				Expect(route.Nexthop).To(Equal(staticHopGWIP))
+				members.Insert(route.ExternalIDs[types.ExternalGWECMPMemberExternalID])
+			}
+			Expect(members).To(Equal(sets.New("", "1", "2")))
+
+			By("decreasing the weight of the static hop")
+			p, err := fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.Background(), weightedPolicy.Name, v1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			p.Spec.NextHops.StaticHops[0].Weight = 1
+			p.Generation++
+			_, err = fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Update(context.Background(), p, v1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() int { return len(findPodRoutes()) }, 5).Should(Equal(1))
+			Expect(findPodRoutes()[0].ExternalIDs).To(BeEmpty())
+		})
+
+		It("only moves the ECMP slots won by a new hop with consistent hashing", func() {
+			consistentPolicy := newPolicy("consistent",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP, "10.30.20.1"),
+				nil,
+				nil,
+				false,
+			)
+			consistentPolicy.Spec.NextHops.ConsistentHashing = true
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{consistentPolicy})
+			eventuallyExpectNumberOfPolicies(1)
+
+			Eventually(func() int { return len(slotOwners()) }, 5).Should(Equal(ecmpConsistentHashSlots))
+			Eventually(func() int { return slotNextHops().Len() }, 5).Should(Equal(2))
+			Expect(findPodRoutes()).To(HaveLen(ecmpConsistentHashSlots))
+			owners := slotOwners()
+
+			By("adding a static hop to the policy")
+			p, err := fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Get(context.Background(), consistentPolicy.Name, v1.GetOptions{})
+			Expect(err).NotTo(HaveOccurred())
+			p.Spec.NextHops.StaticHops = append(p.Spec.NextHops.StaticHops, &adminpolicybasedrouteapi.StaticHop{IP: "10.30.20.2"})
+			p.Generation++
+			_, err = fakeRouteClient.K8sV1().AdminPolicyBasedExternalRoutes().Update(context.Background(), p, v1.UpdateOptions{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(func() bool { return slotNextHops().Has("10.30.20.2") }, 5).Should(BeTrue())
+			Expect(findPodRoutes()).To(HaveLen(ecmpConsistentHashSlots))
+			for slot, owner := range slotOwners() {
+				if owner != "10.30.20.2" {
+					Expect(owner).To(Equal(owners[slot]))
+				}
+			}
+
+			By("deleting the policy")
+			deletePolicy(consistentPolicy.Name, fakeRouteClient)
+			Eventually(func() int { return len(findPodRoutes()) }, 5).Should(BeZero())
+		})
+
+		It("reports an error when consistent hashing is used together with destination CIDRs", func() {
+			consistentPolicy := newPolicy("consistent",
+				&v1.LabelSelector{MatchLabels: targetNamespace1Match},
+				sets.New(staticHopGWIP),
+				nil,
+				nil,
+				false,
+			)
+			consistentPolicy.Spec.NextHops.ConsistentHashing = true
+			consistentPolicy.Spec.From.DestinationCIDRs = []string{"172.16.0.0/16"}
+			initController([]runtime.Object{namespaceTarget, targetPod1}, []runtime.Object{consistentPolicy})
+			eventuallyCheckAPBRouteStatus(consistentPolicy.Name, true)
+		})
+	})
 })
 
 func eventuallyCheckAPBRouteStatus(policyName string, expectFailure bool) {
@@ -147,23 +147,46 @@ func (g *GatewayInfoList) Equal(g2 *GatewayInfoList) bool {
 	return true
 }
 
+// BFDParams holds the timers of the BFD sessions established with the gateways, zero values use the OVN defaults.
+type BFDParams struct {
+	MinTx      int
+	MinRx      int
+	DetectMult int
+}
+
 type GatewayInfo struct {
-	Gateways      sets.Set[string]
-	BFDEnabled    bool
+	Gateways   sets.Set[string]
+	BFDEnabled bool
+	BFDParams  BFDParams
+	// Weight is the number of ECMP members of every gateway, a gateway has a single ECMP member when it is 0.
+	Weight int
+	// ConsistentHashing distributes the ECMP slots of the pod IPs between the gateways with consistent hashing.
+	ConsistentHashing bool
+	// Destinations restricts the traffic routed through the gateways to these CIDRs, all the traffic is routed
+	// through the gateways when empty.
+	Destinations  sets.Set[string]
 	failedToApply bool
 }
 
 func (g *GatewayInfo) String() string {
-	return fmt.Sprintf("BFDEnabled: %t, Gateways: %+v, failedToApply: %t", g.BFDEnabled, g.Gateways, g.failedToApply)
+	return fmt.Sprintf("BFDEnabled: %t, BFDParams: %+v, Gateways: %+v, Weight: %d, ConsistentHashing: %t, Destinations: %+v, "+
+		"failedToApply: %t", g.BFDEnabled, g.BFDParams, g.Gateways, g.Weight, g.ConsistentHashing, g.Destinations, g.failedToApply)
 }
 
 func NewGatewayInfo(items sets.Set[string], bfdEnabled bool) *GatewayInfo {
 	return &GatewayInfo{Gateways: items, BFDEnabled: bfdEnabled}
 }
 
+// NewGatewayInfoWithDestinations returns a GatewayInfo that only routes the traffic to the given destination CIDRs
+// through the gateways.
+func NewGatewayInfoWithDestinations(items sets.Set[string], bfdEnabled bool, destinations sets.Set[string]) *GatewayInfo {
+	return &GatewayInfo{Gateways: items, BFDEnabled: bfdEnabled, Destinations: destinations}
+}
+
 // SameSpec compares GatewayInfo fields, excluding applied
 func (g *GatewayInfo) SameSpec(g2 *GatewayInfo) bool {
-	return g.BFDEnabled == g2.BFDEnabled && g.Gateways.Equal(g2.Gateways)
+	return g.BFDEnabled == g2.BFDEnabled && g.BFDParams == g2.BFDParams && g.Gateways.Equal(g2.Gateways) &&
+		g.Weight == g2.Weight && g.ConsistentHashing == g2.ConsistentHashing && g.Destinations.Equal(g2.Destinations)
 }
 
 func (g *GatewayInfo) RemoveIPs(g2 *GatewayInfo) {
@@ -172,13 +195,21 @@ func (g *GatewayInfo) RemoveIPs(g2 *GatewayInfo) {
 
 // Equal compares all GatewayInfo fields, including BFDEnabled and applied
 func (g *GatewayInfo) Equal(g2 *GatewayInfo) bool {
-	return g.BFDEnabled == g2.BFDEnabled && g.Gateways.Equal(g2.Gateways) && g.failedToApply == g2.failedToApply
+	return g.SameSpec(g2) && g.failedToApply == g2.failedToApply
 }
 
 func (g *GatewayInfo) Has(ip string) bool {
 	return g.Gateways.Has(ip)
 }
 
+// ECMPMembers returns the number of ECMP members of every gateway.
+func (g *GatewayInfo) ECMPMembers() int {
+	if g.Weight < 1 {
+		return 1
+	}
+	return g.Weight
+}
+
 func (g GatewayInfo) Len() int {
 	return g.Gateways.Len()
 }
@@ -70,6 +70,14 @@ var _ = Describe("GatewayInfoList", func() {
 			Expect(s1.Equal(NewGatewayInfoList(failedGwInfo))).To(BeTrue())
 		})
 
+		It("InsertOverwrite replaces an element with the same ips but different destinations", func() {
+			s1 := NewGatewayInfoList(NewGatewayInfo(sets.New("1.1.1.1"), false))
+			s1.InsertOverwrite(NewGatewayInfoWithDestinations(sets.New("1.1.1.1"), false, sets.New("10.0.0.0/8")))
+			Expect(s1.Equal(NewGatewayInfoList(
+				NewGatewayInfoWithDestinations(sets.New("1.1.1.1"), false, sets.New("10.0.0.0/8"))))).To(BeTrue())
+			Expect(s1.Has(NewGatewayInfo(sets.New("1.1.1.1"), false))).To(BeFalse())
+		})
+
 	})
 
 	var _ = Context("Deleting", func() {
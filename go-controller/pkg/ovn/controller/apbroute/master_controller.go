@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"reflect"
 	"strings"
 	"sync"
 
+	libovsdbcache "github.com/ovn-org/libovsdb/cache"
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/model"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -20,8 +23,10 @@ import (
 	adminpolicybasedrouteclient "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/clientset/versioned"
 	adminpolicybasedrouteinformer "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/informers/externalversions/adminpolicybasedroute/v1"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
 // Admin Policy Based Route services
@@ -85,6 +90,20 @@ func NewExternalMasterController(
 func (c *ExternalGatewayMasterController) Run(wg *sync.WaitGroup, threadiness int) error {
 	klog.V(4).Info("Starting Admin Policy Based Route Controller")
 
+	// BFD sessions state is updated by ovn-northd, refresh the status of the policies when it changes
+	c.nbClient.nbClient.Cache().AddEventHandler(&libovsdbcache.EventHandlerFuncs{
+		UpdateFunc: func(table string, old, new model.Model) {
+			if table != nbdb.BFDTable {
+				return
+			}
+			oldBFD, newBFD := old.(*nbdb.BFD), new.(*nbdb.BFD)
+			if reflect.DeepEqual(oldBFD.Status, newBFD.Status) {
+				return
+			}
+			c.mgr.enqueuePoliciesWithBFD()
+		},
+	})
+
 	return c.mgr.Run(wg, threadiness)
 }
 
@@ -124,6 +143,10 @@ func (c *ExternalGatewayMasterController) updateStatusAPBExternalRoute(policyNam
 		newMsg = fmt.Sprintf("%s %s: %v", c.zoneID, types.APBRouteErrorMsg, syncError.Error())
 	}
 	newMsg = types.GetZoneStatus(c.zoneID, newMsg)
+	nextHops, err := c.nbClient.getNextHopsBFDStatus(gwIPs)
+	if err != nil {
+		return err
+	}
 	needsUpdate := true
 	for _, message := range routePolicy.Status.Messages {
 		if message == newMsg {
@@ -132,7 +155,7 @@ func (c *ExternalGatewayMasterController) updateStatusAPBExternalRoute(policyNam
 			break
 		}
 	}
-	if !needsUpdate {
+	if !needsUpdate && !c.nextHopsStatusChanged(routePolicy.Status.NextHops, nextHops) {
 		return nil
 	}
 
@@ -140,10 +163,17 @@ func (c *ExternalGatewayMasterController) updateStatusAPBExternalRoute(policyNam
 		Force:        true,
 		FieldManager: c.zoneID,
 	}
+	statusApply := adminpolicybasedrouteapply.AdminPolicyBasedRouteStatus().
+		WithMessages(newMsg).
+		WithLastTransitionTime(metav1.Now())
+	for _, nextHop := range nextHops {
+		statusApply.WithNextHops(adminpolicybasedrouteapply.NextHopStatus().
+			WithIP(nextHop.IP).
+			WithNode(nextHop.Node).
+			WithBFDStatus(nextHop.BFDStatus))
+	}
 	applyObj := adminpolicybasedrouteapply.AdminPolicyBasedExternalRoute(policyName).
-		WithStatus(adminpolicybasedrouteapply.AdminPolicyBasedRouteStatus().
-			WithMessages(newMsg).
-			WithLastTransitionTime(metav1.Now()))
+		WithStatus(statusApply)
 	_, err = c.apbRoutePolicyClient.K8sV1().AdminPolicyBasedExternalRoutes().ApplyStatus(context.TODO(), applyObj, applyOptions)
 
 	if err != nil {
@@ -152,6 +182,20 @@ func (c *ExternalGatewayMasterController) updateStatusAPBExternalRoute(policyNam
 	return nil
 }
 
+// nextHopsStatusChanged returns true when the next hops status reported for the nodes of the local zone differs from
+// the current one. Next hops of other zones are owned by their respective zone controllers.
+func (c *ExternalGatewayMasterController) nextHopsStatusChanged(reported, current []adminpolicybasedrouteapi.NextHopStatus) bool {
+	reportedLocal := sets.New[adminpolicybasedrouteapi.NextHopStatus]()
+	for _, nextHop := range reported {
+		node, err := c.nbClient.nodeLister.Get(nextHop.Node)
+		if err != nil || util.GetNodeZone(node) != c.nbClient.zone {
+			continue
+		}
+		reportedLocal.Insert(nextHop)
+	}
+	return !reportedLocal.Equal(sets.New(current...))
+}
+
 func (c *ExternalGatewayMasterController) GetDynamicGatewayIPsForTargetNamespace(namespaceName string) (sets.Set[string], error) {
 	return c.mgr.getDynamicGatewayIPsForTargetNamespace(namespaceName)
 }
@@ -2,8 +2,12 @@ package apbroute
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math"
 	"net"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -21,6 +25,7 @@ import (
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
 	"github.com/ovn-org/libovsdb/ovsdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	adminpolicybasedrouteapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1"
 	adminpolicybasedroutelisters "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/adminpolicybasedroute/v1/apis/listers/adminpolicybasedroute/v1"
 	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
@@ -31,6 +36,10 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 )
 
+// ecmpConsistentHashSlots is the number of ECMP routes shared by the gateways of a pod IP routed with consistent
+// hashing.
+const ecmpConsistentHashSlots = 16
+
 type networkClient interface {
 	deleteGatewayIPs(podNsName ktypes.NamespacedName, toBeDeletedGWIPs, toBeKept sets.Set[string]) error
 	addGatewayIPs(pod *v1.Pod, egress *gateway_info.GatewayInfoList) (bool, error)
@@ -71,6 +80,10 @@ func (nb *northBoundClient) deleteLogicalRouterStaticRoutes(routerName string, l
 	return libovsdbops.DeleteLogicalRouterStaticRoutes(nb.nbClient, routerName, lrsrs...)
 }
 
+func (nb *northBoundClient) deleteLogicalRouterPolicyNextHops(routerName string, lrps ...*nbdb.LogicalRouterPolicy) error {
+	return libovsdbops.DeleteNextHopsFromLogicalRouterPolicies(nb.nbClient, routerName, lrps...)
+}
+
 func (nb *northBoundClient) findLogicalRoutersWithPredicate(p func(item *nbdb.LogicalRouter) bool) ([]*nbdb.LogicalRouter, error) {
 	return libovsdbops.FindLogicalRoutersWithPredicate(nb.nbClient, p)
 }
@@ -268,15 +281,37 @@ func (nb *northBoundClient) addGWRoutesForPod(gateways []*gateway_info.GatewayIn
 					continue
 				}
 				podIP := podIPNet.IP.String()
+				var destinations []string
+				if gateway.Destinations.Len() > 0 {
+					destinations = filterCIDRsByFamily(gateway.Destinations, utilnet.IsIPv6(podIPNet.IP))
+					if len(destinations) == 0 {
+						continue
+					}
+				}
 				for _, gw := range gws {
 					// if route was already programmed, skip it
 					if foundGR, ok := routeInfo.PodExternalRoutes[podIP][gw]; ok && foundGR == gr {
 						routesAdded++
 						continue
 					}
-					mask := util.GetIPFullMaskString(podIP)
-					if err := nb.createOrUpdateBFDStaticRoute(gateway.BFDEnabled, gw, podIP, gr, port, mask); err != nil {
-						return err
+					if len(destinations) > 0 {
+						if err := nb.addDestinationPolicyNextHop(gw, podIP, gr, destinations); err != nil {
+							return err
+						}
+					} else if gateway.ConsistentHashing {
+						if routeInfo.PodConsistentHashGateways[podIP] == nil {
+							routeInfo.PodConsistentHashGateways[podIP] = make(map[string]*gateway_info.GatewayInfo)
+						}
+						routeInfo.PodConsistentHashGateways[podIP][gw] = gateway
+						if err := nb.syncConsistentHashRoutes(routeInfo.PodConsistentHashGateways[podIP], podIP, gr, port); err != nil {
+							delete(routeInfo.PodConsistentHashGateways[podIP], gw)
+							return err
+						}
+					} else {
+						mask := util.GetIPFullMaskString(podIP)
+						if err := nb.createOrUpdateBFDStaticRoute(gateway, gw, podIP, gr, port, mask); err != nil {
+							return err
+						}
 					}
 					if routeInfo.PodExternalRoutes[podIP] == nil {
 						routeInfo.PodExternalRoutes[podIP] = make(map[string]string)
@@ -374,53 +409,251 @@ func (nb *northBoundClient) addHybridRoutePolicyForPod(podIP net.IP, node string
 	return nil
 }
 
-func (nb *northBoundClient) createOrUpdateBFDStaticRoute(bfdEnabled bool, gw string, podIP, gr, port, mask string) error {
+// createOrUpdateBFDStaticRoute creates or updates the static routes to gw for the pod IP on the gateway router, one
+// route per ECMP member of the gateway. The first member has no member external ID so that the routes of the
+// gateways without weight are not changed. ovn-northd adds every static route row to the ECMP group of the prefix,
+// including the routes that only differ by their external IDs, so a gateway with N members gets N of the buckets.
+func (nb *northBoundClient) createOrUpdateBFDStaticRoute(gateway *gateway_info.GatewayInfo, gw string, podIP, gr, port,
+	mask string) error {
+	ops, bfdUUID, err := nb.createOrUpdateBFDOps(nil, gateway, gw, port)
+	if err != nil {
+		return err
+	}
+	prefix := podIP + mask
+	members := gateway.ECMPMembers()
+	for member := 0; member < members; member++ {
+		memberID := ""
+		if member > 0 {
+			memberID = strconv.Itoa(member)
+		}
+		ops, err = nb.createOrUpdateECMPStaticRouteOps(ops, gw, prefix, gr, port, bfdUUID,
+			types.ExternalGWECMPMemberExternalID, memberID)
+		if err != nil {
+			return err
+		}
+	}
+	// remove the members left by a higher weight
+	p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+		member, err := strconv.Atoi(item.ExternalIDs[types.ExternalGWECMPMemberExternalID])
+		return err == nil && member >= members &&
+			item.IPPrefix == prefix &&
+			item.Nexthop == gw &&
+			item.OutputPort != nil &&
+			*item.OutputPort == port
+	}
+	ops, err = libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicateOps(nb.nbClient, ops, gr, p)
+	if err != nil {
+		return fmt.Errorf("error deleting stale ECMP members of static route to %s for %s on router %s: %v", gw, prefix, gr, err)
+	}
+
+	_, err = libovsdbops.TransactAndCheck(nb.nbClient, ops)
+	if err != nil {
+		return fmt.Errorf("error transacting static route: %v", err)
+	}
+
+	return nil
+}
+
+// syncConsistentHashRoutes programs the ECMP slots of a pod IP routed with consistent hashing on the gateway router.
+// Every slot is a static route to the gateway winning the slot with weighted rendezvous hashing, so adding or removing
+// a gateway only moves the slots won or lost by that gateway and the flows hashed to the other slots keep their
+// next hop. All the slots are removed when there are no gateways left.
+func (nb *northBoundClient) syncConsistentHashRoutes(gateways map[string]*gateway_info.GatewayInfo, podIP, gr, port string) error {
+	prefix := podIP + util.GetIPFullMaskString(podIP)
+	if len(gateways) == 0 {
+		p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+			return item.IPPrefix == prefix && item.ExternalIDs[types.ExternalGWECMPSlotExternalID] != ""
+		}
+		if err := libovsdbops.DeleteLogicalRouterStaticRoutesWithPredicate(nb.nbClient, gr, p); err != nil {
+			return fmt.Errorf("error deleting ECMP slots of %s from router %s: %v", prefix, gr, err)
+		}
+		return nil
+	}
+
+	ops := []ovsdb.Operation{}
+	bfdUUIDs := make(map[string]*string, len(gateways))
+	for gw, gateway := range gateways {
+		var err error
+		ops, bfdUUIDs[gw], err = nb.createOrUpdateBFDOps(ops, gateway, gw, port)
+		if err != nil {
+			return err
+		}
+	}
+	for slot := 0; slot < ecmpConsistentHashSlots; slot++ {
+		gw := consistentHashGateway(podIP, slot, gateways)
+		var err error
+		ops, err = nb.createOrUpdateECMPStaticRouteOps(ops, gw, prefix, gr, port, bfdUUIDs[gw],
+			types.ExternalGWECMPSlotExternalID, strconv.Itoa(slot))
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := libovsdbops.TransactAndCheck(nb.nbClient, ops)
+	if err != nil {
+		return fmt.Errorf("error transacting ECMP slots of %s: %v", prefix, err)
+	}
+
+	return nil
+}
+
+// consistentHashGateway returns the gateway owning the ECMP slot of the pod IP, every gateway wins a share of the
+// slots proportional to its weight.
+func consistentHashGateway(podIP string, slot int, gateways map[string]*gateway_info.GatewayInfo) string {
+	var owner string
+	var ownerScore float64
+	for gw, gateway := range gateways {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(fmt.Sprintf("%s/%d/%s", podIP, slot, gw)))
+		// map the hash to the (0, 1) interval
+		u := (float64(h.Sum64()>>11) + 0.5) / (1 << 53)
+		score := -float64(gateway.ECMPMembers()) / math.Log(u)
+		if owner == "" || score > ownerScore || (score == ownerScore && gw < owner) {
+			owner, ownerScore = gw, score
+		}
+	}
+	return owner
+}
+
+// createOrUpdateBFDOps returns the ops to create or update the BFD session with gw on the port when the gateway has
+// BFD enabled, and the UUID of the BFD row to reference from the routes.
+func (nb *northBoundClient) createOrUpdateBFDOps(ops []ovsdb.Operation, gateway *gateway_info.GatewayInfo, gw,
+	port string) ([]ovsdb.Operation, *string, error) {
+	if !gateway.BFDEnabled {
+		return ops, nil, nil
+	}
+	bfd := nbdb.BFD{
+		DstIP:       gw,
+		LogicalPort: port,
+	}
+	bfdParams := gateway.BFDParams
+	if bfdParams.MinTx > 0 {
+		bfd.MinTx = &bfdParams.MinTx
+	}
+	if bfdParams.MinRx > 0 {
+		bfd.MinRx = &bfdParams.MinRx
+	}
+	if bfdParams.DetectMult > 0 {
+		bfd.DetectMult = &bfdParams.DetectMult
+	}
+	ops, err := libovsdbops.CreateOrUpdateBFDWithTimersOps(nb.nbClient, ops, &bfd)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error creating or updating BFD %+v: %v", bfd, err)
+	}
+	return ops, &bfd.UUID, nil
+}
+
+// createOrUpdateECMPStaticRouteOps returns the ops to create or update the static route for the prefix identified by
+// the idKey external ID, an empty idValue identifies the route without it. The ECMP slots of consistent hashing are
+// matched regardless of their next hop so that they are moved between gateways in place.
+func (nb *northBoundClient) createOrUpdateECMPStaticRouteOps(ops []ovsdb.Operation, gw, prefix, gr, port string,
+	bfdUUID *string, idKey, idValue string) ([]ovsdb.Operation, error) {
 	lrsr := nbdb.LogicalRouterStaticRoute{
 		Policy: &nbdb.LogicalRouterStaticRoutePolicySrcIP,
 		Options: map[string]string{
 			"ecmp_symmetric_reply": "true",
 		},
 		Nexthop:    gw,
-		IPPrefix:   podIP + mask,
+		IPPrefix:   prefix,
 		OutputPort: &port,
+		BFD:        bfdUUID,
 	}
-
-	ops := []ovsdb.Operation{}
-	var err error
-	if bfdEnabled {
-		bfd := nbdb.BFD{
-			DstIP:       gw,
-			LogicalPort: port,
-		}
-		ops, err = libovsdbops.CreateOrUpdateBFDOps(nb.nbClient, ops, &bfd)
-		if err != nil {
-			return fmt.Errorf("error creating or updating BFD %+v: %v", bfd, err)
-		}
-		lrsr.BFD = &bfd.UUID
+	if idValue != "" {
+		lrsr.ExternalIDs = map[string]string{idKey: idValue}
 	}
+	isSlot := idKey == types.ExternalGWECMPSlotExternalID
 
 	p := func(item *nbdb.LogicalRouterStaticRoute) bool {
+		if isSlot {
+			if item.ExternalIDs[types.ExternalGWECMPSlotExternalID] != idValue {
+				return false
+			}
+		} else if item.Nexthop != lrsr.Nexthop ||
+			item.ExternalIDs[types.ExternalGWECMPSlotExternalID] != "" ||
+			item.ExternalIDs[types.ExternalGWECMPMemberExternalID] != idValue {
+			return false
+		}
 		return item.IPPrefix == lrsr.IPPrefix &&
-			item.Nexthop == lrsr.Nexthop &&
 			item.OutputPort != nil &&
 			*item.OutputPort == *lrsr.OutputPort &&
-			item.Policy == lrsr.Policy
+			item.Policy != nil &&
+			*item.Policy == *lrsr.Policy
 	}
-	ops, err = libovsdbops.CreateOrUpdateLogicalRouterStaticRoutesWithPredicateOps(nb.nbClient, ops, gr, &lrsr, p,
-		&lrsr.Options)
+	ops, err := libovsdbops.CreateOrUpdateLogicalRouterStaticRoutesWithPredicateOps(nb.nbClient, ops, gr, &lrsr, p,
+		&lrsr.Nexthop, &lrsr.BFD, &lrsr.Options)
 	if err != nil {
-		return fmt.Errorf("error creating or updating static route %+v on router %s: %v", lrsr, gr, err)
+		return nil, fmt.Errorf("error creating or updating static route %+v on router %s: %v", lrsr, gr, err)
 	}
+	return ops, nil
+}
 
+// addDestinationPolicyNextHop adds gw to the next hops of the logical router policy rerouting the traffic from podIP
+// to the destinations on the gateway router. Static routes can only match either the source or the destination
+// of the traffic, so the routes restricted to destinations are implemented with policies.
+func (nb *northBoundClient) addDestinationPolicyNextHop(gw, podIP, gr string, destinations []string) error {
+	lrp := nbdb.LogicalRouterPolicy{
+		Priority: types.ExternalGWDestinationReroutePriority,
+		Match:    destinationPolicyMatch(podIP, destinations),
+		Action:   nbdb.LogicalRouterPolicyActionReroute,
+		Nexthops: []string{gw},
+	}
+	p := func(item *nbdb.LogicalRouterPolicy) bool {
+		return item.Priority == lrp.Priority && item.Match == lrp.Match
+	}
+	ops, err := libovsdbops.CreateOrAddNextHopsToLogicalRouterPolicyWithPredicateOps(nb.nbClient, nil, gr, &lrp, p)
+	if err != nil {
+		return fmt.Errorf("error creating or updating policy %+v on router %s: %v", lrp, gr, err)
+	}
 	_, err = libovsdbops.TransactAndCheck(nb.nbClient, ops)
 	if err != nil {
-		return fmt.Errorf("error transacting static route: %v", err)
+		return fmt.Errorf("error transacting policy %+v on router %s: %v", lrp, gr, err)
 	}
+	return nil
+}
 
+// deleteDestinationPolicyNextHop removes gw from the next hops of the logical router policies rerouting the
+// traffic from podIP on the gateway router. Policies left without next hops are deleted.
+func (nb *northBoundClient) deleteDestinationPolicyNextHop(gw, podIP, gr string) error {
+	matchPrefix := destinationPolicyMatchPrefix(podIP)
+	p := func(item *nbdb.LogicalRouterPolicy) bool {
+		return item.Priority == types.ExternalGWDestinationReroutePriority && strings.HasPrefix(item.Match, matchPrefix)
+	}
+	err := libovsdbops.DeleteNextHopFromLogicalRouterPoliciesWithPredicate(nb.nbClient, gr, p, gw)
+	if err != nil {
+		return fmt.Errorf("error deleting next hop %s from pod %s policies on router %s: %v", gw, podIP, gr, err)
+	}
 	return nil
 }
 
-func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP, gwIP, nodeName string, bfdEnabled bool, namespacedName ktypes.NamespacedName) error {
+func destinationPolicyMatchPrefix(podIP string) string {
+	l3Prefix := "ip4"
+	if utilnet.IsIPv6String(podIP) {
+		l3Prefix = "ip6"
+	}
+	return fmt.Sprintf("%s.src == %s && ", l3Prefix, podIP)
+}
+
+func destinationPolicyMatch(podIP string, destinations []string) string {
+	l3Prefix := "ip4"
+	if utilnet.IsIPv6String(podIP) {
+		l3Prefix = "ip6"
+	}
+	return fmt.Sprintf("%s%s.dst == {%s}", destinationPolicyMatchPrefix(podIP), l3Prefix, strings.Join(destinations, ", "))
+}
+
+// filterCIDRsByFamily returns the sorted CIDRs of the given IP family
+func filterCIDRsByFamily(cidrs sets.Set[string], isIPv6 bool) []string {
+	filtered := []string{}
+	for _, cidr := range sets.List(cidrs) {
+		if utilnet.IsIPv6CIDRString(cidr) == isIPv6 {
+			filtered = append(filtered, cidr)
+		}
+	}
+	return filtered
+}
+
+func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP, gwIP, nodeName string,
+	gateway *gateway_info.GatewayInfo, namespacedName ktypes.NamespacedName) error {
 	gr := util.GetGatewayRouterFromNode(nodeName)
 
 	return nb.externalGatewayRouteInfo.CreateOrLoad(namespacedName, func(routeInfo *RouteInfo) error {
@@ -435,13 +668,25 @@ func (nb *northBoundClient) updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP
 			klog.Warningf("Failed to find ext switch prefix for %s %v", nodeName, err)
 			return err
 		}
-		if bfdEnabled {
-			port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
-			// update the BFD static route just in case it has changed
-			if err := nb.createOrUpdateBFDStaticRoute(bfdEnabled, gwIP, podIP, gr, port, mask); err != nil {
+		port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
+		switch {
+		case gateway.ConsistentHashing:
+			if routeInfo.PodConsistentHashGateways[podIP] == nil {
+				routeInfo.PodConsistentHashGateways[podIP] = make(map[string]*gateway_info.GatewayInfo)
+			}
+			routeInfo.PodConsistentHashGateways[podIP][gwIP] = gateway
+			// redistribute the slots between the gateways found so far
+			if err := nb.syncConsistentHashRoutes(routeInfo.PodConsistentHashGateways[podIP], podIP, gr, port); err != nil {
+				delete(routeInfo.PodConsistentHashGateways[podIP], gwIP)
 				return err
 			}
-		} else {
+		case gateway.BFDEnabled || gateway.ECMPMembers() > 1:
+			// update the static routes just in case they have changed
+			if err := nb.createOrUpdateBFDStaticRoute(gateway, gwIP, podIP, gr, port, mask); err != nil {
+				return err
+			}
+		}
+		if !gateway.BFDEnabled {
 			_, err := nb.lookupBFDEntry(gwIP, gr, portPrefix)
 			if err != nil {
 				err = nb.cleanUpBFDEntry(gwIP, gr, portPrefix)
@@ -465,13 +710,34 @@ func (nb *northBoundClient) deletePodGWRoute(routeInfo *RouteInfo, podIP, gw, gr
 		return nil
 	}
 
+	node := util.GetWorkerFromGatewayRouter(gr)
+	portPrefix, err := nb.extSwitchPrefix(node)
+	if err != nil {
+		return err
+	}
+
+	if gateways := routeInfo.PodConsistentHashGateways[podIP]; gateways[gw] != nil {
+		// move the slots of the gateway to the remaining gateways before deleting its routes
+		delete(gateways, gw)
+		port := portPrefix + types.GWRouterToExtSwitchPrefix + gr
+		if err := nb.syncConsistentHashRoutes(gateways, podIP, gr, port); err != nil {
+			return fmt.Errorf("unable to update pod %s ECMP slots to GR %s, GW: %s: %w",
+				routeInfo.PodName, gr, gw, err)
+		}
+		if len(gateways) == 0 {
+			delete(routeInfo.PodConsistentHashGateways, podIP)
+		}
+	}
+
 	mask := util.GetIPFullMaskString(podIP)
 	if err := nb.deleteLogicalRouterStaticRoute(podIP, mask, gw, gr); err != nil {
 		return fmt.Errorf("unable to delete pod %s ECMP route to GR %s, GW: %s: %w",
 			routeInfo.PodName, gr, gw, err)
 	}
-
-	node := util.GetWorkerFromGatewayRouter(gr)
+	if err := nb.deleteDestinationPolicyNextHop(gw, podIP, gr); err != nil {
+		return fmt.Errorf("unable to delete pod %s destination policy to GR %s, GW: %s: %w",
+			routeInfo.PodName, gr, gw, err)
+	}
 
 	// The gw is deleted from the routes cache after this func is called, length 1
 	// means it is the last gw for the pod and the hybrid route policy should be deleted.
@@ -481,10 +747,6 @@ func (nb *northBoundClient) deletePodGWRoute(routeInfo *RouteInfo, podIP, gw, gr
 		}
 	}
 
-	portPrefix, err := nb.extSwitchPrefix(node)
-	if err != nil {
-		return err
-	}
 	return nb.cleanUpBFDEntry(gw, gr, portPrefix)
 }
 
@@ -642,6 +904,54 @@ func (nb *northBoundClient) lookupBFDEntry(gatewayIP, gatewayRouter, prefix stri
 	return found, nil
 }
 
+// getNextHopsBFDStatus returns the state of the BFD sessions established by the gateway routers with the given
+// gateway IPs, sorted by IP and node.
+func (nb *northBoundClient) getNextHopsBFDStatus(gwIPs sets.Set[string]) ([]adminpolicybasedrouteapi.NextHopStatus, error) {
+	bfds, err := libovsdbops.FindBFDsWithPredicate(nb.nbClient, func(item *nbdb.BFD) bool {
+		return gwIPs.Has(item.DstIP)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find BFD entries for gateway IPs %v: %w", sets.List(gwIPs), err)
+	}
+	nextHops := make([]adminpolicybasedrouteapi.NextHopStatus, 0, len(bfds))
+	for _, bfd := range bfds {
+		// the logical port is the gateway router port to the external switch: [prefix]rtoe-GR_<node>
+		idx := strings.Index(bfd.LogicalPort, types.GWRouterToExtSwitchPrefix+types.GWRouterPrefix)
+		if idx < 0 {
+			continue
+		}
+		nextHops = append(nextHops, adminpolicybasedrouteapi.NextHopStatus{
+			IP:        bfd.DstIP,
+			Node:      bfd.LogicalPort[idx+len(types.GWRouterToExtSwitchPrefix+types.GWRouterPrefix):],
+			BFDStatus: bfdStatusType(bfd.Status),
+		})
+	}
+	sort.Slice(nextHops, func(i, j int) bool {
+		if nextHops[i].IP != nextHops[j].IP {
+			return nextHops[i].IP < nextHops[j].IP
+		}
+		return nextHops[i].Node < nextHops[j].Node
+	})
+	return nextHops, nil
+}
+
+func bfdStatusType(status *nbdb.BFDStatus) adminpolicybasedrouteapi.BFDStatusType {
+	if status == nil {
+		return adminpolicybasedrouteapi.BFDStatusUnknown
+	}
+	switch *status {
+	case nbdb.BFDStatusUp:
+		return adminpolicybasedrouteapi.BFDStatusUp
+	case nbdb.BFDStatusDown:
+		return adminpolicybasedrouteapi.BFDStatusDown
+	case nbdb.BFDStatusInit:
+		return adminpolicybasedrouteapi.BFDStatusInit
+	case nbdb.BFDStatusAdminDown:
+		return adminpolicybasedrouteapi.BFDStatusAdminDown
+	}
+	return adminpolicybasedrouteapi.BFDStatusUnknown
+}
+
 // buildPodSNAT builds per pod SNAT rules towards the nodeIP that are applied to the GR where the pod resides
 // if allSNATs flag is set, then all the SNATs (including against egressIPs if any) for that pod will be returned
 func buildPodSNAT(extIPs, podIPNets []*net.IPNet) ([]*nbdb.NAT, error) {
@@ -87,23 +87,26 @@ func (c *ExternalGatewayMasterController) Repair() error {
 		}
 
 		for _, ovnRoute := range ovnRoutes {
-			// if length of the output port is 0, this is a legacy route (we now always specify output interface)
-			if len(ovnRoute.outport) == 0 {
-				continue
-			}
+			// policies of routes restricted to destinations have no output port
+			if ovnRoute.match == "" {
+				// if length of the output port is 0, this is a legacy route (we now always specify output interface)
+				if len(ovnRoute.outport) == 0 {
+					continue
+				}
 
-			node := util.GetWorkerFromGatewayRouter(ovnRoute.router)
-			// prefix will signify secondary exgw bridge, or empty if normal setup
-			// have to determine if a node changed while master was down and if the route swapped from
-			// the default bridge to a new secondary bridge (or vice versa)
-			prefix, err := c.nbClient.extSwitchPrefix(node)
-			if err != nil {
-				// we shouldn't continue in this case, because we cant be sure this is a route we want to remove
-				return fmt.Errorf("cannot sync exgw route: %+v, unable to determine exgw switch prefix: %v",
-					ovnRoute, err)
-			} else if (prefix != "" && !strings.Contains(ovnRoute.outport, prefix)) ||
-				(prefix == "" && strings.Contains(ovnRoute.outport, types.EgressGWSwitchPrefix)) {
-				continue
+				node := util.GetWorkerFromGatewayRouter(ovnRoute.router)
+				// prefix will signify secondary exgw bridge, or empty if normal setup
+				// have to determine if a node changed while master was down and if the route swapped from
+				// the default bridge to a new secondary bridge (or vice versa)
+				prefix, err := c.nbClient.extSwitchPrefix(node)
+				if err != nil {
+					// we shouldn't continue in this case, because we cant be sure this is a route we want to remove
+					return fmt.Errorf("cannot sync exgw route: %+v, unable to determine exgw switch prefix: %v",
+						ovnRoute, err)
+				} else if (prefix != "" && !strings.Contains(ovnRoute.outport, prefix)) ||
+					(prefix == "" && strings.Contains(ovnRoute.outport, types.EgressGWSwitchPrefix)) {
+					continue
+				}
 			}
 			if expectedNextHopsPolicy != nil {
 				ovnRoute.shouldExist = c.processOVNRoute(ovnRoute, expectedNextHopsPolicy.gwList, podIP, expectedNextHopsPolicy, true)
@@ -124,7 +127,16 @@ func (c *ExternalGatewayMasterController) Repair() error {
 	for podIP, ovnRoutes := range ovnRouteCache {
 		podHasAnyECMPRoutes := false
 		for _, ovnRoute := range ovnRoutes {
-			if !ovnRoute.shouldExist {
+			if !ovnRoute.shouldExist && ovnRoute.match != "" {
+				klog.V(4).Infof("Found stale exgw destination policy next hop, podIP: %s, nexthop: %s, router: %s",
+					podIP, ovnRoute.nextHop, ovnRoute.router)
+				lrp := nbdb.LogicalRouterPolicy{UUID: ovnRoute.uuid, Nexthops: []string{ovnRoute.nextHop}}
+				err := c.nbClient.deleteLogicalRouterPolicyNextHops(ovnRoute.router, &lrp)
+				if err != nil {
+					return fmt.Errorf("error deleting next hop %s from policy %s on router %s: %v", ovnRoute.nextHop,
+						ovnRoute.uuid, ovnRoute.router, err)
+				}
+			} else if !ovnRoute.shouldExist {
 				klog.V(4).Infof("Found stale exgw ecmp route, podIP: %s, nexthop: %s, router: %s",
 					podIP, ovnRoute.nextHop, ovnRoute.router)
 				lrsr := nbdb.LogicalRouterStaticRoute{UUID: ovnRoute.uuid}
@@ -263,13 +275,17 @@ func (c *ExternalGatewayMasterController) processOVNRoute(ovnRoute *ovnRoute, gw
 	managedIPGWInfo *managedGWIPs, noDbChanges bool) bool {
 	// podIP exists, check if route matches
 	for _, gwInfo := range gwList.Elems() {
+		if !ovnRoute.implementsDestinations(podIP, gwInfo.Destinations) {
+			continue
+		}
 		for clusterNextHop := range gwInfo.Gateways {
 			if ovnRoute.nextHop == clusterNextHop {
 				// populate the externalGWInfo cache with this pair podIP->next Hop IP.
 				if noDbChanges {
 					return true
 				}
-				err := c.nbClient.updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP, ovnRoute.nextHop, managedIPGWInfo.nodeName, gwInfo.BFDEnabled, managedIPGWInfo.namespacedName)
+				err := c.nbClient.updateExternalGWInfoCacheForPodIPWithGatewayIP(podIP, ovnRoute.nextHop, managedIPGWInfo.nodeName, gwInfo,
+					managedIPGWInfo.namespacedName)
 				if err == nil {
 					return true
 				}
@@ -376,13 +392,24 @@ func populateManagedGWIPsCacheForPods(gwInfo *gateway_info.GatewayInfo, cache ma
 // Build cache of routes in OVN
 // map[podIP][]ovnRoute
 type ovnRoute struct {
-	nextHop     string
-	uuid        string
-	router      string
-	outport     string
+	nextHop string
+	uuid    string
+	router  string
+	outport string
+	// match is only set for the logical router policies implementing routes restricted to destinations
+	match       string
 	shouldExist bool
 }
 
+// implementsDestinations returns true if the route is a static route and there are no destinations, or if it is a
+// policy matching the destinations.
+func (r *ovnRoute) implementsDestinations(podIP string, destinations sets.Set[string]) bool {
+	if destinations.Len() == 0 {
+		return r.match == ""
+	}
+	return r.match == destinationPolicyMatch(podIP, filterCIDRsByFamily(destinations, utilnet.IsIPv6String(podIP)))
+}
+
 func (c *ExternalGatewayMasterController) buildOVNECMPCache() (map[string][]*ovnRoute, error) {
 	p := func(item *nbdb.LogicalRouterStaticRoute) bool {
 		return item.Options["ecmp_symmetric_reply"] == "true"
@@ -415,6 +442,40 @@ func (c *ExternalGatewayMasterController) buildOVNECMPCache() (map[string][]*ovn
 			ovnRouteCache[podIP.String()] = append(ovnRouteCache[podIP.String()], route)
 		}
 	}
+
+	lrpPredicate := func(item *nbdb.LogicalRouterPolicy) bool {
+		return item.Priority == types.ExternalGWDestinationReroutePriority
+	}
+	logicalRouterPolicies, err := c.nbClient.findLogicalRouterPoliciesWithPredicate(lrpPredicate)
+	if err != nil {
+		return nil, fmt.Errorf("CleanECMPRoutes: failed to list destination policies: %v", err)
+	}
+	for _, logicalRouterPolicy := range logicalRouterPolicies {
+		p := func(item *nbdb.LogicalRouter) bool {
+			return util.SliceHasStringItem(item.Policies, logicalRouterPolicy.UUID)
+		}
+		logicalRouters, err := c.nbClient.findLogicalRoutersWithPredicate(p)
+		if err != nil {
+			return nil, fmt.Errorf("CleanECMPRoutes: failed to find logical router for %s, err: %v", logicalRouterPolicy.UUID, err)
+		}
+		// the match starts with "ip4.src == <podIP> && "
+		matchFields := strings.Fields(logicalRouterPolicy.Match)
+		if len(logicalRouters) == 0 || len(matchFields) < 3 {
+			continue
+		}
+		podIP := utilnet.ParseIPSloppy(matchFields[2])
+		if podIP == nil {
+			continue
+		}
+		for _, nextHop := range logicalRouterPolicy.Nexthops {
+			ovnRouteCache[podIP.String()] = append(ovnRouteCache[podIP.String()], &ovnRoute{
+				nextHop: nextHop,
+				uuid:    logicalRouterPolicy.UUID,
+				router:  logicalRouters[0].Name,
+				match:   logicalRouterPolicy.Match,
+			})
+		}
+	}
 	return ovnRouteCache, nil
 }
 
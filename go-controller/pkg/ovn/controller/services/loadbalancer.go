@@ -288,12 +288,19 @@ func EnsureLBs(nbClient libovsdbclient.Client, service *corev1.Service, existing
 		klog.Errorf("Failed to record config duration: %v", err)
 	}
 	ops = append(ops, recordOps...)
+	recordOps, endpointSliceTxOkCallBack, _, err := metrics.GetConfigDurationRecorder().AddOVN(nbClient,
+		"endpointslice", service.Namespace, service.Name)
+	if err != nil {
+		klog.Errorf("Failed to record config duration: %v", err)
+	}
+	ops = append(ops, recordOps...)
 
 	_, err = libovsdbops.TransactAndCheckAndSetUUIDs(nbClient, toNBLoadBalancerList(tlbs), ops)
 	if err != nil {
 		return fmt.Errorf("failed to ensure load balancers for service %s/%s: %w", service.Namespace, service.Name, err)
 	}
 	txOkCallBack()
+	endpointSliceTxOkCallBack()
 
 	// Store UUID of newly created load balancers for future calls.
 	// This is accomplished by the caching of LBs by the caller of this function.
@@ -260,6 +260,7 @@ func (c *Controller) handleErr(err error, key interface{}) {
 	}
 	if err == nil {
 		metrics.GetConfigDurationRecorder().End("service", ns, name)
+		metrics.GetConfigDurationRecorder().End("endpointslice", ns, name)
 		c.queue.Forget(key)
 		return
 	}
@@ -274,6 +275,7 @@ func (c *Controller) handleErr(err error, key interface{}) {
 
 	klog.Warningf("Dropping service %q out of the queue: %v", key, err)
 	metrics.GetConfigDurationRecorder().End("service", ns, name)
+	metrics.GetConfigDurationRecorder().End("endpointslice", ns, name)
 	c.queue.Forget(key)
 	utilruntime.HandleError(err)
 }
@@ -649,6 +651,9 @@ func (c *Controller) queueServiceForEndpointSlice(endpointSlice *discovery.Endpo
 		return
 	}
 
+	// endpoint slice changes are measured per service, since they are applied to the load balancers of their service
+	metrics.GetConfigDurationRecorder().Start("endpointslice", endpointSlice.Namespace,
+		endpointSlice.Labels[discovery.LabelServiceName])
 	c.queue.Add(key)
 }
 
@@ -54,6 +54,10 @@ type DefaultNetworkController struct {
 
 	externalGatewayRouteInfo *apbroutecontroller.ExternalGatewayRouteInfoCache
 
+	// gatewayUplinkNodeIPs is set once the node IPs address set is maintained for the gateway uplinks, protected by
+	// eIPC.nodeIPUpdateMutex
+	gatewayUplinkNodeIPs bool
+
 	// egressFirewalls is a map of namespaces and the egressFirewall attached to it
 	egressFirewalls sync.Map
 
@@ -325,6 +329,7 @@ func (oc *DefaultNetworkController) Start(ctx context.Context) error {
 
 // Stop gracefully stops the controller
 func (oc *DefaultNetworkController) Stop() {
+	metrics.SetFlowSamplingObservationsSource(nil)
 	close(oc.stopChan)
 	oc.cancelableCtx.Cancel()
 	oc.wg.Wait()
@@ -411,6 +416,10 @@ func (oc *DefaultNetworkController) Init(ctx context.Context) error {
 // Run starts the actual watching.
 func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 	oc.syncPeriodic()
+	// the ACLs must keep the observation IDs they are labeled with before the handlers label other ACLs
+	if err := oc.reserveFlowSamplingObservationIDs(); err != nil {
+		return err
+	}
 	klog.Info("Starting all the Watchers...")
 	start := time.Now()
 
@@ -562,6 +571,21 @@ func (oc *DefaultNetworkController) Run(ctx context.Context) error {
 		}()
 	}
 
+	if config.OVNKubernetesFeature.NBDriftAuditInterval > 0 {
+		auditor := newNBDriftAuditor(oc, config.OVNKubernetesFeature.NBDriftAuditMode)
+		oc.wg.Add(1)
+		go func() {
+			defer oc.wg.Done()
+			auditor.Run(time.Duration(config.OVNKubernetesFeature.NBDriftAuditInterval)*time.Second, oc.stopChan)
+		}()
+	}
+	oc.wg.Add(1)
+	go func() {
+		defer oc.wg.Done()
+		oc.runFlowSampling(oc.stopChan)
+	}()
+	metrics.SetFlowSamplingObservationsSource(oc.getFlowSamplingObservations)
+
 	return nil
 }
 
@@ -774,6 +798,9 @@ func (h *defaultNetworkControllerEventHandler) AddResource(obj interface{}, from
 			h.oc.syncHostNetAddrSetFailed.Store(node.Name, true)
 			aggregatedErrors = append(aggregatedErrors, err)
 		}
+		if err = h.oc.ensureGatewayUplinkNodeIPs(false); err != nil {
+			aggregatedErrors = append(aggregatedErrors, err)
+		}
 		return kerrors.NewAggregate(aggregatedErrors)
 
 	case factory.EgressFirewallType:
@@ -954,6 +981,11 @@ func (h *defaultNetworkControllerEventHandler) UpdateResource(oldObj, newObj int
 				h.oc.syncHostNetAddrSetFailed.Delete(newNode.Name)
 			}
 		}
+		if util.NodeHostCIDRsAnnotationChanged(oldNode, newNode) {
+			if err := h.oc.ensureGatewayUplinkNodeIPs(false); err != nil {
+				aggregatedErrors = append(aggregatedErrors, err)
+			}
+		}
 		return kerrors.NewAggregate(aggregatedErrors)
 
 	case factory.EgressIPType:
@@ -1027,7 +1059,10 @@ func (h *defaultNetworkControllerEventHandler) DeleteResource(obj, cachedObj int
 		if !ok {
 			return fmt.Errorf("could not cast obj of type %T to *knet.Node", obj)
 		}
-		return h.oc.deleteNodeEvent(node)
+		if err := h.oc.deleteNodeEvent(node); err != nil {
+			return err
+		}
+		return h.oc.ensureGatewayUplinkNodeIPs(false)
 
 	case factory.EgressFirewallType:
 		egressFirewall := obj.(*egressfirewall.EgressFirewall)
@@ -16,6 +16,7 @@ import (
 	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/batching"
@@ -388,42 +389,16 @@ func (oc *DefaultNetworkController) addEgressFirewallRules(ef *egressFirewall, p
 				continue
 			}
 		}
-		var action string
-		var matchTargets []matchTarget
-		if rule.access == egressfirewallapi.EgressFirewallRuleAllow {
-			action = nbdb.ACLActionAllow
-		} else {
-			action = nbdb.ACLActionDrop
-		}
-		if len(rule.to.nodeAddrs) > 0 {
-			for _, addr := range sets.List(rule.to.nodeAddrs) {
-				// ideally we don't care about sorting this list, but this is being done to ensure Unit Test consistency
-				// and its not like this nodeAddrs can be super large per node EFW rule to cause scale issues
-				if utilnet.IsIPv6String(addr) {
-					matchTargets = append(matchTargets, matchTarget{matchKindV6CIDR, addr, false})
-				} else {
-					matchTargets = append(matchTargets, matchTarget{matchKindV4CIDR, addr, false})
-				}
-			}
-		} else if rule.to.cidrSelector != "" {
-			if utilnet.IsIPv6CIDRString(rule.to.cidrSelector) {
-				matchTargets = []matchTarget{{matchKindV6CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
-			} else {
-				matchTargets = []matchTarget{{matchKindV4CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
-			}
-		} else if len(rule.to.dnsName) > 0 {
-			// rule based on DNS NAME
-			dnsNameAddressSets, err := oc.egressFirewallDNS.Add(ef.namespace, rule.to.dnsName)
+		action := rule.getACLAction()
+		matchTargets, err := rule.getMatchTargets(func(dnsName string) (addressset.AddressSet, error) {
+			dnsNameAddressSets, err := oc.egressFirewallDNS.Add(ef.namespace, dnsName)
 			if err != nil {
-				return fmt.Errorf("error with EgressFirewallDNS - %v", err)
-			}
-			dnsNameIPv4ASHashName, dnsNameIPv6ASHashName := dnsNameAddressSets.GetASHashNames()
-			if dnsNameIPv4ASHashName != "" {
-				matchTargets = append(matchTargets, matchTarget{matchKindV4AddressSet, dnsNameIPv4ASHashName, rule.to.clusterSubnetIntersection})
-			}
-			if dnsNameIPv6ASHashName != "" {
-				matchTargets = append(matchTargets, matchTarget{matchKindV6AddressSet, dnsNameIPv6ASHashName, rule.to.clusterSubnetIntersection})
+				return nil, fmt.Errorf("error with EgressFirewallDNS - %v", err)
 			}
+			return dnsNameAddressSets, nil
+		})
+		if err != nil {
+			return err
 		}
 
 		if len(matchTargets) == 0 {
@@ -448,6 +423,51 @@ func (oc *DefaultNetworkController) addEgressFirewallRules(ef *egressFirewall, p
 	return nil
 }
 
+// getACLAction returns the action of the ACL of the rule
+func (rule *egressFirewallRule) getACLAction() string {
+	if rule.access == egressfirewallapi.EgressFirewallRuleAllow {
+		return nbdb.ACLActionAllow
+	}
+	return nbdb.ACLActionDrop
+}
+
+// getMatchTargets returns the destinations of the rule, getDNSAddressSet returns the address set of the DNS name
+// of a rule based on a DNS name
+func (rule *egressFirewallRule) getMatchTargets(getDNSAddressSet func(dnsName string) (addressset.AddressSet, error)) ([]matchTarget, error) {
+	var matchTargets []matchTarget
+	if len(rule.to.nodeAddrs) > 0 {
+		for _, addr := range sets.List(rule.to.nodeAddrs) {
+			// ideally we don't care about sorting this list, but this is being done to ensure Unit Test consistency
+			// and its not like this nodeAddrs can be super large per node EFW rule to cause scale issues
+			if utilnet.IsIPv6String(addr) {
+				matchTargets = append(matchTargets, matchTarget{matchKindV6CIDR, addr, false})
+			} else {
+				matchTargets = append(matchTargets, matchTarget{matchKindV4CIDR, addr, false})
+			}
+		}
+	} else if rule.to.cidrSelector != "" {
+		if utilnet.IsIPv6CIDRString(rule.to.cidrSelector) {
+			matchTargets = []matchTarget{{matchKindV6CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
+		} else {
+			matchTargets = []matchTarget{{matchKindV4CIDR, rule.to.cidrSelector, rule.to.clusterSubnetIntersection}}
+		}
+	} else if len(rule.to.dnsName) > 0 {
+		// rule based on DNS NAME
+		dnsNameAddressSets, err := getDNSAddressSet(rule.to.dnsName)
+		if err != nil {
+			return nil, err
+		}
+		dnsNameIPv4ASHashName, dnsNameIPv6ASHashName := dnsNameAddressSets.GetASHashNames()
+		if dnsNameIPv4ASHashName != "" {
+			matchTargets = append(matchTargets, matchTarget{matchKindV4AddressSet, dnsNameIPv4ASHashName, rule.to.clusterSubnetIntersection})
+		}
+		if dnsNameIPv6ASHashName != "" {
+			matchTargets = append(matchTargets, matchTarget{matchKindV6AddressSet, dnsNameIPv6ASHashName, rule.to.clusterSubnetIntersection})
+		}
+	}
+	return matchTargets, nil
+}
+
 // createEgressFirewallACLOps uses the previously generated elements and creates the
 // acls for all node switches
 func (oc *DefaultNetworkController) createEgressFirewallACLOps(ops []libovsdb.Operation, ruleIdx int, match, action, namespace, pgName string, aclLogging *libovsdbutil.ACLLoggingLevels) ([]libovsdb.Operation, error) {
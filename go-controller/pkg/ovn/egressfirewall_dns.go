@@ -98,6 +98,20 @@ func (e *EgressDNS) Add(namespace, dnsName string) (addressset.AddressSet, error
 
 }
 
+// GetAddressSet returns the address set of a DNS name added for the given namespace
+func (e *EgressDNS) GetAddressSet(namespace, dnsName string) (addressset.AddressSet, error) {
+	e.lock.Lock()
+	defer e.lock.Unlock()
+	entry, exists := e.dnsEntries[dnsName]
+	if !exists {
+		return nil, fmt.Errorf("DNS name %s was not added", dnsName)
+	}
+	if _, exists := entry.namespaces[namespace]; !exists {
+		return nil, fmt.Errorf("DNS name %s was not added for namespace %s", dnsName, namespace)
+	}
+	return entry.dnsAddressSet, nil
+}
+
 func (e *EgressDNS) Delete(namespace string) error {
 	e.lock.Lock()
 	var dnsNamesToDelete []string
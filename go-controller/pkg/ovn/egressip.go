@@ -72,6 +72,14 @@ func getEgressIPAddrSetDbIDs(name egressIpAddrSetName, controller string) *libov
 //
 //	We only care about `Spec.NamespaceSelector`, `Spec.PodSelector` and `Status` field
 func (oc *DefaultNetworkController) reconcileEgressIP(old, new *egressipv1.EgressIP) (err error) {
+	// Egress IPs referencing a secondary network are configured by that network's
+	// controller: handle them as if they did not exist.
+	if old != nil && old.Spec.Network != "" {
+		old = nil
+	}
+	if new != nil && new.Spec.Network != "" {
+		new = nil
+	}
 	// CASE 1: EIP object deletion, we need to teardown database configuration for all the statuses
 	if old != nil && new == nil {
 		removeStatus := old.Status.Items
@@ -303,6 +311,9 @@ func (oc *DefaultNetworkController) reconcileEgressIPNamespace(old, new *v1.Name
 		return err
 	}
 	for _, egressIP := range egressIPs {
+		if egressIP.Spec.Network != "" {
+			continue
+		}
 		namespaceSelector, err := metav1.LabelSelectorAsSelector(&egressIP.Spec.NamespaceSelector)
 		if err != nil {
 			return err
@@ -373,6 +384,9 @@ func (oc *DefaultNetworkController) reconcileEgressIPPod(old, new *v1.Pod) (err
 		return err
 	}
 	for _, egressIP := range egressIPs {
+		if egressIP.Spec.Network != "" {
+			continue
+		}
 		namespaceSelector, err := metav1.LabelSelectorAsSelector(&egressIP.Spec.NamespaceSelector)
 		if err != nil {
 			return err
@@ -976,7 +990,11 @@ func (oc *DefaultNetworkController) syncStaleEgressReroutePolicy(egressIPCache m
 		if item.Priority != types.EgressIPReroutePriority {
 			return false
 		}
-		egressIPName := item.ExternalIDs["name"]
+		// Only consider the policies owned by the egress IPs of the default network
+		egressIPName, isEgressIPPolicy := item.ExternalIDs["name"]
+		if !isEgressIPPolicy || item.ExternalIDs[types.NetworkExternalID] != "" {
+			return false
+		}
 		cacheEntry, exists := egressIPCache[egressIPName]
 		splitMatch := strings.Split(item.Match, " ")
 		logicalIP := splitMatch[len(splitMatch)-1]
@@ -1038,6 +1056,10 @@ func (oc *DefaultNetworkController) syncStaleSNATRules(egressIPCache map[string]
 		if !exists || item.Type != nbdb.NATTypeSNAT {
 			return false
 		}
+		// Exclude rows of secondary networks, synced by their network controller
+		if item.ExternalIDs[types.NetworkExternalID] != "" {
+			return false
+		}
 		parsedLogicalIP := net.ParseIP(item.LogicalIP).String()
 		cacheEntry, exists := egressIPCache[egressIPName]
 		egressPodIPs := sets.NewString()
@@ -1129,6 +1151,9 @@ func (oc *DefaultNetworkController) generateCacheForEgressIP() (map[string]egres
 		return nil, err
 	}
 	for _, egressIP := range egressIPs {
+		if egressIP.Spec.Network != "" {
+			continue
+		}
 		egressIPCache[egressIP.Name] = egressIPCacheEntry{
 			egressLocalPods:  make(map[string]sets.Set[string]),
 			egressRemotePods: make(map[string]sets.Set[string]),
@@ -0,0 +1,499 @@
+package ovn
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog/v2"
+	utilnet "k8s.io/utils/net"
+)
+
+// WatchEgressIPs starts syncing the egress IPs that reference one of the network's
+// network attachment definitions. For those, pod traffic is rerouted on the network's
+// cluster router towards the management port of the network on the egress node, and
+// SNATed to the egress IP by the egress node, as for the egress IPs of the default network
+// hosted by a secondary host network. Layer3 secondary networks have no gateway router, so
+// the egress IPs hosted by the OVN network of the egress node are SNATed by the node as well,
+// on its gateway bridge interface.
+// The egress IPs are queued by name on the egress IP events, and on the pod, namespace and
+// node events of the network controller that may change their configuration.
+func (oc *SecondaryLayer3NetworkController) WatchEgressIPs() error {
+	if !config.OVNKubernetesFeature.EnableEgressIP || oc.egressIPQueue != nil {
+		return nil
+	}
+	oc.egressIPQueue = workqueue.NewNamedRateLimitingQueue(
+		workqueue.NewItemFastSlowRateLimiter(time.Second, 5*time.Second, 5),
+		oc.GetNetworkName()+"-egressip",
+	)
+
+	// egress IPs deleted while ovnkube-controller was down have no event, sync the
+	// ones that still have reroute policies
+	existing, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(oc.nbClient, oc.isEgressIPReroutePolicy)
+	if err != nil {
+		return fmt.Errorf("failed to find egress IP reroute policies of network %s: %w", oc.GetNetworkName(), err)
+	}
+	for _, lrp := range existing {
+		oc.egressIPQueue.Add(lrp.ExternalIDs["name"])
+	}
+
+	oc.egressIPHandler, err = oc.watchFactory.AddEgressIPHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			oc.enqueueEgressIP(nil, obj.(*egressipv1.EgressIP))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oc.enqueueEgressIP(oldObj.(*egressipv1.EgressIP), newObj.(*egressipv1.EgressIP))
+		},
+		DeleteFunc: func(obj interface{}) {
+			egressIP, ok := obj.(*egressipv1.EgressIP)
+			if !ok {
+				tombstone, ok := obj.(cache.DeletedFinalStateUnknown)
+				if !ok {
+					utilruntime.HandleError(fmt.Errorf("couldn't get object from tombstone %#v", obj))
+					return
+				}
+				if egressIP, ok = tombstone.Obj.(*egressipv1.EgressIP); !ok {
+					utilruntime.HandleError(fmt.Errorf("tombstone contained object that is not an EgressIP %#v", tombstone.Obj))
+					return
+				}
+			}
+			oc.enqueueEgressIP(egressIP, nil)
+		},
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	oc.wg.Add(1)
+	go func() {
+		defer oc.wg.Done()
+		wait.Until(oc.runEgressIPWorker, time.Second, oc.stopChan)
+	}()
+	go func() {
+		<-oc.stopChan
+		oc.egressIPQueue.ShutDown()
+	}()
+	return nil
+}
+
+// stopWatchingEgressIPs removes the egress IP event handler added by WatchEgressIPs
+func (oc *SecondaryLayer3NetworkController) stopWatchingEgressIPs() {
+	if oc.egressIPHandler != nil {
+		oc.watchFactory.RemoveEgressIPHandler(oc.egressIPHandler)
+	}
+}
+
+// isEgressIPOnNetwork returns whether the egress IP references one of the network's
+// network attachment definitions
+func (oc *SecondaryLayer3NetworkController) isEgressIPOnNetwork(egressIP *egressipv1.EgressIP) bool {
+	return egressIP != nil && egressIP.Spec.Network != "" && oc.HasNAD(egressIP.Spec.Network)
+}
+
+// isEgressIPReroutePolicy returns whether the logical router policy is an egress IP reroute
+// policy of the network
+func (oc *SecondaryLayer3NetworkController) isEgressIPReroutePolicy(item *nbdb.LogicalRouterPolicy) bool {
+	return item.Priority == types.SecondaryNetworkEgressIPReroutePriority && item.ExternalIDs["name"] != "" &&
+		item.ExternalIDs[types.NetworkExternalID] == oc.GetNetworkName()
+}
+
+// enqueueEgressIP queues the egress IP if it references the network before or after the change
+func (oc *SecondaryLayer3NetworkController) enqueueEgressIP(oldEgressIP, newEgressIP *egressipv1.EgressIP) {
+	if !oc.isEgressIPOnNetwork(oldEgressIP) && !oc.isEgressIPOnNetwork(newEgressIP) {
+		return
+	}
+	if oldEgressIP != nil && newEgressIP != nil && reflect.DeepEqual(oldEgressIP.Spec, newEgressIP.Spec) &&
+		reflect.DeepEqual(oldEgressIP.Status, newEgressIP.Status) {
+		return
+	}
+	if newEgressIP != nil {
+		oc.egressIPQueue.Add(newEgressIP.Name)
+		return
+	}
+	oc.egressIPQueue.Add(oldEgressIP.Name)
+}
+
+// reconcileEgressIPPod queues the egress IPs of the network that select the pod before or
+// after the change.
+func (oc *SecondaryLayer3NetworkController) reconcileEgressIPPod(oldPod, newPod *kapi.Pod) error {
+	if oc.egressIPQueue == nil {
+		return nil
+	}
+	pod := newPod
+	if pod == nil {
+		pod = oldPod
+	}
+	namespace, err := oc.watchFactory.GetNamespace(pod.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	return oc.enqueueEgressIPsWithPredicate(func(egressIP *egressipv1.EgressIP) (bool, error) {
+		selected := false
+		for _, p := range []*kapi.Pod{oldPod, newPod} {
+			if p == nil || len(util.PodNADIPs(p, egressIP.Spec.Network)) == 0 {
+				continue
+			}
+			ok, err := egressIPSelects(egressIP, namespace.Labels, p.Labels)
+			if err != nil {
+				return false, err
+			}
+			selected = selected || ok
+		}
+		return selected, nil
+	})
+}
+
+// reconcileEgressIPNamespace queues the egress IPs of the network that select the namespace
+// before or after a change of its labels.
+func (oc *SecondaryLayer3NetworkController) reconcileEgressIPNamespace(oldNamespace, newNamespace *kapi.Namespace) error {
+	if oc.egressIPQueue == nil || reflect.DeepEqual(oldNamespace.Labels, newNamespace.Labels) {
+		return nil
+	}
+	return oc.enqueueEgressIPsWithPredicate(func(egressIP *egressipv1.EgressIP) (bool, error) {
+		namespaceSelector, err := metav1.LabelSelectorAsSelector(&egressIP.Spec.NamespaceSelector)
+		if err != nil {
+			return false, err
+		}
+		return namespaceSelector.Matches(labels.Set(oldNamespace.Labels)) ||
+			namespaceSelector.Matches(labels.Set(newNamespace.Labels)), nil
+	})
+}
+
+// reconcileEgressIPNode queues the egress IPs of the network assigned to the node, whose next
+// hop may have changed.
+func (oc *SecondaryLayer3NetworkController) reconcileEgressIPNode(nodeName string) error {
+	if oc.egressIPQueue == nil {
+		return nil
+	}
+	return oc.enqueueEgressIPsWithPredicate(func(egressIP *egressipv1.EgressIP) (bool, error) {
+		for _, status := range egressIP.Status.Items {
+			if status.Node == nodeName {
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+}
+
+// enqueueEgressIPsWithPredicate queues the egress IPs of the network matching the predicate
+func (oc *SecondaryLayer3NetworkController) enqueueEgressIPsWithPredicate(p func(*egressipv1.EgressIP) (bool, error)) error {
+	egressIPs, err := oc.watchFactory.GetEgressIPs()
+	if err != nil {
+		return err
+	}
+	for _, egressIP := range egressIPs {
+		if !oc.isEgressIPOnNetwork(egressIP) {
+			continue
+		}
+		ok, err := p(egressIP)
+		if err != nil {
+			return err
+		}
+		if ok {
+			oc.egressIPQueue.Add(egressIP.Name)
+		}
+	}
+	return nil
+}
+
+// egressIPSelects returns whether the egress IP selects a pod with the given labels in a
+// namespace with the given labels
+func egressIPSelects(egressIP *egressipv1.EgressIP, namespaceLabels, podLabels map[string]string) (bool, error) {
+	namespaceSelector, err := metav1.LabelSelectorAsSelector(&egressIP.Spec.NamespaceSelector)
+	if err != nil {
+		return false, err
+	}
+	podSelector, err := metav1.LabelSelectorAsSelector(&egressIP.Spec.PodSelector)
+	if err != nil {
+		return false, err
+	}
+	return namespaceSelector.Matches(labels.Set(namespaceLabels)) && podSelector.Matches(labels.Set(podLabels)), nil
+}
+
+func (oc *SecondaryLayer3NetworkController) runEgressIPWorker() {
+	for oc.processNextEgressIPWorkItem() {
+	}
+}
+
+func (oc *SecondaryLayer3NetworkController) processNextEgressIPWorkItem() bool {
+	key, shutdown := oc.egressIPQueue.Get()
+	if shutdown {
+		return false
+	}
+	defer oc.egressIPQueue.Done(key)
+
+	if err := oc.syncEgressIP(key.(string)); err != nil {
+		utilruntime.HandleError(fmt.Errorf("failed to sync egress IP %s of network %s: %w", key, oc.GetNetworkName(), err))
+		oc.egressIPQueue.AddRateLimited(key)
+		return true
+	}
+	oc.egressIPQueue.Forget(key)
+	return true
+}
+
+// syncEgressIP computes the reroute policies of the egress IP on the network, none if it
+// was deleted or does not reference the network anymore, and reconciles them with the
+// ones in the NB database.
+func (oc *SecondaryLayer3NetworkController) syncEgressIP(name string) error {
+	egressIP, err := oc.watchFactory.GetEgressIP(name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	reroutes := map[string]*nbdb.LogicalRouterPolicy{}
+	if oc.isEgressIPOnNetwork(egressIP) {
+		reroutes, err = oc.getEgressIPReroutes(egressIP)
+		if err != nil {
+			return err
+		}
+	}
+	ops, err := oc.egressIPReroutesOps(nil, name, reroutes)
+	if err != nil {
+		return err
+	}
+	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
+	return err
+}
+
+// getEgressIPReroutes returns the reroute policies of the egress IP on the network's cluster
+// router, by match
+func (oc *SecondaryLayer3NetworkController) getEgressIPReroutes(egressIP *egressipv1.EgressIP) (map[string]*nbdb.LogicalRouterPolicy, error) {
+	reroutes := map[string]*nbdb.LogicalRouterPolicy{}
+	pods, err := oc.getEgressIPPods(egressIP)
+	if err != nil {
+		return nil, err
+	}
+	if len(pods) == 0 {
+		return reroutes, nil
+	}
+	for _, status := range egressIP.Status.Items {
+		_, isLocalZoneEgressNode := oc.localZoneNodes.Load(status.Node)
+		nextHop, err := oc.getEgressIPNextHop(egressIP.Name, status, isLocalZoneEgressNode)
+		if err != nil {
+			return nil, err
+		}
+		if nextHop == "" {
+			continue
+		}
+		isEgressIPv6 := utilnet.IsIPv6String(status.EgressIP)
+		for _, pod := range pods {
+			// reroute local pods, and remote pods on the egress node to its management port
+			if !oc.isPodScheduledinLocalZone(pod) && !(isLocalZoneEgressNode && config.OVNKubernetesFeature.EnableInterconnect) {
+				continue
+			}
+			for _, podIP := range util.PodNADIPs(pod, egressIP.Spec.Network) {
+				if utilnet.IsIPv6(podIP) != isEgressIPv6 {
+					continue
+				}
+				match := fmt.Sprintf("%s.src == %s", ipFamilyName(isEgressIPv6), podIP.String())
+				lrp, ok := reroutes[match]
+				if !ok {
+					lrp = &nbdb.LogicalRouterPolicy{
+						Match:    match,
+						Priority: types.SecondaryNetworkEgressIPReroutePriority,
+						Action:   nbdb.LogicalRouterPolicyActionReroute,
+						ExternalIDs: map[string]string{
+							"name":                  egressIP.Name,
+							types.NetworkExternalID: oc.GetNetworkName(),
+						},
+					}
+					reroutes[match] = lrp
+				}
+				if !sets.New(lrp.Nexthops...).Has(nextHop) {
+					lrp.Nexthops = append(lrp.Nexthops, nextHop)
+					sort.Strings(lrp.Nexthops)
+				}
+			}
+		}
+	}
+	return reroutes, nil
+}
+
+// egressIPReroutesOps returns the ops that create the missing reroute policies of the egress
+// IP, update the ones with different next hops and delete its stale ones.
+func (oc *SecondaryLayer3NetworkController) egressIPReroutesOps(ops []ovsdb.Operation, egressIPName string,
+	reroutes map[string]*nbdb.LogicalRouterPolicy) ([]ovsdb.Operation, error) {
+	clusterRouter := oc.GetNetworkScopedName(types.OVNClusterRouter)
+	isEgressIPReroutePolicy := func(item *nbdb.LogicalRouterPolicy) bool {
+		return oc.isEgressIPReroutePolicy(item) && item.ExternalIDs["name"] == egressIPName
+	}
+	existing, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(oc.nbClient, isEgressIPReroutePolicy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reroute policies of egress IP %s: %w", egressIPName, err)
+	}
+	staleUUIDs := sets.New[string]()
+	upToDate := sets.New[string]()
+	for _, item := range existing {
+		lrp, ok := reroutes[item.Match]
+		if !ok || upToDate.Has(item.Match) {
+			staleUUIDs.Insert(item.UUID)
+			continue
+		}
+		nexthops := append([]string{}, item.Nexthops...)
+		sort.Strings(nexthops)
+		if reflect.DeepEqual(nexthops, lrp.Nexthops) {
+			upToDate.Insert(item.Match)
+		}
+	}
+	if len(staleUUIDs) > 0 {
+		klog.Infof("Deleting %d stale reroute policies of egress IP %s on network %s", len(staleUUIDs), egressIPName,
+			oc.GetNetworkName())
+		ops, err = libovsdbops.DeleteLogicalRouterPolicyWithPredicateOps(oc.nbClient, ops, clusterRouter,
+			func(item *nbdb.LogicalRouterPolicy) bool { return staleUUIDs.Has(item.UUID) })
+		if err != nil {
+			return nil, fmt.Errorf("failed to delete stale reroute policies of egress IP %s: %w", egressIPName, err)
+		}
+	}
+	for match, lrp := range reroutes {
+		if upToDate.Has(match) {
+			continue
+		}
+		lrp := lrp
+		ops, err = libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicateOps(oc.nbClient, ops, clusterRouter, lrp,
+			func(item *nbdb.LogicalRouterPolicy) bool {
+				return isEgressIPReroutePolicy(item) && item.Match == lrp.Match && !staleUUIDs.Has(item.UUID)
+			})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create egress IP reroute policy %+v on router %s: %w", lrp, clusterRouter, err)
+		}
+	}
+	return ops, nil
+}
+
+// getEgressIPPods returns the scheduled, running pods selected by the egress IP that are
+// attached to the network through its network attachment definition.
+func (oc *SecondaryLayer3NetworkController) getEgressIPPods(egressIP *egressipv1.EgressIP) ([]*kapi.Pod, error) {
+	namespaces, err := oc.watchFactory.GetNamespacesBySelector(egressIP.Spec.NamespaceSelector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get namespaces of egress IP %s: %w", egressIP.Name, err)
+	}
+	var selected []*kapi.Pod
+	for _, namespace := range namespaces {
+		pods, err := oc.watchFactory.GetPodsBySelector(namespace.Name, egressIP.Spec.PodSelector)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pods of egress IP %s in namespace %s: %w", egressIP.Name, namespace.Name, err)
+		}
+		for _, pod := range pods {
+			if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !util.PodScheduled(pod) {
+				continue
+			}
+			if len(util.PodNADIPs(pod, egressIP.Spec.Network)) == 0 {
+				continue
+			}
+			selected = append(selected, pod)
+		}
+	}
+	return selected, nil
+}
+
+// getEgressIPNextHop returns the next hop on the network's cluster router towards the egress node:
+// - the egress node's management port of the network, if the egress node is local
+// - the egress node's transit switch port of the network, if the egress node is remote
+// The egress node SNATs the traffic whether it hosts the egress IP on a secondary host network or
+// on its OVN network. It returns an empty next hop if the network has no such port for the egress
+// node yet.
+func (oc *SecondaryLayer3NetworkController) getEgressIPNextHop(egressIPName string, status egressipv1.EgressIPStatusItem,
+	isLocalZoneEgressNode bool) (string, error) {
+	isEgressIPv6 := utilnet.IsIPv6String(status.EgressIP)
+
+	var nextHops []net.IP
+	var err error
+	switch {
+	case isLocalZoneEgressNode:
+		nextHops, err = oc.getLogicalSwitchPortIPs(oc.GetNetworkScopedName(types.K8sPrefix + status.Node))
+		if err != nil {
+			return "", err
+		}
+	case config.OVNKubernetesFeature.EnableInterconnect:
+		nextHops, err = oc.getLogicalSwitchPortIPs(oc.GetNetworkScopedName(types.TransitSwitchToRouterPrefix + status.Node))
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, nextHop := range nextHops {
+		if utilnet.IsIPv6(nextHop) == isEgressIPv6 {
+			return nextHop.String(), nil
+		}
+	}
+	klog.V(5).Infof("Network %s has no next hop towards egress node %s for egress IP %s (%s)",
+		oc.GetNetworkName(), status.Node, egressIPName, status.EgressIP)
+	return "", nil
+}
+
+// getLogicalSwitchPortIPs returns the IPs of the addresses of the logical switch port,
+// or none if it does not exist.
+func (oc *SecondaryLayer3NetworkController) getLogicalSwitchPortIPs(name string) ([]net.IP, error) {
+	lsp, err := libovsdbops.GetLogicalSwitchPort(oc.nbClient, &nbdb.LogicalSwitchPort{Name: name})
+	if errors.Is(err, libovsdbclient.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get logical switch port %s: %w", name, err)
+	}
+	var ips []net.IP
+	for _, address := range lsp.Addresses {
+		// addresses are in the "<mac> <ip>[/<prefix>] ..." format
+		fields := strings.Fields(address)
+		if len(fields) < 2 {
+			continue
+		}
+		for _, field := range fields[1:] {
+			ip, _, err := net.ParseCIDR(field)
+			if err != nil {
+				ip = net.ParseIP(field)
+			}
+			if ip != nil {
+				ips = append(ips, ip)
+			}
+		}
+	}
+	return ips, nil
+}
+
+// createNodeManagementPort creates the management port of the network on the node switch,
+// the next hop of the egress IPs of the network hosted by the node. ovnkube-node plugs it
+// with the MAC address derived from the management IP of the first host subnet.
+func (oc *SecondaryLayer3NetworkController) createNodeManagementPort(nodeName string, hostSubnets []*net.IPNet) error {
+	mgmtIfAddrs := make([]string, 0, len(hostSubnets))
+	for _, hostSubnet := range hostSubnets {
+		mgmtIfAddrs = append(mgmtIfAddrs, util.GetNodeManagementIfAddr(hostSubnet).IP.String())
+	}
+	macAddress := util.IPAddrToHWAddr(util.GetNodeManagementIfAddr(hostSubnets[0]).IP)
+	logicalSwitchPort := nbdb.LogicalSwitchPort{
+		Name:      oc.GetNetworkScopedName(types.K8sPrefix + nodeName),
+		Addresses: []string{macAddress.String() + " " + strings.Join(mgmtIfAddrs, " ")},
+		ExternalIDs: map[string]string{
+			types.NetworkExternalID:  oc.GetNetworkName(),
+			types.TopologyExternalID: oc.TopologyType(),
+		},
+	}
+	sw := nbdb.LogicalSwitch{Name: oc.GetNetworkScopedName(nodeName)}
+	if err := libovsdbops.CreateOrUpdateLogicalSwitchPortsOnSwitch(oc.nbClient, &sw, &logicalSwitchPort); err != nil {
+		return fmt.Errorf("failed to create management port %s of network %s: %w", logicalSwitchPort.Name,
+			oc.GetNetworkName(), err)
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package ovn
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressipv1 "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressip/v1"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+)
+
+var _ = ginkgo.Describe("OVN secondary network EgressIP Operations", func() {
+	const (
+		netName      = "bluenet"
+		nadName      = "nad1"
+		nodeName     = "node1"
+		egressIP     = "10.10.10.101"
+		podNetworkIP = "10.1.1.3"
+		mgmtPortIP   = "10.1.1.2"
+	)
+	var (
+		fakeOvn  *FakeOVN
+		nadKey   = util.GetNADName(eipNamespace, nadName)
+		netConf  ovncnitypes.NetConf
+		netInfo  util.NetInfo
+		l3Ctrl   *SecondaryLayer3NetworkController
+		scoped   func(string) string
+		nodeObj  v1.Node
+		eIP      egressipv1.EgressIP
+		eIPPod   *v1.Pod
+		eIPNSObj *v1.Namespace
+	)
+
+	ginkgo.BeforeEach(func() {
+		config.PrepareTestConfig()
+		config.OVNKubernetesFeature.EnableEgressIP = true
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+
+		netConf = ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: netName, Type: "ovn-k8s-cni-overlay"},
+			Topology: types.Layer3Topology,
+			NADName:  nadKey,
+			Subnets:  "10.1.0.0/16/24",
+		}
+		var err error
+		netInfo, err = util.NewNetInfo(&netConf)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		scoped = netInfo.GetNetworkScopedName
+
+		// the egress IP is hosted by a secondary host network of the node
+		nodeObj = getNodeObj(nodeName, map[string]string{
+			"k8s.ovn.org/node-primary-ifaddr": `{"ipv4": "192.168.126.202/24"}`,
+			util.OVNNodeHostCIDRs:             `["192.168.126.202/24", "10.10.10.5/24"]`,
+		}, map[string]string{"k8s.ovn.org/egress-assignable": ""})
+
+		eIPNSObj = newNamespace(eipNamespace)
+		eIPPod = newPodWithLabels(eipNamespace, podName, nodeName, podV4IP, egressPodLabel)
+		eIPPod.Annotations, err = util.MarshalPodAnnotation(nil, &util.PodAnnotation{
+			IPs: []*net.IPNet{{IP: net.ParseIP(podNetworkIP), Mask: net.CIDRMask(24, 32)}},
+			MAC: util.IPAddrToHWAddr(net.ParseIP(podNetworkIP)),
+		}, nadKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		eIP = egressipv1.EgressIP{
+			ObjectMeta: newEgressIPMeta(egressIPName),
+			Spec: egressipv1.EgressIPSpec{
+				EgressIPs:         []string{egressIP},
+				PodSelector:       metav1.LabelSelector{MatchLabels: egressPodLabel},
+				NamespaceSelector: metav1.LabelSelector{MatchLabels: map[string]string{"name": eipNamespace}},
+				Network:           nadKey,
+			},
+			Status: egressipv1.EgressIPStatus{
+				Items: []egressipv1.EgressIPStatusItem{{Node: nodeName, EgressIP: egressIP}},
+			},
+		}
+
+		fakeOvn = NewFakeOVN(true)
+	})
+
+	ginkgo.AfterEach(func() {
+		if l3Ctrl != nil {
+			l3Ctrl.Stop()
+			l3Ctrl = nil
+		}
+		fakeOvn.shutdown()
+	})
+
+	startController := func(initialDB []libovsdbtest.TestData) {
+		fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{NBData: initialDB},
+			&egressipv1.EgressIPList{Items: []egressipv1.EgressIP{eIP}},
+			&v1.NodeList{Items: []v1.Node{nodeObj}},
+			&v1.NamespaceList{Items: []v1.Namespace{*eIPNSObj}},
+			&v1.PodList{Items: []v1.Pod{*eIPPod}},
+		)
+		l3Ctrl = NewSecondaryLayer3NetworkController(&fakeOvn.controller.CommonNetworkControllerInfo, netInfo)
+		l3Ctrl.AddNAD(nadKey)
+		l3Ctrl.localZoneNodes.Store(nodeName, true)
+	}
+
+	hostSubnets := []*net.IPNet{ovntest.MustParseIPNet("10.1.1.0/24")}
+
+	clusterRouter := func(policies ...string) *nbdb.LogicalRouter {
+		return &nbdb.LogicalRouter{UUID: "cluster-router-UUID", Name: scoped(types.OVNClusterRouter), Policies: policies}
+	}
+
+	nodeSwitch := func(ports ...string) *nbdb.LogicalSwitch {
+		return &nbdb.LogicalSwitch{UUID: "node-switch-UUID", Name: scoped(nodeName), Ports: ports}
+	}
+
+	mgmtPort := func() *nbdb.LogicalSwitchPort {
+		return &nbdb.LogicalSwitchPort{
+			UUID:      "mgmt-port-UUID",
+			Name:      scoped(types.K8sPrefix + nodeName),
+			Addresses: []string{util.IPAddrToHWAddr(net.ParseIP(mgmtPortIP)).String() + " " + mgmtPortIP},
+			ExternalIDs: map[string]string{
+				types.NetworkExternalID:  netName,
+				types.TopologyExternalID: types.Layer3Topology,
+			},
+		}
+	}
+
+	reroute := func() *nbdb.LogicalRouterPolicy {
+		return &nbdb.LogicalRouterPolicy{
+			UUID:        "reroute-UUID",
+			Priority:    types.SecondaryNetworkEgressIPReroutePriority,
+			Match:       fmt.Sprintf("ip4.src == %s", podNetworkIP),
+			Action:      nbdb.LogicalRouterPolicyActionReroute,
+			Nexthops:    []string{mgmtPortIP},
+			ExternalIDs: map[string]string{"name": egressIPName, types.NetworkExternalID: netName},
+		}
+	}
+
+	ginkgo.It("reroutes the pods' network IPs to the management port of the network on the egress node", func() {
+		startController([]libovsdbtest.TestData{clusterRouter(), nodeSwitch()})
+		gomega.Expect(l3Ctrl.createNodeManagementPort(nodeName, hostSubnets)).To(gomega.Succeed())
+
+		gomega.Expect(l3Ctrl.syncEgressIP(egressIPName)).To(gomega.Succeed())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			reroute(), clusterRouter("reroute-UUID"), mgmtPort(), nodeSwitch("mgmt-port-UUID"),
+		}))
+
+		ginkgo.By("deleting the egress IP, the reroute is removed")
+		err := fakeOvn.fakeClient.EgressIPClient.K8sV1().EgressIPs().Delete(context.TODO(), eIP.Name, metav1.DeleteOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(func() int {
+			egressIPs, err := fakeOvn.watcher.GetEgressIPs()
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return len(egressIPs)
+		}).Should(gomega.Equal(0))
+		gomega.Expect(l3Ctrl.syncEgressIP(egressIPName)).To(gomega.Succeed())
+
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			clusterRouter(), mgmtPort(), nodeSwitch("mgmt-port-UUID"),
+		}))
+	})
+
+	ginkgo.It("reroutes the pods' network IPs for an egress IP hosted by the OVN network of the egress node", func() {
+		eIP.Spec.EgressIPs = []string{"192.168.126.101"}
+		eIP.Status.Items = []egressipv1.EgressIPStatusItem{{Node: nodeName, EgressIP: "192.168.126.101"}}
+		startController([]libovsdbtest.TestData{clusterRouter(), nodeSwitch()})
+		gomega.Expect(l3Ctrl.createNodeManagementPort(nodeName, hostSubnets)).To(gomega.Succeed())
+
+		gomega.Expect(l3Ctrl.syncEgressIP(egressIPName)).To(gomega.Succeed())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			reroute(), clusterRouter("reroute-UUID"), mgmtPort(), nodeSwitch("mgmt-port-UUID"),
+		}))
+	})
+
+	ginkgo.It("queues the egress IPs selecting a pod on its events", func() {
+		startController([]libovsdbtest.TestData{clusterRouter()})
+		l3Ctrl.egressIPQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+
+		unselectedPod := eIPPod.DeepCopy()
+		unselectedPod.Labels = nil
+		gomega.Expect(l3Ctrl.reconcileEgressIPPod(nil, unselectedPod)).To(gomega.Succeed())
+		gomega.Expect(l3Ctrl.egressIPQueue.Len()).To(gomega.Equal(0))
+
+		gomega.Expect(l3Ctrl.reconcileEgressIPPod(unselectedPod, eIPPod)).To(gomega.Succeed())
+		gomega.Expect(l3Ctrl.egressIPQueue.Len()).To(gomega.Equal(1))
+		key, _ := l3Ctrl.egressIPQueue.Get()
+		gomega.Expect(key).To(gomega.Equal(egressIPName))
+	})
+
+	ginkgo.It("is not configured by the default network controller", func() {
+		startController([]libovsdbtest.TestData{
+			&nbdb.LogicalRouter{UUID: "default-cluster-router-UUID", Name: types.OVNClusterRouter},
+		})
+		gomega.Expect(fakeOvn.controller.reconcileEgressIP(nil, &eIP)).To(gomega.Succeed())
+		gomega.Expect(fakeOvn.controller.eIPC.podAssignment).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("is not synced by the default network controller", func() {
+		defaultClusterRouter := &nbdb.LogicalRouter{UUID: "default-cluster-router-UUID", Name: types.OVNClusterRouter}
+		startController([]libovsdbtest.TestData{reroute(), clusterRouter("reroute-UUID"), defaultClusterRouter})
+		gomega.Expect(fakeOvn.controller.syncStaleEgressReroutePolicy(map[string]egressIPCacheEntry{})).To(gomega.Succeed())
+		gomega.Consistently(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+			reroute(), clusterRouter("reroute-UUID"), defaultClusterRouter,
+		}))
+	})
+})
@@ -5827,6 +5827,51 @@ var _ = ginkgo.Describe("OVN master EgressIP Operations", func() {
 			gomega.Expect(err).NotTo(gomega.HaveOccurred())
 		})
 	})
+	ginkgo.Context("on stale reroute policies sync", func() {
+		ginkgo.It("only deletes the policies owned by egress IPs", func() {
+			app.Action = func(ctx *cli.Context) error {
+				staleEgressIPPolicy := &nbdb.LogicalRouterPolicy{
+					UUID:        "stale-egressip-policy-UUID",
+					Priority:    types.EgressIPReroutePriority,
+					Match:       "ip4.src == 10.128.0.15",
+					Action:      nbdb.LogicalRouterPolicyActionReroute,
+					Nexthops:    []string{"100.64.0.2"},
+					ExternalIDs: map[string]string{"name": egressIPName},
+				}
+				otherPolicy := &nbdb.LogicalRouterPolicy{
+					UUID:     "other-policy-UUID",
+					Priority: types.EgressIPReroutePriority,
+					Match:    "ip4.src == 10.128.0.16",
+					Action:   nbdb.LogicalRouterPolicyActionReroute,
+					Nexthops: []string{"172.18.0.5"},
+				}
+				clusterRouter := &nbdb.LogicalRouter{
+					Name:     types.OVNClusterRouter,
+					UUID:     types.OVNClusterRouter + "-UUID",
+					Policies: []string{staleEgressIPPolicy.UUID},
+				}
+				gatewayRouter := &nbdb.LogicalRouter{
+					Name:     types.GWRouterPrefix + node1Name,
+					UUID:     types.GWRouterPrefix + node1Name + "-UUID",
+					Policies: []string{otherPolicy.UUID},
+				}
+				fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+					NBData: []libovsdbtest.TestData{staleEgressIPPolicy, otherPolicy, clusterRouter, gatewayRouter},
+				})
+
+				err := fakeOvn.controller.syncStaleEgressReroutePolicy(map[string]egressIPCacheEntry{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				clusterRouter.Policies = nil
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{
+					otherPolicy, clusterRouter, gatewayRouter,
+				}))
+				return nil
+			}
+			err := app.Run([]string{app.Name})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		})
+	})
 	ginkgo.Context("WatchEgressNodes", func() {
 
 		ginkgo.It("should populated egress node data as they are tagged `egress assignable` with variants of IPv4/IPv6", func() {
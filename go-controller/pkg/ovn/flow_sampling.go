@@ -0,0 +1,616 @@
+package ovn
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// The tables and columns of the flow sampling support of the NB schema, since OVN 24.09. The NB model in use
+	// doesn't have them, the client would reject older NB databases otherwise, so they are programmed with raw
+	// operations when the NB database has them.
+	nbSampleCollectorTable = "Sample_Collector"
+	nbSampleTable          = "Sample"
+	nbACLSampleNewColumn   = "sample_new"
+	nbACLSampleEstColumn   = "sample_est"
+
+	// maxSampleCollectorID is the highest ID of a sample collector
+	maxSampleCollectorID = 255
+	// flowSamplingSyncInterval is the interval between two syncs of the flow sampling ports and of the samples of the
+	// ACLs
+	flowSamplingSyncInterval = 10 * time.Second
+	// flowSamplingPortGroupSuffix is the suffix of the name of the port group of the sampled ports of a namespace
+	flowSamplingPortGroupSuffix = "flowSampling"
+)
+
+// flowSamplingUpdateNsInfo parses the flow sampling annotation of the namespace and sets nsInfo.aclLogging.FlowSampling,
+// flow sampling is disabled if the annotation is malformed
+func (bnc *BaseNetworkController) flowSamplingUpdateNsInfo(ns *kapi.Namespace, nsInfo *namespaceInfo) {
+	flowSampling, err := util.ParseFlowSamplingAnnotation(ns.Annotations)
+	if err != nil {
+		klog.Warningf("Namespace %s: flow sampling contained malformed annotation, flow sampling is disabled: %v",
+			ns.Name, err)
+	}
+	nsInfo.aclLogging.FlowSampling = flowSampling
+}
+
+// flowSamplingSupported returns whether the NB database supports sampling the ACLs
+func flowSamplingSupported(nbClient libovsdbclient.Client) bool {
+	schema := nbClient.Schema()
+	for _, table := range []string{nbSampleCollectorTable, nbSampleTable} {
+		if _, ok := schema.Tables[table]; !ok {
+			return false
+		}
+	}
+	_, ok := schema.Tables[nbdb.ACLTable].Columns[nbACLSampleNewColumn]
+	return ok
+}
+
+// runFlowSampling periodically syncs the flow sampling ports of the sampled namespaces and, when the NB database
+// supports it, samples the ACLs labeled with an observation ID with the probability of their namespace, until stopCh
+// is closed
+func (oc *DefaultNetworkController) runFlowSampling(stopCh <-chan struct{}) {
+	samplesSupported := flowSamplingSupported(oc.nbClient)
+	if !samplesSupported {
+		klog.Infof("The OVN NB database doesn't support sampling ACLs, the flow sampling probability of namespaces " +
+			"is only reported")
+	}
+	wait.Until(func() {
+		if err := oc.syncFlowSampling(samplesSupported); err != nil {
+			klog.Errorf("Failed to sync flow sampling: %v", err)
+		}
+	}, flowSamplingSyncInterval, stopCh)
+}
+
+// findLabeledACLs returns the ACLs labeled with an observation ID
+func findLabeledACLs(nbClient libovsdbclient.Client) ([]*nbdb.ACL, error) {
+	acls, err := libovsdbops.FindACLsWithPredicate(nbClient, func(acl *nbdb.ACL) bool {
+		return acl.Label != 0
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find sampled ACLs: %w", err)
+	}
+	sort.Slice(acls, func(i, j int) bool {
+		return acls[i].UUID < acls[j].UUID
+	})
+	return acls, nil
+}
+
+// reserveFlowSamplingObservationIDs reserves the labels of the labeled ACLs as their observation IDs, for the ACLs to
+// keep their observation IDs across restarts. It is called before the handlers build ACLs.
+func (oc *DefaultNetworkController) reserveFlowSamplingObservationIDs() error {
+	acls, err := findLabeledACLs(oc.nbClient)
+	if err != nil {
+		return err
+	}
+	for _, acl := range acls {
+		libovsdbutil.ReserveFlowSamplingObservationID(acl)
+	}
+	return nil
+}
+
+func (oc *DefaultNetworkController) getFlowSamplingPortGroupName(namespace string) string {
+	return libovsdbutil.HashedPortGroup(namespace + "_" + flowSamplingPortGroupSuffix)
+}
+
+func getFlowSamplingACLDbIDs(namespace, podName string, aclDir libovsdbutil.ACLDirection,
+	controller string) *libovsdbops.DbObjectIDs {
+	return libovsdbops.NewDbObjectIDs(libovsdbops.ACLFlowSampling, controller,
+		map[libovsdbops.ExternalIDKey]string{
+			libovsdbops.ObjectNameKey:      namespace + ":" + podName,
+			libovsdbops.PolicyDirectionKey: string(aclDir),
+		})
+}
+
+func getFlowSamplingACLMatch(portName string, aclDir libovsdbutil.ACLDirection) string {
+	if aclDir == libovsdbutil.ACLEgress {
+		return fmt.Sprintf("inport == %q", portName)
+	}
+	return fmt.Sprintf("outport == %q", portName)
+}
+
+// syncFlowSamplingPorts samples the logical ports of the pods selected by the flow sampling annotation of their
+// namespace: every sampled namespace gets a port group with the logical switch ports of its selected pods, and an
+// allow ACL per pod and direction, labeled with the observation ID of the pod. The ACLs have the lowest priority of
+// the last tier, so they only match the traffic that no other ACL matched, which is allowed anyway: they don't change
+// any verdict, but sample the traffic of the pods that no network policy applies to. allow-related ACLs would make the
+// switches of the pods stateful, sending all their traffic through conntrack; allow ACLs only commit the connections
+// on the switches that are already stateful.
+func (oc *DefaultNetworkController) syncFlowSamplingPorts() error {
+	namespaces, err := oc.watchFactory.GetNamespaces()
+	if err != nil {
+		return fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLFlowSampling, oc.controllerName, nil)
+	existingACLs, err := libovsdbops.FindACLsWithPredicate(oc.nbClient,
+		libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil))
+	if err != nil {
+		return fmt.Errorf("failed to find flow sampling ACLs: %w", err)
+	}
+	staleNamespaces := sets.New[string]()
+	existing := map[string]*nbdb.ACL{}
+	for _, acl := range existingACLs {
+		_, namespace, _ := libovsdbutil.GetACLOwner(acl.ExternalIDs)
+		staleNamespaces.Insert(namespace)
+		existing[acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()]] = acl
+	}
+
+	var ops []ovsdb.Operation
+	for _, namespace := range namespaces {
+		flowSampling, err := util.ParseFlowSamplingAnnotation(namespace.Annotations)
+		if err != nil || flowSampling == nil {
+			// a malformed annotation is logged by the namespace handler
+			continue
+		}
+		ports, acls, err := oc.buildFlowSamplingPorts(namespace.Name, flowSampling)
+		if err != nil {
+			return err
+		}
+		if len(ports) == 0 {
+			continue
+		}
+		staleNamespaces.Delete(namespace.Name)
+		portGroupName := oc.getFlowSamplingPortGroupName(namespace.Name)
+		if flowSamplingPortGroupUpToDate(oc.nbClient, portGroupName, ports, acls, existing) {
+			continue
+		}
+		ops, err = libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, ops, acls...)
+		if err != nil {
+			return fmt.Errorf("failed to create flow sampling ACLs of namespace %s: %w", namespace.Name, err)
+		}
+		pg := oc.buildPortGroup(portGroupName, namespace.Name+"_"+flowSamplingPortGroupSuffix, ports, acls)
+		ops, err = libovsdbops.CreateOrUpdatePortGroupsOps(oc.nbClient, ops, pg)
+		if err != nil {
+			return fmt.Errorf("failed to create flow sampling port group of namespace %s: %w", namespace.Name, err)
+		}
+	}
+	// the ACLs of the deleted port groups are garbage collected by the database
+	for _, namespace := range sets.List(staleNamespaces) {
+		ops, err = libovsdbops.DeletePortGroupsOps(oc.nbClient, ops, oc.getFlowSamplingPortGroupName(namespace))
+		if err != nil {
+			return fmt.Errorf("failed to delete flow sampling port group of namespace %s: %w", namespace, err)
+		}
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to sync flow sampling ports: %w", err)
+	}
+	return nil
+}
+
+// buildFlowSamplingPorts returns the logical switch ports of the pods of the namespace selected by its flow sampling
+// setting, and their flow sampling ACLs. The pods whose logical switch port doesn't exist, because it is not created
+// yet or the pod is in another zone, are skipped.
+func (oc *DefaultNetworkController) buildFlowSamplingPorts(namespace string,
+	flowSampling *util.FlowSampling) ([]*nbdb.LogicalSwitchPort, []*nbdb.ACL, error) {
+	selector, err := flowSampling.GetPodSelector()
+	if err != nil {
+		return nil, nil, err
+	}
+	pods, err := oc.watchFactory.GetPods(namespace)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list the pods of namespace %s: %w", namespace, err)
+	}
+	sort.Slice(pods, func(i, j int) bool {
+		return pods[i].Name < pods[j].Name
+	})
+
+	aclLogging := &libovsdbutil.ACLLoggingLevels{FlowSampling: flowSampling}
+	var ports []*nbdb.LogicalSwitchPort
+	var acls []*nbdb.ACL
+	for _, pod := range pods {
+		if util.PodWantsHostNetwork(pod) || util.PodCompleted(pod) || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		portName := util.GetLogicalPortName(pod.Namespace, pod.Name)
+		lsp, err := libovsdbops.GetLogicalSwitchPort(oc.nbClient, &nbdb.LogicalSwitchPort{Name: portName})
+		if errors.Is(err, libovsdbclient.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get logical switch port %s: %w", portName, err)
+		}
+		ports = append(ports, lsp)
+		for _, aclDir := range []libovsdbutil.ACLDirection{libovsdbutil.ACLEgress, libovsdbutil.ACLIngress} {
+			dbIDs := getFlowSamplingACLDbIDs(pod.Namespace, pod.Name, aclDir, oc.controllerName)
+			acls = append(acls, libovsdbutil.BuildACL(dbIDs, types.FlowSamplingACLPriority,
+				getFlowSamplingACLMatch(portName, aclDir), nbdb.ACLActionAllow, aclLogging,
+				libovsdbutil.ACLDirectionToACLPipeline(aclDir)))
+		}
+	}
+	return ports, acls, nil
+}
+
+// flowSamplingPortGroupUpToDate returns whether the flow sampling port group of a namespace already has the given
+// ports and ACLs
+func flowSamplingPortGroupUpToDate(nbClient libovsdbclient.Client, portGroupName string,
+	ports []*nbdb.LogicalSwitchPort, acls []*nbdb.ACL, existing map[string]*nbdb.ACL) bool {
+	pg, err := libovsdbops.GetPortGroup(nbClient, &nbdb.PortGroup{Name: portGroupName})
+	if err != nil || len(pg.Ports) != len(ports) || len(pg.ACLs) != len(acls) {
+		return false
+	}
+	pgPorts := sets.New(pg.Ports...)
+	for _, port := range ports {
+		if !pgPorts.Has(port.UUID) {
+			return false
+		}
+	}
+	pgACLs := sets.New(pg.ACLs...)
+	for _, acl := range acls {
+		existingACL := existing[acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()]]
+		if existingACL == nil || !pgACLs.Has(existingACL.UUID) || existingACL.Match != acl.Match ||
+			existingACL.Action != acl.Action || existingACL.Priority != acl.Priority ||
+			existingACL.Tier != acl.Tier || existingACL.Label != acl.Label {
+			return false
+		}
+	}
+	return true
+}
+
+// sampledACL is an ACL to sample with the probability of its namespace
+type sampledACL struct {
+	uuid          string
+	observationID int
+	probability   int
+}
+
+// nbSample is a row of the Sample table
+type nbSample struct {
+	uuid       string
+	collectors []string
+}
+
+// flowSamplingState is the flow sampling configuration of the NB database
+type flowSamplingState struct {
+	// collectors are the UUIDs of the sample collectors owned by the controller, by probability
+	collectors map[int]string
+	// collectorIDs are the IDs of all the sample collectors
+	collectorIDs sets.Set[int]
+	// samples are the samples by metadata, i.e. by observation ID
+	samples map[int]*nbSample
+	// aclSamples are the UUIDs of the samples of new and established connections of the sampled ACLs, by ACL UUID
+	aclSamples map[string][2]string
+}
+
+// syncFlowSampling syncs the flow sampling ports, relabels the ACLs whose label is the observation ID of another ACL,
+// and when samplesSupported, sets a sample of the observation ID of every labeled ACL, sent to the sample collector
+// of the probability of its namespace, and clears the samples of the ACLs that are not labeled anymore
+func (oc *DefaultNetworkController) syncFlowSampling(samplesSupported bool) error {
+	start := time.Now()
+	if err := oc.syncFlowSamplingPorts(); err != nil {
+		return err
+	}
+	acls, err := findLabeledACLs(oc.nbClient)
+	if err != nil {
+		return err
+	}
+	var ops []ovsdb.Operation
+	inUse := sets.New[string]()
+	sampled := make([]sampledACL, 0, len(acls))
+	for _, acl := range acls {
+		inUse.Insert(acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()])
+		observationID := libovsdbutil.ReserveFlowSamplingObservationID(acl)
+		if observationID != acl.Label {
+			relabeled := acl.DeepCopy()
+			relabeled.Label = observationID
+			if ops, err = libovsdbops.UpdateACLsLoggingOps(oc.nbClient, ops, relabeled); err != nil {
+				return fmt.Errorf("failed to relabel ACL %s: %w", acl.UUID, err)
+			}
+		}
+		_, namespace, _ := libovsdbutil.GetACLOwner(acl.ExternalIDs)
+		probability := getNamespaceFlowSamplingProbability(oc.watchFactory.GetNamespace(namespace))
+		if probability == 0 {
+			// the ACL is relabeled with the namespace update
+			continue
+		}
+		sampled = append(sampled, sampledACL{uuid: acl.UUID, observationID: observationID, probability: probability})
+	}
+	// the ACLs labeled since the previous sync may not be committed yet
+	libovsdbutil.ReleaseFlowSamplingObservationIDs(inUse, start.Add(-flowSamplingSyncInterval))
+
+	if samplesSupported {
+		state, err := getFlowSamplingState(oc.nbClient, oc.controllerName)
+		if err != nil {
+			return err
+		}
+		samplesOps, err := buildFlowSamplingOps(oc.controllerName, state, sampled)
+		if err != nil {
+			return err
+		}
+		ops = append(ops, samplesOps...)
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	if _, err := libovsdbops.TransactAndCheck(oc.nbClient, ops); err != nil {
+		return fmt.Errorf("failed to sample ACLs: %w", err)
+	}
+	return nil
+}
+
+// getNamespaceFlowSamplingProbability returns the flow sampling probability of the namespace, 0 if it is not sampled
+func getNamespaceFlowSamplingProbability(ns *kapi.Namespace, err error) int {
+	if err != nil {
+		return 0
+	}
+	flowSampling, err := util.ParseFlowSamplingAnnotation(ns.Annotations)
+	if err != nil || flowSampling == nil {
+		return 0
+	}
+	return flowSampling.Probability
+}
+
+// getFlowSamplingState reads the sample collectors, the samples and the samples of the ACLs from the NB database.
+// The client cache doesn't have them, so they are selected from the database.
+func getFlowSamplingState(nbClient libovsdbclient.Client, controllerName string) (*flowSamplingState, error) {
+	ops := []ovsdb.Operation{
+		{
+			Op:      ovsdb.OperationSelect,
+			Table:   nbSampleCollectorTable,
+			Columns: []string{"_uuid", "id", "probability", "external_ids"},
+		},
+		{
+			Op:      ovsdb.OperationSelect,
+			Table:   nbSampleTable,
+			Columns: []string{"_uuid", "metadata", "collectors"},
+		},
+		{
+			Op:    ovsdb.OperationSelect,
+			Table: nbdb.ACLTable,
+			Where: []ovsdb.Condition{
+				ovsdb.NewCondition(nbACLSampleNewColumn, ovsdb.ConditionNotEqual, ovsdb.OvsSet{GoSet: []interface{}{}}),
+			},
+			Columns: []string{"_uuid", nbACLSampleNewColumn, nbACLSampleEstColumn},
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), types.OVSDBTimeout)
+	defer cancel()
+	results, err := nbClient.Transact(ctx, ops...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the flow sampling configuration: %w", err)
+	}
+	if _, err := ovsdb.CheckOperationResults(results, ops); err != nil {
+		return nil, fmt.Errorf("failed to read the flow sampling configuration: %w", err)
+	}
+
+	state := &flowSamplingState{
+		collectors:   map[int]string{},
+		collectorIDs: sets.New[int](),
+		samples:      map[int]*nbSample{},
+		aclSamples:   map[string][2]string{},
+	}
+	for _, row := range results[0].Rows {
+		state.collectorIDs.Insert(ovsInteger(row["id"]))
+		externalIDs, _ := row["external_ids"].(ovsdb.OvsMap)
+		if externalIDs.GoMap[libovsdbops.OwnerControllerKey.String()] == controllerName {
+			state.collectors[ovsInteger(row["probability"])] = ovsUUIDs(row["_uuid"])[0]
+		}
+	}
+	for _, row := range results[1].Rows {
+		state.samples[ovsInteger(row["metadata"])] = &nbSample{
+			uuid:       ovsUUIDs(row["_uuid"])[0],
+			collectors: ovsUUIDs(row["collectors"]),
+		}
+	}
+	for _, row := range results[2].Rows {
+		var samples [2]string
+		for i, column := range []string{nbACLSampleNewColumn, nbACLSampleEstColumn} {
+			if uuids := ovsUUIDs(row[column]); len(uuids) > 0 {
+				samples[i] = uuids[0]
+			}
+		}
+		state.aclSamples[ovsUUIDs(row["_uuid"])[0]] = samples
+	}
+	return state, nil
+}
+
+// buildFlowSamplingOps returns the operations that make the NB database sample the given ACLs and only them, out
+// of the ones sampled with the sample collectors owned by the controller
+func buildFlowSamplingOps(controllerName string, state *flowSamplingState,
+	sampled []sampledACL) ([]ovsdb.Operation, error) {
+	var ops []ovsdb.Operation
+	sort.Slice(sampled, func(i, j int) bool {
+		return sampled[i].uuid < sampled[j].uuid
+	})
+
+	// a collector per probability, referenced by its UUID or by its named UUID when created
+	collectors := map[int]string{}
+	collectorIDs := state.collectorIDs.Clone()
+	nextID := 1
+	for _, acl := range sampled {
+		if _, ok := collectors[acl.probability]; ok {
+			continue
+		}
+		if uuid, ok := state.collectors[acl.probability]; ok {
+			collectors[acl.probability] = uuid
+			continue
+		}
+		for collectorIDs.Has(nextID) {
+			nextID++
+		}
+		if nextID > maxSampleCollectorID {
+			return nil, fmt.Errorf("no sample collector ID left for flow sampling probability %d", acl.probability)
+		}
+		collectorIDs.Insert(nextID)
+		namedUUID := fmt.Sprintf("collector%d", nextID)
+		ops = append(ops, ovsdb.Operation{
+			Op:       ovsdb.OperationInsert,
+			Table:    nbSampleCollectorTable,
+			UUIDName: namedUUID,
+			Row: ovsdb.Row{
+				"id":          nextID,
+				"name":        fmt.Sprintf("%s-%d", controllerName, acl.probability),
+				"probability": acl.probability,
+				"set_id":      types.FlowSamplingCollectorSetID,
+				"external_ids": ovsdb.OvsMap{GoMap: map[interface{}]interface{}{
+					libovsdbops.OwnerControllerKey.String(): controllerName,
+				}},
+			},
+		})
+		collectors[acl.probability] = namedUUID
+	}
+
+	// a sample per observation ID
+	samples := map[int]string{}
+	sampledACLs := sets.New[string]()
+	for _, acl := range sampled {
+		sampledACLs.Insert(acl.uuid)
+		collector := collectors[acl.probability]
+		sample, ok := samples[acl.observationID]
+		if !ok {
+			if existing, found := state.samples[acl.observationID]; found {
+				sample = existing.uuid
+				if len(existing.collectors) != 1 || existing.collectors[0] != collector {
+					ops = append(ops, ovsdb.Operation{
+						Op:    ovsdb.OperationUpdate,
+						Table: nbSampleTable,
+						Where: []ovsdb.Condition{ovsdb.NewCondition("_uuid", ovsdb.ConditionEqual, ovsdb.UUID{GoUUID: sample})},
+						Row:   ovsdb.Row{"collectors": ovsUUIDSet(collector)},
+					})
+				}
+			} else {
+				sample = fmt.Sprintf("sample%d", acl.observationID)
+				ops = append(ops, ovsdb.Operation{
+					Op:       ovsdb.OperationInsert,
+					Table:    nbSampleTable,
+					UUIDName: sample,
+					Row: ovsdb.Row{
+						"metadata":   acl.observationID,
+						"collectors": ovsUUIDSet(collector),
+					},
+				})
+			}
+			samples[acl.observationID] = sample
+		}
+		if state.aclSamples[acl.uuid] != [2]string{sample, sample} {
+			ops = append(ops, updateACLSamplesOp(acl.uuid, ovsUUIDSet(sample)))
+		}
+	}
+
+	// clear the samples of the ACLs that are not sampled anymore, the samples that are not referenced anymore are
+	// garbage collected by the database, then the collectors
+	ownedCollectors := sets.New[string]()
+	for _, uuid := range state.collectors {
+		ownedCollectors.Insert(uuid)
+	}
+	ownedSamples := sets.New[string]()
+	for _, sample := range state.samples {
+		if ownedCollectors.HasAny(sample.collectors...) {
+			ownedSamples.Insert(sample.uuid)
+		}
+	}
+	for _, aclUUID := range sets.List(sets.KeySet(state.aclSamples)) {
+		aclSamples := state.aclSamples[aclUUID]
+		if !sampledACLs.Has(aclUUID) && (ownedSamples.Has(aclSamples[0]) || ownedSamples.Has(aclSamples[1])) {
+			ops = append(ops, updateACLSamplesOp(aclUUID, ovsdb.OvsSet{GoSet: []interface{}{}}))
+		}
+	}
+	usedCollectors := sets.New[string]()
+	for _, sample := range state.samples {
+		usedCollectors.Insert(sample.collectors...)
+	}
+	for _, probability := range sets.List(sets.KeySet(state.collectors)) {
+		uuid := state.collectors[probability]
+		if _, ok := collectors[probability]; ok || usedCollectors.Has(uuid) {
+			// collectors still referenced by samples are deleted by a later sync
+			continue
+		}
+		ops = append(ops, ovsdb.Operation{
+			Op:    ovsdb.OperationDelete,
+			Table: nbSampleCollectorTable,
+			Where: []ovsdb.Condition{ovsdb.NewCondition("_uuid", ovsdb.ConditionEqual, ovsdb.UUID{GoUUID: uuid})},
+		})
+	}
+	return ops, nil
+}
+
+func updateACLSamplesOp(aclUUID string, samples ovsdb.OvsSet) ovsdb.Operation {
+	return ovsdb.Operation{
+		Op:    ovsdb.OperationUpdate,
+		Table: nbdb.ACLTable,
+		Where: []ovsdb.Condition{ovsdb.NewCondition("_uuid", ovsdb.ConditionEqual, ovsdb.UUID{GoUUID: aclUUID})},
+		Row: ovsdb.Row{
+			nbACLSampleNewColumn: samples,
+			nbACLSampleEstColumn: samples,
+		},
+	}
+}
+
+func ovsUUIDSet(uuid string) ovsdb.OvsSet {
+	return ovsdb.OvsSet{GoSet: []interface{}{ovsdb.UUID{GoUUID: uuid}}}
+}
+
+// ovsUUIDs returns the UUIDs of a UUID or set of UUIDs column of a selected row
+func ovsUUIDs(value interface{}) []string {
+	switch v := value.(type) {
+	case ovsdb.UUID:
+		return []string{v.GoUUID}
+	case ovsdb.OvsSet:
+		uuids := make([]string, 0, len(v.GoSet))
+		for _, elem := range v.GoSet {
+			if uuid, ok := elem.(ovsdb.UUID); ok {
+				uuids = append(uuids, uuid.GoUUID)
+			}
+		}
+		return uuids
+	}
+	return nil
+}
+
+// ovsInteger returns the value of an integer column of a selected row
+func ovsInteger(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// getFlowSamplingObservations maps the observation IDs of the sampled ACLs of all networks to the Kubernetes objects
+// the ACLs are derived from
+func (oc *DefaultNetworkController) getFlowSamplingObservations() ([]metrics.FlowSamplingObservation, error) {
+	acls, err := findLabeledACLs(oc.nbClient)
+	if err != nil {
+		return nil, err
+	}
+
+	observations := make([]metrics.FlowSamplingObservation, 0, len(acls))
+	for _, acl := range acls {
+		observation := metrics.FlowSamplingObservation{
+			ObservationID: acl.Label,
+			Controller:    acl.ExternalIDs[libovsdbops.OwnerControllerKey.String()],
+			Direction:     acl.ExternalIDs[libovsdbops.PolicyDirectionKey.String()],
+			Action:        acl.Action,
+		}
+		observation.OwnerType, observation.Namespace, observation.Name = libovsdbutil.GetACLOwner(acl.ExternalIDs)
+		observation.Probability = getNamespaceFlowSamplingProbability(oc.watchFactory.GetNamespace(observation.Namespace))
+		observations = append(observations, observation)
+	}
+	sort.Slice(observations, func(i, j int) bool {
+		return observations[i].ObservationID < observations[j].ObservationID
+	})
+	return observations, nil
+}
@@ -0,0 +1,189 @@
+package ovn
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/ovn-org/libovsdb/ovsdb"
+
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+func TestBuildFlowSamplingOps(t *testing.T) {
+	const controllerName = "default-network-controller"
+	uuidCondition := func(uuid string) []ovsdb.Condition {
+		return []ovsdb.Condition{ovsdb.NewCondition("_uuid", ovsdb.ConditionEqual, ovsdb.UUID{GoUUID: uuid})}
+	}
+	insertCollector := func(id, probability int) ovsdb.Operation {
+		return ovsdb.Operation{
+			Op:       ovsdb.OperationInsert,
+			Table:    nbSampleCollectorTable,
+			UUIDName: fmt.Sprintf("collector%d", id),
+			Row: ovsdb.Row{
+				"id":          id,
+				"name":        fmt.Sprintf("%s-%d", controllerName, probability),
+				"probability": probability,
+				"set_id":      types.FlowSamplingCollectorSetID,
+				"external_ids": ovsdb.OvsMap{GoMap: map[interface{}]interface{}{
+					libovsdbops.OwnerControllerKey.String(): controllerName,
+				}},
+			},
+		}
+	}
+	updateACL := func(aclUUID string, samples ovsdb.OvsSet) ovsdb.Operation {
+		return ovsdb.Operation{
+			Op:    ovsdb.OperationUpdate,
+			Table: nbdb.ACLTable,
+			Where: uuidCondition(aclUUID),
+			Row:   ovsdb.Row{nbACLSampleNewColumn: samples, nbACLSampleEstColumn: samples},
+		}
+	}
+	newState := func() *flowSamplingState {
+		return &flowSamplingState{
+			collectors:   map[int]string{},
+			collectorIDs: sets.New[int](),
+			samples:      map[int]*nbSample{},
+			aclSamples:   map[string][2]string{},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		state       func() *flowSamplingState
+		sampled     []sampledACL
+		expectedOps []ovsdb.Operation
+	}{
+		{
+			name:  "creates a collector per probability and a sample per observation ID",
+			state: newState,
+			sampled: []sampledACL{
+				{uuid: "acl1", observationID: 10, probability: 5},
+				{uuid: "acl2", observationID: 10, probability: 5},
+				{uuid: "acl3", observationID: 20, probability: 7},
+			},
+			expectedOps: []ovsdb.Operation{
+				insertCollector(1, 5),
+				insertCollector(2, 7),
+				{
+					Op:       ovsdb.OperationInsert,
+					Table:    nbSampleTable,
+					UUIDName: "sample10",
+					Row:      ovsdb.Row{"metadata": 10, "collectors": ovsUUIDSet("collector1")},
+				},
+				updateACL("acl1", ovsUUIDSet("sample10")),
+				updateACL("acl2", ovsUUIDSet("sample10")),
+				{
+					Op:       ovsdb.OperationInsert,
+					Table:    nbSampleTable,
+					UUIDName: "sample20",
+					Row:      ovsdb.Row{"metadata": 20, "collectors": ovsUUIDSet("collector2")},
+				},
+				updateACL("acl3", ovsUUIDSet("sample20")),
+			},
+		},
+		{
+			name: "skips the collector IDs in use and keeps the sampled ACLs",
+			state: func() *flowSamplingState {
+				state := newState()
+				state.collectorIDs.Insert(1, 2)
+				state.collectors[5] = "c5"
+				state.samples[10] = &nbSample{uuid: "s10", collectors: []string{"c5"}}
+				state.aclSamples["acl1"] = [2]string{"s10", "s10"}
+				return state
+			},
+			sampled: []sampledACL{
+				{uuid: "acl1", observationID: 10, probability: 5},
+				{uuid: "acl2", observationID: 20, probability: 7},
+			},
+			expectedOps: []ovsdb.Operation{
+				insertCollector(3, 7),
+				{
+					Op:       ovsdb.OperationInsert,
+					Table:    nbSampleTable,
+					UUIDName: "sample20",
+					Row:      ovsdb.Row{"metadata": 20, "collectors": ovsUUIDSet("collector3")},
+				},
+				updateACL("acl2", ovsUUIDSet("sample20")),
+			},
+		},
+		{
+			name: "moves the samples of a namespace to the collector of its new probability",
+			state: func() *flowSamplingState {
+				state := newState()
+				state.collectorIDs.Insert(1)
+				state.collectors[5] = "c5"
+				state.samples[10] = &nbSample{uuid: "s10", collectors: []string{"c5"}}
+				state.aclSamples["acl1"] = [2]string{"s10", "s10"}
+				return state
+			},
+			sampled: []sampledACL{
+				{uuid: "acl1", observationID: 10, probability: 7},
+			},
+			expectedOps: []ovsdb.Operation{
+				insertCollector(2, 7),
+				{
+					Op:    ovsdb.OperationUpdate,
+					Table: nbSampleTable,
+					Where: uuidCondition("s10"),
+					Row:   ovsdb.Row{"collectors": ovsUUIDSet("collector2")},
+				},
+			},
+		},
+		{
+			name: "clears the samples of the ACLs not sampled anymore and deletes the unused collectors",
+			state: func() *flowSamplingState {
+				state := newState()
+				state.collectorIDs.Insert(1, 2, 3)
+				state.collectors[5] = "c5"
+				state.collectors[7] = "c7"
+				state.samples[10] = &nbSample{uuid: "s10", collectors: []string{"c5"}}
+				// a sample of another controller
+				state.samples[30] = &nbSample{uuid: "s30", collectors: []string{"other"}}
+				state.aclSamples["acl1"] = [2]string{"s10", "s10"}
+				state.aclSamples["acl3"] = [2]string{"s30", "s30"}
+				return state
+			},
+			expectedOps: []ovsdb.Operation{
+				updateACL("acl1", ovsdb.OvsSet{GoSet: []interface{}{}}),
+				{
+					Op:    ovsdb.OperationDelete,
+					Table: nbSampleCollectorTable,
+					Where: uuidCondition("c7"),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ops, err := buildFlowSamplingOps(controllerName, tt.state(), tt.sampled)
+			if err != nil {
+				t.Fatalf("failed to build the flow sampling operations: %v", err)
+			}
+			if !reflect.DeepEqual(ops, tt.expectedOps) {
+				t.Errorf("expected operations:\n%v\ngot:\n%v", tt.expectedOps, ops)
+			}
+		})
+	}
+}
+
+func TestBuildFlowSamplingOpsNoCollectorIDLeft(t *testing.T) {
+	state := &flowSamplingState{
+		collectors:   map[int]string{},
+		collectorIDs: sets.New[int](),
+		samples:      map[int]*nbSample{},
+		aclSamples:   map[string][2]string{},
+	}
+	for id := 1; id <= maxSampleCollectorID; id++ {
+		state.collectorIDs.Insert(id)
+	}
+	_, err := buildFlowSamplingOps("default-network-controller", state,
+		[]sampledACL{{uuid: "acl1", observationID: 10, probability: 5}})
+	if err == nil {
+		t.Fatalf("expected an error when all the sample collector IDs are in use")
+	}
+}
@@ -81,6 +81,17 @@ func (oc *DefaultNetworkController) gatewayCleanup(nodeName string) error {
 		return fmt.Errorf("failed to delete external switch %s: %w", exGWexternalSwitch, err)
 	}
 
+	uplinkSwitches, err := oc.findGatewayUplinkSwitches(nodeName)
+	if err != nil {
+		return err
+	}
+	for _, uplinkSwitch := range uplinkSwitches {
+		err = libovsdbops.DeleteLogicalSwitch(oc.nbClient, uplinkSwitch.Name)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return fmt.Errorf("failed to delete external switch %s: %w", uplinkSwitch.Name, err)
+		}
+	}
+
 	// This will cleanup the NodeSubnetPolicy in local and shared gateway modes. It will be a no-op for any other mode.
 	oc.delPbrAndNatRules(nodeName, nil)
 	return nil
@@ -20,6 +20,7 @@ import (
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/node"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/gateway"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
@@ -282,6 +283,22 @@ func (oc *DefaultNetworkController) gatewayInit(nodeName string, clusterIPSubnet
 		}
 	}
 
+	for _, uplink := range l3GatewayConfig.Uplinks {
+		if err := oc.addExternalSwitch(gatewayUplinkSwitchPrefix(uplink.Name),
+			uplink.InterfaceID,
+			nodeName,
+			gatewayRouter,
+			uplink.MACAddress.String(),
+			types.GatewayUplinkPrefix+uplink.Name,
+			uplink.IPAddresses,
+			nil); err != nil {
+			return err
+		}
+	}
+	if err := oc.syncGatewayUplinks(nodeName, clusterIPSubnet, l3GatewayConfig.Uplinks); err != nil {
+		return err
+	}
+
 	externalRouterPort := types.GWRouterToExtSwitchPrefix + gatewayRouter
 
 	nextHops := l3GatewayConfig.NextHops
@@ -606,6 +623,172 @@ func (oc *DefaultNetworkController) addExternalSwitch(prefix, interfaceID, nodeN
 	return nil
 }
 
+// gatewayUplinkSwitchPrefix returns the prefix of the names of the external switch of a gateway uplink and of its
+// ports
+func gatewayUplinkSwitchPrefix(uplink string) string {
+	return types.GatewayUplinkPrefix + uplink + "-"
+}
+
+// findGatewayUplinkSwitches returns the external switches of the gateway uplinks of the node, by uplink name
+func (oc *DefaultNetworkController) findGatewayUplinkSwitches(nodeName string) (map[string]*nbdb.LogicalSwitch, error) {
+	suffix := "-" + externalSwitchName("", nodeName)
+	p := func(item *nbdb.LogicalSwitch) bool {
+		return strings.HasPrefix(item.Name, types.GatewayUplinkPrefix) && strings.HasSuffix(item.Name, suffix)
+	}
+	switches, err := libovsdbops.FindLogicalSwitchesWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find the gateway uplink switches of node %s: %w", nodeName, err)
+	}
+	uplinkSwitches := make(map[string]*nbdb.LogicalSwitch, len(switches))
+	for _, sw := range switches {
+		uplink := strings.TrimSuffix(strings.TrimPrefix(sw.Name, types.GatewayUplinkPrefix), suffix)
+		uplinkSwitches[uplink] = sw
+	}
+	return uplinkSwitches, nil
+}
+
+// ensureGatewayUplinkNodeIPs sets the IPs of all the nodes in the node IPs address set, whose traffic is not rerouted
+// through the gateway uplinks. The address set is maintained by the egress IP controller when egress IP is enabled.
+// Otherwise it is only maintained once a gateway uplink needed it, when create is set.
+func (oc *DefaultNetworkController) ensureGatewayUplinkNodeIPs(create bool) error {
+	if config.Gateway.Mode != config.GatewayModeShared || config.OVNKubernetesFeature.EnableEgressIP {
+		return nil
+	}
+	oc.eIPC.nodeIPUpdateMutex.Lock()
+	defer oc.eIPC.nodeIPUpdateMutex.Unlock()
+	if !create && !oc.gatewayUplinkNodeIPs {
+		return nil
+	}
+	nodes, err := oc.watchFactory.GetNodes()
+	if err != nil {
+		return err
+	}
+	v4NodeAddrs, v6NodeAddrs, err := util.GetNodeAddresses(config.IPv4Mode, config.IPv6Mode, nodes...)
+	if err != nil {
+		return err
+	}
+	as, err := oc.addressSetFactory.EnsureAddressSet(getEgressIPAddrSetDbIDs(NodeIPAddrSetName, oc.controllerName))
+	if err != nil {
+		return fmt.Errorf("cannot ensure that addressSet %s exists %v", NodeIPAddrSetName, err)
+	}
+	if err = as.SetIPs(append(v4NodeAddrs, v6NodeAddrs...)); err != nil {
+		return fmt.Errorf("unable to set IPs to address set %s: %w", NodeIPAddrSetName, err)
+	}
+	oc.gatewayUplinkNodeIPs = true
+	return nil
+}
+
+// gatewayUplinkExcludedSubnets returns the subnets whose traffic is not rerouted through the gateway uplinks: the
+// cluster subnets, the join subnet and the masquerade subnet
+func gatewayUplinkExcludedSubnets(clusterIPSubnet []*net.IPNet, isIPv6 bool) []string {
+	subnets := []string{}
+	for _, subnet := range clusterIPSubnet {
+		if utilnet.IsIPv6CIDR(subnet) == isIPv6 {
+			subnets = append(subnets, subnet.String())
+		}
+	}
+	if isIPv6 {
+		return append(subnets, config.Gateway.V6JoinSubnet, config.Gateway.V6MasqueradeSubnet)
+	}
+	return append(subnets, config.Gateway.V4JoinSubnet, config.Gateway.V4MasqueradeSubnet)
+}
+
+// syncGatewayUplinks removes the external switches and gateway router ports of the gateway uplinks removed from the
+// node, and syncs the logical router policies rerouting the traffic of the namespaces mapped to the gateway uplinks
+// through them. The traffic of the pods only egresses through the gateway router in shared gateway mode, so the
+// policies are only added in that mode. The traffic to the cluster, join and masquerade subnets and to the node IPs,
+// which covers the host network endpoints, is not rerouted, nor are the replies of the load balanced connections,
+// e.g. the node port connections entering through the gateway bridge, which are sent back through it.
+func (oc *DefaultNetworkController) syncGatewayUplinks(nodeName string, clusterIPSubnet []*net.IPNet,
+	uplinks []util.L3GatewayUplink) error {
+	gatewayRouter := types.GWRouterPrefix + nodeName
+	uplinkNames := sets.New[string]()
+	for _, uplink := range uplinks {
+		uplinkNames.Insert(uplink.Name)
+	}
+
+	uplinkSwitches, err := oc.findGatewayUplinkSwitches(nodeName)
+	if err != nil {
+		return err
+	}
+	for uplink, sw := range uplinkSwitches {
+		if uplinkNames.Has(uplink) {
+			continue
+		}
+		logicalRouter := nbdb.LogicalRouter{Name: gatewayRouter}
+		logicalRouterPort := nbdb.LogicalRouterPort{
+			Name: gatewayUplinkSwitchPrefix(uplink) + types.GWRouterToExtSwitchPrefix + gatewayRouter,
+		}
+		err = libovsdbops.DeleteLogicalRouterPorts(oc.nbClient, &logicalRouter, &logicalRouterPort)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return fmt.Errorf("failed to delete port %s on router %s: %w", logicalRouterPort.Name, gatewayRouter, err)
+		}
+		err = libovsdbops.DeleteLogicalSwitch(oc.nbClient, sw.Name)
+		if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+			return fmt.Errorf("failed to delete external switch %s: %w", sw.Name, err)
+		}
+	}
+
+	policies := map[string]*nbdb.LogicalRouterPolicy{}
+	if config.Gateway.Mode == config.GatewayModeShared && len(uplinks) > 0 {
+		if err := oc.ensureGatewayUplinkNodeIPs(true); err != nil {
+			return err
+		}
+		nodeIPsV4, nodeIPsV6 := addressset.GetHashNamesForAS(getEgressIPAddrSetDbIDs(NodeIPAddrSetName, oc.controllerName))
+		for _, uplink := range uplinks {
+			for _, namespace := range uplink.Namespaces {
+				hashNameV4, hashNameV6 := addressset.GetHashNamesForAS(getNamespaceAddrSetDbIDs(namespace, oc.controllerName))
+				for _, nextHop := range uplink.NextHops {
+					isIPv6 := utilnet.IsIPv6(nextHop)
+					l3Prefix, hashName, nodeIPs := "ip4", hashNameV4, nodeIPsV4
+					if isIPv6 {
+						l3Prefix, hashName, nodeIPs = "ip6", hashNameV6, nodeIPsV6
+					}
+					match := fmt.Sprintf("%s.src == $%s && %s.dst != {%s} && %s.dst != $%s && ct_mark.natted != 1",
+						l3Prefix, hashName, l3Prefix,
+						strings.Join(gatewayUplinkExcludedSubnets(clusterIPSubnet, isIPv6), ", "), l3Prefix, nodeIPs)
+					policy, ok := policies[match]
+					if !ok {
+						policy = &nbdb.LogicalRouterPolicy{
+							Priority:    types.GatewayUplinkReroutePriority,
+							Match:       match,
+							Action:      nbdb.LogicalRouterPolicyActionReroute,
+							ExternalIDs: map[string]string{types.GatewayUplinkExternalID: uplink.Name},
+						}
+						policies[match] = policy
+					}
+					policy.Nexthops = append(policy.Nexthops, nextHop.String())
+				}
+			}
+		}
+	}
+
+	logicalRouter, err := libovsdbops.GetLogicalRouter(oc.nbClient, &nbdb.LogicalRouter{Name: gatewayRouter})
+	if err != nil {
+		return fmt.Errorf("unable to retrieve logical router %s: %w", gatewayRouter, err)
+	}
+	for _, policy := range policies {
+		p := func(item *nbdb.LogicalRouterPolicy) bool {
+			return item.Priority == policy.Priority && item.Match == policy.Match &&
+				util.SliceHasStringItem(logicalRouter.Policies, item.UUID)
+		}
+		err = libovsdbops.CreateOrUpdateLogicalRouterPolicyWithPredicate(oc.nbClient, gatewayRouter, policy, p,
+			&policy.Nexthops, &policy.Action, &policy.ExternalIDs)
+		if err != nil {
+			return fmt.Errorf("failed to add gateway uplink policy %+v on router %s: %w", policy, gatewayRouter, err)
+		}
+	}
+	p := func(item *nbdb.LogicalRouterPolicy) bool {
+		return item.ExternalIDs[types.GatewayUplinkExternalID] != "" && policies[item.Match] == nil &&
+			util.SliceHasStringItem(logicalRouter.Policies, item.UUID)
+	}
+	err = libovsdbops.DeleteLogicalRouterPoliciesWithPredicate(oc.nbClient, gatewayRouter, p)
+	if err != nil && !errors.Is(err, libovsdbclient.ErrNotFound) {
+		return fmt.Errorf("failed to delete stale gateway uplink policies on router %s: %w", gatewayRouter, err)
+	}
+	return nil
+}
+
 func (oc *DefaultNetworkController) addPolicyBasedRoutes(nodeName, mgmtPortIP string, hostIfCIDR *net.IPNet, otherHostAddrs []string) error {
 	var l3Prefix string
 	if utilnet.IsIPv6(hostIfCIDR.IP) {
@@ -12,8 +12,12 @@ import (
 
 	utilnet "k8s.io/utils/net"
 
+	libovsdbclient "github.com/ovn-org/libovsdb/client"
+
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
 	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
@@ -1073,6 +1077,99 @@ var _ = ginkgo.Describe("Gateway Init Operations", func() {
 			expectedDatabaseState = append(expectedDatabaseState, ignoreRoute4)
 			gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedDatabaseState))
 		})
+
+		ginkgo.It("adds and removes the gateway uplinks", func() {
+			fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{
+				NBData: []libovsdbtest.TestData{
+					&nbdb.LogicalSwitch{
+						UUID: types.OVNJoinSwitch + "-UUID",
+						Name: types.OVNJoinSwitch,
+					},
+					&nbdb.LogicalRouter{
+						UUID: types.OVNClusterRouter + "-UUID",
+						Name: types.OVNClusterRouter,
+					},
+					&nbdb.LogicalSwitch{
+						UUID: nodeName + "-UUID",
+						Name: nodeName,
+					},
+				},
+			})
+
+			clusterIPSubnets := ovntest.MustParseIPNets("10.128.0.0/14")
+			hostSubnets := ovntest.MustParseIPNets("10.130.0.0/23")
+			joinLRPIPs := ovntest.MustParseIPNets("100.64.0.3/16")
+			defLRPIPs := ovntest.MustParseIPNets("100.64.0.1/16")
+			l3GatewayConfig := &util.L3GatewayConfig{
+				Mode:           config.GatewayModeShared,
+				ChassisID:      "SYSTEM-ID",
+				InterfaceID:    "INTERFACE-ID",
+				MACAddress:     ovntest.MustParseMAC("11:22:33:44:55:66"),
+				IPAddresses:    ovntest.MustParseIPNets("169.254.33.2/24"),
+				NextHops:       ovntest.MustParseIPs("169.254.33.1"),
+				NodePortEnable: true,
+				Uplinks: []util.L3GatewayUplink{
+					{
+						Name:        "storage",
+						InterfaceID: "breth2_" + nodeName,
+						MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:77"),
+						IPAddresses: ovntest.MustParseIPNets("192.168.100.10/24"),
+						NextHops:    ovntest.MustParseIPs("192.168.100.1"),
+						Namespaces:  []string{"database"},
+					},
+				},
+			}
+
+			var err error
+			fakeOvn.controller.defaultCOPPUUID, err = EnsureDefaultCOPP(fakeOvn.nbClient)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = fakeOvn.controller.gatewayInit(
+				nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, false, joinLRPIPs, defLRPIPs, true)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+			gatewayRouter := types.GWRouterPrefix + nodeName
+			uplinkSwitch := "uplink-storage-" + types.ExternalSwitchPrefix + nodeName
+			uplinkRouterPort := "uplink-storage-" + types.GWRouterToExtSwitchPrefix + gatewayRouter
+			sw, err := libovsdbops.GetLogicalSwitch(fakeOvn.nbClient, &nbdb.LogicalSwitch{Name: uplinkSwitch})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(sw.Ports).To(gomega.HaveLen(2))
+			lrp, err := libovsdbops.GetLogicalRouterPort(fakeOvn.nbClient, &nbdb.LogicalRouterPort{Name: uplinkRouterPort})
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			gomega.Expect(lrp.Networks).To(gomega.ConsistOf("192.168.100.10/24"))
+
+			hashNameV4, _ := addressset.GetHashNamesForAS(getNamespaceAddrSetDbIDs("database", DefaultNetworkControllerName))
+			findUplinkPolicies := func() []*nbdb.LogicalRouterPolicy {
+				policies, err := libovsdbops.FindLogicalRouterPoliciesWithPredicate(fakeOvn.nbClient,
+					func(item *nbdb.LogicalRouterPolicy) bool {
+						return item.Priority == types.GatewayUplinkReroutePriority
+					})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				return policies
+			}
+			policies := findUplinkPolicies()
+			gomega.Expect(policies).To(gomega.HaveLen(1))
+			nodeIPsV4, _ := addressset.GetHashNamesForAS(getEgressIPAddrSetDbIDs(NodeIPAddrSetName, DefaultNetworkControllerName))
+			gomega.Expect(policies[0].Match).To(gomega.Equal(
+				fmt.Sprintf("ip4.src == $%s && ip4.dst != {10.128.0.0/14, %s, %s} && ip4.dst != $%s && ct_mark.natted != 1",
+					hashNameV4, config.Gateway.V4JoinSubnet, config.Gateway.V4MasqueradeSubnet, nodeIPsV4)))
+			fakeOvn.asf.ExpectEmptyAddressSet(getEgressIPAddrSetDbIDs(NodeIPAddrSetName, DefaultNetworkControllerName))
+			gomega.Expect(policies[0].Action).To(gomega.Equal(nbdb.LogicalRouterPolicyActionReroute))
+			gomega.Expect(policies[0].Nexthops).To(gomega.ConsistOf("192.168.100.1"))
+			gomega.Expect(policies[0].ExternalIDs).To(gomega.HaveKeyWithValue(types.GatewayUplinkExternalID, "storage"))
+
+			ginkgo.By("removing the uplink")
+			l3GatewayConfig.Uplinks = nil
+			err = fakeOvn.controller.gatewayInit(
+				nodeName, clusterIPSubnets, hostSubnets, l3GatewayConfig, false, joinLRPIPs, defLRPIPs, true)
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			_, err = libovsdbops.GetLogicalSwitch(fakeOvn.nbClient, &nbdb.LogicalSwitch{Name: uplinkSwitch})
+			gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+			_, err = libovsdbops.GetLogicalRouterPort(fakeOvn.nbClient, &nbdb.LogicalRouterPort{Name: uplinkRouterPort})
+			gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+			gomega.Expect(findUplinkPolicies()).To(gomega.BeEmpty())
+		})
+
 	})
 
 	ginkgo.Context("Gateway Create Operations Local Gateway Mode", func() {
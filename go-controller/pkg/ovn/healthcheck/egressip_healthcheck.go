@@ -12,21 +12,32 @@ import (
 	"net"
 	"strconv"
 	"sync"
+	"sync/atomic"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
 )
 
 const (
 	serviceEgressIPNode = "Service_Egress_IP"
+
+	// egressIPNodeChecksInterval matches the interval at which the cluster manager probes egress nodes
+	egressIPNodeChecksInterval       = 5 * time.Second
+	egressIPNodeChecksDefaultTimeout = time.Second
 )
 
 // UnimplementedHealthServer must be embedded to have forward compatible implementations.
 type healthServer struct {
 	UnimplementedHealthServer
+
+	// healthy is the combined result of the last run of the node checks
+	healthy atomic.Bool
 }
 
-func (healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
+func (hs *healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
 	response := HealthCheckResponse{}
 
-	if req.GetService() == serviceEgressIPNode {
+	if req.GetService() == serviceEgressIPNode && hs.healthy.Load() {
 		response.Status = HealthCheckResponse_SERVING
 	} else {
 		response.Status = HealthCheckResponse_NOT_SERVING
@@ -34,10 +45,36 @@ func (healthServer) Check(_ context.Context, req *HealthCheckRequest) (*HealthCh
 	return &response, nil
 }
 
+// runChecks runs all node checks concurrently, so that each of them gets the whole timeout, and records whether all
+// of them passed.
+func (hs *healthServer) runChecks(checks []EgressIPNodeCheck, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var failed atomic.Bool
+	wg := &sync.WaitGroup{}
+	for _, check := range checks {
+		wg.Add(1)
+		go func(check EgressIPNodeCheck) {
+			defer wg.Done()
+			if err := check.Check(ctx); err != nil {
+				klog.Warningf("Egress IP node health check %s failed: %v", check.Name(), err)
+				failed.Store(true)
+			}
+		}(check)
+	}
+	wg.Wait()
+	healthy := !failed.Load()
+	if hs.healthy.Swap(healthy) != healthy {
+		klog.Infof("Egress IP node health checks changed state, healthy: %v", healthy)
+	}
+}
+
 // EgressIPHealthServer interface is the means for spawning a gRPC server for
 // the egress ip health check service.
 type EgressIPHealthServer interface {
 	Run(stopCh <-chan struct{})
+	// SetChecks replaces the additional checks that must pass for the node to be reported as serving
+	SetChecks(checks []EgressIPNodeCheck)
 }
 type egressIPHealthServer struct {
 	// Management port bound by server
@@ -45,16 +82,34 @@ type egressIPHealthServer struct {
 
 	// EgressIP Node reachability gRPC port (0 means it should use dial instead)
 	healthCheckPort int
+
+	// Additional checks that must pass for the gRPC health check to report the node as serving
+	checksLock sync.Mutex
+	checks     []EgressIPNodeCheck
 }
 
-// NewEgressIPHealthServer allocates an Egress IP health server.
-func NewEgressIPHealthServer(nodeMgmtIP net.IP, healthCheckPort int) (EgressIPHealthServer, error) {
+// NewEgressIPHealthServer allocates an Egress IP health server. The server only reports the
+// node as serving while all the given checks pass.
+func NewEgressIPHealthServer(nodeMgmtIP net.IP, healthCheckPort int, checks ...EgressIPNodeCheck) (EgressIPHealthServer, error) {
 	return &egressIPHealthServer{
 		nodeMgmtIP:      nodeMgmtIP,
 		healthCheckPort: healthCheckPort,
+		checks:          checks,
 	}, nil
 }
 
+func (ehs *egressIPHealthServer) SetChecks(checks []EgressIPNodeCheck) {
+	ehs.checksLock.Lock()
+	defer ehs.checksLock.Unlock()
+	ehs.checks = checks
+}
+
+func (ehs *egressIPHealthServer) getChecks() []EgressIPNodeCheck {
+	ehs.checksLock.Lock()
+	defer ehs.checksLock.Unlock()
+	return ehs.checks
+}
+
 // Run spawns gRPC server for handling the egress ip health check service.
 func (ehs *egressIPHealthServer) Run(stopCh <-chan struct{}) {
 	nodeAddr := net.JoinHostPort(ehs.nodeMgmtIP.String(), strconv.Itoa(ehs.healthCheckPort))
@@ -79,11 +134,25 @@ func (ehs *egressIPHealthServer) Run(stopCh <-chan struct{}) {
 	}
 	grpcServer := grpc.NewServer(opts...)
 
+	hs := &healthServer{}
+	// the checks must complete within the time the cluster manager waits for a probe
+	timeout := time.Duration(config.OVNKubernetesFeature.EgressIPReachabiltyTotalTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = egressIPNodeChecksDefaultTimeout
+	}
+	hs.runChecks(ehs.getChecks(), timeout)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// the checks can be replaced at any time, a node without checks is always reported as serving
+		wait.Until(func() { hs.runChecks(ehs.getChecks(), timeout) }, egressIPNodeChecksInterval, stopCh)
+	}()
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
 
-		RegisterHealthServer(grpcServer, &healthServer{})
+		RegisterHealthServer(grpcServer, hs)
 		klog.Infof("Starting Egress IP Health Server on %s:%d", ehs.nodeMgmtIP.String(), ehs.healthCheckPort)
 		if err := grpcServer.Serve(lis); err != nil && err != grpc.ErrServerStopped {
 			klog.Fatalf("Egress IP Health checking server failed: %v", err)
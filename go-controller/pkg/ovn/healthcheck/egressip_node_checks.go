@@ -0,0 +1,237 @@
+package healthcheck
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+	"golang.org/x/net/context"
+)
+
+const (
+	// EgressIPNodeCheckICMP checks that upstream targets reply to ICMP echo requests
+	EgressIPNodeCheckICMP = "icmp"
+	// EgressIPNodeCheckBFD checks that a BFD session of the node's gateway router is up
+	EgressIPNodeCheckBFD = "bfd"
+
+	icmpEchoTimeout = time.Second
+)
+
+// EgressIPNodeCheck is a reachability check run by the egress node. Its result is
+// combined with the gRPC health check that the cluster manager probes.
+type EgressIPNodeCheck interface {
+	Name() string
+	// Check returns an error if the node should not be considered reachable
+	Check(ctx context.Context) error
+}
+
+// NewEgressIPNodeChecks builds the checks named in the comma separated checks list.
+func NewEgressIPNodeChecks(nodeName, checks, icmpTargets string) ([]EgressIPNodeCheck, error) {
+	var nodeChecks []EgressIPNodeCheck
+	for _, name := range strings.Split(checks, ",") {
+		switch strings.TrimSpace(name) {
+		case "":
+			continue
+		case EgressIPNodeCheckICMP:
+			check, err := newICMPCheck(icmpTargets)
+			if err != nil {
+				return nil, err
+			}
+			nodeChecks = append(nodeChecks, check)
+		case EgressIPNodeCheckBFD:
+			nodeChecks = append(nodeChecks, &bfdCheck{nodeName: nodeName})
+		default:
+			return nil, fmt.Errorf("unknown egress IP node health check %q", name)
+		}
+	}
+	return nodeChecks, nil
+}
+
+// icmpCheck passes if any of its targets replies to an ICMP echo request.
+type icmpCheck struct {
+	targets []net.IP
+}
+
+func newICMPCheck(targets string) (*icmpCheck, error) {
+	check := &icmpCheck{}
+	for _, target := range strings.Split(targets, ",") {
+		target = strings.TrimSpace(target)
+		if target == "" {
+			continue
+		}
+		ip := net.ParseIP(target)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid egress IP node health check ICMP target %q", target)
+		}
+		check.targets = append(check.targets, ip)
+	}
+	if len(check.targets) == 0 {
+		return nil, fmt.Errorf("egress IP node health check %q requires at least one ICMP target", EgressIPNodeCheckICMP)
+	}
+	return check, nil
+}
+
+func (c *icmpCheck) Name() string {
+	return EgressIPNodeCheckICMP
+}
+
+func (c *icmpCheck) Check(ctx context.Context) error {
+	var errs []error
+	for _, target := range c.targets {
+		err := icmpEcho(ctx, target)
+		if err == nil {
+			return nil
+		}
+		errs = append(errs, err)
+	}
+	return fmt.Errorf("no ICMP target replied: %v", errs)
+}
+
+// icmpEcho sends a single ICMP echo request to target and waits for the matching reply.
+func icmpEcho(ctx context.Context, target net.IP) error {
+	network, echoType, replyType := "ip4:icmp", byte(8), byte(0)
+	if target.To4() == nil {
+		network, echoType, replyType = "ip6:ipv6-icmp", byte(128), byte(129)
+	}
+	conn, err := net.Dial(network, target.String())
+	if err != nil {
+		return fmt.Errorf("failed to open ICMP socket to %s: %w", target, err)
+	}
+	defer conn.Close()
+
+	deadline := time.Now().Add(icmpEchoTimeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	id := uint16(os.Getpid() & 0xffff)
+	seq := uint16(time.Now().UnixNano() & 0xffff)
+	msg := []byte{echoType, 0, 0, 0, byte(id >> 8), byte(id), byte(seq >> 8), byte(seq)}
+	// ICMPv4 requests need a checksum, the kernel computes the ICMPv6 one
+	if echoType == 8 {
+		csum := icmpChecksum(msg)
+		msg[2], msg[3] = byte(csum>>8), byte(csum)
+	}
+	if _, err := conn.Write(msg); err != nil {
+		return fmt.Errorf("failed to send ICMP echo request to %s: %w", target, err)
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return fmt.Errorf("no ICMP echo reply from %s: %w", target, err)
+		}
+		reply := buf[:n]
+		// IPv4 raw sockets return the IP header
+		if echoType == 8 && n > 0 && reply[0]>>4 == 4 {
+			hdrLen := int(reply[0]&0x0f) * 4
+			if n < hdrLen {
+				continue
+			}
+			reply = reply[hdrLen:]
+		}
+		if len(reply) >= 8 && reply[0] == replyType &&
+			reply[4] == msg[4] && reply[5] == msg[5] && reply[6] == msg[6] && reply[7] == msg[7] {
+			return nil
+		}
+	}
+}
+
+func icmpChecksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum>>16 != 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
+
+// bfdCheck fails if the node's gateway router has BFD sessions and none of them is up.
+// Nodes without BFD sessions pass the check. The sessions are read from the southbound
+// database, where ovn-controller reports their status, as the node has no access to the
+// northbound database unless it runs its own zone.
+type bfdCheck struct {
+	nodeName string
+}
+
+func (c *bfdCheck) Name() string {
+	return EgressIPNodeCheckBFD
+}
+
+func (c *bfdCheck) Check(ctx context.Context) error {
+	logicalPort := types.GWRouterToExtSwitchPrefix + types.GWRouterPrefix + c.nodeName
+	stdout, stderr, err := util.RunOVNSbctlWithTimeout(ctxTimeoutSeconds(ctx), "--format=json", "--columns=status",
+		"find", "BFD", "logical_port="+logicalPort)
+	if err != nil {
+		return fmt.Errorf("failed to get BFD sessions of %s, stderr: %q: %w", logicalPort, stderr, err)
+	}
+	statuses, err := parseBFDStatuses(stdout)
+	if err != nil {
+		return fmt.Errorf("failed to parse BFD sessions of %s: %w", logicalPort, err)
+	}
+	if len(statuses) == 0 {
+		return nil
+	}
+	for _, status := range statuses {
+		if status == string(nbdb.BFDStatusUp) {
+			return nil
+		}
+	}
+	return fmt.Errorf("none of the BFD sessions of %s is up: %v", logicalPort, statuses)
+}
+
+// ctxTimeoutSeconds returns the whole seconds left before the deadline of the context, at least one, as the timeout of
+// the commands run for a check, or the default timeout of the checks if the context has no deadline
+func ctxTimeoutSeconds(ctx context.Context) int {
+	timeout := egressIPNodeChecksDefaultTimeout
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout = time.Until(deadline)
+	}
+	seconds := int(timeout / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
+
+// parseBFDStatuses parses the output of ovn-sbctl --format=json --columns=status find BFD
+func parseBFDStatuses(output string) ([]string, error) {
+	if output == "" {
+		return nil, nil
+	}
+	var table struct {
+		Data [][]interface{} `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(output), &table); err != nil {
+		return nil, err
+	}
+	var statuses []string
+	for _, row := range table.Data {
+		if len(row) == 0 {
+			continue
+		}
+		switch status := row[0].(type) {
+		case string:
+			statuses = append(statuses, status)
+		default:
+			// an unset optional column is printed as ["set",[]]
+			statuses = append(statuses, "")
+		}
+	}
+	return statuses, nil
+}
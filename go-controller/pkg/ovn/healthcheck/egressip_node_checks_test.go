@@ -0,0 +1,165 @@
+package healthcheck
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+type fakeNodeCheck struct {
+	err error
+	// delay is how long the check takes, it fails if the context is done first
+	delay time.Duration
+}
+
+func (c *fakeNodeCheck) Name() string {
+	return "fake"
+}
+
+func (c *fakeNodeCheck) Check(ctx context.Context) error {
+	select {
+	case <-time.After(c.delay):
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestNewEgressIPNodeChecks(t *testing.T) {
+	tests := []struct {
+		desc        string
+		checks      string
+		icmpTargets string
+		expected    []string
+		expectErr   bool
+	}{
+		{
+			desc:     "no checks",
+			expected: nil,
+		},
+		{
+			desc:        "icmp and bfd",
+			checks:      "icmp, bfd",
+			icmpTargets: "10.0.0.1,fd00::1",
+			expected:    []string{EgressIPNodeCheckICMP, EgressIPNodeCheckBFD},
+		},
+		{
+			desc:      "icmp without targets",
+			checks:    "icmp",
+			expectErr: true,
+		},
+		{
+			desc:        "icmp with an invalid target",
+			checks:      "icmp",
+			icmpTargets: "not-an-ip",
+			expectErr:   true,
+		},
+		{
+			desc:      "unknown check",
+			checks:    "bfd,tcp",
+			expectErr: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			checks, err := NewEgressIPNodeChecks("node1", tc.checks, tc.icmpTargets)
+			if tc.expectErr {
+				if err == nil {
+					t.Fatalf("expected an error, got checks %v", checks)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var names []string
+			for _, check := range checks {
+				names = append(names, check.Name())
+			}
+			if !reflect.DeepEqual(names, tc.expected) {
+				t.Fatalf("expected checks %v, got %v", tc.expected, names)
+			}
+		})
+	}
+}
+
+func TestParseBFDStatuses(t *testing.T) {
+	output := `{"data":[["up"],["down"],[["set",[]]]],"headings":["status"]}`
+	statuses, err := parseBFDStatuses(output)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"up", "down", ""}
+	if !reflect.DeepEqual(statuses, expected) {
+		t.Fatalf("expected statuses %v, got %v", expected, statuses)
+	}
+}
+
+func TestHealthServerCheck(t *testing.T) {
+	hs := &healthServer{}
+	req := &HealthCheckRequest{Service: serviceEgressIPNode}
+
+	hs.runChecks([]EgressIPNodeCheck{&fakeNodeCheck{}, &fakeNodeCheck{err: fmt.Errorf("uplink down")}}, egressIPNodeChecksDefaultTimeout)
+	response, _ := hs.Check(context.Background(), req)
+	if response.Status != HealthCheckResponse_NOT_SERVING {
+		t.Fatalf("expected NOT_SERVING with a failed check, got %v", response.Status)
+	}
+
+	hs.runChecks([]EgressIPNodeCheck{&fakeNodeCheck{}}, egressIPNodeChecksDefaultTimeout)
+	response, _ = hs.Check(context.Background(), req)
+	if response.Status != HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING with passing checks, got %v", response.Status)
+	}
+}
+
+func TestHealthServerRunsChecksConcurrently(t *testing.T) {
+	hs := &healthServer{}
+	req := &HealthCheckRequest{Service: serviceEgressIPNode}
+
+	// the checks only pass within the timeout if they run concurrently
+	hs.runChecks([]EgressIPNodeCheck{&fakeNodeCheck{delay: 300 * time.Millisecond},
+		&fakeNodeCheck{delay: 300 * time.Millisecond}}, 500*time.Millisecond)
+	response, _ := hs.Check(context.Background(), req)
+	if response.Status != HealthCheckResponse_SERVING {
+		t.Fatalf("expected SERVING with checks passing within the timeout, got %v", response.Status)
+	}
+}
+
+func TestCtxTimeoutSeconds(t *testing.T) {
+	tests := []struct {
+		desc     string
+		timeout  time.Duration
+		expected int
+	}{
+		{
+			desc:     "no deadline",
+			expected: 1,
+		},
+		{
+			desc:     "deadline in seconds",
+			timeout:  3500 * time.Millisecond,
+			expected: 3,
+		},
+		{
+			desc:     "deadline under a second",
+			timeout:  100 * time.Millisecond,
+			expected: 1,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tc.timeout)
+				defer cancel()
+			}
+			if seconds := ctxTimeoutSeconds(ctx); seconds != tc.expected {
+				t.Fatalf("expected a timeout of %d seconds, got %d", tc.expected, seconds)
+			}
+		})
+	}
+}
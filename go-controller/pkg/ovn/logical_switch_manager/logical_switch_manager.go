@@ -46,6 +46,12 @@ func (manager *LogicalSwitchManager) AddOrUpdateSwitch(switchName string, hostSu
 	return manager.allocator.AddOrUpdateSubnet(switchName, hostSubnets, excludeSubnets...)
 }
 
+// ExpandSwitch adds host subnets and exclude subnets to a switch keeping the
+// IPs already allocated on its current host subnets.
+func (manager *LogicalSwitchManager) ExpandSwitch(switchName string, hostSubnets []*net.IPNet, excludeSubnets ...*net.IPNet) error {
+	return manager.allocator.ExpandSubnet(switchName, hostSubnets, excludeSubnets...)
+}
+
 // AddNoHostSubnetSwitch adds/updates a switch without any host subnets
 // to the logical switch manager
 func (manager *LogicalSwitchManager) AddNoHostSubnetSwitch(switchName string) error {
@@ -586,6 +586,60 @@ var _ = ginkgo.Describe("OVN Multicast with IP Address Family", func() {
 				gomega.Expect(err).NotTo(gomega.HaveOccurred())
 			})
 
+			ginkgo.It("tests logging the multicast ACLs of a namespace "+ipModeStr(m), func() {
+				app.Action = func(ctx *cli.Context) error {
+					namespace1 := *newNamespace(namespaceName1)
+
+					fakeOvn.startWithDBSetup(libovsdb.TestSetup{},
+						&v1.NamespaceList{
+							Items: []v1.Namespace{
+								namespace1,
+							},
+						},
+					)
+					setIpMode(m)
+
+					err := fakeOvn.controller.WatchNamespaces()
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					ns, err := fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Get(context.TODO(), namespace1.Name, metav1.GetOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+					updateMulticast(fakeOvn, ns, true)
+					expectedData := getMulticastPolicyExpectedData(namespace1.Name, nil)
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+
+					// Enable logging the allowed traffic, the ACLs get named to map the logged verdicts.
+					logSeverity := nbdb.ACLSeverityInfo
+					ns.Annotations[util.AclLoggingAnnotation] = `{"allow": "` + logSeverity + `"}`
+					_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					loggedData := getMulticastPolicyExpectedData(namespace1.Name, nil)
+					for _, aclDir := range []libovsdbutil.ACLDirection{libovsdbutil.ACLEgress, libovsdbutil.ACLIngress} {
+						acl := loggedData[0].(*nbdb.ACL)
+						if aclDir == libovsdbutil.ACLIngress {
+							acl = loggedData[1].(*nbdb.ACL)
+						}
+						aclName := libovsdbutil.GetMulticastACLName(getNamespaceMcastACLDbIDs(namespace1.Name, aclDir,
+							DefaultNetworkControllerName))
+						acl.Name = &aclName
+						acl.Log = true
+						acl.Severity = &logSeverity
+					}
+					gomega.Expect(*loggedData[1].(*nbdb.ACL).Name).To(gomega.Equal("MC:" + namespace1.Name + ":Ingress"))
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(loggedData...))
+
+					// Disable logging, the ACLs are unnamed again.
+					delete(ns.Annotations, util.AclLoggingAnnotation)
+					_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), ns, metav1.UpdateOptions{})
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					gomega.Eventually(fakeOvn.nbClient).Should(libovsdb.HaveData(expectedData...))
+					return nil
+				}
+
+				err := app.Run([]string{app.Name})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			})
+
 			ginkgo.It("tests enabling multicast in a namespace with a pod "+ipModeStr(m), func() {
 				app.Action = func(ctx *cli.Context) error {
 					namespace1 := *newNamespace(namespaceName1)
@@ -0,0 +1,842 @@
+package ovn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	egressfirewallapi "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/crd/egressfirewall/v1"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	kapi "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// nbDriftStale is the kind of drift of NB objects whose Kubernetes owner doesn't exist
+	nbDriftStale = "stale"
+	// nbDriftMissing is the kind of drift of NB objects expected for an existing Kubernetes owner, but not in the
+	// NB database
+	nbDriftMissing = "missing"
+	// nbDriftMismatched is the kind of drift of NB objects expected for an existing Kubernetes owner whose content
+	// differs from the expected one
+	nbDriftMismatched = "mismatched"
+
+	nbDriftEventReason = "NBDrift"
+
+	nbObjectTypeAddressSet = "AddressSet"
+	nbObjectTypeACL        = "ACL"
+	nbObjectTypePortGroup  = "PortGroup"
+)
+
+// nbDrift is a difference between the NB objects the controller expects for a Kubernetes owner and the ones in
+// the NB database
+type nbDrift struct {
+	ownerType  string
+	objectType string
+	kind       string
+	// ownerKey identifies the Kubernetes owner, e.g. its namespace and name
+	ownerKey string
+	// objects is the number of drifted NB objects
+	objects int
+	// owner is the Kubernetes object the drift is reported on with an event, nil if it doesn't exist
+	owner runtime.Object
+	// repair fixes the drift. The owner may have been handled since the audit, so repair re-verifies the drift
+	// holding the locks the handlers of the owner hold, or hands the owner back to its retry framework.
+	repair func() error
+}
+
+func (d *nbDrift) key() string {
+	return strings.Join([]string{d.ownerType, d.objectType, d.kind, d.ownerKey}, "/")
+}
+
+func (d *nbDrift) String() string {
+	return fmt.Sprintf("%d %s %s object(s) of type %s owned by %s", d.objects, d.kind, d.ownerType, d.objectType, d.ownerKey)
+}
+
+// nbDriftAuditor periodically compares the NB objects the controller owns, as identified by their DbObjectIDs, with
+// the ones expected from the Kubernetes objects, and reports the drift as metrics and events. In repair mode, stale
+// objects are deleted, and missing and mismatched objects are recreated.
+// The NB objects of a Kubernetes object that is being handled may be transiently incomplete, therefore drift is only
+// reported once it was found by two consecutive audits.
+type nbDriftAuditor struct {
+	oc     *DefaultNetworkController
+	repair bool
+	// suspects is the drift found by the previous audit, by drift key
+	suspects sets.Set[string]
+}
+
+func newNBDriftAuditor(oc *DefaultNetworkController, mode string) *nbDriftAuditor {
+	return &nbDriftAuditor{
+		oc:       oc,
+		repair:   mode == config.NBDriftAuditModeRepair,
+		suspects: sets.New[string](),
+	}
+}
+
+// Run audits the NB database every interval until stopCh is closed
+func (a *nbDriftAuditor) Run(interval time.Duration, stopCh <-chan struct{}) {
+	klog.Infof("Starting OVN NB drift auditor every %v, repair: %v", interval, a.repair)
+	metrics.RegisterNBDriftMetrics()
+	wait.Until(func() {
+		if err := a.audit(); err != nil {
+			klog.Errorf("OVN NB drift audit failed: %v", err)
+		}
+	}, interval, stopCh)
+}
+
+// audit finds the drift of every audited owner type, and reports or repairs the drift that was also found by the
+// previous audit
+func (a *nbDriftAuditor) audit() error {
+	start := time.Now()
+	audits := []func() ([]*nbDrift, error){
+		a.auditNamespaceAddressSets,
+		a.auditNetworkPolicyACLs,
+	}
+	if a.oc.needNamespacedPortGroup() {
+		audits = append(audits, a.auditNamespacePortGroups)
+	}
+	if config.OVNKubernetesFeature.EnableEgressFirewall {
+		audits = append(audits, a.auditEgressFirewallACLs)
+	}
+	if config.OVNKubernetesFeature.EnableAdminNetworkPolicy {
+		audits = append(audits, a.auditAdminNetworkPolicyACLs)
+	}
+	// TODO: audit the egress IP reroute policies, the egress QoS rules and the port groups of the network policies
+
+	var errs []error
+	var found []*nbDrift
+	for _, audit := range audits {
+		drift, err := audit()
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		found = append(found, drift...)
+	}
+
+	suspects := sets.New[string]()
+	counts := map[metrics.NBDriftKey]int{}
+	for _, drift := range found {
+		key := drift.key()
+		suspects.Insert(key)
+		if !a.suspects.Has(key) {
+			klog.V(5).Infof("Suspected OVN NB drift: %s", drift)
+			continue
+		}
+		counts[metrics.NBDriftKey{OwnerType: drift.ownerType, ObjectType: drift.objectType, Kind: drift.kind}] += drift.objects
+		if !a.repair {
+			// the drift is reported again by the next audit until it is fixed
+			klog.Warningf("Found OVN NB drift: %s, not repairing in dry-run mode", drift)
+			a.recordEvent(drift, fmt.Sprintf("Found %s, not repairing in dry-run mode", drift))
+			continue
+		}
+		if err := a.repairDrift(drift); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		// a repaired drift has to be found by two consecutive audits again to be reported
+		suspects.Delete(key)
+	}
+	a.suspects = suspects
+	metrics.RecordNBDriftAudit(counts, time.Since(start))
+	return utilerrors.NewAggregate(errs)
+}
+
+// repairDrift repairs the drift and records the result with an event on its owner
+func (a *nbDriftAuditor) repairDrift(drift *nbDrift) error {
+	klog.Warningf("Found OVN NB drift: %s, repairing", drift)
+	err := drift.repair()
+	metrics.RecordNBDriftRepair(drift.ownerType, drift.objectType, err)
+	if err != nil {
+		a.recordEvent(drift, fmt.Sprintf("Failed to repair %s: %v", drift, err))
+		return fmt.Errorf("failed to repair OVN NB drift %s: %w", drift, err)
+	}
+	a.recordEvent(drift, fmt.Sprintf("Repaired %s", drift))
+	return nil
+}
+
+func (a *nbDriftAuditor) recordEvent(drift *nbDrift, message string) {
+	if drift.owner == nil || a.oc.recorder == nil {
+		return
+	}
+	a.oc.recorder.Event(drift.owner, kapi.EventTypeWarning, nbDriftEventReason, message)
+}
+
+// auditNamespaceAddressSets finds the address sets of namespaces that don't exist, and the namespaces handled by
+// the controller without an address set
+func (a *nbDriftAuditor) auditNamespaceAddressSets() ([]*nbDrift, error) {
+	oc := a.oc
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.AddressSetNamespace, oc.controllerName, nil)
+	p := libovsdbops.GetPredicate[*nbdb.AddressSet](predicateIDs, nil)
+	addrSets, err := libovsdbops.FindAddressSetsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find namespace address sets: %w", err)
+	}
+	// namespace name -> ip family -> exists
+	existing := map[string]sets.Set[string]{}
+	for _, addrSet := range addrSets {
+		namespace := addrSet.ExternalIDs[libovsdbops.ObjectNameKey.String()]
+		if existing[namespace] == nil {
+			existing[namespace] = sets.New[string]()
+		}
+		existing[namespace].Insert(addrSet.ExternalIDs[libovsdbops.AddressSetIPFamilyKey.String()])
+	}
+	// a namespace has an address set per IP family
+	expectedAddrSets := 0
+	if config.IPv4Mode {
+		expectedAddrSets++
+	}
+	if config.IPv6Mode {
+		expectedAddrSets++
+	}
+
+	var drift []*nbDrift
+	for _, namespace := range sets.List(sets.KeySet(existing)) {
+		if _, err := oc.watchFactory.GetNamespace(namespace); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+		}
+		namespace := namespace
+		drift = append(drift, &nbDrift{
+			ownerType:  string(libovsdbops.NamespaceOwnerType),
+			objectType: nbObjectTypeAddressSet,
+			kind:       nbDriftStale,
+			ownerKey:   namespace,
+			objects:    existing[namespace].Len(),
+			repair: func() error {
+				return oc.deleteStaleNamespaceAddressSet(namespace)
+			},
+		})
+	}
+
+	namespaces, err := oc.watchFactory.GetNamespaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	for _, ns := range namespaces {
+		missing := expectedAddrSets - existing[ns.Name].Len()
+		if missing <= 0 || !oc.isNamespaceHandled(ns.Name) {
+			// namespaces that are not handled yet have no address set
+			continue
+		}
+		namespace := ns.Name
+		drift = append(drift, &nbDrift{
+			ownerType:  string(libovsdbops.NamespaceOwnerType),
+			objectType: nbObjectTypeAddressSet,
+			kind:       nbDriftMissing,
+			ownerKey:   namespace,
+			objects:    missing,
+			owner:      ns,
+			repair: func() error {
+				var err error
+				oc.retryNamespaces.DoWithLock(namespace, func(string) {
+					err = oc.recreateNamespaceAddressSet(namespace)
+				})
+				return err
+			},
+		})
+	}
+	return drift, nil
+}
+
+// auditNamespacePortGroups finds the port groups of namespaces that don't exist, and the namespaces handled by
+// the controller without a port group
+func (a *nbDriftAuditor) auditNamespacePortGroups() ([]*nbDrift, error) {
+	oc := a.oc
+	// the port group of a namespace is named after the hash of the namespace, which is its name external ID. The
+	// port groups of network policies are named the same way after names that are not valid namespace names.
+	p := func(pg *nbdb.PortGroup) bool {
+		namespace, ok := pg.ExternalIDs["name"]
+		return ok && pg.ExternalIDs[types.NetworkExternalID] == "" && len(validation.IsDNS1123Label(namespace)) == 0 &&
+			pg.Name == oc.getNamespacePortGroupName(namespace)
+	}
+	portGroups, err := libovsdbops.FindPortGroupsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find namespace port groups: %w", err)
+	}
+	existing := sets.New[string]()
+	for _, pg := range portGroups {
+		existing.Insert(pg.ExternalIDs["name"])
+	}
+
+	var drift []*nbDrift
+	for _, namespace := range sets.List(existing) {
+		if _, err := oc.watchFactory.GetNamespace(namespace); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+		}
+		namespace := namespace
+		drift = append(drift, &nbDrift{
+			ownerType:  string(libovsdbops.NamespaceOwnerType),
+			objectType: nbObjectTypePortGroup,
+			kind:       nbDriftStale,
+			ownerKey:   namespace,
+			objects:    1,
+			repair: func() error {
+				return oc.deleteStaleNamespacePortGroup(namespace)
+			},
+		})
+	}
+
+	namespaces, err := oc.watchFactory.GetNamespaces()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list namespaces: %w", err)
+	}
+	for _, ns := range namespaces {
+		if existing.Has(ns.Name) || !oc.isNamespaceHandled(ns.Name) {
+			// namespaces that are not handled yet have no port group
+			continue
+		}
+		namespace := ns.Name
+		drift = append(drift, &nbDrift{
+			ownerType:  string(libovsdbops.NamespaceOwnerType),
+			objectType: nbObjectTypePortGroup,
+			kind:       nbDriftMissing,
+			ownerKey:   namespace,
+			objects:    1,
+			owner:      ns,
+			repair: func() error {
+				var err error
+				oc.retryNamespaces.DoWithLock(namespace, func(string) {
+					err = a.recreateNamespacePortGroup(namespace)
+				})
+				return err
+			},
+		})
+	}
+	return drift, nil
+}
+
+// diffACLs compares the ACLs of an owner in the NB database with the expected ones, as identified by their
+// DbObjectIDs, and returns the number of stale ACLs, of missing ACLs, and of ACLs whose match, action or priority
+// differ from the expected ones
+func diffACLs(expected, existing []*nbdb.ACL) (stale, missing, mismatched int) {
+	existingByID := map[string]*nbdb.ACL{}
+	for _, acl := range existing {
+		existingByID[acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()]] = acl
+	}
+	for _, expectedACL := range expected {
+		id := expectedACL.ExternalIDs[libovsdbops.PrimaryIDKey.String()]
+		acl, ok := existingByID[id]
+		if !ok {
+			missing++
+			continue
+		}
+		delete(existingByID, id)
+		if acl.Match != expectedACL.Match || acl.Action != expectedACL.Action || acl.Priority != expectedACL.Priority {
+			mismatched++
+		}
+	}
+	return len(existingByID), missing, mismatched
+}
+
+// newACLDrift returns the drift of the ACLs of an existing owner, repaired by repair
+func newACLDrift(ownerType, ownerKey string, owner runtime.Object, stale, missing, mismatched int,
+	repair func() error) []*nbDrift {
+	var drift []*nbDrift
+	for _, kindObjects := range []struct {
+		kind    string
+		objects int
+	}{{nbDriftStale, stale}, {nbDriftMissing, missing}, {nbDriftMismatched, mismatched}} {
+		if kindObjects.objects == 0 {
+			continue
+		}
+		drift = append(drift, &nbDrift{
+			ownerType:  ownerType,
+			objectType: nbObjectTypeACL,
+			kind:       kindObjects.kind,
+			ownerKey:   ownerKey,
+			objects:    kindObjects.objects,
+			owner:      owner,
+			repair:     repair,
+		})
+	}
+	return drift
+}
+
+// auditNetworkPolicyACLs finds the ACLs of network policies that don't exist, and compares the ACLs of the network
+// policies handled by the controller with the ones built from their gress policies
+func (a *nbDriftAuditor) auditNetworkPolicyACLs() ([]*nbDrift, error) {
+	oc := a.oc
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, oc.controllerName, nil)
+	p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil)
+	acls, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find network policy ACLs: %w", err)
+	}
+	policyACLs := map[string][]*nbdb.ACL{}
+	for _, acl := range acls {
+		policyKey := acl.ExternalIDs[libovsdbops.ObjectNameKey.String()]
+		policyACLs[policyKey] = append(policyACLs[policyKey], acl)
+	}
+
+	var drift []*nbDrift
+	for _, policyKey := range sets.List(sets.KeySet(policyACLs)) {
+		namespace, name, err := parseACLPolicyKey(policyKey)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := oc.watchFactory.GetNetworkPolicy(namespace, name); err == nil {
+			continue
+		} else if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get network policy %s/%s: %w", namespace, name, err)
+		}
+		drift = append(drift, &nbDrift{
+			ownerType:  string(libovsdbops.NetworkPolicyOwnerType),
+			objectType: nbObjectTypeACL,
+			kind:       nbDriftStale,
+			ownerKey:   namespace + "/" + name,
+			objects:    len(policyACLs[policyKey]),
+			repair: func() error {
+				return a.deleteStaleNetworkPolicyACLs(namespace, name)
+			},
+		})
+	}
+
+	npKeys := oc.networkPolicies.GetKeys()
+	sort.Strings(npKeys)
+	for _, npKey := range npKeys {
+		np, loaded := oc.networkPolicies.Load(npKey)
+		if !loaded {
+			continue
+		}
+		np.RLock()
+		if np.deleted {
+			np.RUnlock()
+			continue
+		}
+		namespace, name := np.namespace, np.name
+		// the logging levels are not compared
+		expected := oc.buildNetworkPolicyACLs(np, nil)
+		np.RUnlock()
+		policy, err := oc.watchFactory.GetNetworkPolicy(namespace, name)
+		if err != nil {
+			// the network policy is being deleted
+			continue
+		}
+		stale, missing, mismatched := diffACLs(expected, policyACLs[getACLPolicyKey(namespace, name)])
+		drift = append(drift, newACLDrift(string(libovsdbops.NetworkPolicyOwnerType), npKey, policy, stale, missing, mismatched,
+			func() error {
+				return a.syncNetworkPolicyACLs(namespace, name)
+			})...)
+	}
+	return drift, nil
+}
+
+// auditEgressFirewallACLs finds the ACLs of egress firewalls that don't exist, and compares the ACLs of the egress
+// firewalls handled by the controller with the ones built from their rules
+func (a *nbDriftAuditor) auditEgressFirewallACLs() ([]*nbDrift, error) {
+	oc := a.oc
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLEgressFirewall, oc.controllerName, nil)
+	p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil)
+	acls, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find egress firewall ACLs: %w", err)
+	}
+	namespaceACLs := map[string][]*nbdb.ACL{}
+	for _, acl := range acls {
+		namespace := acl.ExternalIDs[libovsdbops.ObjectNameKey.String()]
+		namespaceACLs[namespace] = append(namespaceACLs[namespace], acl)
+	}
+
+	var drift []*nbDrift
+	lister := oc.watchFactory.EgressFirewallInformer().Lister()
+	namespaces := sets.KeySet(namespaceACLs)
+	oc.egressFirewalls.Range(func(key, _ interface{}) bool {
+		namespaces.Insert(key.(string))
+		return true
+	})
+	for _, namespace := range sets.List(namespaces) {
+		efs, err := lister.EgressFirewalls(namespace).List(labels.Everything())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list egress firewalls in namespace %s: %w", namespace, err)
+		}
+		namespace := namespace
+		if len(efs) == 0 {
+			if len(namespaceACLs[namespace]) == 0 {
+				continue
+			}
+			drift = append(drift, &nbDrift{
+				ownerType:  string(libovsdbops.EgressFirewallOwnerType),
+				objectType: nbObjectTypeACL,
+				kind:       nbDriftStale,
+				ownerKey:   namespace,
+				objects:    len(namespaceACLs[namespace]),
+				repair: func() error {
+					return a.deleteStaleEgressFirewallACLs(namespace)
+				},
+			})
+			continue
+		}
+		obj, loaded := oc.egressFirewalls.Load(namespace)
+		if !loaded {
+			// the egress firewall is not handled yet
+			continue
+		}
+		expected, err := a.buildEgressFirewallACLs(obj.(*egressFirewall))
+		if err != nil {
+			klog.V(5).Infof("Not auditing the ACLs of the egress firewall of namespace %s being handled: %v",
+				namespace, err)
+			continue
+		}
+		stale, missing, mismatched := diffACLs(expected, namespaceACLs[namespace])
+		// adding an egress firewall that already exists recreates all its ACLs
+		ef := efs[0]
+		drift = append(drift, newACLDrift(string(libovsdbops.EgressFirewallOwnerType), namespace, ef, stale, missing, mismatched,
+			func() error {
+				return a.retryEgressFirewall(ef)
+			})...)
+	}
+	return drift, nil
+}
+
+// auditAdminNetworkPolicyACLs finds the ACLs of admin network policies and of baseline admin network policies that
+// don't exist. The ACLs of the handled policies are not compared with the expected ones.
+func (a *nbDriftAuditor) auditAdminNetworkPolicyACLs() ([]*nbDrift, error) {
+	oc := a.oc
+	var drift []*nbDrift
+	for _, policyType := range []struct {
+		ownerType string
+		idsType   *libovsdbops.ObjectIDsType
+		isBanp    bool
+		exists    func(name string) error
+	}{
+		{
+			ownerType: string(libovsdbops.AdminNetworkPolicyOwnerType),
+			idsType:   libovsdbops.ACLAdminNetworkPolicy,
+			exists: func(name string) error {
+				_, err := oc.watchFactory.ANPInformer().Lister().Get(name)
+				return err
+			},
+		},
+		{
+			ownerType: string(libovsdbops.BaselineAdminNetworkPolicyOwnerType),
+			idsType:   libovsdbops.ACLBaselineAdminNetworkPolicy,
+			isBanp:    true,
+			exists: func(name string) error {
+				_, err := oc.watchFactory.BANPInformer().Lister().Get(name)
+				return err
+			},
+		},
+	} {
+		predicateIDs := libovsdbops.NewDbObjectIDs(policyType.idsType, oc.controllerName, nil)
+		p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil)
+		acls, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find %s ACLs: %w", policyType.ownerType, err)
+		}
+		policyACLs := map[string]int{}
+		for _, acl := range acls {
+			policyACLs[acl.ExternalIDs[libovsdbops.ObjectNameKey.String()]]++
+		}
+		for _, name := range sets.List(sets.KeySet(policyACLs)) {
+			if err := policyType.exists(name); err == nil {
+				continue
+			} else if !apierrors.IsNotFound(err) {
+				return nil, fmt.Errorf("failed to get %s %s: %w", policyType.ownerType, name, err)
+			}
+			name, isBanp := name, policyType.isBanp
+			drift = append(drift, &nbDrift{
+				ownerType:  policyType.ownerType,
+				objectType: nbObjectTypeACL,
+				kind:       nbDriftStale,
+				ownerKey:   name,
+				objects:    policyACLs[name],
+				repair: func() error {
+					return oc.anpController.DeleteStaleAdminNetworkPolicy(name, isBanp)
+				},
+			})
+		}
+	}
+	return drift, nil
+}
+
+// buildEgressFirewallACLs builds the ACLs of the rules of a handled egress firewall, the logging levels are not set.
+// It fails if the address set of a DNS name of a rule was not added by the egress firewall handlers.
+func (a *nbDriftAuditor) buildEgressFirewallACLs(ef *egressFirewall) ([]*nbdb.ACL, error) {
+	oc := a.oc
+	pgName := oc.getNamespacePortGroupName(ef.namespace)
+	ef.Lock()
+	defer ef.Unlock()
+	var acls []*nbdb.ACL
+	for _, rule := range ef.egressRules {
+		matchTargets, err := rule.getMatchTargets(func(dnsName string) (addressset.AddressSet, error) {
+			return oc.egressFirewallDNS.GetAddressSet(ef.namespace, dnsName)
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(matchTargets) == 0 {
+			continue
+		}
+		acls = append(acls, libovsdbutil.BuildACL(
+			oc.getEgressFirewallACLDbIDs(ef.namespace, rule.id),
+			types.EgressFirewallStartPriority-rule.id,
+			generateMatch(pgName, matchTargets, rule.ports),
+			rule.getACLAction(),
+			nil,
+			libovsdbutil.LportIngress,
+		))
+	}
+	return acls, nil
+}
+
+// deleteStaleNamespaceAddressSet deletes the address set of a namespace, unless the namespace was added since the
+// audit. The namespace handlers hold the key of the namespace in the retry framework, and create the address set
+// holding namespacesMutex.
+func (bnc *BaseNetworkController) deleteStaleNamespaceAddressSet(ns string) error {
+	var err error
+	bnc.retryNamespaces.DoWithLock(ns, func(string) {
+		if _, getErr := bnc.watchFactory.GetNamespace(ns); getErr == nil {
+			klog.Infof("Namespace %s was added since the OVN NB drift audit, not deleting its address set", ns)
+			return
+		} else if !apierrors.IsNotFound(getErr) {
+			err = fmt.Errorf("failed to get namespace %s: %w", ns, getErr)
+			return
+		}
+		bnc.namespacesMutex.Lock()
+		defer bnc.namespacesMutex.Unlock()
+		if bnc.namespaces[ns] != nil {
+			klog.Infof("Namespace %s is still handled, not deleting its address set", ns)
+			return
+		}
+		err = bnc.addressSetFactory.DestroyAddressSet(getNamespaceAddrSetDbIDs(ns, bnc.controllerName))
+	})
+	return err
+}
+
+// deleteStaleNamespacePortGroup deletes the port group of a namespace, unless the namespace was added since the
+// audit. The namespace handlers hold the key of the namespace in the retry framework, and create the port group
+// holding namespacesMutex.
+func (bnc *BaseNetworkController) deleteStaleNamespacePortGroup(ns string) error {
+	var err error
+	bnc.retryNamespaces.DoWithLock(ns, func(string) {
+		if _, getErr := bnc.watchFactory.GetNamespace(ns); getErr == nil {
+			klog.Infof("Namespace %s was added since the OVN NB drift audit, not deleting its port group", ns)
+			return
+		} else if !apierrors.IsNotFound(getErr) {
+			err = fmt.Errorf("failed to get namespace %s: %w", ns, getErr)
+			return
+		}
+		bnc.namespacesMutex.Lock()
+		defer bnc.namespacesMutex.Unlock()
+		if bnc.namespaces[ns] != nil {
+			klog.Infof("Namespace %s is still handled, not deleting its port group", ns)
+			return
+		}
+		err = libovsdbops.DeletePortGroups(bnc.nbClient, bnc.getNamespacePortGroupName(ns))
+	})
+	return err
+}
+
+// deleteStaleNetworkPolicyACLs deletes the port group and the ACLs of a network policy, unless the network policy
+// was added since the audit. The ACLs are looked up again while holding the locks of the network policy handlers.
+func (a *nbDriftAuditor) deleteStaleNetworkPolicyACLs(namespace, name string) error {
+	oc := a.oc
+	policyKey := getACLPolicyKey(namespace, name)
+	var err error
+	oc.retryNetworkPolicies.DoWithLock(namespace+"/"+name, func(string) {
+		if _, getErr := oc.watchFactory.GetNetworkPolicy(namespace, name); getErr == nil {
+			klog.Infof("Network policy %s/%s was added since the OVN NB drift audit, not deleting its ACLs", namespace, name)
+			return
+		} else if !apierrors.IsNotFound(getErr) {
+			err = fmt.Errorf("failed to get network policy %s/%s: %w", namespace, name, getErr)
+			return
+		}
+		err = oc.networkPolicies.DoWithLock(namespace+"/"+name, func(npKey string) error {
+			if _, found := oc.networkPolicies.Load(npKey); found {
+				klog.Infof("Network policy %s is still handled, not deleting its ACLs", npKey)
+				return nil
+			}
+			predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, oc.controllerName,
+				map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: policyKey})
+			p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil)
+			staleACLs, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+			if err != nil {
+				return fmt.Errorf("failed to find ACLs of network policy %s: %w", npKey, err)
+			}
+			pgName, _ := oc.getNetworkPolicyPGName(namespace, name)
+			if err := libovsdbops.DeletePortGroups(oc.nbClient, pgName); err != nil {
+				return err
+			}
+			// the ACLs may also be referenced by other port groups
+			return libovsdbops.DeleteACLsFromAllPortGroups(oc.nbClient, staleACLs...)
+		})
+	})
+	return err
+}
+
+// syncNetworkPolicyACLs recreates the ACLs of a handled network policy from its gress policies, and deletes its
+// other ACLs. Like peerNamespaceUpdate, it holds the namespace lock and the read lock of the network policy.
+func (a *nbDriftAuditor) syncNetworkPolicyACLs(namespace, name string) error {
+	oc := a.oc
+	nsInfo, nsUnlock := oc.getNamespaceLocked(namespace, true)
+	aclLogging := &libovsdbutil.ACLLoggingLevels{}
+	if nsInfo != nil {
+		defer nsUnlock()
+		aclLogging = &nsInfo.aclLogging
+	}
+	npKey := namespace + "/" + name
+	np, loaded := oc.networkPolicies.Load(npKey)
+	if !loaded {
+		klog.Infof("Network policy %s is not handled anymore, not syncing its ACLs", npKey)
+		return nil
+	}
+	np.RLock()
+	defer np.RUnlock()
+	if np.deleted {
+		return nil
+	}
+	acls := oc.buildNetworkPolicyACLs(np, aclLogging)
+	expectedIDs := sets.New[string]()
+	for _, acl := range acls {
+		expectedIDs.Insert(acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()])
+	}
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, oc.controllerName,
+		map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: getACLPolicyKey(namespace, name)})
+	p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, func(acl *nbdb.ACL) bool {
+		return !expectedIDs.Has(acl.ExternalIDs[libovsdbops.PrimaryIDKey.String()])
+	})
+	staleACLs, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return fmt.Errorf("failed to find ACLs of network policy %s: %w", npKey, err)
+	}
+	ops, err := libovsdbops.CreateOrUpdateACLsOps(oc.nbClient, nil, acls...)
+	if err != nil {
+		return err
+	}
+	ops, err = libovsdbops.AddACLsToPortGroupOps(oc.nbClient, ops, np.portGroupName, acls...)
+	if err != nil {
+		return err
+	}
+	ops, err = libovsdbops.DeleteACLsFromPortGroupOps(oc.nbClient, ops, np.portGroupName, staleACLs...)
+	if err != nil {
+		return err
+	}
+	_, err = libovsdbops.TransactAndCheck(oc.nbClient, ops)
+	return err
+}
+
+// deleteStaleEgressFirewallACLs deletes the ACLs of the egress firewall of a namespace, unless an egress firewall
+// was added to the namespace or is still being deleted since the audit. The egress firewall handlers only run once
+// the egress firewall is in the informer cache, and the ACLs are looked up again right before deleting them.
+func (a *nbDriftAuditor) deleteStaleEgressFirewallACLs(namespace string) error {
+	oc := a.oc
+	efs, err := oc.watchFactory.EgressFirewallInformer().Lister().EgressFirewalls(namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list egress firewalls in namespace %s: %w", namespace, err)
+	}
+	if _, loaded := oc.egressFirewalls.Load(namespace); len(efs) > 0 || loaded {
+		klog.Infof("Egress firewall of namespace %s was handled since the OVN NB drift audit, not deleting its ACLs",
+			namespace)
+		return nil
+	}
+	predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLEgressFirewall, oc.controllerName,
+		map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: namespace})
+	p := libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil)
+	staleACLs, err := libovsdbops.FindACLsWithPredicate(oc.nbClient, p)
+	if err != nil {
+		return fmt.Errorf("failed to find ACLs of egress firewall of namespace %s: %w", namespace, err)
+	}
+	return libovsdbops.DeleteACLsFromAllPortGroups(oc.nbClient, staleACLs...)
+}
+
+// retryEgressFirewall hands an existing egress firewall back to its retry framework, adding an egress firewall that
+// already exists recreates all its ACLs
+func (a *nbDriftAuditor) retryEgressFirewall(ef *egressfirewallapi.EgressFirewall) error {
+	if err := a.oc.retryEgressFirewalls.AddRetryObjWithAddNoBackoff(ef); err != nil {
+		return err
+	}
+	a.oc.retryEgressFirewalls.RequestRetryObjs()
+	return nil
+}
+
+// recreateNamespacePortGroup creates the port group of a handled namespace with the ports of its local pods and
+// the multicast ACLs of the namespace, and hands the egress firewall of the namespace back to its retry framework
+// to add its ACLs back to the port group
+func (a *nbDriftAuditor) recreateNamespacePortGroup(ns string) error {
+	oc := a.oc
+	nsInfo, nsUnlock := oc.getNamespaceLocked(ns, false)
+	if nsInfo == nil {
+		return fmt.Errorf("namespace %s doesn't exist", ns)
+	}
+	defer nsUnlock()
+	if nsInfo.portGroupName == "" {
+		return nil
+	}
+	pods, err := oc.watchFactory.GetPods(ns)
+	if err != nil {
+		return fmt.Errorf("failed to list pods of namespace %s: %w", ns, err)
+	}
+	var ports []*nbdb.LogicalSwitchPort
+	for _, pod := range pods {
+		if !oc.isPodScheduledinLocalZone(pod) {
+			continue
+		}
+		portInfo, err := oc.logicalPortCache.get(pod, types.DefaultNetworkName)
+		if err != nil {
+			// the pod handlers add the port of the pod once it is created
+			continue
+		}
+		ports = append(ports, &nbdb.LogicalSwitchPort{UUID: portInfo.uuid})
+	}
+	pg := oc.buildPortGroup(nsInfo.portGroupName, ns, ports, nil)
+	if err := libovsdbops.CreateOrUpdatePortGroups(oc.nbClient, pg); err != nil {
+		return fmt.Errorf("failed to create port group for namespace %s: %w", ns, err)
+	}
+	if oc.multicastSupport && nsInfo.multicastEnabled {
+		if err := oc.createMulticastAllowPolicy(ns, nsInfo); err != nil {
+			return fmt.Errorf("failed to add multicast ACLs of namespace %s: %w", ns, err)
+		}
+	}
+	if !config.OVNKubernetesFeature.EnableEgressFirewall {
+		return nil
+	}
+	efs, err := oc.watchFactory.EgressFirewallInformer().Lister().EgressFirewalls(ns).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf("failed to list egress firewalls in namespace %s: %w", ns, err)
+	}
+	if len(efs) == 0 {
+		return nil
+	}
+	return a.retryEgressFirewall(efs[0])
+}
+
+// isNamespaceHandled returns whether the namespace was added by the controller
+func (bnc *BaseNetworkController) isNamespaceHandled(ns string) bool {
+	bnc.namespacesMutex.Lock()
+	defer bnc.namespacesMutex.Unlock()
+	return bnc.namespaces[ns] != nil
+}
+
+// recreateNamespaceAddressSet creates the address set of a handled namespace with the IPs of its pods
+func (bnc *BaseNetworkController) recreateNamespaceAddressSet(ns string) error {
+	nsInfo, nsUnlock := bnc.getNamespaceLocked(ns, false)
+	if nsInfo == nil {
+		return fmt.Errorf("namespace %s doesn't exist", ns)
+	}
+	defer nsUnlock()
+	addressSet, err := bnc.createNamespaceAddrSetAllPods(ns, bnc.getAllNamespacePodAddresses(ns))
+	if err != nil {
+		return fmt.Errorf("failed to create address set for namespace: %s, error: %v", ns, err)
+	}
+	nsInfo.addressSet = addressSet
+	return nil
+}
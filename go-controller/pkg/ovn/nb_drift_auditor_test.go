@@ -0,0 +1,273 @@
+package ovn
+
+import (
+	"context"
+	"net"
+
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	libovsdbops "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/ops"
+	libovsdbutil "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/libovsdb/util"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/nbdb"
+	addressset "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/ovn/address_set"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	ovntypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	v1 "k8s.io/api/core/v1"
+	knet "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = ginkgo.Describe("OVN NB drift auditor", func() {
+	const (
+		namespaceName      = "namespace1"
+		staleNamespaceName = "namespace2"
+		stalePolicyName    = "deleted-policy"
+	)
+	var (
+		fakeOvn *FakeOVN
+
+		nsAddrSet, staleNSAddrSet *nbdb.AddressSet
+		staleACL                  *nbdb.ACL
+		stalePG                   *nbdb.PortGroup
+	)
+
+	ginkgo.BeforeEach(func() {
+		// Restore global default values before each testcase
+		err := config.PrepareTestConfig()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		config.IPv4Mode = true
+		fakeOvn = NewFakeOVN(false)
+
+		nsAddrSet, _ = addressset.GetTestDbAddrSets(getNamespaceAddrSetDbIDs(namespaceName, DefaultNetworkControllerName),
+			[]net.IP{})
+		staleNSAddrSet, _ = addressset.GetTestDbAddrSets(getNamespaceAddrSetDbIDs(staleNamespaceName, DefaultNetworkControllerName),
+			[]net.IP{net.ParseIP("10.128.1.3")})
+		aclIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, DefaultNetworkControllerName,
+			map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:         getACLPolicyKey(namespaceName, stalePolicyName),
+				libovsdbops.PolicyDirectionKey:    string(libovsdbutil.ACLIngress),
+				libovsdbops.GressIdxKey:           "0",
+				libovsdbops.PortPolicyProtocolKey: "None",
+				libovsdbops.IpBlockIndexKey:       "-1",
+			})
+		staleACL = libovsdbutil.BuildACL(aclIDs, ovntypes.DefaultAllowPriority, "ip4", nbdb.ACLActionAllowRelated, nil,
+			libovsdbutil.LportIngress)
+		staleACL.UUID = "stale-acl-UUID"
+		stalePG = libovsdbops.BuildPortGroup(libovsdbutil.HashedPortGroup(namespaceName+"_"+stalePolicyName), nil,
+			[]*nbdb.ACL{staleACL}, nil)
+		stalePG.UUID = "stale-pg-UUID"
+	})
+
+	ginkgo.AfterEach(func() {
+		fakeOvn.shutdown()
+	})
+
+	// startController starts the controller, then creates the stale objects that would otherwise be cleaned up by
+	// the startup sync
+	startController := func() {
+		fakeOvn.startWithDBSetup(
+			libovsdbtest.TestSetup{NBData: []libovsdbtest.TestData{nsAddrSet}},
+			&v1.NamespaceList{Items: []v1.Namespace{*newNamespace(namespaceName)}},
+		)
+		err := fakeOvn.controller.WatchNamespaces()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		_, err = fakeOvn.controller.addressSetFactory.NewAddressSet(
+			getNamespaceAddrSetDbIDs(staleNamespaceName, DefaultNetworkControllerName), []net.IP{net.ParseIP("10.128.1.3")})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ops, err := libovsdbops.CreateOrUpdateACLsOps(fakeOvn.nbClient, nil, staleACL)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ops, err = libovsdbops.CreateOrUpdatePortGroupsOps(fakeOvn.nbClient, ops, stalePG)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(fakeOvn.nbClient, ops)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(
+			[]libovsdbtest.TestData{nsAddrSet, staleNSAddrSet, staleACL, stalePG}))
+	}
+
+	ginkgo.It("only reports the drift in dry-run mode", func() {
+		startController()
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeDryRun)
+		for i := 0; i < 3; i++ {
+			gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		}
+		gomega.Expect(auditor.suspects.UnsortedList()).To(gomega.ConsistOf(
+			"Namespace/AddressSet/stale/"+staleNamespaceName,
+			"NetworkPolicy/ACL/stale/"+namespaceName+"/"+stalePolicyName,
+		))
+		gomega.Consistently(fakeOvn.nbClient).Should(libovsdbtest.HaveData(
+			[]libovsdbtest.TestData{nsAddrSet, staleNSAddrSet, staleACL, stalePG}))
+	})
+
+	ginkgo.It("repairs stale objects once found by two consecutive audits", func() {
+		startController()
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeRepair)
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(fakeOvn.nbClient).To(libovsdbtest.HaveData(
+			[]libovsdbtest.TestData{nsAddrSet, staleNSAddrSet, staleACL, stalePG}))
+
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{nsAddrSet}))
+		gomega.Expect(auditor.suspects).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("does not repair the drift of owners handled since the audit", func() {
+		startController()
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeRepair)
+		nsDrift, err := auditor.auditNamespaceAddressSets()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(nsDrift).To(gomega.HaveLen(1))
+		policyDrift, err := auditor.auditNetworkPolicyACLs()
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(policyDrift).To(gomega.HaveLen(1))
+
+		_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Create(context.TODO(),
+			newNamespace(staleNamespaceName), metav1.CreateOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(func() bool { return fakeOvn.controller.isNamespaceHandled(staleNamespaceName) }).Should(
+			gomega.BeTrue())
+		_, err = fakeOvn.fakeClient.KubeClient.NetworkingV1().NetworkPolicies(namespaceName).Create(context.TODO(),
+			newNetworkPolicy(stalePolicyName, namespaceName, metav1.LabelSelector{}, nil, nil), metav1.CreateOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Eventually(func() error {
+			_, err := fakeOvn.controller.watchFactory.GetNetworkPolicy(namespaceName, stalePolicyName)
+			return err
+		}).Should(gomega.Succeed())
+
+		gomega.Expect(nsDrift[0].repair()).To(gomega.Succeed())
+		gomega.Expect(policyDrift[0].repair()).To(gomega.Succeed())
+		// the address set of the added namespace holds the IPs of its pods
+		addedNSAddrSet, _ := addressset.GetTestDbAddrSets(
+			getNamespaceAddrSetDbIDs(staleNamespaceName, DefaultNetworkControllerName), []net.IP{})
+		gomega.Consistently(fakeOvn.nbClient).Should(libovsdbtest.HaveData(
+			[]libovsdbtest.TestData{nsAddrSet, addedNSAddrSet, staleACL, stalePG}))
+	})
+
+	ginkgo.It("recreates the missing address set of a namespace", func() {
+		startController()
+		err := libovsdbops.DeleteAddressSets(fakeOvn.nbClient, nsAddrSet)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeRepair)
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(auditor.suspects.Has("Namespace/AddressSet/missing/" + namespaceName)).To(gomega.BeTrue())
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(
+			[]libovsdbtest.TestData{nsAddrSet}))
+	})
+
+	ginkgo.It("recreates the mismatched and missing ACLs of a network policy", func() {
+		const policyName = "allow-all"
+		policy := newNetworkPolicy(policyName, namespaceName, metav1.LabelSelector{},
+			[]knet.NetworkPolicyIngressRule{{}, {}}, nil)
+		fakeOvn.startWithDBSetup(
+			libovsdbtest.TestSetup{NBData: []libovsdbtest.TestData{nsAddrSet, newClusterPortGroup()}},
+			&v1.NamespaceList{Items: []v1.Namespace{*newNamespace(namespaceName)}},
+			&knet.NetworkPolicyList{Items: []knet.NetworkPolicy{*policy}},
+		)
+		gomega.Expect(fakeOvn.controller.WatchNamespaces()).To(gomega.Succeed())
+		gomega.Expect(fakeOvn.controller.WatchNetworkPolicy()).To(gomega.Succeed())
+
+		predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLNetworkPolicy, DefaultNetworkControllerName,
+			map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: getACLPolicyKey(namespaceName, policyName)})
+		findPolicyACLs := func() []*nbdb.ACL {
+			acls, err := libovsdbops.FindACLsWithPredicate(fakeOvn.nbClient,
+				libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil))
+			gomega.Expect(err).NotTo(gomega.HaveOccurred())
+			return acls
+		}
+		acls := findPolicyACLs()
+		gomega.Expect(acls).To(gomega.HaveLen(2))
+		expectedMatches := []string{acls[0].Match, acls[1].Match}
+
+		mismatchedACL := acls[0].DeepCopy()
+		mismatchedACL.Match = "ip4.src == 10.128.1.3 && " + mismatchedACL.Match
+		gomega.Expect(libovsdbops.CreateOrUpdateACLs(fakeOvn.nbClient, mismatchedACL)).To(gomega.Succeed())
+		pgName, _ := fakeOvn.controller.getNetworkPolicyPGName(namespaceName, policyName)
+		gomega.Expect(libovsdbops.DeleteACLsFromPortGroups(fakeOvn.nbClient, []string{pgName}, acls[1])).To(
+			gomega.Succeed())
+
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeRepair)
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(auditor.suspects.UnsortedList()).To(gomega.ConsistOf(
+			"NetworkPolicy/ACL/mismatched/"+namespaceName+"/"+policyName,
+			"NetworkPolicy/ACL/missing/"+namespaceName+"/"+policyName,
+		))
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Eventually(func() []string {
+			matches := []string{}
+			for _, acl := range findPolicyACLs() {
+				matches = append(matches, acl.Match)
+			}
+			return matches
+		}).Should(gomega.ConsistOf(expectedMatches))
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(auditor.suspects).To(gomega.BeEmpty())
+	})
+	ginkgo.It("repairs the stale and missing port groups of namespaces", func() {
+		config.EnableMulticast = true
+		fakeOvn.startWithDBSetup(
+			libovsdbtest.TestSetup{NBData: []libovsdbtest.TestData{nsAddrSet}},
+			&v1.NamespaceList{Items: []v1.Namespace{*newNamespace(namespaceName)}},
+		)
+		gomega.Expect(fakeOvn.controller.WatchNamespaces()).To(gomega.Succeed())
+		nsPG := fakeOvn.controller.buildPortGroup(fakeOvn.controller.getNamespacePortGroupName(namespaceName),
+			namespaceName, nil, nil)
+		nsPG.UUID = "ns-pg-UUID"
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{nsAddrSet, nsPG}))
+
+		gomega.Expect(libovsdbops.DeletePortGroups(fakeOvn.nbClient, nsPG.Name)).To(gomega.Succeed())
+		staleNSPG := fakeOvn.controller.buildPortGroup(fakeOvn.controller.getNamespacePortGroupName(staleNamespaceName),
+			staleNamespaceName, nil, nil)
+		gomega.Expect(libovsdbops.CreateOrUpdatePortGroups(fakeOvn.nbClient, staleNSPG)).To(gomega.Succeed())
+
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeRepair)
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(auditor.suspects.UnsortedList()).To(gomega.ConsistOf(
+			"Namespace/PortGroup/stale/"+staleNamespaceName,
+			"Namespace/PortGroup/missing/"+namespaceName,
+		))
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveDataIgnoringUUIDs(
+			[]libovsdbtest.TestData{nsAddrSet, nsPG}))
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(auditor.suspects).To(gomega.BeEmpty())
+	})
+
+	ginkgo.It("repairs the stale ACLs of admin network policies", func() {
+		const staleANPName = "deleted-anp"
+		config.OVNKubernetesFeature.EnableAdminNetworkPolicy = true
+		fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{})
+		gomega.Expect(fakeOvn.controller.newANPController()).To(gomega.Succeed())
+
+		aclIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLAdminNetworkPolicy, DefaultNetworkControllerName,
+			map[libovsdbops.ExternalIDKey]string{
+				libovsdbops.ObjectNameKey:         staleANPName,
+				libovsdbops.PolicyDirectionKey:    string(libovsdbutil.ACLIngress),
+				libovsdbops.GressIdxKey:           "0",
+				libovsdbops.PortPolicyProtocolKey: "None",
+			})
+		staleANPACL := libovsdbutil.BuildACL(aclIDs, 30000, "ip4", nbdb.ACLActionAllowRelated, nil,
+			libovsdbutil.LportIngress)
+		staleANPACL.UUID = "stale-anp-acl-UUID"
+		staleANPPG := libovsdbops.BuildPortGroup(util.HashForOVN("ANP:"+staleANPName), nil, []*nbdb.ACL{staleANPACL},
+			map[string]string{"AdminNetworkPolicy": staleANPName, "name": "ANP:" + staleANPName})
+		ops, err := libovsdbops.CreateOrUpdateACLsOps(fakeOvn.nbClient, nil, staleANPACL)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		ops, err = libovsdbops.CreateOrUpdatePortGroupsOps(fakeOvn.nbClient, ops, staleANPPG)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		_, err = libovsdbops.TransactAndCheck(fakeOvn.nbClient, ops)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		auditor := newNBDriftAuditor(fakeOvn.controller, config.NBDriftAuditModeRepair)
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Expect(auditor.suspects.UnsortedList()).To(gomega.ConsistOf(
+			"AdminNetworkPolicy/ACL/stale/" + staleANPName))
+		gomega.Expect(auditor.audit()).To(gomega.Succeed())
+		gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData([]libovsdbtest.TestData{}))
+	})
+})
@@ -12,6 +12,7 @@ import (
 	"github.com/onsi/ginkgo/extensions/table"
 	"github.com/onsi/gomega"
 	"github.com/onsi/gomega/format"
+	gomegatypes "github.com/onsi/gomega/types"
 	"github.com/urfave/cli/v2"
 
 	libovsdbclient "github.com/ovn-org/libovsdb/client"
@@ -1860,6 +1861,171 @@ var _ = ginkgo.Describe("OVN NetworkPolicy Operations", func() {
 		})
 	})
 
+	ginkgo.Context("Flow sampling for network policies", func() {
+		// withFlowSamplingLabels sets the labels the ACLs are expected to have when flow sampling is enabled, the
+		// ARP allow ACLs are not sampled
+		withFlowSamplingLabels := func(data []libovsdbtest.TestData) []libovsdbtest.TestData {
+			for _, obj := range data {
+				if acl, ok := obj.(*nbdb.ACL); ok && acl.ExternalIDs[libovsdbops.TypeKey.String()] != string(arpAllowACL) {
+					acl.Label = libovsdbutil.GetFlowSamplingObservationID(acl)
+				}
+			}
+			return data
+		}
+
+		ginkgo.It("labels the ACLs of sampled namespaces and maps their observation IDs", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespace1 := *newNamespace(namespaceName1)
+				namespace1.Annotations = map[string]string{util.FlowSamplingAnnotation: `{"probability": 100}`}
+				networkPolicy := getPortNetworkPolicy(netPolicyName1, namespace1.Name, labelName, labelVal, portNum)
+				startOvn(initialDB, []v1.Namespace{namespace1}, []knet.NetworkPolicy{*networkPolicy}, nil, nil)
+
+				policyData := withFlowSamplingLabels(getPolicyData(newNetpolDataParams(networkPolicy).
+					withTCPPeerPorts(portNum)))
+				defaultDenyData := withFlowSamplingLabels(getDefaultDenyData(newNetpolDataParams(networkPolicy)))
+				expectedData := append(append(append([]libovsdbtest.TestData{}, initialDB.NBData...), policyData...),
+					defaultDenyData...)
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData...))
+
+				observations, err := fakeOvn.controller.getFlowSamplingObservations()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(observations).To(gomega.ContainElement(gomega.And(
+					gomega.HaveField("OwnerType", string(libovsdbops.NetworkPolicyOwnerType)),
+					gomega.HaveField("Namespace", namespaceName1),
+					gomega.HaveField("Name", netPolicyName1),
+					gomega.HaveField("Probability", 100),
+				)))
+				// the NB schema of the tests has no sample tables, the ACLs are only labeled
+				gomega.Expect(flowSamplingSupported(fakeOvn.nbClient)).To(gomega.BeFalse())
+
+				ginkgo.By("disabling flow sampling for the namespace")
+				namespace1.Annotations = map[string]string{}
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), &namespace1,
+					metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				expectedData = append(append(append([]libovsdbtest.TestData{}, initialDB.NBData...),
+					getPolicyData(newNetpolDataParams(networkPolicy).withTCPPeerPorts(portNum))...),
+					getDefaultDenyData(newNetpolDataParams(networkPolicy))...)
+				gomega.Eventually(fakeOvn.nbClient).Should(libovsdbtest.HaveData(expectedData...))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+
+		ginkgo.It("samples the logical ports of the selected pods of sampled namespaces", func() {
+			app.Action = func(ctx *cli.Context) error {
+				namespace1 := *newNamespace(namespaceName1)
+				namespace1.Annotations = map[string]string{util.FlowSamplingAnnotation: fmt.Sprintf(
+					`{"probability": 100, "podSelector": {"matchLabels": {"%s": "%s"}}}`, labelName, labelVal)}
+				sampledPod := getTestPod(namespace1.Name, nodeName)
+				otherPod := newTPod(nodeName, "10.128.1.0/24", "10.128.1.2", "10.128.1.1", "myPod2",
+					"10.128.1.4", "0a:58:0a:80:01:04", namespace1.Name)
+				startOvn(initialDB, []v1.Namespace{namespace1}, nil, []testPod{sampledPod, otherPod}, nil)
+
+				ginkgo.By("selecting a pod")
+				pod, err := fakeOvn.fakeClient.KubeClient.CoreV1().Pods(namespace1.Name).Get(context.TODO(),
+					sampledPod.podName, metav1.GetOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				pod.Labels = map[string]string{labelName: labelVal}
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Pods(namespace1.Name).Update(context.TODO(), pod,
+					metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+				findFlowSamplingACLs := func() []*nbdb.ACL {
+					gomega.Expect(fakeOvn.controller.syncFlowSamplingPorts()).To(gomega.Succeed())
+					predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLFlowSampling, DefaultNetworkControllerName, nil)
+					acls, err := libovsdbops.FindACLsWithPredicate(fakeOvn.nbClient,
+						libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil))
+					gomega.Expect(err).NotTo(gomega.HaveOccurred())
+					return acls
+				}
+				flowSamplingACL := func(match string) gomegatypes.GomegaMatcher {
+					return gomega.And(
+						gomega.HaveField("Match", match),
+						gomega.HaveField("Action", nbdb.ACLActionAllow),
+						gomega.HaveField("Priority", types.FlowSamplingACLPriority),
+						gomega.HaveField("Tier", types.DefaultBANPACLTier),
+						gomega.WithTransform(func(acl *nbdb.ACL) bool {
+							return acl.Label != 0 && acl.Label == libovsdbutil.GetFlowSamplingObservationID(acl)
+						}, gomega.BeTrue()),
+					)
+				}
+				gomega.Eventually(findFlowSamplingACLs).Should(gomega.ConsistOf(
+					flowSamplingACL(fmt.Sprintf("inport == %q", sampledPod.portName)),
+					flowSamplingACL(fmt.Sprintf("outport == %q", sampledPod.portName)),
+				))
+				pg, err := libovsdbops.GetPortGroup(fakeOvn.nbClient,
+					&nbdb.PortGroup{Name: fakeOvn.controller.getFlowSamplingPortGroupName(namespace1.Name)})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(pg.Ports).To(gomega.HaveLen(1))
+				gomega.Expect(pg.ACLs).To(gomega.HaveLen(2))
+
+				observations, err := fakeOvn.controller.getFlowSamplingObservations()
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Expect(observations).To(gomega.ContainElement(gomega.And(
+					gomega.HaveField("OwnerType", string(libovsdbops.FlowSamplingOwnerType)),
+					gomega.HaveField("Namespace", namespaceName1),
+					gomega.HaveField("Name", sampledPod.podName),
+					gomega.HaveField("Direction", string(libovsdbutil.ACLEgress)),
+					gomega.HaveField("Probability", 100),
+				)))
+
+				ginkgo.By("disabling flow sampling for the namespace")
+				namespace1.Annotations = map[string]string{}
+				_, err = fakeOvn.fakeClient.KubeClient.CoreV1().Namespaces().Update(context.TODO(), &namespace1,
+					metav1.UpdateOptions{})
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				gomega.Eventually(findFlowSamplingACLs).Should(gomega.BeEmpty())
+				_, err = libovsdbops.GetPortGroup(fakeOvn.nbClient,
+					&nbdb.PortGroup{Name: fakeOvn.controller.getFlowSamplingPortGroupName(namespace1.Name)})
+				gomega.Expect(err).To(gomega.MatchError(libovsdbclient.ErrNotFound))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+
+		ginkgo.It("relabels the ACLs sharing an observation ID", func() {
+			app.Action = func(ctx *cli.Context) error {
+				const collisionNamespace = "flow-sampling-collision"
+				var acls []*nbdb.ACL
+				for i := 0; i < 2; i++ {
+					dbIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLEgressFirewall, DefaultNetworkControllerName,
+						map[libovsdbops.ExternalIDKey]string{
+							libovsdbops.ObjectNameKey: collisionNamespace,
+							libovsdbops.RuleIndex:     fmt.Sprintf("%d", i),
+						})
+					acl := libovsdbops.BuildACL("", nbdb.ACLDirectionToLport, types.EgressFirewallStartPriority-i,
+						"ip4.dst == 1.1.1.1", nbdb.ACLActionAllow, "", "", false, dbIDs.GetExternalIDs(), nil,
+						types.DefaultACLTier)
+					acl.UUID = fmt.Sprintf("collision-acl%d-UUID", i)
+					// the same label, e.g. the hash collision of an older version
+					acl.Label = 4242
+					acls = append(acls, acl)
+				}
+				pg := libovsdbops.BuildPortGroup("collision_pg", nil, acls, nil)
+				pg.UUID = "collision_pg-UUID"
+				initialDB.NBData = append(initialDB.NBData, acls[0], acls[1], pg)
+				startOvn(initialDB, nil, nil, nil, nil)
+
+				gomega.Expect(fakeOvn.controller.reserveFlowSamplingObservationIDs()).To(gomega.Succeed())
+				gomega.Expect(fakeOvn.controller.syncFlowSampling(false)).To(gomega.Succeed())
+				predicateIDs := libovsdbops.NewDbObjectIDs(libovsdbops.ACLEgressFirewall, DefaultNetworkControllerName,
+					map[libovsdbops.ExternalIDKey]string{libovsdbops.ObjectNameKey: collisionNamespace})
+				acls, err := libovsdbops.FindACLsWithPredicate(fakeOvn.nbClient,
+					libovsdbops.GetPredicate[*nbdb.ACL](predicateIDs, nil))
+				gomega.Expect(err).NotTo(gomega.HaveOccurred())
+				var labels []int
+				for _, acl := range acls {
+					gomega.Expect(acl.Label).To(gomega.Equal(libovsdbutil.GetFlowSamplingObservationID(acl)))
+					labels = append(labels, acl.Label)
+				}
+				gomega.Expect(labels).To(gomega.ConsistOf(4242, 4243))
+				return nil
+			}
+			gomega.Expect(app.Run([]string{app.Name})).To(gomega.Succeed())
+		})
+	})
+
 	ginkgo.Context("ACL logging for network policies", func() {
 
 		var originalNamespace v1.Namespace
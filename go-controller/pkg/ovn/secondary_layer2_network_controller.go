@@ -109,6 +109,13 @@ func (oc *SecondaryLayer2NetworkController) Init() error {
 	return err
 }
 
+// Reconfigure applies the changes of the network configuration that can be
+// applied live, called from net-attach-def routine
+func (oc *SecondaryLayer2NetworkController) Reconfigure(netInfo util.BasicNetInfo) error {
+	klog.Infof("Reconfigure controller for secondary network %s", oc.GetNetworkName())
+	return oc.BaseSecondaryLayer2NetworkController.reconfigure(oc.GetNetworkScopedName(types.OVNLayer2Switch), netInfo)
+}
+
 func (oc *SecondaryLayer2NetworkController) Stop() {
 	klog.Infof("Stoping controller for secondary network %s", oc.GetNetworkName())
 	oc.BaseSecondaryLayer2NetworkController.stop()
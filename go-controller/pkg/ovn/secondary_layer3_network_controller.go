@@ -25,6 +25,7 @@ import (
 	kapi "k8s.io/api/core/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog/v2"
 )
 
@@ -117,6 +118,15 @@ func (h *secondaryLayer3NetworkControllerEventHandler) AddResource(obj interface
 				return err
 			}
 		}
+	case factory.PodType:
+		pod, ok := obj.(*kapi.Pod)
+		if !ok {
+			return fmt.Errorf("could not cast %T object to *kapi.Pod", obj)
+		}
+		if err := h.oc.AddSecondaryNetworkResourceCommon(h.objType, obj); err != nil {
+			return err
+		}
+		return h.oc.reconcileEgressIPPod(nil, pod)
 	default:
 		return h.oc.AddSecondaryNetworkResourceCommon(h.objType, obj)
 	}
@@ -171,6 +181,16 @@ func (h *secondaryLayer3NetworkControllerEventHandler) UpdateResource(oldObj, ne
 			}
 			return h.oc.addUpdateRemoteNodeEvent(newNode, syncZoneIC)
 		}
+	case factory.PodType:
+		if err := h.oc.UpdateSecondaryNetworkResourceCommon(h.objType, oldObj, newObj, inRetryCache); err != nil {
+			return err
+		}
+		return h.oc.reconcileEgressIPPod(oldObj.(*kapi.Pod), newObj.(*kapi.Pod))
+	case factory.NamespaceType:
+		if err := h.oc.UpdateSecondaryNetworkResourceCommon(h.objType, oldObj, newObj, inRetryCache); err != nil {
+			return err
+		}
+		return h.oc.reconcileEgressIPNamespace(oldObj.(*kapi.Namespace), newObj.(*kapi.Namespace))
 	default:
 		return h.oc.UpdateSecondaryNetworkResourceCommon(h.objType, oldObj, newObj, inRetryCache)
 	}
@@ -188,6 +208,11 @@ func (h *secondaryLayer3NetworkControllerEventHandler) DeleteResource(obj, cache
 		}
 		return h.oc.deleteNodeEvent(node)
 
+	case factory.PodType:
+		if err := h.oc.DeleteSecondaryNetworkResourceCommon(h.objType, obj, cachedObj); err != nil {
+			return err
+		}
+		return h.oc.reconcileEgressIPPod(obj.(*kapi.Pod), nil)
 	default:
 		return h.oc.DeleteSecondaryNetworkResourceCommon(h.objType, obj, cachedObj)
 	}
@@ -238,6 +263,10 @@ type SecondaryLayer3NetworkController struct {
 	addNodeFailed               sync.Map
 	nodeClusterRouterPortFailed sync.Map
 	syncZoneICFailed            sync.Map
+
+	// egressIPQueue holds the names of the egress IPs referencing the network to sync
+	egressIPQueue   workqueue.RateLimitingInterface
+	egressIPHandler *factory.Handler
 }
 
 // NewSecondaryLayer3NetworkController create a new OVN controller for the given secondary layer3 NAD
@@ -340,6 +369,73 @@ func (oc *SecondaryLayer3NetworkController) Start(ctx context.Context) error {
 	return oc.Run()
 }
 
+// Reconfigure applies the changes of the network configuration that can be
+// applied live, called from net-attach-def routine. The node subnets of the
+// subnets added to the network are allocated by cluster manager, and the local
+// pods get routes to the added subnets; ovnkube-node applies the MTU and the
+// routes to the interfaces of the running pods.
+func (oc *SecondaryLayer3NetworkController) Reconfigure(netInfo util.BasicNetInfo) error {
+	klog.Infof("Reconfigure secondary %s network controller of network %s", oc.TopologyType(), oc.GetNetworkName())
+	subnetsAdded := len(netInfo.Subnets()) != len(oc.Subnets())
+	if err := util.UpdateNetInfo(oc.NetInfo, netInfo); err != nil {
+		return err
+	}
+	if !subnetsAdded {
+		return nil
+	}
+	return oc.updatePodRoutes()
+}
+
+// updatePodRoutes updates the routes in the annotations of the local pods of
+// the network to route all the subnets of the network through the node
+// switch, so that the interfaces created again for the pods get the routes to
+// the subnets added to the network.
+func (oc *SecondaryLayer3NetworkController) updatePodRoutes() error {
+	pods, err := oc.watchFactory.GetAllPods()
+	if err != nil {
+		return fmt.Errorf("failed to list the pods of network %s: %w", oc.GetNetworkName(), err)
+	}
+	var errs []error
+	for _, pod := range pods {
+		if !util.PodScheduled(pod) || util.PodWantsHostNetwork(pod) || !oc.isPodScheduledinLocalZone(pod) {
+			continue
+		}
+		on, networkMap, err := util.GetPodNADToNetworkMapping(pod, oc.NetInfo)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !on {
+			continue
+		}
+		for nadName, network := range networkMap {
+			podAnnotation, err := util.UnmarshalPodAnnotation(pod.Annotations, nadName)
+			if err != nil {
+				// the routes are set when the pod annotation is allocated
+				continue
+			}
+			updatedAnnotation := *podAnnotation
+			updatedAnnotation.Routes = nil
+			updatedAnnotation.Gateways = nil
+			if err := util.AddRoutesGatewayIP(oc.NetInfo, pod, &updatedAnnotation, network); err != nil {
+				errs = append(errs, fmt.Errorf("failed to compute the routes of pod %s/%s for NAD %s: %w",
+					pod.Namespace, pod.Name, nadName, err))
+				continue
+			}
+			if reflect.DeepEqual(updatedAnnotation.Routes, podAnnotation.Routes) {
+				continue
+			}
+			klog.Infof("Updating the routes of pod %s/%s for NAD %s to the subnets of network %s",
+				pod.Namespace, pod.Name, nadName, oc.GetNetworkName())
+			if err := oc.updatePodAnnotationWithRetry(pod, &updatedAnnotation, nadName); err != nil {
+				errs = append(errs, fmt.Errorf("failed to update the routes of pod %s/%s for NAD %s: %w",
+					pod.Namespace, pod.Name, nadName, err))
+			}
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
 // Stop gracefully stops the controller, and delete all logical entities for this network if requested
 func (oc *SecondaryLayer3NetworkController) Stop() {
 	klog.Infof("Stop secondary %s network controller of network %s", oc.TopologyType(), oc.GetNetworkName())
@@ -359,6 +455,7 @@ func (oc *SecondaryLayer3NetworkController) Stop() {
 	if oc.namespaceHandler != nil {
 		oc.watchFactory.RemoveNamespaceHandler(oc.namespaceHandler)
 	}
+	oc.stopWatchingEgressIPs()
 }
 
 // Cleanup cleans up logical entities for the given network, called from net-attach-def routine
@@ -430,6 +527,10 @@ func (oc *SecondaryLayer3NetworkController) Run() error {
 		return err
 	}
 
+	if err := oc.WatchEgressIPs(); err != nil {
+		return err
+	}
+
 	klog.Infof("Completing all the Watchers for network %s took %v", oc.GetNetworkName(), time.Since(start))
 
 	return nil
@@ -479,6 +580,10 @@ func (oc *SecondaryLayer3NetworkController) addUpdateLocalNodeEvent(node *kapi.N
 			return err
 		}
 		oc.addNodeFailed.Delete(node.Name)
+		// the node's management port may be the next hop of egress IPs
+		if err := oc.reconcileEgressIPNode(node.Name); err != nil {
+			errs = append(errs, err)
+		}
 	}
 
 	if nSyncs.syncClusterRouterPort {
@@ -528,6 +633,8 @@ func (oc *SecondaryLayer3NetworkController) addUpdateRemoteNodeEvent(node *kapi.
 			oc.syncZoneICFailed.Store(node.Name, true)
 		} else {
 			oc.syncZoneICFailed.Delete(node.Name)
+			// the node's transit switch port may be the next hop of egress IPs
+			err = oc.reconcileEgressIPNode(node.Name)
 		}
 	}
 	return err
@@ -546,6 +653,11 @@ func (oc *SecondaryLayer3NetworkController) addNode(node *kapi.Node) ([]*net.IPN
 	if err != nil {
 		return nil, err
 	}
+	if config.OVNKubernetesFeature.EnableEgressIP {
+		if err = oc.createNodeManagementPort(node.Name, hostSubnets); err != nil {
+			return nil, err
+		}
+	}
 	return hostSubnets, nil
 }
 
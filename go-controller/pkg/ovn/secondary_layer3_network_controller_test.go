@@ -0,0 +1,100 @@
+package ovn
+
+import (
+	"context"
+	"net"
+
+	cnitypes "github.com/containernetworking/cni/pkg/types"
+	"github.com/onsi/ginkgo"
+	"github.com/onsi/gomega"
+
+	ovncnitypes "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/cni/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
+	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
+	libovsdbtest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/libovsdb"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+
+	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = ginkgo.Describe("OVN secondary layer3 network reconfiguration", func() {
+	const (
+		netName  = "bluenet"
+		nadName  = "nad1"
+		nodeName = "node1"
+	)
+	var (
+		fakeOvn *FakeOVN
+		l3Ctrl  *SecondaryLayer3NetworkController
+		nadKey  = util.GetNADName("namespace1", nadName)
+	)
+
+	netConf := func(subnets string) *ovncnitypes.NetConf {
+		return &ovncnitypes.NetConf{
+			NetConf:  cnitypes.NetConf{Name: netName, Type: "ovn-k8s-cni-overlay"},
+			Topology: types.Layer3Topology,
+			NADName:  nadKey,
+			MTU:      1400,
+			Subnets:  subnets,
+		}
+	}
+
+	ginkgo.BeforeEach(func() {
+		config.PrepareTestConfig()
+		config.OVNKubernetesFeature.EnableMultiNetwork = true
+		fakeOvn = NewFakeOVN(true)
+	})
+
+	ginkgo.AfterEach(func() {
+		if l3Ctrl != nil {
+			l3Ctrl.Stop()
+			l3Ctrl = nil
+		}
+		fakeOvn.shutdown()
+	})
+
+	ginkgo.It("routes the subnets added to the network in the annotations of the local pods", func() {
+		netInfo, err := util.NewNetInfo(netConf("10.1.0.0/16/24"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		pod := newPod("namespace1", "pod1", nodeName, "10.128.0.15")
+		pod.Annotations = map[string]string{nadapi.NetworkAttachmentAnnot: nadName}
+		podAnnotation := &util.PodAnnotation{
+			IPs:      ovntest.MustParseIPNets("10.1.1.3/24"),
+			MAC:      util.IPAddrToHWAddr(net.ParseIP("10.1.1.3")),
+			Gateways: []net.IP{},
+			Routes: []util.PodRoute{{
+				Dest:    ovntest.MustParseIPNet("10.1.0.0/16"),
+				NextHop: net.ParseIP("10.1.1.1"),
+			}},
+		}
+		pod.Annotations, err = util.MarshalPodAnnotation(pod.Annotations, podAnnotation, nadKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+
+		fakeOvn.startWithDBSetup(libovsdbtest.TestSetup{},
+			&v1.NodeList{Items: []v1.Node{*newNode(nodeName, "192.168.126.202/24")}},
+			&v1.NamespaceList{Items: []v1.Namespace{*newNamespace("namespace1")}},
+			&v1.PodList{Items: []v1.Pod{*pod}},
+		)
+		l3Ctrl = NewSecondaryLayer3NetworkController(&fakeOvn.controller.CommonNetworkControllerInfo, netInfo)
+		l3Ctrl.AddNAD(nadKey)
+		l3Ctrl.localZoneNodes.Store(nodeName, true)
+
+		newNetInfo, err := util.NewNetInfo(netConf("10.1.0.0/16/24, 10.2.0.0/16/24"))
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(l3Ctrl.Reconfigure(newNetInfo)).To(gomega.Succeed())
+
+		updatedPod, err := fakeOvn.fakeClient.KubeClient.CoreV1().Pods(pod.Namespace).Get(context.TODO(), pod.Name, metav1.GetOptions{})
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		updatedAnnotation, err := util.UnmarshalPodAnnotation(updatedPod.Annotations, nadKey)
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		gomega.Expect(updatedAnnotation.IPs).To(gomega.Equal(podAnnotation.IPs))
+		gomega.Expect(updatedAnnotation.Routes).To(gomega.ConsistOf(
+			util.PodRoute{Dest: ovntest.MustParseIPNet("10.1.0.0/16"), NextHop: net.ParseIP("10.1.1.1")},
+			util.PodRoute{Dest: ovntest.MustParseIPNet("10.2.0.0/16"), NextHop: net.ParseIP("10.1.1.1")},
+		))
+	})
+})
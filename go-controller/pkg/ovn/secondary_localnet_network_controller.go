@@ -130,6 +130,13 @@ func (oc *SecondaryLocalnetNetworkController) Init() error {
 	return nil
 }
 
+// Reconfigure applies the changes of the network configuration that can be
+// applied live, called from net-attach-def routine
+func (oc *SecondaryLocalnetNetworkController) Reconfigure(netInfo util.BasicNetInfo) error {
+	klog.Infof("Reconfigure controller for secondary network %s", oc.GetNetworkName())
+	return oc.BaseSecondaryLayer2NetworkController.reconfigure(oc.GetNetworkScopedName(types.OVNLocalnetSwitch), netInfo)
+}
+
 func (oc *SecondaryLocalnetNetworkController) Stop() {
 	klog.Infof("Stoping controller for secondary network %s", oc.GetNetworkName())
 	oc.BaseSecondaryLayer2NetworkController.stop()
@@ -0,0 +1,297 @@
+package ovndbmanager
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	snapshotTimeFormat = "2006-01-02_150405"
+	snapshotExt        = ".db"
+	checksumExt        = ".sha256"
+)
+
+// snapshotTarget describes a database to take snapshots of
+type snapshotTarget struct {
+	dbName     string
+	serverSock string
+	// prefix of the snapshot files of the database
+	prefix string
+}
+
+// snapshotTargets are the databases that can be backed up, by their short name
+var snapshotTargets = map[string]snapshotTarget{
+	"nb": {dbName: "OVN_Northbound", serverSock: nbdbServerSock, prefix: "ovnnb_db"},
+	"sb": {dbName: "OVN_Southbound", serverSock: sbdbServerSock, prefix: "ovnsb_db"},
+}
+
+// overridden in unit tests
+var (
+	runOVSDBClientRaw = util.RunOVSDBClientRaw
+	runOVSDBTool      = util.RunOVSDBTool
+)
+
+// BackupConfig holds the configuration of the database backups
+type BackupConfig struct {
+	// Dir is the directory the snapshots are written to
+	Dir string
+	// Databases are the short names of the databases to back up: nb and/or sb
+	Databases []string
+	// Interval between two snapshots, a single snapshot is taken if 0
+	Interval time.Duration
+	// Retention is the number of snapshots kept per database, all of them are kept if 0
+	Retention int
+}
+
+// RestoreConfig holds the configuration of a database restore
+type RestoreConfig struct {
+	// Snapshot is the snapshot file to restore
+	Snapshot string
+	// DBFile is the database file to create from the snapshot
+	DBFile string
+	// LocalAddress is the Raft address of the local server, e.g. ssl:10.1.1.185:9643. The database is
+	// restored as a single member cluster the other members can join. The database is restored as
+	// a standalone database if empty.
+	LocalAddress string
+	// Force renames an existing DBFile instead of failing
+	Force bool
+}
+
+// RunDBBackups takes snapshots of the databases every interval until stopCh is closed, or a single
+// snapshot if the interval is 0.
+func RunDBBackups(cfg *BackupConfig, stopCh <-chan struct{}) error {
+	if cfg.Dir == "" {
+		return fmt.Errorf("no backup directory provided")
+	}
+	if cfg.Retention < 0 {
+		return fmt.Errorf("invalid backup retention %d", cfg.Retention)
+	}
+	targets := make([]snapshotTarget, 0, len(cfg.Databases))
+	for _, db := range cfg.Databases {
+		target, ok := snapshotTargets[db]
+		if !ok {
+			return fmt.Errorf("invalid database %q to back up, supported databases are nb and sb", db)
+		}
+		targets = append(targets, target)
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return fmt.Errorf("failed to create backup directory %s: %v", cfg.Dir, err)
+	}
+
+	backup := func() error {
+		var errs []error
+		for _, target := range targets {
+			snapshot, err := takeSnapshot(target, cfg.Dir, cfg.Retention)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			klog.Infof("Took snapshot %s of %s", snapshot, target.dbName)
+		}
+		return utilerrors.NewAggregate(errs)
+	}
+
+	if cfg.Interval == 0 {
+		return backup()
+	}
+	klog.Infof("Starting backups of %v to %s every %v", cfg.Databases, cfg.Dir, cfg.Interval)
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+	for {
+		if err := backup(); err != nil {
+			klog.Errorf("Failed to back up the databases: %v", err)
+		}
+		select {
+		case <-ticker.C:
+		case <-stopCh:
+			return nil
+		}
+	}
+}
+
+// takeSnapshot writes a compacted snapshot of the database served by the local server to dir, along with its
+// checksum, and prunes the snapshots beyond retention. The snapshot is a standalone database.
+func takeSnapshot(target snapshotTarget, dir string, retention int) (string, error) {
+	// ovsdb-client backup only returns committed data, so a consistent snapshot is taken from any member of the cluster
+	out, stderr, err := runOVSDBClientRaw("backup", target.serverSock, target.dbName)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to back up %s, stderr: %q, error: %v", DBError, target.dbName, stderr, err)
+	}
+
+	snapshot := filepath.Join(dir, target.prefix+"_"+time.Now().UTC().Format(snapshotTimeFormat)+snapshotExt)
+	tmpSnapshot := snapshot + ".tmp"
+	defer os.Remove(tmpSnapshot)
+	if err := os.WriteFile(tmpSnapshot, out, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write snapshot %s: %v", tmpSnapshot, err)
+	}
+	if _, stderr, err := runOVSDBTool("compact", tmpSnapshot); err != nil {
+		return "", fmt.Errorf("failed to compact snapshot %s, stderr: %q, error: %v", tmpSnapshot, stderr, err)
+	}
+	dbName, err := getStandaloneDBName(tmpSnapshot)
+	if err != nil {
+		return "", err
+	}
+	if dbName != target.dbName {
+		return "", fmt.Errorf("snapshot %s holds database %s, expected %s", tmpSnapshot, dbName, target.dbName)
+	}
+	checksum, err := fileChecksum(tmpSnapshot)
+	if err != nil {
+		return "", err
+	}
+	// the checksum is written in the sha256sum format, so that snapshots can also be checked with it
+	checksumFile := snapshot + checksumExt
+	if err := os.WriteFile(checksumFile, []byte(fmt.Sprintf("%s  %s\n", checksum, filepath.Base(snapshot))), 0o600); err != nil {
+		return "", fmt.Errorf("failed to write checksum %s: %v", checksumFile, err)
+	}
+	if err := os.Rename(tmpSnapshot, snapshot); err != nil {
+		return "", fmt.Errorf("failed to rename snapshot %s: %v", tmpSnapshot, err)
+	}
+
+	if err := pruneSnapshots(dir, target.prefix, retention); err != nil {
+		// the snapshot was taken, the old ones will be pruned next time
+		klog.Warningf("Failed to prune the snapshots of %s: %v", target.dbName, err)
+	}
+	return snapshot, nil
+}
+
+// pruneSnapshots removes the oldest snapshots with the given prefix, keeping retention snapshots
+func pruneSnapshots(dir, prefix string, retention int) error {
+	if retention == 0 {
+		return nil
+	}
+	snapshots, err := filepath.Glob(filepath.Join(dir, prefix+"_*"+snapshotExt))
+	if err != nil {
+		return err
+	}
+	if len(snapshots) <= retention {
+		return nil
+	}
+	// the timestamp format of the snapshot names sorts them from the oldest to the newest
+	sort.Strings(snapshots)
+	for _, snapshot := range snapshots[:len(snapshots)-retention] {
+		klog.Infof("Removing snapshot %s beyond retention of %d snapshots", snapshot, retention)
+		for _, file := range []string{snapshot, snapshot + checksumExt} {
+			if err := os.Remove(file); err != nil && !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// VerifySnapshot checks the integrity of the given snapshot against its checksum, and that it is a
+// readable standalone database. It returns the name of the database.
+func VerifySnapshot(snapshot string) (string, error) {
+	checksumFile := snapshot + checksumExt
+	content, err := os.ReadFile(checksumFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read checksum of snapshot %s: %v", snapshot, err)
+	}
+	fields := strings.Fields(string(content))
+	if len(fields) == 0 {
+		return "", fmt.Errorf("invalid checksum file %s", checksumFile)
+	}
+	checksum, err := fileChecksum(snapshot)
+	if err != nil {
+		return "", err
+	}
+	if checksum != fields[0] {
+		return "", fmt.Errorf("snapshot %s is corrupted: checksum %s does not match expected %s", snapshot, checksum, fields[0])
+	}
+	return getStandaloneDBName(snapshot)
+}
+
+// RestoreDB creates the database file from a snapshot. The database server must be stopped. When restoring a
+// clustered database, the other members must be reset to join the restored one.
+func RestoreDB(cfg *RestoreConfig) error {
+	dbName, err := VerifySnapshot(cfg.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	if _, err := os.Stat(cfg.DBFile); err == nil {
+		if !cfg.Force {
+			return fmt.Errorf("database file %s already exists", cfg.DBFile)
+		}
+		backupDB := cfg.DBFile + "." + time.Now().UTC().Format(snapshotTimeFormat) + ".db_bak"
+		if err := os.Rename(cfg.DBFile, backupDB); err != nil {
+			return fmt.Errorf("failed to back up the db to backupFile: %s, error: %v", backupDB, err)
+		}
+		klog.Infof("Backed up the db to backupFile: %s", backupDB)
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	if cfg.LocalAddress == "" {
+		if err := copyFile(cfg.Snapshot, cfg.DBFile); err != nil {
+			return fmt.Errorf("failed to restore snapshot %s to %s: %v", cfg.Snapshot, cfg.DBFile, err)
+		}
+		klog.Infof("Restored %s standalone database %s from snapshot %s", dbName, cfg.DBFile, cfg.Snapshot)
+		return nil
+	}
+
+	// create-cluster converts the standalone snapshot into the first member of a new cluster
+	_, stderr, err := runOVSDBTool("create-cluster", cfg.DBFile, cfg.Snapshot, cfg.LocalAddress)
+	if err != nil {
+		return fmt.Errorf("failed to create %s cluster %s from snapshot %s, stderr: %q, error: %v",
+			dbName, cfg.DBFile, cfg.Snapshot, stderr, err)
+	}
+	if _, stderr, err := runOVSDBTool("check-cluster", cfg.DBFile); err != nil {
+		return fmt.Errorf("restored %s cluster %s is inconsistent, stderr: %q, error: %v", dbName, cfg.DBFile, stderr, err)
+	}
+	klog.Infof("Restored %s clustered database %s at %s from snapshot %s", dbName, cfg.DBFile, cfg.LocalAddress, cfg.Snapshot)
+	return nil
+}
+
+// getStandaloneDBName checks that the given file is a readable standalone database and returns its name
+func getStandaloneDBName(dbFile string) (string, error) {
+	if _, stderr, err := runOVSDBTool("db-is-standalone", dbFile); err != nil {
+		return "", fmt.Errorf("%s is not a standalone database, stderr: %q, error: %v", dbFile, stderr, err)
+	}
+	dbName, stderr, err := runOVSDBTool("db-name", dbFile)
+	if err != nil {
+		return "", fmt.Errorf("failed to read database name of %s, stderr: %q, error: %v", dbFile, stderr, err)
+	}
+	return dbName, nil
+}
+
+func fileChecksum(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("failed to compute checksum of %s: %v", file, err)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil)), nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o640)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
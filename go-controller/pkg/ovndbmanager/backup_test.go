@@ -0,0 +1,168 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func mockOVSDBTools(t *testing.T, dbName string, toolErrors map[string]error) {
+	origClient, origTool := runOVSDBClientRaw, runOVSDBTool
+	t.Cleanup(func() {
+		runOVSDBClientRaw, runOVSDBTool = origClient, origTool
+	})
+	runOVSDBClientRaw = func(args ...string) ([]byte, string, error) {
+		return []byte(fmt.Sprintf("OVSDB JSON 2 0\n{}\n%s", dbName)), "", nil
+	}
+	runOVSDBTool = func(args ...string) (string, string, error) {
+		if err := toolErrors[args[0]]; err != nil {
+			return "", "failure", err
+		}
+		switch args[0] {
+		case "db-name":
+			return dbName, "", nil
+		case "create-cluster":
+			return "", "", os.WriteFile(args[1], []byte("clustered"), 0o600)
+		}
+		return "", "", nil
+	}
+}
+
+func TestRunDBBackups(t *testing.T) {
+	tests := []struct {
+		desc        string
+		databases   []string
+		dbName      string
+		retention   int
+		toolErrors  map[string]error
+		existing    []string
+		expected    []string
+		errorString string
+	}{
+		{
+			desc:        "invalid database",
+			databases:   []string{"foo"},
+			errorString: "invalid database",
+		},
+		{
+			desc:        "snapshot of another database",
+			databases:   []string{"nb"},
+			dbName:      "OVN_Southbound",
+			errorString: "holds database OVN_Southbound, expected OVN_Northbound",
+		},
+		{
+			desc:        "compaction failure",
+			databases:   []string{"nb"},
+			dbName:      "OVN_Northbound",
+			toolErrors:  map[string]error{"compact": fmt.Errorf("failure")},
+			errorString: "failed to compact snapshot",
+		},
+		{
+			desc:      "snapshot taken and old snapshots pruned",
+			databases: []string{"nb"},
+			dbName:    "OVN_Northbound",
+			retention: 2,
+			existing:  []string{"ovnnb_db_2023-01-01_000000.db", "ovnnb_db_2023-01-02_000000.db", "ovnsb_db_2023-01-01_000000.db"},
+			expected:  []string{"ovnnb_db_2023-01-02_000000.db", "ovnsb_db_2023-01-01_000000.db"},
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockOVSDBTools(t, tc.dbName, tc.toolErrors)
+			dir := t.TempDir()
+			for _, snapshot := range tc.existing {
+				createDbFile(t, filepath.Join(dir, snapshot))
+				createDbFile(t, filepath.Join(dir, snapshot+checksumExt))
+			}
+
+			err := RunDBBackups(&BackupConfig{Dir: dir, Databases: tc.databases, Retention: tc.retention}, nil)
+			failOnErrorMismatch(t, err, tc.errorString)
+			if err != nil {
+				return
+			}
+
+			for _, snapshot := range tc.expected {
+				if _, err := os.Stat(filepath.Join(dir, snapshot)); err != nil {
+					t.Errorf("Expected snapshot %s to be kept: %v", snapshot, err)
+				}
+			}
+			snapshots, err := filepath.Glob(filepath.Join(dir, "ovnnb_db_*"+snapshotExt))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(snapshots) != tc.retention {
+				t.Fatalf("Expected %d snapshots, got %v", tc.retention, snapshots)
+			}
+			// the new snapshot is the most recent one
+			if _, err := VerifySnapshot(snapshots[len(snapshots)-1]); err != nil {
+				t.Errorf("Expected the snapshot to be valid: %v", err)
+			}
+		})
+	}
+}
+
+func TestRestoreDB(t *testing.T) {
+	tests := []struct {
+		desc         string
+		corrupt      bool
+		existingDB   bool
+		force        bool
+		localAddress string
+		errorString  string
+	}{
+		{
+			desc:        "corrupted snapshot",
+			corrupt:     true,
+			errorString: "is corrupted",
+		},
+		{
+			desc:        "existing database",
+			existingDB:  true,
+			errorString: "already exists",
+		},
+		{
+			desc:       "existing database replaced",
+			existingDB: true,
+			force:      true,
+		},
+		{
+			desc:         "standalone to cluster conversion",
+			localAddress: "ssl:10.1.1.185:9643",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			mockOVSDBTools(t, "OVN_Northbound", nil)
+			dir := t.TempDir()
+			snapshot, err := takeSnapshot(snapshotTargets["nb"], dir, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.corrupt {
+				if err := os.WriteFile(snapshot, []byte("corrupted"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			dbFile := filepath.Join(dir, "ovnnb_db.db")
+			if tc.existingDB {
+				createDbFile(t, dbFile)
+			}
+
+			err = RestoreDB(&RestoreConfig{Snapshot: snapshot, DBFile: dbFile, LocalAddress: tc.localAddress, Force: tc.force})
+			failOnErrorMismatch(t, err, tc.errorString)
+			if err != nil {
+				return
+			}
+			if _, err := os.Stat(dbFile); err != nil {
+				t.Errorf("Expected database %s to be restored: %v", dbFile, err)
+			}
+			if tc.existingDB {
+				backups, _ := filepath.Glob(dbFile + ".*.db_bak")
+				if len(backups) != 1 {
+					t.Errorf("Expected the existing database to be backed up, got %v", backups)
+				}
+			}
+		})
+	}
+}
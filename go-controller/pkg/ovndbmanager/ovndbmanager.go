@@ -88,6 +88,7 @@ func ensureOvnDBState(db string, kclient kube.Interface, stopCh <-chan struct{})
 	}
 
 	var dbRetry int32
+	compactionState := newRaftCompactionState(dbProperties)
 
 	for {
 		select {
@@ -119,6 +120,9 @@ func ensureOvnDBState(db string, kclient kube.Interface, stopCh <-chan struct{})
 					dbRetry = 0
 				}
 			}
+			if err := ensureRaftCompaction(dbProperties, compactionState); err != nil {
+				klog.Error(err)
+			}
 		case <-stopCh:
 			ticker.Stop()
 			return
@@ -0,0 +1,85 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"k8s.io/klog/v2"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/metrics"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+const (
+	compactionReasonLogSize = "log_size"
+	compactionReasonMaxAge  = "max_age"
+)
+
+// raftCompactionState is the state the DB checker keeps between two checks of the compaction policy of a database
+type raftCompactionState struct {
+	// snapshotSize is the size of the database file after the last compaction, or when the checker started
+	snapshotSize   int64
+	lastCompaction time.Time
+}
+
+func newRaftCompactionState(db *util.OvsDbProperties) *raftCompactionState {
+	state := &raftCompactionState{lastCompaction: time.Now()}
+	if info, err := os.Stat(db.DbAlias); err == nil {
+		state.snapshotSize = info.Size()
+	}
+	return state
+}
+
+// ensureRaftCompaction compacts the database according to the compaction policy. The health of the Raft cluster
+// (log indexes, election timer, connections, leader changes) is reported by the ovn_db_cluster_* metrics.
+func ensureRaftCompaction(db *util.OvsDbProperties, state *raftCompactionState) error {
+	if db.CompactionLogSize == 0 && db.CompactionMaxAge == 0 {
+		return nil
+	}
+	clusterStatus, err := metrics.GetOVNDBClusterStatusInfo(5, db)
+	if err != nil {
+		return fmt.Errorf("%w: unable to get cluster status for: %s, err: %v", DBError, db.DbAlias, err)
+	}
+	if clusterStatus.LogEntries() == 0 {
+		// nothing to compact
+		return nil
+	}
+
+	var logSize int64
+	if info, err := os.Stat(db.DbAlias); err == nil {
+		logSize = info.Size() - state.snapshotSize
+		if logSize < 0 {
+			// the database was compacted by ovsdb-server itself
+			state.snapshotSize = info.Size()
+			logSize = 0
+		}
+	} else {
+		klog.Warningf("Unable to get the size of %s: %v", db.DbAlias, err)
+	}
+
+	var reason string
+	switch {
+	case db.CompactionLogSize > 0 && logSize > db.CompactionLogSize:
+		reason = compactionReasonLogSize
+	case db.CompactionMaxAge > 0 && time.Since(state.lastCompaction) > db.CompactionMaxAge:
+		reason = compactionReasonMaxAge
+	default:
+		return nil
+	}
+	return compactDB(db, state, reason)
+}
+
+// compactDB compacts the database of the local server and resets the compaction state
+func compactDB(db *util.OvsDbProperties, state *raftCompactionState, reason string) error {
+	klog.Infof("Compacting %s, reason: %s", db.DbName, reason)
+	_, stderr, err := db.AppCtl(60, "ovsdb-server/compact", db.DbName)
+	if err != nil {
+		return fmt.Errorf("%w: unable to compact: %s, stderr: %v, err: %v", DBError, db.DbAlias, stderr, err)
+	}
+	state.lastCompaction = time.Now()
+	if info, err := os.Stat(db.DbAlias); err == nil {
+		state.snapshotSize = info.Size()
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package ovndbmanager
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util"
+)
+
+func TestEnsureRaftCompaction(t *testing.T) {
+	tests := []struct {
+		desc              string
+		compactionLogSize int64
+		compactionMaxAge  time.Duration
+		logGrowth         int
+		lastCompaction    time.Duration
+		expectCompaction  bool
+		errorString       string
+	}{
+		{
+			desc:      "compaction disabled",
+			logGrowth: 2048,
+		},
+		{
+			desc:              "log below the size threshold",
+			compactionLogSize: 4096,
+			logGrowth:         2048,
+		},
+		{
+			desc:              "log above the size threshold",
+			compactionLogSize: 1024,
+			logGrowth:         2048,
+			expectCompaction:  true,
+		},
+		{
+			desc:             "last compaction too old",
+			compactionMaxAge: time.Hour,
+			lastCompaction:   2 * time.Hour,
+			expectCompaction: true,
+		},
+		{
+			desc:              "compaction failure",
+			compactionLogSize: 1024,
+			logGrowth:         2048,
+			expectCompaction:  true,
+			errorString:       "unable to compact",
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			dbFile := filepath.Join(t.TempDir(), "ovnnb_db.db")
+			if err := os.WriteFile(dbFile, make([]byte, 1024), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			compacted := false
+			db := &util.OvsDbProperties{
+				DbAlias:           dbFile,
+				DbName:            "OVN_Northbound",
+				CompactionLogSize: tc.compactionLogSize,
+				CompactionMaxAge:  tc.compactionMaxAge,
+				AppCtl: func(timeout int, args ...string) (string, string, error) {
+					switch args[0] {
+					case "cluster/status":
+						return fmt.Sprintf(status_template, "OVN_Northbound", serverAddress, "follower", "1000", servers), "", nil
+					case "ovsdb-server/compact":
+						compacted = true
+						if tc.errorString != "" {
+							return "", "failure", fmt.Errorf("failure")
+						}
+						return "", "", os.WriteFile(dbFile, make([]byte, 512), 0o600)
+					}
+					return "", "unexpected call", fmt.Errorf("unexpected call %v", args)
+				},
+			}
+			state := newRaftCompactionState(db)
+			state.lastCompaction = time.Now().Add(-tc.lastCompaction)
+			if err := os.WriteFile(dbFile, make([]byte, 1024+tc.logGrowth), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			err := ensureRaftCompaction(db, state)
+			failOnErrorMismatch(t, err, tc.errorString)
+			if compacted != tc.expectCompaction {
+				t.Errorf("Expected compaction %v, got %v", tc.expectCompaction, compacted)
+			}
+			if tc.expectCompaction && err == nil && state.snapshotSize != 512 {
+				t.Errorf("Expected the snapshot size to be updated after compaction, got %d", state.snapshotSize)
+			}
+		})
+	}
+}
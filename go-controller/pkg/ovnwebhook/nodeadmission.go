@@ -185,6 +185,24 @@ func (p NodeAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 			oldNodeShallowCopy.Status.Conditions = conditionsDeepCopy
 		}
 	}
+	// ovnkube-node reports the progress of the migration of the gateway mode of the node, allow it to set the
+	// GatewayModeMigration condition
+	if _, newCondition := nodeutil.GetNodeCondition(&(newNodeShallowCopy.Status), types.GatewayModeMigrationCondition); newCondition != nil {
+		conditionsDeepCopy := make([]corev1.NodeCondition, len(oldNodeShallowCopy.Status.Conditions))
+		copy(conditionsDeepCopy, oldNodeShallowCopy.Status.Conditions)
+		if oldId, _ := nodeutil.GetNodeCondition(&(oldNodeShallowCopy.Status), types.GatewayModeMigrationCondition); oldId >= 0 {
+			conditionsDeepCopy[oldId] = *newCondition
+		} else {
+			conditionsDeepCopy = append(conditionsDeepCopy, *newCondition)
+		}
+		oldNodeShallowCopy.Status.Conditions = conditionsDeepCopy
+	}
+	// ovnkube-node advertises the device pools of the node as extended resources, allow it to set their capacity and
+	// allocatable
+	oldNodeShallowCopy.Status.Capacity = withDevicePoolResources(oldNodeShallowCopy.Status.Capacity,
+		newNodeShallowCopy.Status.Capacity)
+	oldNodeShallowCopy.Status.Allocatable = withDevicePoolResources(oldNodeShallowCopy.Status.Allocatable,
+		newNodeShallowCopy.Status.Allocatable)
 	if !apiequality.Semantic.DeepEqual(oldNodeShallowCopy.ObjectMeta, newNodeShallowCopy.ObjectMeta) ||
 		!apiequality.Semantic.DeepEqual(oldNodeShallowCopy.Status, newNodeShallowCopy.Status) {
 		return nil, fmt.Errorf("ovnkube-node on node: %q is not allowed to modify anything other than annotations", nodeName)
@@ -192,3 +210,20 @@ func (p NodeAdmission) ValidateUpdate(ctx context.Context, oldObj, newObj runtim
 
 	return nil, nil
 }
+
+// withDevicePoolResources returns the given resources with the device pool resources of newResources instead of their
+// own
+func withDevicePoolResources(resources, newResources corev1.ResourceList) corev1.ResourceList {
+	merged := corev1.ResourceList{}
+	for name, quantity := range resources {
+		if !strings.HasPrefix(string(name), types.DevicePoolResourcePrefix) {
+			merged[name] = quantity
+		}
+	}
+	for name, quantity := range newResources {
+		if strings.HasPrefix(string(name), types.DevicePoolResourcePrefix) {
+			merged[name] = quantity
+		}
+	}
+	return merged
+}
@@ -9,13 +9,19 @@ const (
 	HybridOverlayPrefix   = "int-"
 	HybridOverlayGRSubfix = "-gr"
 
+	// K8sMgmtIntfNamePrefix is the prefix of the names of the OVS internal management ports on the node,
+	// suffixed by the ID of their network
+	K8sMgmtIntfNamePrefix = "ovn-k8s-mp"
 	// K8sMgmtIntfName name to be used as an OVS internal port on the node
-	K8sMgmtIntfName = "ovn-k8s-mp0"
+	K8sMgmtIntfName = K8sMgmtIntfNamePrefix + "0"
 
 	// PhysicalNetworkName is the name that maps to an OVS bridge that provides
 	// access to physical/external network
 	PhysicalNetworkName     = "physnet"
 	PhysicalNetworkExGwName = "exgwphysnet"
+	// GatewayUplinkPrefix is the prefix of the physical network names of the gateway uplinks, and of the names of
+	// their external switches
+	GatewayUplinkPrefix = "uplink-"
 
 	// LocalNetworkName is the name that maps to an OVS bridge that provides
 	// access to local service
@@ -70,6 +76,8 @@ const (
 	DefaultAllowPriority = 1001
 	// Default deny acl rule priority
 	DefaultDenyPriority = 1000
+	// Flow sampling acl rule priority, the lowest one of the baseline admin network policy tier
+	FlowSamplingACLPriority = 0
 
 	// ACL Tiers
 	// Tier 0 is currently un-used and is a placeholder tier for future use cases (can be renamed when we have a use for it).
@@ -96,6 +104,17 @@ const (
 	EgressIPReroutePriority               = 100
 	EgressLiveMigrationReroutePiority     = 10
 
+	// priority of logical router policies on the cluster routers of secondary networks that reroute the
+	// traffic of pods to the egress nodes of their egress IPs
+	SecondaryNetworkEgressIPReroutePriority = 99
+
+	// priority of logical router policies on the gateway routers that reroute the traffic of pods to specific
+	// destinations through external gateways
+	ExternalGWDestinationReroutePriority = 95
+	// priority of logical router policies on the gateway routers that reroute the traffic of namespaces through
+	// the gateway uplink they are mapped to
+	GatewayUplinkReroutePriority = 90
+
 	V6NodeLocalNATSubnet           = "fd99::/64"
 	V6NodeLocalNATSubnetPrefix     = 64
 	V6NodeLocalNATSubnetNextHop    = "fd99::1"
@@ -122,12 +141,23 @@ const (
 	OvnK8sTopoAnno         = OvnK8sPrefix + "/" + "topology-version"
 	OvnK8sSmallMTUTaintKey = OvnK8sPrefix + "/" + "mtu-too-small"
 
+	// GatewayModeMigrationCondition is the node condition reporting the migration of the gateway of the node to
+	// another gateway mode, true while the migration is in progress
+	GatewayModeMigrationCondition = "GatewayModeMigration"
+
+	// DevicePoolResourcePrefix is the prefix of the extended resources of the node advertising its device pools,
+	// followed by the name of the pool
+	DevicePoolResourcePrefix = "devicepool." + OvnK8sPrefix + "/"
+
 	// name of the configmap used to synchronize status (e.g. watch for topology changes)
 	OvnK8sStatusCMName         = "control-plane-status"
 	OvnK8sStatusKeyTopoVersion = "topology-version"
 
 	// Monitoring constants
 	SFlowAgent = "ovn-k8s-mp0"
+	// FlowSamplingCollectorSetID is the ID of the OVS flow sample collector set of br-int the samples of the
+	// ACLs of the namespaces with flow sampling are sent to
+	FlowSamplingCollectorSetID = 4242
 
 	// OVNKube-Node Node types
 	NodeModeFull    = "full"
@@ -159,6 +189,12 @@ const (
 	LoadBalancerKindExternalID = OvnK8sPrefix + "/" + "kind"
 	// key for load_balancer service external-id
 	LoadBalancerOwnerExternalID = OvnK8sPrefix + "/" + "owner"
+	// key for the member index external-id of the duplicated static routes of weighted external gateways
+	ExternalGWECMPMemberExternalID = OvnK8sPrefix + "/" + "ecmp-member"
+	// key for the slot index external-id of the static routes of external gateways using consistent hashing
+	ExternalGWECMPSlotExternalID = OvnK8sPrefix + "/" + "ecmp-slot"
+	// key for the gateway uplink external-id of the logical router policies rerouting namespaces through it
+	GatewayUplinkExternalID = OvnK8sPrefix + "/" + "gateway-uplink"
 
 	// different secondary network topology type defined in CNI netconf
 	Layer3Topology   = "layer3"
@@ -7,8 +7,9 @@ import (
 
 // this file defines error messages that are used to figure out if a resource reconciliation failed
 const (
-	APBRouteErrorMsg       = "failed to apply policy"
-	EgressFirewallErrorMsg = "EgressFirewall Rules not correctly applied"
+	APBRouteErrorMsg        = "failed to apply policy"
+	EgressFirewallErrorMsg  = "EgressFirewall Rules not correctly applied"
+	NetworkTopologyErrorMsg = "failed to create network topology"
 )
 
 func GetZoneStatus(zoneID, message string) string {
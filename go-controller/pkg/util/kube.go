@@ -7,6 +7,7 @@ import (
 	"crypto/x509/pkix"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -251,9 +252,34 @@ func newKubernetesRestConfig(conf *config.KubernetesConfig) (*rest.Config, error
 	kconfig.UserAgent = fmt.Sprintf("%s/%s@%s (%s/%s) kubernetes/%s",
 		adjustNodeName(), filepath.Base(os.Args[0]), adjustCommit(), runtime.GOOS, runtime.GOARCH,
 		version.Get().GitVersion)
+	if config.OVNKubernetesFeature.EnableShadowMode {
+		// a shadow instance must not change the Kubernetes objects the active instance handles
+		kconfig.Wrap(newDryRunRoundTripper)
+	}
 	return kconfig, nil
 }
 
+// dryRunRoundTripper sends the requests that change Kubernetes objects as server-side dry runs: the API server
+// validates and answers them as usual, but doesn't persist the changes
+type dryRunRoundTripper struct {
+	rt http.RoundTripper
+}
+
+func newDryRunRoundTripper(rt http.RoundTripper) http.RoundTripper {
+	return &dryRunRoundTripper{rt: rt}
+}
+
+func (d *dryRunRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		req = req.Clone(req.Context())
+		query := req.URL.Query()
+		query.Set("dryRun", metav1.DryRunAll)
+		req.URL.RawQuery = query.Encode()
+	}
+	return d.rt.RoundTrip(req)
+}
+
 // StartNodeCertificateManager manages the creation and rotation of the node-specific client certificate.
 // When there is no existing certificate, it will use the BootstrapKubeconfig kubeconfig to create a CSR and it will
 // wait for the certificate before returning.
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 
@@ -110,6 +113,45 @@ func TestNewClientset(t *testing.T) {
 	}
 }
 
+func TestDryRunRoundTripper(t *testing.T) {
+	tests := []struct {
+		method     string
+		expectDry  bool
+		extraQuery string
+	}{
+		{method: http.MethodGet},
+		{method: http.MethodPost, expectDry: true},
+		{method: http.MethodPut, expectDry: true},
+		{method: http.MethodPatch, expectDry: true, extraQuery: "fieldManager=test"},
+		{method: http.MethodDelete, expectDry: true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.method, func(t *testing.T) {
+			var query url.Values
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				query = req.URL.Query()
+			}))
+			defer server.Close()
+
+			req, err := http.NewRequest(tc.method, server.URL+"/api/v1/nodes/node1?"+tc.extraQuery, nil)
+			assert.NoError(t, err)
+			resp, err := newDryRunRoundTripper(http.DefaultTransport).RoundTrip(req)
+			assert.NoError(t, err)
+			resp.Body.Close()
+			if tc.expectDry {
+				assert.Equal(t, []string{metav1.DryRunAll}, query["dryRun"])
+			} else {
+				assert.NotContains(t, query, "dryRun")
+			}
+			if tc.extraQuery != "" {
+				assert.Equal(t, "test", query.Get("fieldManager"))
+			}
+			// the request of the caller is not modified
+			assert.NotContains(t, req.URL.Query(), "dryRun")
+		})
+	}
+}
+
 func TestIsClusterIPSet(t *testing.T) {
 	tests := []struct {
 		desc   string
@@ -93,6 +93,86 @@ func (_m *NetLinkOps) ConntrackDeleteFilter(table netlink.ConntrackTableType, fa
 	return r0, r1
 }
 
+// DevLinkGetAllPortList provides a mock function with given fields:
+func (_m *NetLinkOps) DevLinkGetAllPortList() ([]*netlink.DevlinkPort, error) {
+	ret := _m.Called()
+
+	var r0 []*netlink.DevlinkPort
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]*netlink.DevlinkPort, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []*netlink.DevlinkPort); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*netlink.DevlinkPort)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DevLinkPortAdd provides a mock function with given fields: bus, device, flavour, attrs
+func (_m *NetLinkOps) DevLinkPortAdd(bus string, device string, flavour uint16, attrs netlink.DevLinkPortAddAttrs) (*netlink.DevlinkPort, error) {
+	ret := _m.Called(bus, device, flavour, attrs)
+
+	var r0 *netlink.DevlinkPort
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, uint16, netlink.DevLinkPortAddAttrs) (*netlink.DevlinkPort, error)); ok {
+		return rf(bus, device, flavour, attrs)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, uint16, netlink.DevLinkPortAddAttrs) *netlink.DevlinkPort); ok {
+		r0 = rf(bus, device, flavour, attrs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*netlink.DevlinkPort)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, uint16, netlink.DevLinkPortAddAttrs) error); ok {
+		r1 = rf(bus, device, flavour, attrs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DevLinkPortDel provides a mock function with given fields: bus, device, portIndex
+func (_m *NetLinkOps) DevLinkPortDel(bus string, device string, portIndex uint32) error {
+	ret := _m.Called(bus, device, portIndex)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, uint32) error); ok {
+		r0 = rf(bus, device, portIndex)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DevlinkPortFnSet provides a mock function with given fields: bus, device, portIndex, fnAttrs
+func (_m *NetLinkOps) DevlinkPortFnSet(bus string, device string, portIndex uint32, fnAttrs netlink.DevlinkPortFnSetAttrs) error {
+	ret := _m.Called(bus, device, portIndex, fnAttrs)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, uint32, netlink.DevlinkPortFnSetAttrs) error); ok {
+		r0 = rf(bus, device, portIndex, fnAttrs)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // IsLinkNotFoundError provides a mock function with given fields: err
 func (_m *NetLinkOps) IsLinkNotFoundError(err error) bool {
 	ret := _m.Called(err)
@@ -15,6 +15,56 @@ type SriovnetOps struct {
 	mock.Mock
 }
 
+// GetAuxNetDevicesFromPci provides a mock function with given fields: pciAddr
+func (_m *SriovnetOps) GetAuxNetDevicesFromPci(pciAddr string) ([]string, error) {
+	ret := _m.Called(pciAddr)
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) ([]string, error)); ok {
+		return rf(pciAddr)
+	}
+	if rf, ok := ret.Get(0).(func(string) []string); ok {
+		r0 = rf(pciAddr)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(pciAddr)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetNetDevPhysPortName provides a mock function with given fields: netdev
+func (_m *SriovnetOps) GetNetDevPhysPortName(netdev string) (string, error) {
+	ret := _m.Called(netdev)
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (string, error)); ok {
+		return rf(netdev)
+	}
+	if rf, ok := ret.Get(0).(func(string) string); ok {
+		r0 = rf(netdev)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(netdev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetNetDevicesFromAux provides a mock function with given fields: auxDev
 func (_m *SriovnetOps) GetNetDevicesFromAux(auxDev string) ([]string, error) {
 	ret := _m.Called(auxDev)
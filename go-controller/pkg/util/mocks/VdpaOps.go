@@ -12,6 +12,60 @@ type VdpaOps struct {
 	mock.Mock
 }
 
+// AddVdpaDevice provides a mock function with given fields: mgmtDeviceName, vdpaDeviceName
+func (_m *VdpaOps) AddVdpaDevice(mgmtDeviceName string, vdpaDeviceName string) error {
+	ret := _m.Called(mgmtDeviceName, vdpaDeviceName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string) error); ok {
+		r0 = rf(mgmtDeviceName, vdpaDeviceName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVdpaDevice provides a mock function with given fields: vdpaDeviceName
+func (_m *VdpaOps) DeleteVdpaDevice(vdpaDeviceName string) error {
+	ret := _m.Called(vdpaDeviceName)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(vdpaDeviceName)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetVdpaDeviceByAuxDev provides a mock function with given fields: auxDev
+func (_m *VdpaOps) GetVdpaDeviceByAuxDev(auxDev string) (kvdpa.VdpaDevice, error) {
+	ret := _m.Called(auxDev)
+
+	var r0 kvdpa.VdpaDevice
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (kvdpa.VdpaDevice, error)); ok {
+		return rf(auxDev)
+	}
+	if rf, ok := ret.Get(0).(func(string) kvdpa.VdpaDevice); ok {
+		r0 = rf(auxDev)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(kvdpa.VdpaDevice)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(auxDev)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetVdpaDeviceByPci provides a mock function with given fields: pciAddress
 func (_m *VdpaOps) GetVdpaDeviceByPci(pciAddress string) (kvdpa.VdpaDevice, error) {
 	ret := _m.Called(pciAddress)
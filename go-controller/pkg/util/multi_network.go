@@ -11,6 +11,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	kapi "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
 	knet "k8s.io/utils/net"
 
 	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -138,6 +139,10 @@ type secondaryNetInfo struct {
 	subnets            []config.CIDRNetworkEntry
 	excludeSubnets     []*net.IPNet
 
+	// protects the mtu, subnets and excludeSubnets that can be updated live,
+	// see UpdateNetInfo
+	mutex sync.RWMutex
+
 	// all net-attach-def NAD names for this network, used to determine if a pod needs
 	// to be plumbed for this network
 	nadNames sync.Map
@@ -195,6 +200,8 @@ func (nInfo *secondaryNetInfo) TopologyType() string {
 
 // MTU returns the layer3NetConfInfo's MTU value
 func (nInfo *secondaryNetInfo) MTU() int {
+	nInfo.mutex.RLock()
+	defer nInfo.mutex.RUnlock()
 	return nInfo.mtu
 }
 
@@ -210,11 +217,15 @@ func (nInfo *secondaryNetInfo) IPMode() (bool, bool) {
 
 // Subnets returns the Subnets value
 func (nInfo *secondaryNetInfo) Subnets() []config.CIDRNetworkEntry {
+	nInfo.mutex.RLock()
+	defer nInfo.mutex.RUnlock()
 	return nInfo.subnets
 }
 
 // ExcludeSubnets returns the ExcludeSubnets value
 func (nInfo *secondaryNetInfo) ExcludeSubnets() []*net.IPNet {
+	nInfo.mutex.RLock()
+	defer nInfo.mutex.RUnlock()
 	return nInfo.excludeSubnets
 }
 
@@ -226,7 +237,7 @@ func (nInfo *secondaryNetInfo) CompareNetInfo(other BasicNetInfo) bool {
 	if nInfo.topology != other.TopologyType() {
 		return false
 	}
-	if nInfo.mtu != other.MTU() {
+	if nInfo.MTU() != other.MTU() {
 		return false
 	}
 	if nInfo.vlan != other.Vlan() {
@@ -234,12 +245,80 @@ func (nInfo *secondaryNetInfo) CompareNetInfo(other BasicNetInfo) bool {
 	}
 
 	lessCIDRNetworkEntry := func(a, b config.CIDRNetworkEntry) bool { return a.String() < b.String() }
-	if !cmp.Equal(nInfo.subnets, other.Subnets(), cmpopts.SortSlices(lessCIDRNetworkEntry)) {
+	if !cmp.Equal(nInfo.Subnets(), other.Subnets(), cmpopts.SortSlices(lessCIDRNetworkEntry)) {
 		return false
 	}
 
 	lessIPNet := func(a, b net.IPNet) bool { return a.String() < b.String() }
-	return cmp.Equal(nInfo.excludeSubnets, other.ExcludeSubnets(), cmpopts.SortSlices(lessIPNet))
+	return cmp.Equal(nInfo.ExcludeSubnets(), other.ExcludeSubnets(), cmpopts.SortSlices(lessIPNet))
+}
+
+// ValidateNetInfoUpdate checks that the network information can be updated from
+// oldInfo to newInfo live, without recreating the network. Only changes to the
+// MTU and additions of subnets and exclude subnets are supported; the IP
+// families of the network can't change and a network without subnets can't get
+// subnets, as this would change its IPAM.
+func ValidateNetInfoUpdate(oldInfo, newInfo BasicNetInfo) error {
+	if oldInfo.GetNetworkName() != newInfo.GetNetworkName() {
+		return fmt.Errorf("network name changed from %s to %s", oldInfo.GetNetworkName(), newInfo.GetNetworkName())
+	}
+	if !oldInfo.IsSecondary() {
+		return fmt.Errorf("the default network can't be updated")
+	}
+	if oldInfo.TopologyType() != newInfo.TopologyType() {
+		return fmt.Errorf("topology changed from %s to %s", oldInfo.TopologyType(), newInfo.TopologyType())
+	}
+	if oldInfo.Vlan() != newInfo.Vlan() {
+		return fmt.Errorf("VLAN changed from %d to %d", oldInfo.Vlan(), newInfo.Vlan())
+	}
+	oldIPv4Mode, oldIPv6Mode := oldInfo.IPMode()
+	newIPv4Mode, newIPv6Mode := newInfo.IPMode()
+	if len(oldInfo.Subnets()) > 0 && (oldIPv4Mode != newIPv4Mode || oldIPv6Mode != newIPv6Mode) {
+		return fmt.Errorf("IP families of the subnets changed")
+	}
+
+	newSubnets := sets.New[string]()
+	for _, subnet := range newInfo.Subnets() {
+		newSubnets.Insert(subnet.String())
+	}
+	for _, subnet := range oldInfo.Subnets() {
+		if !newSubnets.Has(subnet.String()) {
+			return fmt.Errorf("subnet %s was removed", subnet)
+		}
+	}
+	if len(oldInfo.Subnets()) == 0 && len(newInfo.Subnets()) > 0 {
+		return fmt.Errorf("subnets were added to a network without subnets")
+	}
+
+	newExcludeSubnets := sets.New[string]()
+	for _, excludeSubnet := range newInfo.ExcludeSubnets() {
+		newExcludeSubnets.Insert(excludeSubnet.String())
+	}
+	for _, excludeSubnet := range oldInfo.ExcludeSubnets() {
+		if !newExcludeSubnets.Has(excludeSubnet.String()) {
+			return fmt.Errorf("exclude subnet %s was removed", excludeSubnet)
+		}
+	}
+	return nil
+}
+
+// UpdateNetInfo updates nInfo live with the MTU, subnets and exclude subnets of
+// other, once validated with ValidateNetInfoUpdate. The update is seen by all
+// the users of nInfo.
+func UpdateNetInfo(nInfo NetInfo, other BasicNetInfo) error {
+	if err := ValidateNetInfoUpdate(nInfo, other); err != nil {
+		return err
+	}
+	secondaryInfo, ok := nInfo.(*secondaryNetInfo)
+	if !ok {
+		return fmt.Errorf("network %s can't be updated", nInfo.GetNetworkName())
+	}
+	secondaryInfo.mutex.Lock()
+	defer secondaryInfo.mutex.Unlock()
+	secondaryInfo.mtu = other.MTU()
+	secondaryInfo.subnets = other.Subnets()
+	secondaryInfo.excludeSubnets = other.ExcludeSubnets()
+	return nil
 }
 
 func newLayer3NetConfInfo(netconf *ovncnitypes.NetConf) (NetInfo, error) {
@@ -408,7 +487,7 @@ func ParseNADInfo(netattachdef *nettypes.NetworkAttachmentDefinition) (NetInfo,
 func ParseNetConf(netattachdef *nettypes.NetworkAttachmentDefinition) (*ovncnitypes.NetConf, error) {
 	netconf, err := config.ParseNetConf([]byte(netattachdef.Spec.Config))
 	if err != nil {
-		return nil, fmt.Errorf("error parsing Network Attachment Definition %s/%s: %v", netattachdef.Namespace, netattachdef.Name, err)
+		return nil, fmt.Errorf("error parsing Network Attachment Definition %s/%s: %w", netattachdef.Namespace, netattachdef.Name, err)
 	}
 
 	if netconf.Name != types.DefaultNetworkName {
@@ -1,6 +1,8 @@
 package util
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"testing"
@@ -129,6 +131,14 @@ func TestParseSubnets(t *testing.T) {
 	}
 }
 
+// netConfUnmarshalError returns the error of the decoding of the CNI config
+// the way config.ParseNetConf decodes it, the cause of the parsing errors of
+// malformed configs
+func netConfUnmarshalError(conf string) error {
+	netconf := &ovncnitypes.NetConf{}
+	return json.Unmarshal([]byte(conf), &netconf)
+}
+
 func TestParseNetconf(t *testing.T) {
 	type testConfig struct {
 		desc                        string
@@ -139,11 +149,21 @@ func TestParseNetconf(t *testing.T) {
 		expectedError               error
 		unsupportedReason           string
 	}
+	const wrongIPAMTypeConf = `
+    {
+            "name": "tenantred",
+            "type": "ovn-k8s-cni-overlay",
+            "topology": "localnet",
+            "vlanID": 10,
+            "netAttachDefName": "default/tenantred",
+            "ipam": "this is wrong"
+    }
+`
 
 	tests := []testConfig{
 		{
 			desc:          "empty network attachment configuration",
-			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: unexpected end of JSON input"),
+			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: %w", netConfUnmarshalError("")),
 		},
 		{
 			desc: "net-attach-def-name attribute does not match the metadata",
@@ -168,7 +188,8 @@ func TestParseNetconf(t *testing.T) {
             "netAttachDefName": "default/tenantred"
     }
 `,
-			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: invalid name in in secondary network netconf ()"),
+			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: %w",
+				errors.New("invalid name in in secondary network netconf ()")),
 		},
 		{
 			desc: "attachment definition for another plugin",
@@ -181,21 +202,13 @@ func TestParseNetconf(t *testing.T) {
             "netAttachDefName": "default/tenantred"
     }
 `,
-			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: net-attach-def not managed by OVN"),
+			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: %w", config.ErrorAttachDefNotOvnManaged),
 		},
 		{
-			desc: "attachment definition with IPAM key defined, using a wrong type",
-			inputNetAttachDefConfigSpec: `
-    {
-            "name": "tenantred",
-            "type": "ovn-k8s-cni-overlay",
-            "topology": "localnet",
-            "vlanID": 10,
-            "netAttachDefName": "default/tenantred",
-            "ipam": "this is wrong"
-    }
-`,
-			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: json: cannot unmarshal string into Go struct field NetConf.ipam of type types.IPAM"),
+			desc:                        "attachment definition with IPAM key defined, using a wrong type",
+			inputNetAttachDefConfigSpec: wrongIPAMTypeConf,
+			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: %w",
+				netConfUnmarshalError(wrongIPAMTypeConf)),
 		},
 		{
 			desc: "attachment definition with IPAM key defined",
@@ -221,7 +234,8 @@ func TestParseNetconf(t *testing.T) {
             "vlanID": 10
     }
 `,
-			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: missing NADName in secondary network netconf tenantred"),
+			expectedError: fmt.Errorf("error parsing Network Attachment Definition ns1/nad1: %w",
+				errors.New("missing NADName in secondary network netconf tenantred")),
 		},
 		{
 			desc: "valid attachment definition for a localnet topology with a VLAN",
@@ -305,6 +319,104 @@ func TestParseNetconf(t *testing.T) {
 	}
 }
 
+func TestValidateNetInfoUpdate(t *testing.T) {
+	layer2NetConf := func(mtu int, subnets, excludes string) *ovncnitypes.NetConf {
+		return &ovncnitypes.NetConf{
+			NetConf:        cnitypes.NetConf{Name: "tenantred"},
+			Topology:       types.Layer2Topology,
+			MTU:            mtu,
+			Subnets:        subnets,
+			ExcludeSubnets: excludes,
+		}
+	}
+	tests := []struct {
+		desc        string
+		oldNetConf  *ovncnitypes.NetConf
+		newNetConf  *ovncnitypes.NetConf
+		expectError bool
+	}{
+		{
+			desc:       "MTU change",
+			oldNetConf: layer2NetConf(1400, "192.168.1.0/24", ""),
+			newNetConf: layer2NetConf(9000, "192.168.1.0/24", ""),
+		},
+		{
+			desc:       "subnet and exclude subnet added",
+			oldNetConf: layer2NetConf(1400, "192.168.1.0/24", "192.168.1.0/29"),
+			newNetConf: layer2NetConf(1400, "192.168.1.0/24, 192.168.2.0/24", "192.168.1.0/29, 192.168.2.0/29"),
+		},
+		{
+			desc:        "subnet removed",
+			oldNetConf:  layer2NetConf(1400, "192.168.1.0/24, 192.168.2.0/24", ""),
+			newNetConf:  layer2NetConf(1400, "192.168.1.0/24", ""),
+			expectError: true,
+		},
+		{
+			desc:        "exclude subnet removed",
+			oldNetConf:  layer2NetConf(1400, "192.168.1.0/24", "192.168.1.0/29"),
+			newNetConf:  layer2NetConf(1400, "192.168.1.0/24", ""),
+			expectError: true,
+		},
+		{
+			desc:        "IP family added",
+			oldNetConf:  layer2NetConf(1400, "192.168.1.0/24", ""),
+			newNetConf:  layer2NetConf(1400, "192.168.1.0/24, fda6::/48", ""),
+			expectError: true,
+		},
+		{
+			desc: "subnet added to a layer3 network",
+			oldNetConf: &ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantred"},
+				Topology: types.Layer3Topology,
+				MTU:      1400,
+				Subnets:  "192.168.0.0/16/24",
+			},
+			newNetConf: &ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantred"},
+				Topology: types.Layer3Topology,
+				MTU:      1400,
+				Subnets:  "192.168.0.0/16/24, 10.128.0.0/16/24",
+			},
+		},
+		{
+			desc:        "subnets added to a network without subnets",
+			oldNetConf:  layer2NetConf(1400, "", ""),
+			newNetConf:  layer2NetConf(1400, "192.168.1.0/24", ""),
+			expectError: true,
+		},
+		{
+			desc:       "topology change",
+			oldNetConf: layer2NetConf(1400, "192.168.1.0/24", ""),
+			newNetConf: &ovncnitypes.NetConf{
+				NetConf:  cnitypes.NetConf{Name: "tenantred"},
+				Topology: types.LocalnetTopology,
+				MTU:      1400,
+				Subnets:  "192.168.1.0/24",
+			},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			oldNetInfo, err := NewNetInfo(tc.oldNetConf)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			newNetInfo, err := NewNetInfo(tc.newNetConf)
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+
+			err = UpdateNetInfo(oldNetInfo, newNetInfo)
+			if tc.expectError {
+				g.Expect(err).To(gomega.HaveOccurred())
+				g.Expect(oldNetInfo.CompareNetInfo(newNetInfo)).To(gomega.BeFalse())
+				return
+			}
+			g.Expect(err).NotTo(gomega.HaveOccurred())
+			g.Expect(oldNetInfo.CompareNetInfo(newNetInfo)).To(gomega.BeTrue())
+		})
+	}
+}
+
 func applyNADDefaults(nad *nadv1.NetworkAttachmentDefinition) *nadv1.NetworkAttachmentDefinition {
 	const (
 		name      = "nad1"
@@ -0,0 +1,147 @@
+package util
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	nettypes "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadclientset "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned"
+	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// OvnNetworkAcceptedAnnotation holds the Accepted condition of a net-attach-def, set by ovnkube-cluster-manager
+	OvnNetworkAcceptedAnnotation = "k8s.ovn.org/network-accepted"
+	// OvnNetworkTopologyReadyAnnotation holds the TopologyReady condition of a net-attach-def, aggregated by
+	// ovnkube-cluster-manager from the status reported by every zone
+	OvnNetworkTopologyReadyAnnotation = "k8s.ovn.org/network-topology-ready"
+	// ovnNetworkZoneStatusAnnotationPrefix prefixes the annotations holding the status of the network topology
+	// of a net-attach-def reported by each zone
+	ovnNetworkZoneStatusAnnotationPrefix = "k8s.ovn.org/network-zone-status."
+
+	// NetworkConditionAccepted is the type of the condition telling if ovn-kubernetes accepted the net-attach-def
+	NetworkConditionAccepted = "Accepted"
+	// NetworkConditionTopologyReady is the type of the condition telling if the network topology of the
+	// net-attach-def was created in every zone
+	NetworkConditionTopologyReady = "TopologyReady"
+
+	// Reasons of the net-attach-def conditions
+	NetworkReasonAccepted          = "Accepted"
+	NetworkReasonInvalidConfig     = "InvalidConfig"
+	NetworkReasonNetworkConflict   = "NetworkConflict"
+	NetworkReasonUnsupportedUpdate = "UnsupportedUpdate"
+	NetworkReasonTopologyCreated   = "TopologyCreated"
+	NetworkReasonTopologyFailed    = "TopologyFailed"
+)
+
+// GetNetworkZoneStatusAnnotation returns the annotation holding the status of the network topology
+// reported by the given zone. Zone names that can't be used in an annotation key are hashed.
+func GetNetworkZoneStatusAnnotation(zone string) string {
+	annotation := ovnNetworkZoneStatusAnnotationPrefix + zone
+	if len(validation.IsQualifiedName(annotation)) > 0 {
+		annotation = ovnNetworkZoneStatusAnnotationPrefix + HashForOVN(zone)
+	}
+	return annotation
+}
+
+// GetNADZoneStatusMessages returns the sorted status messages reported by the zones for the given
+// net-attach-def, as built with types.GetZoneStatus.
+func GetNADZoneStatusMessages(nad *nettypes.NetworkAttachmentDefinition) []string {
+	messages := []string{}
+	for annotation, message := range nad.Annotations {
+		if strings.HasPrefix(annotation, ovnNetworkZoneStatusAnnotationPrefix) {
+			messages = append(messages, message)
+		}
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+// GetNADCondition returns the condition held by the given annotation of the net-attach-def, or nil
+// if the annotation is not set.
+func GetNADCondition(nad *nettypes.NetworkAttachmentDefinition, annotation string) (*metav1.Condition, error) {
+	value, ok := nad.Annotations[annotation]
+	if !ok {
+		return nil, nil
+	}
+	condition := &metav1.Condition{}
+	if err := json.Unmarshal([]byte(value), condition); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal annotation %s of net-attach-def %s/%s: %v",
+			annotation, nad.Namespace, nad.Name, err)
+	}
+	return condition, nil
+}
+
+// SetNADCondition sets the given condition as the value of the given annotation of the net-attach-def.
+// The last transition time of the condition is kept if its status doesn't change. It returns false if
+// the net-attach-def already has the condition.
+func SetNADCondition(client nadclientset.Interface, nad *nettypes.NetworkAttachmentDefinition, annotation string,
+	condition metav1.Condition) (bool, error) {
+	oldCondition, err := GetNADCondition(nad, annotation)
+	if err != nil {
+		return false, err
+	}
+	condition.ObservedGeneration = nad.Generation
+	condition.LastTransitionTime = metav1.Now()
+	if oldCondition != nil {
+		if oldCondition.Status == condition.Status && oldCondition.Reason == condition.Reason &&
+			oldCondition.Message == condition.Message && oldCondition.ObservedGeneration == condition.ObservedGeneration {
+			return false, nil
+		}
+		if oldCondition.Status == condition.Status {
+			condition.LastTransitionTime = oldCondition.LastTransitionTime
+		}
+	}
+	bytes, err := json.Marshal(condition)
+	if err != nil {
+		return false, err
+	}
+	value := string(bytes)
+	return true, patchNADAnnotations(client, nad, map[string]*string{annotation: &value})
+}
+
+// SetNADZoneStatus sets the status of the network topology of the net-attach-def reported by the given zone.
+func SetNADZoneStatus(client nadclientset.Interface, nad *nettypes.NetworkAttachmentDefinition, zone, message string) error {
+	annotation := GetNetworkZoneStatusAnnotation(zone)
+	value := types.GetZoneStatus(zone, message)
+	if nad.Annotations[annotation] == value {
+		return nil
+	}
+	return patchNADAnnotations(client, nad, map[string]*string{annotation: &value})
+}
+
+// RemoveNADAnnotation removes the given annotation from the net-attach-def, if set.
+func RemoveNADAnnotation(client nadclientset.Interface, nad *nettypes.NetworkAttachmentDefinition, annotation string) error {
+	if _, ok := nad.Annotations[annotation]; !ok {
+		return nil
+	}
+	return patchNADAnnotations(client, nad, map[string]*string{annotation: nil})
+}
+
+// patchNADAnnotations merge patches the given annotations of the net-attach-def, nil values remove the
+// annotation. Merge patches let every zone and the cluster manager update their own annotations
+// without conflicting with each other.
+func patchNADAnnotations(client nadclientset.Interface, nad *nettypes.NetworkAttachmentDefinition, annotations map[string]*string) error {
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"annotations": annotations,
+		},
+	}
+	patchData, err := json.Marshal(patch)
+	if err != nil {
+		return err
+	}
+	_, err = client.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).Patch(context.TODO(), nad.Name,
+		ktypes.MergePatchType, patchData, metav1.PatchOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to patch annotations of net-attach-def %s/%s: %v", nad.Namespace, nad.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package util
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/onsi/gomega"
+
+	nadv1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	nadfake "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/fake"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func TestGetNetworkZoneStatusAnnotation(t *testing.T) {
+	tests := []struct {
+		desc   string
+		zone   string
+		hashed bool
+	}{
+		{
+			desc: "valid zone name",
+			zone: "zone-1",
+		},
+		{
+			desc:   "zone name too long",
+			zone:   strings.Repeat("z", 64),
+			hashed: true,
+		},
+		{
+			desc:   "zone name with invalid characters",
+			zone:   "zone/1",
+			hashed: true,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.desc, func(t *testing.T) {
+			g := gomega.NewWithT(t)
+			annotation := GetNetworkZoneStatusAnnotation(tc.zone)
+			g.Expect(validation.IsQualifiedName(annotation)).To(gomega.BeEmpty())
+			g.Expect(strings.HasSuffix(annotation, tc.zone)).To(gomega.Equal(!tc.hashed))
+		})
+	}
+}
+
+func TestSetNADCondition(t *testing.T) {
+	g := gomega.NewWithT(t)
+	nad := &nadv1.NetworkAttachmentDefinition{
+		ObjectMeta: NewObjectMeta("nad", "ns"),
+	}
+	client := nadfake.NewSimpleClientset()
+	_, err := client.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).Create(context.TODO(), nad, metav1.CreateOptions{})
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	getNAD := func() *nadv1.NetworkAttachmentDefinition {
+		nad, err := client.K8sCniCncfIoV1().NetworkAttachmentDefinitions(nad.Namespace).Get(context.TODO(), nad.Name, metav1.GetOptions{})
+		g.Expect(err).NotTo(gomega.HaveOccurred())
+		return nad
+	}
+
+	condition := metav1.Condition{
+		Type:    NetworkConditionAccepted,
+		Status:  metav1.ConditionFalse,
+		Reason:  NetworkReasonInvalidConfig,
+		Message: "invalid",
+	}
+	updated, err := SetNADCondition(client, getNAD(), OvnNetworkAcceptedAnnotation, condition)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(updated).To(gomega.BeTrue())
+	setCondition, err := GetNADCondition(getNAD(), OvnNetworkAcceptedAnnotation)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(setCondition.Reason).To(gomega.Equal(NetworkReasonInvalidConfig))
+
+	// setting the same condition is a no-op
+	updated, err = SetNADCondition(client, getNAD(), OvnNetworkAcceptedAnnotation, condition)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(updated).To(gomega.BeFalse())
+
+	g.Expect(SetNADZoneStatus(client, getNAD(), "zone1", "network topology created")).To(gomega.Succeed())
+	g.Expect(GetNADZoneStatusMessages(getNAD())).To(gomega.Equal([]string{"zone1: network topology created"}))
+
+	g.Expect(RemoveNADAnnotation(client, getNAD(), GetNetworkZoneStatusAnnotation("zone1"))).To(gomega.Succeed())
+	g.Expect(GetNADZoneStatusMessages(getNAD())).To(gomega.BeEmpty())
+	setCondition, err = GetNADCondition(getNAD(), OvnNetworkAcceptedAnnotation)
+	g.Expect(err).NotTo(gomega.HaveOccurred())
+	g.Expect(setCondition).NotTo(gomega.BeNil())
+}
@@ -1,11 +1,14 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
 	"net"
 	"strings"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 )
@@ -21,8 +24,49 @@ const (
 	ExternalGatewayPodIPsAnnotation = "k8s.ovn.org/external-gw-pod-ips"
 	// Annotation for enabling ACL logging to controller's log file
 	AclLoggingAnnotation = "k8s.ovn.org/acl-logging"
+	// Annotation for enabling flow sampling of the traffic of the namespace
+	FlowSamplingAnnotation = "k8s.ovn.org/flow-sampling"
+
+	// MaxFlowSamplingProbability is the probability with which every flow is sampled
+	MaxFlowSamplingProbability = 65535
 )
 
+// FlowSampling is the flow sampling setting of a namespace
+type FlowSampling struct {
+	// Probability with which the flows are sampled, out of MaxFlowSamplingProbability
+	Probability int `json:"probability"`
+	// PodSelector selects the pods whose logical ports are sampled, all the pods of the namespace if nil
+	PodSelector *metav1.LabelSelector `json:"podSelector,omitempty"`
+}
+
+// GetPodSelector returns the selector of the pods whose logical ports are sampled
+func (flowSampling *FlowSampling) GetPodSelector() (labels.Selector, error) {
+	if flowSampling.PodSelector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(flowSampling.PodSelector)
+}
+
+// ParseFlowSamplingAnnotation returns the flow sampling setting of a namespace, nil if flow sampling is disabled
+func ParseFlowSamplingAnnotation(annotations map[string]string) (*FlowSampling, error) {
+	annotation, ok := annotations[FlowSamplingAnnotation]
+	if !ok || annotation == "" {
+		return nil, nil
+	}
+	flowSampling := &FlowSampling{}
+	if err := json.Unmarshal([]byte(annotation), flowSampling); err != nil {
+		return nil, fmt.Errorf("could not parse flow sampling annotation %q: %v", annotation, err)
+	}
+	if flowSampling.Probability <= 0 || flowSampling.Probability > MaxFlowSamplingProbability {
+		return nil, fmt.Errorf("invalid flow sampling probability %d, expected a value between 1 and %d",
+			flowSampling.Probability, MaxFlowSamplingProbability)
+	}
+	if _, err := flowSampling.GetPodSelector(); err != nil {
+		return nil, fmt.Errorf("invalid flow sampling pod selector: %v", err)
+	}
+	return flowSampling, nil
+}
+
 func UpdateExternalGatewayPodIPsAnnotation(k kube.Interface, namespace string, exgwIPs []string) error {
 	exgwPodAnnotation := strings.Join(exgwIPs, ",")
 	err := k.SetAnnotationsOnNamespace(namespace, map[string]interface{}{ExternalGatewayPodIPsAnnotation: exgwPodAnnotation})
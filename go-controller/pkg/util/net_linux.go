@@ -47,6 +47,10 @@ type NetLinkOps interface {
 	NeighDel(neigh *netlink.Neigh) error
 	NeighList(linkIndex, family int) ([]netlink.Neigh, error)
 	ConntrackDeleteFilter(table netlink.ConntrackTableType, family netlink.InetFamily, filter netlink.CustomConntrackFilter) (uint, error)
+	DevLinkGetAllPortList() ([]*netlink.DevlinkPort, error)
+	DevLinkPortAdd(bus string, device string, flavour uint16, attrs netlink.DevLinkPortAddAttrs) (*netlink.DevlinkPort, error)
+	DevLinkPortDel(bus string, device string, portIndex uint32) error
+	DevlinkPortFnSet(bus string, device string, portIndex uint32, fnAttrs netlink.DevlinkPortFnSetAttrs) error
 }
 
 type defaultNetLinkOps struct {
@@ -169,6 +173,22 @@ func (defaultNetLinkOps) ConntrackDeleteFilter(table netlink.ConntrackTableType,
 	return netlink.ConntrackDeleteFilter(table, family, filter)
 }
 
+func (defaultNetLinkOps) DevLinkGetAllPortList() ([]*netlink.DevlinkPort, error) {
+	return netlink.DevLinkGetAllPortList()
+}
+
+func (defaultNetLinkOps) DevLinkPortAdd(bus string, device string, flavour uint16, attrs netlink.DevLinkPortAddAttrs) (*netlink.DevlinkPort, error) {
+	return netlink.DevLinkPortAdd(bus, device, flavour, attrs)
+}
+
+func (defaultNetLinkOps) DevLinkPortDel(bus string, device string, portIndex uint32) error {
+	return netlink.DevLinkPortDel(bus, device, portIndex)
+}
+
+func (defaultNetLinkOps) DevlinkPortFnSet(bus string, device string, portIndex uint32, fnAttrs netlink.DevlinkPortFnSetAttrs) error {
+	return netlink.DevlinkPortFnSet(bus, device, portIndex, fnAttrs)
+}
+
 func getFamily(ip net.IP) int {
 	if utilnet.IsIPv6(ip) {
 		return netlink.FAMILY_V6
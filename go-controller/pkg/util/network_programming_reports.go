@@ -0,0 +1,80 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// NetworkProgrammingReportsLabel labels the ConfigMaps holding the network programming durations measured by the
+	// ovnkube-controller of a zone, for ovnkube-cluster-manager to aggregate them across zones. There is one
+	// ConfigMap per zone in the ovn-kubernetes namespace, written by the ovnkube-controller of the zone.
+	NetworkProgrammingReportsLabel = "k8s.ovn.org/network-programming-reports"
+	// networkProgrammingReportsKey is the key of the reports in the data of the ConfigMap
+	networkProgrammingReportsKey = "reports"
+	// networkProgrammingReportsPrefix is the prefix of the name of the ConfigMap of a zone
+	networkProgrammingReportsPrefix = "ovn-network-programming-reports-"
+)
+
+// NetworkProgrammingReports are the last network programming durations measured by the ovnkube-controller of a zone
+type NetworkProgrammingReports struct {
+	Zone string `json:"zone"`
+	// Epoch identifies the ovnkube-controller instance of the zone, sequence numbers restart with every instance
+	Epoch   int64                      `json:"epoch"`
+	Reports []NetworkProgrammingReport `json:"reports"`
+}
+
+// NetworkProgrammingReport is the duration a zone took to apply a change of a Kubernetes object, from the change
+// received by ovnkube-controller to the flows installed on all the nodes of the zone
+type NetworkProgrammingReport struct {
+	Seq       uint64    `json:"seq"`
+	Kind      string    `json:"kind"`
+	Namespace string    `json:"namespace,omitempty"`
+	Name      string    `json:"name"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+// GetNetworkProgrammingReportsConfigMapName returns the name of the ConfigMap holding the reports of the zone
+func GetNetworkProgrammingReportsConfigMapName(zone string) string {
+	return networkProgrammingReportsPrefix + zone
+}
+
+// NewNetworkProgrammingReportsConfigMap returns the ConfigMap holding the reports of their zone in the namespace
+func NewNetworkProgrammingReportsConfigMap(namespace string, reports *NetworkProgrammingReports) (*corev1.ConfigMap, error) {
+	data, err := json.Marshal(reports)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal the network programming reports of zone %s: %v", reports.Zone, err)
+	}
+	return &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GetNetworkProgrammingReportsConfigMapName(reports.Zone),
+			Namespace: namespace,
+			Labels:    map[string]string{NetworkProgrammingReportsLabel: ""},
+		},
+		Data: map[string]string{networkProgrammingReportsKey: string(data)},
+	}, nil
+}
+
+// ParseNetworkProgrammingReports returns the network programming reports held by the ConfigMap, nil if none
+func ParseNetworkProgrammingReports(configMap *corev1.ConfigMap) (*NetworkProgrammingReports, error) {
+	data, ok := configMap.Data[networkProgrammingReportsKey]
+	if !ok {
+		return nil, nil
+	}
+	reports := &NetworkProgrammingReports{}
+	if err := json.Unmarshal([]byte(data), reports); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the network programming reports %s of ConfigMap %s/%s: %v",
+			data, configMap.Namespace, configMap.Name, err)
+	}
+	return reports, nil
+}
+
+// NetworkProgrammingReportsChanged returns true if the network programming reports held by the ConfigMaps differ
+func NetworkProgrammingReportsChanged(oldConfigMap, newConfigMap *corev1.ConfigMap) bool {
+	return oldConfigMap.Data[networkProgrammingReportsKey] != newConfigMap.Data[networkProgrammingReportsKey]
+}
@@ -11,12 +11,23 @@ import (
 
 	"github.com/k8snetworkplumbingwg/sriovnet"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 	"k8s.io/klog/v2"
 )
 
 const (
 	ubuntuDefaultFile = "/etc/default/openvswitch-switch"
 	rhelDefaultFile   = "/etc/default/openvswitch"
+
+	// maxLinkNameLength is the maximum length of the name of a link, IFNAMSIZ - 1
+	maxLinkNameLength = 15
+
+	// bridgeUplinkMembers is the external ID of the bridges created by
+	// NicsToBondBridge listing the NICs of their OVS bond
+	bridgeUplinkMembers = "bridge-uplink-members"
+	// bridgeNMManaged is the external ID of the bridges listing their NICs
+	// that NetworkManager managed before they were moved to the bridge
+	bridgeNMManaged = "nm-managed"
 )
 
 func GetBridgeName(iface string) string {
@@ -93,7 +104,57 @@ func GetNicName(brName string) (string, error) {
 	return stdout, nil
 }
 
-func saveIPAddress(oldLink, newLink netlink.Link, addrs []netlink.Addr) error {
+// nicMigration records how to undo the steps of a migration of the IP
+// configuration between NICs and an OVS bridge, so that a migration failing
+// halfway leaves the host as it found it.
+type nicMigration struct {
+	// cleanups remove what the migration added, they are run in reverse order
+	cleanups []func() error
+	// restores add back what the migration removed, they are run in order so
+	// that addresses are restored before the routes using them
+	restores []func() error
+}
+
+func (m *nicMigration) onCleanup(cleanup func() error) {
+	if m != nil {
+		m.cleanups = append(m.cleanups, cleanup)
+	}
+}
+
+func (m *nicMigration) onRestore(restore func() error) {
+	if m != nil {
+		m.restores = append(m.restores, restore)
+	}
+}
+
+// rollback undoes the steps of the migration done so far. It goes on when an
+// undo operation fails to restore as much as possible.
+func (m *nicMigration) rollback() {
+	for i := len(m.cleanups) - 1; i >= 0; i-- {
+		if err := m.cleanups[i](); err != nil {
+			klog.Errorf("Failed to clean up after a failed migration: %v", err)
+		}
+	}
+	for _, restore := range m.restores {
+		if err := restore(); err != nil {
+			klog.Errorf("Failed to restore the configuration after a failed migration: %v", err)
+		}
+	}
+	m.cleanups = nil
+	m.restores = nil
+}
+
+// addrAddForMigration adds the address of another link to link. IPv6 addresses
+// are added without duplicate address detection: they were already in use on
+// the host and are usable right away instead of being tentative.
+func addrAddForMigration(link netlink.Link, addr netlink.Addr) error {
+	if addr.IP.To4() == nil {
+		addr.Flags |= unix.IFA_F_NODAD
+	}
+	return netLinkOps.AddrAdd(link, &addr)
+}
+
+func saveIPAddress(oldLink, newLink netlink.Link, addrs []netlink.Addr, m *nicMigration) error {
 	for i := range addrs {
 		addr := addrs[i]
 
@@ -103,13 +164,23 @@ func saveIPAddress(oldLink, newLink netlink.Link, addrs []netlink.Addr) error {
 				klog.Errorf("Remove addr from %q failed: %v", oldLink.Attrs().Name, err)
 				return err
 			}
+			oldAddr := addr
+			m.onRestore(func() error {
+				if err := addrAddForMigration(oldLink, oldAddr); err != nil && !os.IsExist(err) {
+					return fmt.Errorf("failed to add back addr %q: %v", oldAddr.String(), err)
+				}
+				return nil
+			})
 
 			// Add to newLink
 			addr.Label = newLink.Attrs().Name
-			if err := netLinkOps.AddrAdd(newLink, &addr); err != nil {
+			if err := addrAddForMigration(newLink, addr); err != nil {
 				klog.Errorf("Add addr %q to newLink %q failed: %v", addr.String(), addr.Label, err)
 				return err
 			}
+			m.onCleanup(func() error {
+				return netLinkOps.AddrDel(newLink, &addr)
+			})
 			klog.Infof("Successfully saved addr %q to newLink %q", addr.String(), addr.Label)
 		}
 	}
@@ -117,39 +188,97 @@ func saveIPAddress(oldLink, newLink netlink.Link, addrs []netlink.Addr) error {
 	return netLinkOps.LinkSetUp(newLink)
 }
 
+// listRoutes returns the routes through link, including the multipath routes
+// with a next hop through link which are not listed when filtering by link.
+func listRoutes(link netlink.Link) ([]netlink.Route, error) {
+	routes, err := netLinkOps.RouteList(link, syscall.AF_UNSPEC)
+	if err != nil {
+		return nil, err
+	}
+	allRoutes, err := netLinkOps.RouteListFiltered(syscall.AF_UNSPEC,
+		&netlink.Route{Table: unix.RT_TABLE_MAIN}, netlink.RT_FILTER_TABLE)
+	if err != nil {
+		return nil, err
+	}
+	for _, route := range allRoutes {
+		for _, nextHop := range route.MultiPath {
+			if nextHop.LinkIndex == link.Attrs().Index {
+				routes = append(routes, route)
+				break
+			}
+		}
+	}
+	return routes, nil
+}
+
+// isDefaultGatewayRoute returns whether route is a default route through one
+// or several gateways
+func isDefaultGatewayRoute(route netlink.Route) bool {
+	return IsAnyNetwork(route.Dst) && (route.Gw != nil && route.LinkIndex > 0 || len(route.MultiPath) > 0)
+}
+
 // delAddRoute removes 'route' from 'oldLink' and moves to 'newLink'
-func delAddRoute(oldLink, newLink netlink.Link, route netlink.Route) error {
+func delAddRoute(oldLink, newLink netlink.Link, route netlink.Route, m *nicMigration) error {
 	// Remove route from old interface
-	if err := netLinkOps.RouteDel(&route); err != nil && !strings.Contains(err.Error(), "no such process") {
-		klog.Errorf("Remove route from %q failed: %v", oldLink.Attrs().Name, err)
-		return err
+	if err := netLinkOps.RouteDel(&route); err != nil {
+		if !strings.Contains(err.Error(), "no such process") {
+			klog.Errorf("Remove route from %q failed: %v", oldLink.Attrs().Name, err)
+			return err
+		}
+	} else {
+		oldRoute := route
+		m.onRestore(func() error {
+			if err := netLinkOps.RouteAdd(&oldRoute); err != nil && !os.IsExist(err) {
+				return fmt.Errorf("failed to add back route %q: %v", oldRoute.String(), err)
+			}
+			return nil
+		})
 	}
 
 	// Add route to newLink
-	route.LinkIndex = newLink.Attrs().Index
-	if err := netLinkOps.RouteAdd(&route); err != nil && !os.IsExist(err) {
-		klog.Errorf("Add route to newLink %q failed: %v", newLink.Attrs().Name, err)
-		return err
+	if len(route.MultiPath) > 0 {
+		oldIndex := oldLink.Attrs().Index
+		nextHops := make([]*netlink.NexthopInfo, 0, len(route.MultiPath))
+		for _, nextHop := range route.MultiPath {
+			nh := *nextHop
+			if nh.LinkIndex == oldIndex {
+				nh.LinkIndex = newLink.Attrs().Index
+			}
+			nextHops = append(nextHops, &nh)
+		}
+		route.MultiPath = nextHops
+	} else {
+		route.LinkIndex = newLink.Attrs().Index
+	}
+	if err := netLinkOps.RouteAdd(&route); err != nil {
+		if !os.IsExist(err) {
+			klog.Errorf("Add route to newLink %q failed: %v", newLink.Attrs().Name, err)
+			return err
+		}
+	} else {
+		m.onCleanup(func() error {
+			return netLinkOps.RouteDel(&route)
+		})
 	}
 
 	klog.Infof("Successfully saved route %q", route.String())
 	return nil
 }
 
-func saveRoute(oldLink, newLink netlink.Link, routes []netlink.Route) error {
+func saveRoute(oldLink, newLink netlink.Link, routes []netlink.Route, m *nicMigration) error {
 	for i := range routes {
 		route := routes[i]
 
 		// Handle routes for default gateway later.  This is a special case for
 		// GCE where we have /32 IP addresses and we can't add the default
 		// gateway before the route to the gateway.
-		if IsAnyNetwork(route.Dst) && route.Gw != nil && route.LinkIndex > 0 {
+		if isDefaultGatewayRoute(route) {
 			continue
 		} else if route.Dst != nil && !route.Dst.IP.IsGlobalUnicast() {
 			continue
 		}
 
-		err := delAddRoute(oldLink, newLink, route)
+		err := delAddRoute(oldLink, newLink, route, m)
 		if err != nil {
 			return err
 		}
@@ -158,9 +287,9 @@ func saveRoute(oldLink, newLink netlink.Link, routes []netlink.Route) error {
 	// Now add the default gateway (if any) via this interface.
 	for i := range routes {
 		route := routes[i]
-		if IsAnyNetwork(route.Dst) && route.Gw != nil && route.LinkIndex > 0 {
+		if isDefaultGatewayRoute(route) {
 			// Remove route from 'oldLink' and move it to 'newLink'
-			err := delAddRoute(oldLink, newLink, route)
+			err := delAddRoute(oldLink, newLink, route, m)
 			if err != nil {
 				return err
 			}
@@ -219,8 +348,87 @@ func setupDefaultFile() {
 	}
 }
 
+// checkBridgeUplink returns an error if link can't be the uplink of an OVS
+// bridge because it is enslaved to a Linux bond, team or bridge
+func checkBridgeUplink(link netlink.Link) error {
+	masterIndex := link.Attrs().MasterIndex
+	if masterIndex == 0 {
+		return nil
+	}
+	master, err := netLinkOps.LinkByIndex(masterIndex)
+	if err != nil {
+		return fmt.Errorf("failed to get the master of interface %q: %v", link.Attrs().Name, err)
+	}
+	// The ports of OVS bridges, e.g. of a bridge created before, are enslaved
+	// to the OVS datapath
+	if master.Type() == "openvswitch" {
+		return nil
+	}
+	return fmt.Errorf("interface %q is enslaved to the %s %q, use the %s as uplink instead",
+		link.Attrs().Name, master.Type(), master.Attrs().Name, master.Type())
+}
+
+// unmanageNics stops NetworkManager from managing the NICs it manages, so that
+// it does not reconfigure them once they are ports of an OVS bridge, and returns
+// their names. Nothing is done if NetworkManager is not installed.
+func unmanageNics(links []netlink.Link, m *nicMigration) ([]string, error) {
+	if !IsNmcliAvailable() {
+		return nil, nil
+	}
+
+	var managed []string
+	for _, link := range links {
+		nic := link.Attrs().Name
+		stdout, stderr, err := RunNmcli("-g", "GENERAL.NM-MANAGED", "device", "show", nic)
+		if err != nil {
+			// NetworkManager may be installed but not running
+			klog.Warningf("Failed to get whether NetworkManager manages %q, stderr: %q, error: %v", nic, stderr, err)
+			continue
+		}
+		if stdout != "yes" {
+			continue
+		}
+		if _, stderr, err = RunNmcli("device", "set", nic, "managed", "no"); err != nil {
+			return nil, fmt.Errorf("failed to stop NetworkManager from managing %q, stderr: %q, error: %v",
+				nic, stderr, err)
+		}
+		m.onCleanup(func() error {
+			return manageNic(nic)
+		})
+		klog.Infof("NetworkManager no longer manages %q", nic)
+		managed = append(managed, nic)
+	}
+	return managed, nil
+}
+
+// manageNic lets NetworkManager manage the NIC again
+func manageNic(nic string) error {
+	if _, stderr, err := RunNmcli("device", "set", nic, "managed", "yes"); err != nil {
+		return fmt.Errorf("failed to let NetworkManager manage %q, stderr: %q, error: %v", nic, stderr, err)
+	}
+	return nil
+}
+
+// getBridgeExternalIDs returns the external IDs of the OVS bridge
+func getBridgeExternalIDs(bridge string) (map[string]string, error) {
+	stdout, stderr, err := RunOVSVsctl("br-get-external-id", bridge)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the external IDs of the bridge %q, stderr: %q, error: %v",
+			bridge, stderr, err)
+	}
+	externalIDs := make(map[string]string)
+	for _, line := range strings.Split(stdout, "\n") {
+		key, value, found := strings.Cut(strings.TrimSpace(line), "=")
+		if found {
+			externalIDs[key] = strings.Trim(value, "\"")
+		}
+	}
+	return externalIDs, nil
+}
+
 // NicToBridge creates a OVS bridge for the 'iface' and also moves the IP
-// address and routes of 'iface' to OVS bridge.
+// address and routes of 'iface' to OVS bridge. 'iface' can be a NIC, a Linux
+// bond or a VLAN sub-interface. The changes are rolled back if a step fails.
 func NicToBridge(iface string) (string, error) {
 	ifaceLink, err := netLinkOps.LinkByName(iface)
 	if err != nil {
@@ -228,53 +436,173 @@ func NicToBridge(iface string) (string, error) {
 	}
 
 	bridge := GetBridgeName(iface)
-	stdout, stderr, err := RunOVSVsctl(
-		"--", "--may-exist", "add-br", bridge,
-		"--", "br-set-external-id", bridge, "bridge-id", bridge,
-		"--", "br-set-external-id", bridge, "bridge-uplink", iface,
-		"--", "set", "bridge", bridge, "fail-mode=standalone",
-		fmt.Sprintf("other_config:hwaddr=%s", ifaceLink.Attrs().HardwareAddr),
-		"--", "--may-exist", "add-port", bridge, iface,
-		"--", "set", "port", iface, "other-config:transient=true")
-	if err != nil {
-		klog.Errorf("Failed to create OVS bridge, stdout: %q, stderr: %q, error: %v", stdout, stderr, err)
+	if err = nicsToBridge(bridge, iface, []netlink.Link{ifaceLink}, ""); err != nil {
 		return "", err
 	}
-	klog.Infof("Successfully created OVS bridge %q", bridge)
+	return bridge, nil
+}
 
-	setupDefaultFile()
+// NicsToBondBridge creates a OVS bridge for an OVS bond named 'bond' of the
+// 'ifaces' NICs and moves the IP addresses and routes of the NICs to the OVS
+// bridge. 'bondMode' is the bond_mode of the bond, the default of OVS if empty.
+// The changes are rolled back if a step fails.
+func NicsToBondBridge(bond string, ifaces []string, bondMode string) (string, error) {
+	if len(ifaces) < 2 {
+		return "", fmt.Errorf("an OVS bond needs at least two interfaces, got %v", ifaces)
+	}
+	links := make([]netlink.Link, 0, len(ifaces))
+	for _, iface := range ifaces {
+		link, err := netLinkOps.LinkByName(iface)
+		if err != nil {
+			return "", err
+		}
+		links = append(links, link)
+	}
+
+	bridge := GetBridgeName(bond)
+	if err := nicsToBridge(bridge, bond, links, bondMode); err != nil {
+		return "", err
+	}
+	return bridge, nil
+}
+
+// nicsToBridge moves the links to the OVS bridge with the 'uplink' port, and
+// rolls the migration back if it fails
+func nicsToBridge(bridge, uplink string, links []netlink.Link, bondMode string) error {
+	if len(bridge) > maxLinkNameLength {
+		return fmt.Errorf("the name of the bridge %q for %q is longer than %d characters",
+			bridge, uplink, maxLinkNameLength)
+	}
+	for _, link := range links {
+		if err := checkBridgeUplink(link); err != nil {
+			return err
+		}
+	}
+
+	m := &nicMigration{}
+	if err := migrateNicsToBridge(bridge, uplink, links, bondMode, m); err != nil {
+		klog.Errorf("Failed to move %q to OVS bridge %q, rolling back: %v", uplink, bridge, err)
+		m.rollback()
+		return err
+	}
+	return nil
+}
+
+func migrateNicsToBridge(bridge, uplink string, links []netlink.Link, bondMode string, m *nicMigration) error {
+	// The bridge is only deleted on failure if it is created here
+	_, err := netLinkOps.LinkByName(bridge)
+	if err != nil && !netLinkOps.IsLinkNotFoundError(err) {
+		return err
+	}
+	bridgeExists := err == nil
+	// The uplink port is only deleted on failure if it is added here
+	uplinkExists := false
+	if bridgeExists {
+		stdout, stderr, err := RunOVSVsctl("list-ports", bridge)
+		if err != nil {
+			klog.Errorf("Failed to get ports of OVS bridge %q, stderr: %q, error: %v", bridge, stderr, err)
+			return err
+		}
+		for _, port := range strings.Split(stdout, "\n") {
+			if strings.TrimSpace(port) == uplink {
+				uplinkExists = true
+				break
+			}
+		}
+	}
 
 	// Get ip addresses and routes before any real operations.
 	family := syscall.AF_UNSPEC
-	addrs, err := netLinkOps.AddrList(ifaceLink, family)
+	addrs := make([][]netlink.Addr, len(links))
+	routes := make([][]netlink.Route, len(links))
+	nics := make([]string, 0, len(links))
+	for i, link := range links {
+		if addrs[i], err = netLinkOps.AddrList(link, family); err != nil {
+			return err
+		}
+		if routes[i], err = listRoutes(link); err != nil {
+			return err
+		}
+		nics = append(nics, link.Attrs().Name)
+	}
+
+	nmManaged, err := unmanageNics(links, m)
 	if err != nil {
-		return "", err
+		return err
 	}
-	routes, err := netLinkOps.RouteList(ifaceLink, family)
+
+	args := []string{
+		"--", "--may-exist", "add-br", bridge,
+		"--", "br-set-external-id", bridge, "bridge-id", bridge,
+		"--", "br-set-external-id", bridge, "bridge-uplink", uplink,
+		"--", "set", "bridge", bridge, "fail-mode=standalone",
+		fmt.Sprintf("other_config:hwaddr=%s", links[0].Attrs().HardwareAddr),
+	}
+	if len(links) == 1 {
+		args = append(args, "--", "--may-exist", "add-port", bridge, uplink)
+	} else {
+		args = append(args, "--", "--may-exist", "add-bond", bridge, uplink)
+		args = append(args, nics...)
+		if bondMode != "" {
+			args = append(args, "bond_mode="+bondMode)
+		}
+		args = append(args, "--", "br-set-external-id", bridge, bridgeUplinkMembers, strings.Join(nics, ","))
+	}
+	args = append(args, "--", "set", "port", uplink, "other-config:transient=true")
+	if len(nmManaged) > 0 {
+		args = append(args, "--", "br-set-external-id", bridge, bridgeNMManaged, strings.Join(nmManaged, ","))
+	}
+	stdout, stderr, err := RunOVSVsctl(args...)
 	if err != nil {
-		return "", err
+		klog.Errorf("Failed to create OVS bridge, stdout: %q, stderr: %q, error: %v", stdout, stderr, err)
+		return err
 	}
+	if !bridgeExists {
+		m.onCleanup(func() error {
+			if _, stderr, err := RunOVSVsctl("--if-exists", "del-br", bridge); err != nil {
+				return fmt.Errorf("failed to delete OVS bridge %q, stderr: %q, error: %v", bridge, stderr, err)
+			}
+			return nil
+		})
+	} else if !uplinkExists {
+		m.onCleanup(func() error {
+			if _, stderr, err := RunOVSVsctl("--if-exists", "del-port", bridge, uplink); err != nil {
+				return fmt.Errorf("failed to delete port %q of OVS bridge %q, stderr: %q, error: %v",
+					uplink, bridge, stderr, err)
+			}
+			return nil
+		})
+	}
+	klog.Infof("Successfully created OVS bridge %q", bridge)
+
+	setupDefaultFile()
 
 	bridgeLink, err := netLinkOps.LinkByName(bridge)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	// save ip addresses to bridge.
-	if err = saveIPAddress(ifaceLink, bridgeLink, addrs); err != nil {
-		return "", err
+	for i, link := range links {
+		if err = saveIPAddress(link, bridgeLink, addrs[i], m); err != nil {
+			return err
+		}
 	}
 
 	// save routes to bridge.
-	if err = saveRoute(ifaceLink, bridgeLink, routes); err != nil {
-		return "", err
+	for i, link := range links {
+		if err = saveRoute(link, bridgeLink, routes[i], m); err != nil {
+			return err
+		}
 	}
 
-	return bridge, nil
+	return nil
 }
 
 // BridgeToNic moves the IP address and routes of internal port of the bridge to
-// underlying NIC interface and deletes the OVS bridge.
+// underlying NIC interface and deletes the OVS bridge. They are moved to the
+// first NIC of the bond of a bridge created by NicsToBondBridge. The changes
+// are rolled back if moving them or deleting the bridge fails.
 func BridgeToNic(bridge string) error {
 	// Internal port is named same as the bridge
 	bridgeLink, err := netLinkOps.LinkByName(bridge)
@@ -288,7 +616,7 @@ func BridgeToNic(bridge string) error {
 	if err != nil {
 		return err
 	}
-	routes, err := netLinkOps.RouteList(bridgeLink, family)
+	routes, err := listRoutes(bridgeLink)
 	if err != nil {
 		return err
 	}
@@ -297,29 +625,57 @@ func BridgeToNic(bridge string) error {
 	if err != nil {
 		return err
 	}
+	externalIDs, err := getBridgeExternalIDs(bridge)
+	if err != nil {
+		return err
+	}
+	if members := externalIDs[bridgeUplinkMembers]; members != "" {
+		nicName = strings.Split(members, ",")[0]
+	}
 	ifaceLink, err := netLinkOps.LinkByName(nicName)
 	if err != nil {
 		return err
 	}
 
+	m := &nicMigration{}
+	if err = bridgeToNic(bridge, bridgeLink, ifaceLink, addrs, routes, m); err != nil {
+		klog.Errorf("Failed to move OVS bridge %q back to %q, rolling back: %v", bridge, nicName, err)
+		m.rollback()
+		return err
+	}
+
+	if nics := externalIDs[bridgeNMManaged]; nics != "" && IsNmcliAvailable() {
+		for _, nic := range strings.Split(nics, ",") {
+			if err = manageNic(nic); err != nil {
+				klog.Warningf("Failed to hand %q back to NetworkManager: %v", nic, err)
+			}
+		}
+	}
+	return nil
+}
+
+func bridgeToNic(bridge string, bridgeLink, ifaceLink netlink.Link, addrs []netlink.Addr, routes []netlink.Route,
+	m *nicMigration) error {
 	// save ip addresses to iface.
-	if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil {
+	if err := saveIPAddress(bridgeLink, ifaceLink, addrs, m); err != nil {
 		return err
 	}
 
 	// save routes to iface.
-	if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil {
+	if err := saveRoute(bridgeLink, ifaceLink, routes, m); err != nil {
 		return err
 	}
 
 	// for every bridge interface that is of type "patch", find the peer
-	// interface and delete that interface from the integration bridge
+	// interface on the integration bridge. The peers are only deleted once
+	// the bridge is gone so that a rollback finds them in place.
 	stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)
 	if err != nil {
 		klog.Errorf("Failed to get interfaces for OVS bridge: %q, "+
 			"stderr: %q, error: %v", bridge, stderr, err)
 		return err
 	}
+	var peers []string
 	ifacesList := strings.Split(strings.TrimSpace(stdout), "\n")
 	for _, iface := range ifacesList {
 		stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")
@@ -336,13 +692,7 @@ func BridgeToNic(bridge string) error {
 				"stderr: %q, error: %v", iface, stderr, err)
 			continue
 		}
-		// stdout has the peer interface, just delete it
-		peer := strings.TrimSpace(stdout)
-		_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)
-		if err != nil {
-			klog.Warningf("Failed to delete patch port %q on br-int, "+
-				"stderr: %q, error: %v", peer, stderr, err)
-		}
+		peers = append(peers, strings.TrimSpace(stdout))
 	}
 
 	// Now delete the bridge
@@ -352,6 +702,16 @@ func BridgeToNic(bridge string) error {
 		return err
 	}
 	klog.Infof("Successfully deleted OVS bridge %q", bridge)
+
+	// the patch ports left on the integration bridge lead nowhere, failing to
+	// delete them does not undo the migration
+	for _, peer := range peers {
+		_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)
+		if err != nil {
+			klog.Warningf("Failed to delete patch port %q on br-int, "+
+				"stderr: %q, error: %v", peer, stderr, err)
+		}
+	}
 	return nil
 }
 
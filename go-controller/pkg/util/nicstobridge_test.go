@@ -2,12 +2,16 @@ package util
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
+	"strings"
+	"syscall"
 	"testing"
 
 	ovntest "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing"
 	netlink_mocks "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/github.com/vishvananda/netlink"
 	"github.com/vishvananda/netlink"
+	"golang.org/x/sys/unix"
 
 	mock_k8s_io_utils_exec "github.com/ovn-org/ovn-kubernetes/go-controller/pkg/testing/mocks/k8s.io/utils/exec"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/util/mocks"
@@ -231,7 +235,7 @@ func TestSaveIPAddress(t *testing.T) {
 			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.onRetArgsNetLinkLibOpers)
 			ovntest.ProcessMockFnList(&mockLink.Mock, tc.onRetArgsLinkIfaceOpers)
 
-			err := saveIPAddress(tc.inpNewLink, tc.inpOldLink, tc.inpAddrs)
+			err := saveIPAddress(tc.inpNewLink, tc.inpOldLink, tc.inpAddrs, nil)
 			t.Log(err)
 			if tc.errExp {
 				assert.Error(t, err)
@@ -306,7 +310,7 @@ func TestDelAddRoute(t *testing.T) {
 			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.onRetArgsNetLinkLibOpers)
 			ovntest.ProcessMockFnList(&mockLink.Mock, tc.onRetArgsLinkIfaceOpers)
 
-			err := delAddRoute(tc.inpOldLink, tc.inpNewLink, tc.inpRoute)
+			err := delAddRoute(tc.inpOldLink, tc.inpNewLink, tc.inpRoute, nil)
 			t.Log(err)
 			if tc.errExp {
 				assert.Error(t, err)
@@ -390,7 +394,7 @@ func TestSaveRoute(t *testing.T) {
 			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.onRetArgsNetLinkLibOpers)
 			ovntest.ProcessMockFnList(&mockLink.Mock, tc.onRetArgsLinkIfaceOpers)
 
-			err := saveRoute(tc.inpOldLink, tc.inpNewLink, tc.inpRoutes)
+			err := saveRoute(tc.inpOldLink, tc.inpNewLink, tc.inpRoutes, nil)
 			t.Log(err)
 			if tc.errExp {
 				assert.Error(t, err)
@@ -403,767 +407,786 @@ func TestSaveRoute(t *testing.T) {
 	}
 }
 
+var errFakeLinkNotFound = errors.New("link not found")
+
+// fakeNicNetLinkOps is a netlink fake holding the links, addresses and routes
+// of a host for the NIC migration tests. Calling the methods it does not
+// implement panics.
+type fakeNicNetLinkOps struct {
+	NetLinkOps
+	links  []netlink.Link
+	addrs  map[string][]netlink.Addr
+	routes []netlink.Route
+	// errs are the errors of the calls of a method on a link, keyed by the
+	// method and the link name, e.g. "AddrAdd breth0"
+	errs map[string]error
+}
+
+func (f *fakeNicNetLinkOps) linkName(index int) string {
+	for _, link := range f.links {
+		if link.Attrs().Index == index {
+			return link.Attrs().Name
+		}
+	}
+	return ""
+}
+
+func (f *fakeNicNetLinkOps) LinkByName(name string) (netlink.Link, error) {
+	for _, link := range f.links {
+		if link.Attrs().Name == name {
+			return link, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w", name, errFakeLinkNotFound)
+}
+
+func (f *fakeNicNetLinkOps) LinkByIndex(index int) (netlink.Link, error) {
+	return f.LinkByName(f.linkName(index))
+}
+
+func (f *fakeNicNetLinkOps) IsLinkNotFoundError(err error) bool {
+	return errors.Is(err, errFakeLinkNotFound)
+}
+
+func (f *fakeNicNetLinkOps) LinkSetUp(link netlink.Link) error {
+	return f.errs["LinkSetUp "+link.Attrs().Name]
+}
+
+func (f *fakeNicNetLinkOps) AddrList(link netlink.Link, _ int) ([]netlink.Addr, error) {
+	if err := f.errs["AddrList "+link.Attrs().Name]; err != nil {
+		return nil, err
+	}
+	return append([]netlink.Addr{}, f.addrs[link.Attrs().Name]...), nil
+}
+
+func (f *fakeNicNetLinkOps) AddrAdd(link netlink.Link, addr *netlink.Addr) error {
+	name := link.Attrs().Name
+	if err := f.errs["AddrAdd "+name]; err != nil {
+		return err
+	}
+	for _, a := range f.addrs[name] {
+		if a.IPNet.String() == addr.IPNet.String() {
+			return syscall.EEXIST
+		}
+	}
+	f.addrs[name] = append(f.addrs[name], *addr)
+	return nil
+}
+
+func (f *fakeNicNetLinkOps) AddrDel(link netlink.Link, addr *netlink.Addr) error {
+	name := link.Attrs().Name
+	if err := f.errs["AddrDel "+name]; err != nil {
+		return err
+	}
+	for i, a := range f.addrs[name] {
+		if a.IPNet.String() == addr.IPNet.String() {
+			f.addrs[name] = append(f.addrs[name][:i], f.addrs[name][i+1:]...)
+			return nil
+		}
+	}
+	return syscall.EADDRNOTAVAIL
+}
+
+func (f *fakeNicNetLinkOps) RouteList(link netlink.Link, _ int) ([]netlink.Route, error) {
+	if err := f.errs["RouteList "+link.Attrs().Name]; err != nil {
+		return nil, err
+	}
+	var routes []netlink.Route
+	for _, route := range f.routes {
+		if route.LinkIndex == link.Attrs().Index {
+			routes = append(routes, route)
+		}
+	}
+	return routes, nil
+}
+
+func (f *fakeNicNetLinkOps) RouteListFiltered(_ int, _ *netlink.Route, _ uint64) ([]netlink.Route, error) {
+	return append([]netlink.Route{}, f.routes...), nil
+}
+
+// routeLinkName returns the name of the link of the route, or of its first
+// next hop for a multipath route
+func (f *fakeNicNetLinkOps) routeLinkName(route *netlink.Route) string {
+	if len(route.MultiPath) > 0 {
+		return f.linkName(route.MultiPath[0].LinkIndex)
+	}
+	return f.linkName(route.LinkIndex)
+}
+
+func fakeRouteKey(route netlink.Route) string {
+	return fmt.Sprintf("dst %s gw %s dev %d nexthops %v", route.Dst, route.Gw, route.LinkIndex, route.MultiPath)
+}
+
+func (f *fakeNicNetLinkOps) RouteAdd(route *netlink.Route) error {
+	if err := f.errs["RouteAdd "+f.routeLinkName(route)]; err != nil {
+		return err
+	}
+	for _, r := range f.routes {
+		if fakeRouteKey(r) == fakeRouteKey(*route) {
+			return syscall.EEXIST
+		}
+	}
+	f.routes = append(f.routes, *route)
+	return nil
+}
+
+func (f *fakeNicNetLinkOps) RouteDel(route *netlink.Route) error {
+	if err := f.errs["RouteDel "+f.routeLinkName(route)]; err != nil {
+		return err
+	}
+	for i, r := range f.routes {
+		if fakeRouteKey(r) == fakeRouteKey(*route) {
+			f.routes = append(f.routes[:i], f.routes[i+1:]...)
+			return nil
+		}
+	}
+	return syscall.ESRCH
+}
+
+// addrsByLink returns the addresses of the fake host by link name
+func (f *fakeNicNetLinkOps) addrsByLink() map[string][]string {
+	addrs := make(map[string][]string)
+	for name, linkAddrs := range f.addrs {
+		for _, addr := range linkAddrs {
+			addrs[name] = append(addrs[name], addr.IPNet.String())
+		}
+	}
+	return addrs
+}
+
+func fakeRouteKeys(routes []netlink.Route) []string {
+	keys := make([]string, 0, len(routes))
+	for _, route := range routes {
+		keys = append(keys, fakeRouteKey(route))
+	}
+	return keys
+}
+
+func newFakeNicNetLinkOps(links []netlink.Link, addrs map[string][]string, routes []netlink.Route,
+	errs map[string]error) *fakeNicNetLinkOps {
+	f := &fakeNicNetLinkOps{
+		links:  append([]netlink.Link{}, links...),
+		addrs:  make(map[string][]netlink.Addr),
+		routes: append([]netlink.Route{}, routes...),
+		errs:   errs,
+	}
+	for name, cidrs := range addrs {
+		for _, cidr := range cidrs {
+			f.addrs[name] = append(f.addrs[name], netlink.Addr{IPNet: ovntest.MustParseIPNet(cidr)})
+		}
+	}
+	return f
+}
+
+const (
+	fakeNicMAC      = "0a:58:0a:f4:00:02"
+	fakeBridgeIndex = 100
+)
+
+var (
+	fakeOVSSystem = &netlink.GenericLink{LinkAttrs: netlink.LinkAttrs{Name: "ovs-system", Index: 1}, LinkType: "openvswitch"}
+	fakeEth0      = &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0", Index: 2, HardwareAddr: ovntest.MustParseMAC(fakeNicMAC)}}
+	fakeEth1      = &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth1", Index: 3, HardwareAddr: ovntest.MustParseMAC(fakeNicMAC)}}
+	fakeEth2      = &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth2", Index: 4, HardwareAddr: ovntest.MustParseMAC(fakeNicMAC)}}
+)
+
+// fakeNicRoutes returns IPv4 and IPv6 routes through the link with the index,
+// and a multipath route with a next hop through it and another through eth1
+func fakeNicRoutes(index int) []netlink.Route {
+	return []netlink.Route{
+		{Dst: ovntest.MustParseIPNet("192.168.1.0/24"), LinkIndex: index},
+		{Dst: ovntest.MustParseIPNet("0.0.0.0/0"), Gw: ovntest.MustParseIP("192.168.1.1"), LinkIndex: index},
+		{Dst: ovntest.MustParseIPNet("fd00::/64"), LinkIndex: index},
+		{Dst: ovntest.MustParseIPNet("::/0"), Gw: ovntest.MustParseIP("fd00::1"), LinkIndex: index},
+		{Dst: ovntest.MustParseIPNet("10.0.0.0/8"), MultiPath: []*netlink.NexthopInfo{
+			{LinkIndex: index, Gw: ovntest.MustParseIP("192.168.1.2")},
+			{LinkIndex: fakeEth1.Index, Gw: ovntest.MustParseIP("192.168.2.2")},
+		}},
+	}
+}
+
+// fakeLinkLocalRoute is not moved between links
+var fakeLinkLocalRoute = netlink.Route{Dst: ovntest.MustParseIPNet("fe80::/64"), LinkIndex: fakeEth0.Index}
+
+func addBridgeCmd(bridge, uplink string, extraArgs ...string) string {
+	cmd := fmt.Sprintf("ovs-vsctl --timeout=15 -- --may-exist add-br %[1]s -- br-set-external-id %[1]s bridge-id %[1]s "+
+		"-- br-set-external-id %[1]s bridge-uplink %[2]s -- set bridge %[1]s fail-mode=standalone other_config:hwaddr=%[3]s",
+		bridge, uplink, fakeNicMAC)
+	if len(extraArgs) == 0 {
+		extraArgs = []string{fmt.Sprintf("-- --may-exist add-port %s %s -- set port %s other-config:transient=true", bridge, uplink, uplink)}
+	}
+	return cmd + " " + strings.Join(extraArgs, " ")
+}
+
 func TestNicToBridge(t *testing.T) {
-	mockKexecIface := new(mock_k8s_io_utils_exec.Interface)
-	mockExecRunner := new(mocks.ExecRunner)
-	mockCmd := new(mock_k8s_io_utils_exec.Cmd)
-	// below is defined in ovs.go
-	runCmdExecRunner = mockExecRunner
-	// note runner is defined in ovs.go file
-	runner = &execHelper{exec: mockKexecIface}
+	eth0Addrs := map[string][]string{"eth0": {"192.168.1.10/24", "fd00::10/64", "fe80::10/64"}}
+	eth0Routes := append(fakeNicRoutes(fakeEth0.Index), fakeLinkLocalRoute)
+	breth0Addrs := map[string][]string{"eth0": {"fe80::10/64"}, "breth0": {"192.168.1.10/24", "fd00::10/64"}}
+	breth0Routes := append(fakeNicRoutes(fakeBridgeIndex), fakeLinkLocalRoute)
+	vlan := &netlink.Vlan{LinkAttrs: netlink.LinkAttrs{Name: "eth0.100", Index: 5, ParentIndex: fakeEth0.Index,
+		HardwareAddr: ovntest.MustParseMAC(fakeNicMAC)}, VlanId: 100}
+	bond := &netlink.Bond{LinkAttrs: netlink.LinkAttrs{Name: "bond0", Index: 6, HardwareAddr: ovntest.MustParseMAC(fakeNicMAC)}}
+	bondMember := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth3", Index: 7, MasterIndex: bond.Index}}
+	ovsPort := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "eth0", Index: 2, MasterIndex: fakeOVSSystem.Index,
+		HardwareAddr: ovntest.MustParseMAC(fakeNicMAC)}}
 
-	mockNetLinkOps := new(mocks.NetLinkOps)
-	mockLink := new(netlink_mocks.Link)
-	// below is defined in net_linux.go
-	netLinkOps = mockNetLinkOps
 	tests := []struct {
-		desc                     string
-		inpIface                 string
-		outBridge                string
-		errExp                   bool
-		onRetArgsExecUtilsIface  *ovntest.TestifyMockHelper
-		onRetArgsKexecIface      *ovntest.TestifyMockHelper
-		onRetArgsNetLinkLibOpers []ovntest.TestifyMockHelper
-		onRetArgsLinkIfaceOpers  []ovntest.TestifyMockHelper
+		desc      string
+		iface     string
+		bridge    string
+		nmcli     bool
+		links     []netlink.Link
+		addrs     map[string][]string
+		routes    []netlink.Route
+		errs      map[string]error
+		cmds      []ovntest.ExpectedCmd
+		errExp    bool
+		expAddrs  map[string][]string
+		expRoutes []netlink.Route
 	}{
 		{
-			desc:     "invalid interface name fails to return a link",
-			inpIface: "",
-			errExp:   true,
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
-			},
+			desc:   "missing interface fails",
+			iface:  "eth0",
+			errExp: true,
 		},
 		{
-			desc:                    "RunOVSVsctl fails to create OVS bridge",
-			inpIface:                "eth0",
-			errExp:                  true,
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("RunOVSVsctl error")}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:      "the member of a Linux bond is refused",
+			iface:     "eth3",
+			links:     []netlink.Link{bond, bondMember},
+			errExp:    true,
+			expAddrs:  map[string][]string{},
+			expRoutes: []netlink.Route{},
 		},
 		{
-			desc:                    "IP address retrieval for link fails",
-			inpIface:                "eth0",
-			errExp:                  true,
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:      "an interface with a too long bridge name is refused",
+			iface:     "enp0s20f0u1.100",
+			links:     []netlink.Link{&netlink.Vlan{LinkAttrs: netlink.LinkAttrs{Name: "enp0s20f0u1.100", Index: 8}}},
+			errExp:    true,
+			expAddrs:  map[string][]string{},
+			expRoutes: []netlink.Route{},
 		},
 		{
-			desc:                    "Route retrieval for link fails",
-			inpIface:                "eth0",
-			errExp:                  true,
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
+			desc:   "creating the OVS bridge fails",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBridgeCmd("breth0", "eth0"), Err: fmt.Errorf("mock error")},
 			},
+			errExp:    true,
+			expAddrs:  eth0Addrs,
+			expRoutes: eth0Routes,
 		},
 		{
-			desc:                    "Retrieving link by bridge name fails",
-			errExp:                  true,
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Dst: ovntest.MustParseIPNet("10.168.1.0/24")}}, nil}},
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "the IPv4 and IPv6 addresses and routes of a NIC are moved to the bridge",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBridgeCmd("breth0", "eth0")},
+			},
+			expAddrs:  breth0Addrs,
+			expRoutes: breth0Routes,
 		},
 		{
-			desc:                    "Saving IP address to bridge fails",
-			errExp:                  true,
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{}, nil}},
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{fmt.Errorf("mock error")}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
+			desc:   "the port of an existing OVS bridge is accepted",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeOVSSystem, ovsPort, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBridgeCmd("breth0", "eth0")},
+			},
+			expAddrs:  breth0Addrs,
+			expRoutes: breth0Routes,
+		},
+		{
+			desc:   "the addresses and routes of a VLAN sub-interface are moved to the bridge",
+			iface:  "eth0.100",
+			bridge: "breth0.100",
+			links:  []netlink.Link{fakeEth0, fakeEth1, vlan},
+			addrs:  map[string][]string{"eth0.100": {"192.168.1.10/24", "fd00::10/64"}},
+			routes: fakeNicRoutes(vlan.Index),
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBridgeCmd("breth0.100", "eth0.100")},
+			},
+			expAddrs:  map[string][]string{"breth0.100": {"192.168.1.10/24", "fd00::10/64"}},
+			expRoutes: fakeNicRoutes(fakeBridgeIndex),
+		},
+		{
+			desc:   "the addresses and routes of a Linux bond are moved to the bridge",
+			iface:  "bond0",
+			bridge: "brbond0",
+			links:  []netlink.Link{fakeEth1, bond, bondMember},
+			addrs:  map[string][]string{"bond0": {"192.168.1.10/24", "fd00::10/64"}},
+			routes: fakeNicRoutes(bond.Index),
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBridgeCmd("brbond0", "bond0")},
+			},
+			expAddrs:  map[string][]string{"brbond0": {"192.168.1.10/24", "fd00::10/64"}},
+			expRoutes: fakeNicRoutes(fakeBridgeIndex),
+		},
+		{
+			desc:   "moving the routes fails and the migration is rolled back",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			errs:   map[string]error{"RouteAdd breth0": fmt.Errorf("mock error")},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBridgeCmd("breth0", "eth0")},
+				{Cmd: "ovs-vsctl --timeout=15 --if-exists del-br breth0"},
 			},
+			errExp:    true,
+			expAddrs:  eth0Addrs,
+			expRoutes: eth0Routes,
 		},
 		{
-			desc:                    "Saving routes to bridge fails",
-			errExp:                  true,
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{fmt.Errorf("mock error")}},
+			desc:   "a bridge that existed before is not deleted when the migration is rolled back",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "breth0", Index: fakeBridgeIndex}}},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			errs:   map[string]error{"LinkSetUp breth0": fmt.Errorf("mock error")},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "ovs-vsctl --timeout=15 list-ports breth0", Output: "patch-breth0_node1-to-br-int"},
+				{Cmd: addBridgeCmd("breth0", "eth0")},
+				{Cmd: "ovs-vsctl --timeout=15 --if-exists del-port breth0 eth0"},
 			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
+			errExp:    true,
+			expAddrs:  eth0Addrs,
+			expRoutes: eth0Routes,
+		},
+		{
+			desc:   "the uplink port of a bridge that existed before is kept when the migration is rolled back",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "breth0", Index: fakeBridgeIndex}}},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			errs:   map[string]error{"LinkSetUp breth0": fmt.Errorf("mock error")},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "ovs-vsctl --timeout=15 list-ports breth0", Output: "eth0\npatch-breth0_node1-to-br-int"},
+				{Cmd: addBridgeCmd("breth0", "eth0")},
 			},
+			errExp:    true,
+			expAddrs:  eth0Addrs,
+			expRoutes: eth0Routes,
 		},
 		{
-			desc:                    "IP address and Routes of interface to OVS bridge succeeds",
-			onRetArgsExecUtilsIface: &ovntest.TestifyMockHelper{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 31, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			onRetArgsKexecIface:     &ovntest.TestifyMockHelper{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 32, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{}, nil}},
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
+			desc:   "listing the ports of a bridge that existed before fails",
+			iface:  "eth0",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "breth0", Index: fakeBridgeIndex}}},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "ovs-vsctl --timeout=15 list-ports breth0", Err: fmt.Errorf("mock error")},
 			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
+			errExp:    true,
+			expAddrs:  eth0Addrs,
+			expRoutes: eth0Routes,
+		},
+		{
+			desc:   "NetworkManager stops managing the NIC it manages",
+			iface:  "eth0",
+			bridge: "breth0",
+			nmcli:  true,
+			links:  []netlink.Link{fakeEth0, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "nmcli -g GENERAL.NM-MANAGED device show eth0", Output: "yes"},
+				{Cmd: "nmcli device set eth0 managed no"},
+				{Cmd: addBridgeCmd("breth0", "eth0",
+					"-- --may-exist add-port breth0 eth0 -- set port eth0 other-config:transient=true",
+					"-- br-set-external-id breth0 nm-managed eth0")},
+			},
+			expAddrs:  breth0Addrs,
+			expRoutes: breth0Routes,
+		},
+		{
+			desc:   "NetworkManager manages the NIC again when the migration is rolled back",
+			iface:  "eth0",
+			bridge: "breth0",
+			nmcli:  true,
+			links:  []netlink.Link{fakeEth0, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			errs:   map[string]error{"AddrAdd breth0": fmt.Errorf("mock error")},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "nmcli -g GENERAL.NM-MANAGED device show eth0", Output: "yes"},
+				{Cmd: "nmcli device set eth0 managed no"},
+				{Cmd: addBridgeCmd("breth0", "eth0",
+					"-- --may-exist add-port breth0 eth0 -- set port eth0 other-config:transient=true",
+					"-- br-set-external-id breth0 nm-managed eth0")},
+				{Cmd: "ovs-vsctl --timeout=15 --if-exists del-br breth0"},
+				{Cmd: "nmcli device set eth0 managed yes"},
 			},
+			errExp:    true,
+			expAddrs:  eth0Addrs,
+			expRoutes: eth0Routes,
+		},
+		{
+			desc:   "a NIC NetworkManager does not manage is left alone",
+			iface:  "eth0",
+			bridge: "breth0",
+			nmcli:  true,
+			links:  []netlink.Link{fakeEth0, fakeEth1},
+			addrs:  eth0Addrs,
+			routes: eth0Routes,
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "nmcli -g GENERAL.NM-MANAGED device show eth0", Output: "no"},
+				{Cmd: addBridgeCmd("breth0", "eth0")},
+			},
+			expAddrs:  breth0Addrs,
+			expRoutes: breth0Routes,
 		},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
-			if tc.onRetArgsExecUtilsIface != nil {
-				ovntest.ProcessMockFn(&mockExecRunner.Mock, *tc.onRetArgsExecUtilsIface)
+			fakeOps := newFakeNicNetLinkOps(tc.links, tc.addrs, tc.routes, tc.errs)
+			netLinkOps = fakeOps
+			defer ResetNetLinkOpMockInst()
+
+			runCmdExecRunner = &defaultExecRunner{}
+			fexec := ovntest.NewFakeExec()
+			commands := []string{"ovs-vsctl"}
+			if tc.nmcli {
+				commands = append(commands, "nmcli")
 			}
-			if tc.onRetArgsKexecIface != nil {
-				ovntest.ProcessMockFn(&mockKexecIface.Mock, *tc.onRetArgsKexecIface)
+			if err := SetSpecificExec(fexec, commands...); err != nil {
+				t.Fatal(err)
+			}
+			for j := range tc.cmds {
+				cmd := tc.cmds[j]
+				if strings.Contains(cmd.Cmd, " add-br ") && cmd.Err == nil {
+					cmd.Action = func() error {
+						if _, err := fakeOps.LinkByName(tc.bridge); err != nil {
+							fakeOps.links = append(fakeOps.links,
+								&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: tc.bridge, Index: fakeBridgeIndex}})
+						}
+						return nil
+					}
+				}
+				fexec.AddFakeCmd(&cmd)
 			}
-			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.onRetArgsNetLinkLibOpers)
-			ovntest.ProcessMockFnList(&mockLink.Mock, tc.onRetArgsLinkIfaceOpers)
 
-			res, err := NicToBridge(tc.inpIface)
+			res, err := NicToBridge(tc.iface)
 			t.Log(res, err)
 			if tc.errExp {
 				assert.Error(t, err)
 				assert.Equal(t, len(res), 0)
 			} else {
 				assert.Nil(t, err)
-				assert.Greater(t, len(res), 0)
+				assert.Equal(t, tc.bridge, res)
+			}
+			assert.True(t, fexec.CalledMatchesExpected(), fexec.ErrorDesc())
+
+			addrs := fakeOps.addrsByLink()
+			for name := range addrs {
+				assert.ElementsMatch(t, tc.expAddrs[name], addrs[name], "addresses of %s", name)
+			}
+			for name := range tc.expAddrs {
+				assert.ElementsMatch(t, tc.expAddrs[name], addrs[name], "addresses of %s", name)
+			}
+			assert.ElementsMatch(t, fakeRouteKeys(tc.expRoutes), fakeRouteKeys(fakeOps.routes))
+			// the moved IPv6 addresses are usable right away
+			for _, addr := range fakeOps.addrs[tc.bridge] {
+				if addr.IP.To4() == nil {
+					assert.NotZero(t, addr.Flags&unix.IFA_F_NODAD, "flags of %s", addr.IPNet)
+				}
 			}
-			mockKexecIface.AssertExpectations(t)
-			mockExecRunner.AssertExpectations(t)
-			mockNetLinkOps.AssertExpectations(t)
-			mockLink.AssertExpectations(t)
 		})
 	}
 }
 
-func TestBridgeToNic(t *testing.T) {
-	mockKexecIface := new(mock_k8s_io_utils_exec.Interface)
-	mockExecRunner := new(mocks.ExecRunner)
-	mockCmd := new(mock_k8s_io_utils_exec.Cmd)
-	// below is defined in ovs.go
-	runCmdExecRunner = mockExecRunner
-	// note runner is defined in ovs.go file
-	runner = &execHelper{exec: mockKexecIface}
+func TestNicsToBondBridge(t *testing.T) {
+	links := []netlink.Link{fakeEth1, fakeEth2}
+	addrs := map[string][]string{"eth1": {"192.168.1.10/24", "fd00::10/64"}}
+	routes := fakeNicRoutes(fakeEth1.Index)[:4]
+	addBondCmd := addBridgeCmd("brbond0", "bond0",
+		"-- --may-exist add-bond brbond0 bond0 eth1 eth2 bond_mode=active-backup",
+		"-- br-set-external-id brbond0 bridge-uplink-members eth1,eth2",
+		"-- set port bond0 other-config:transient=true")
 
-	mockNetLinkOps := new(mocks.NetLinkOps)
-	mockLink := new(netlink_mocks.Link)
-	// below is defined in net_linux.go
-	netLinkOps = mockNetLinkOps
 	tests := []struct {
-		desc                     string
-		inpBridge                string
-		errExp                   bool
-		onRetArgsExecUtilsIface  []ovntest.TestifyMockHelper
-		onRetArgsKexecIface      []ovntest.TestifyMockHelper
-		onRetArgsNetLinkLibOpers []ovntest.TestifyMockHelper
-		onRetArgsLinkIfaceOpers  []ovntest.TestifyMockHelper
+		desc      string
+		ifaces    []string
+		errs      map[string]error
+		cmds      []ovntest.ExpectedCmd
+		errExp    bool
+		expAddrs  map[string][]string
+		expRoutes []netlink.Route
 	}{
 		{
-			desc:      "invalid bridge name fails to return a link",
-			inpBridge: "brinvalid",
+			desc:      "a bond of a single NIC is refused",
+			ifaces:    []string{"eth1"},
 			errExp:    true,
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
-			},
+			expAddrs:  addrs,
+			expRoutes: routes,
 		},
 		{
-			desc:      "IP address retrieval for link fails",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+			desc:   "the addresses and routes of the NICs are moved to the bridge of the bond",
+			ifaces: []string{"eth1", "eth2"},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBondCmd},
 			},
+			expAddrs:  map[string][]string{"brbond0": {"192.168.1.10/24", "fd00::10/64"}},
+			expRoutes: fakeNicRoutes(fakeBridgeIndex)[:4],
 		},
 		{
-			desc:      "Route retrieval for link fails",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
+			desc:   "moving the addresses fails and the migration is rolled back",
+			ifaces: []string{"eth1", "eth2"},
+			errs:   map[string]error{"AddrAdd brbond0": fmt.Errorf("mock error")},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: addBondCmd},
+				{Cmd: "ovs-vsctl --timeout=15 --if-exists del-br brbond0"},
 			},
-		},
-		{
-			desc:      "Nic Name retrieval using bridge name fails",
-			inpBridge: "breth0",
 			errExp:    true,
-			// Below two entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below entry is for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below entry is for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, nil}},
-			},
+			expAddrs:  addrs,
+			expRoutes: routes,
 		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			fakeOps := newFakeNicNetLinkOps(links, addrs, routes, tc.errs)
+			netLinkOps = fakeOps
+			defer ResetNetLinkOpMockInst()
+
+			runCmdExecRunner = &defaultExecRunner{}
+			fexec := ovntest.NewFakeExec()
+			if err := SetSpecificExec(fexec, "ovs-vsctl"); err != nil {
+				t.Fatal(err)
+			}
+			for j := range tc.cmds {
+				cmd := tc.cmds[j]
+				if strings.Contains(cmd.Cmd, " add-br ") {
+					cmd.Action = func() error {
+						fakeOps.links = append(fakeOps.links,
+							&netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "brbond0", Index: fakeBridgeIndex}})
+						return nil
+					}
+				}
+				fexec.AddFakeCmd(&cmd)
+			}
+
+			res, err := NicsToBondBridge("bond0", tc.ifaces, "active-backup")
+			t.Log(res, err)
+			if tc.errExp {
+				assert.Error(t, err)
+				assert.Equal(t, len(res), 0)
+			} else {
+				assert.Nil(t, err)
+				assert.Equal(t, "brbond0", res)
+			}
+			assert.True(t, fexec.CalledMatchesExpected(), fexec.ErrorDesc())
+			resAddrs := fakeOps.addrsByLink()
+			for name := range tc.expAddrs {
+				assert.ElementsMatch(t, tc.expAddrs[name], resAddrs[name], "addresses of %s", name)
+			}
+			assert.ElementsMatch(t, fakeRouteKeys(tc.expRoutes), fakeRouteKeys(fakeOps.routes))
+		})
+	}
+}
+
+func TestBridgeToNic(t *testing.T) {
+	breth0 := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "breth0", Index: fakeBridgeIndex}}
+	brbond0 := &netlink.Device{LinkAttrs: netlink.LinkAttrs{Name: "brbond0", Index: fakeBridgeIndex}}
+	getNicNameCmds := []ovntest.ExpectedCmd{
+		{Cmd: "ovs-vsctl --timeout=15 list-ports breth0", Output: "eth0"},
+		{Cmd: "ovs-vsctl --timeout=15 get Port eth0 Interfaces", Output: "[080841d8-4e12-4003-b0ac-36fefd63bae1]"},
+		{Cmd: "ovs-vsctl --timeout=15 get Interface 080841d8-4e12-4003-b0ac-36fefd63bae1 Type", Output: "system"},
+		{Cmd: "ovs-vsctl --timeout=15 br-get-external-id breth0", Output: "bridge-id=breth0\nbridge-uplink=eth0\n"},
+	}
+	listIfacesCmd := ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 list-ifaces breth0", Output: "eth0\npatch-breth0_node1-to-br-int"}
+	nicTypeCmd := ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 get interface eth0 type", Output: "\"\""}
+	patchTypeCmd := ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 get interface patch-breth0_node1-to-br-int type", Output: "patch"}
+	peerCmd := ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 get interface patch-breth0_node1-to-br-int options:peer",
+		Output: "patch-br-int-to-breth0_node1"}
+	delBridgeCmd := ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 -- --if-exists del-br breth0"}
+	delPeerCmd := ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 --if-exists del-port br-int patch-br-int-to-breth0_node1"}
+	// bridgeToNicCmds returns the commands of a migration of breth0 back to
+	// eth0 after the ones looking up its NIC
+	bridgeToNicCmds := func(cmds ...ovntest.ExpectedCmd) []ovntest.ExpectedCmd {
+		return append(append([]ovntest.ExpectedCmd{}, getNicNameCmds...), cmds...)
+	}
+	breth0Addrs := map[string][]string{"breth0": {"192.168.1.10/24", "fd00::10/64"}}
+	eth0Addrs := map[string][]string{"eth0": {"192.168.1.10/24", "fd00::10/64"}}
+
+	tests := []struct {
+		desc      string
+		bridge    string
+		nmcli     bool
+		links     []netlink.Link
+		addrs     map[string][]string
+		errs      map[string]error
+		cmds      []ovntest.ExpectedCmd
+		errExp    bool
+		expAddrs  map[string][]string
+		expRoutes []netlink.Route
+	}{
 		{
-			desc:      "retrieving interface link using nic name fails",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{nil, fmt.Errorf("mock error")}},
-			},
+			desc:   "missing bridge fails",
+			bridge: "breth0",
+			errExp: true,
 		},
 		{
-			desc:      "saving IP address to iface fails",
-			inpBridge: "breth0",
-			errExp:    true,
-			// Below two entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}}},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{nil, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three rows are to invoke the save ip adddress function
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{fmt.Errorf("mock error")}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "route retrieval for the bridge fails",
+			bridge: "breth0",
+			links:  []netlink.Link{breth0},
+			addrs:  breth0Addrs,
+			errs:   map[string]error{"RouteList breth0": fmt.Errorf("mock error")},
+			errExp: true,
 		},
 		{
-			desc:      "saving routes to iface fails",
-			inpBridge: "breth0",
-			errExp:    true,
-			// Below two entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{fmt.Errorf("mock error")}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:      "the addresses and routes of the bridge are moved to the NIC",
+			bridge:    "breth0",
+			links:     []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:     breth0Addrs,
+			cmds:      bridgeToNicCmds(listIfacesCmd, nicTypeCmd, patchTypeCmd, peerCmd, delBridgeCmd, delPeerCmd),
+			expAddrs:  eth0Addrs,
+			expRoutes: fakeNicRoutes(fakeEth0.Index),
 		},
 		{
-			desc:      "list-ifaces (via RunOVSVsctl) for given bridge errors out",
-			inpBridge: "breth0",
+			desc:   "listing the interfaces of the bridge fails and the migration is rolled back",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:  breth0Addrs,
+			cmds: bridgeToNicCmds(
+				ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 list-ifaces breth0", Err: fmt.Errorf("mock error")}),
 			errExp:    true,
-			// Below two entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				//Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below entry is for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			expAddrs:  breth0Addrs,
+			expRoutes: fakeNicRoutes(fakeBridgeIndex),
 		},
 		{
-			desc:      "determining iface type fails",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("9d2bc35689fa975")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below rows are for mocking the three RunOVSVsctl executed inside the for loop that processes the interface list returned
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "an interface of unknown type is skipped",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:  breth0Addrs,
+			cmds: bridgeToNicCmds(listIfacesCmd,
+				ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 get interface eth0 type", Err: fmt.Errorf("mock error")},
+				patchTypeCmd, peerCmd, delBridgeCmd, delPeerCmd),
+			expAddrs:  eth0Addrs,
+			expRoutes: fakeNicRoutes(fakeEth0.Index),
 		},
 		{
-			desc:      "test code path where the interface type IS NOT patch",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("9d2bc35689fa975")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("internal")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below rows are for mocking the three RunOVSVsctl executed inside the for loop that processes the interface list returned
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "a bridge without patch ports leaves br-int alone",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:  breth0Addrs,
+			cmds: bridgeToNicCmds(
+				ovntest.ExpectedCmd{Cmd: "ovs-vsctl --timeout=15 list-ifaces breth0", Output: "eth0"},
+				nicTypeCmd, delBridgeCmd),
+			expAddrs:  eth0Addrs,
+			expRoutes: fakeNicRoutes(fakeEth0.Index),
 		},
 		{
-			desc:      "fails to get peer port for patch interface",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("ovn-3e22f4-0")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("patch")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below rows are for mocking the three RunOVSVsctl executed inside the for loop that processes the interface list returned
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "a patch port without a known peer is skipped",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:  breth0Addrs,
+			cmds: bridgeToNicCmds(listIfacesCmd, nicTypeCmd, patchTypeCmd,
+				ovntest.ExpectedCmd{Cmd: peerCmd.Cmd, Err: fmt.Errorf("mock error")},
+				delBridgeCmd),
+			expAddrs:  eth0Addrs,
+			expRoutes: fakeNicRoutes(fakeEth0.Index),
 		},
 		{
-			desc:      "failed to delete peer patch port on br-int",
-			inpBridge: "breth0",
-			errExp:    true,
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("ovn-3e22f4-0")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("patch")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below rows are for mocking the three RunOVSVsctl executed inside the for loop that processes the interface list returned
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "failing to delete the peer on br-int does not undo the migration",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:  breth0Addrs,
+			cmds: bridgeToNicCmds(listIfacesCmd, nicTypeCmd, patchTypeCmd, peerCmd, delBridgeCmd,
+				ovntest.ExpectedCmd{Cmd: delPeerCmd.Cmd, Err: fmt.Errorf("mock error")}),
+			expAddrs:  eth0Addrs,
+			expRoutes: fakeNicRoutes(fakeEth0.Index),
 		},
 		{
-			desc:      "deleting the bridge fails",
-			inpBridge: "breth0",
+			desc:   "deleting the bridge fails and the migration is rolled back with the br-int peers in place",
+			bridge: "breth0",
+			links:  []netlink.Link{fakeEth0, fakeEth1, breth0},
+			addrs:  breth0Addrs,
+			cmds: bridgeToNicCmds(listIfacesCmd, nicTypeCmd, patchTypeCmd, peerCmd,
+				ovntest.ExpectedCmd{Cmd: delBridgeCmd.Cmd, Err: fmt.Errorf("mock error")}),
 			errExp:    true,
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("ovn-3e22f4-0")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("patch")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), fmt.Errorf("mock error")}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below rows are for mocking the three RunOVSVsctl executed inside the for loop that processes the interface list returned
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			expAddrs:  breth0Addrs,
+			expRoutes: fakeNicRoutes(fakeBridgeIndex),
 		},
 		{
-			desc:      "delete bridge successfully",
-			inpBridge: "breth0",
-			onRetArgsExecUtilsIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("eth0")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("[080841d8-4e12-4003-b0ac-36fefd63bae1]")), bytes.NewBuffer([]byte("")), nil}},
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("system")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 3, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("ovn-3e22f4-0")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "type")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("patch")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "RunCmd", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{"*mocks.Cmd", "string", "[]string"}, RetArgList: []interface{}{bytes.NewBuffer([]byte("")), bytes.NewBuffer([]byte("")), nil}},
-			},
-			onRetArgsKexecIface: []ovntest.TestifyMockHelper{
-				//Below three entries are for mocking the `nicName, err := GetNicName(bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 5, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err := RunOVSVsctl("list-ifaces", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 4, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below rows are for mocking the three RunOVSVsctl executed inside the for loop that processes the interface list returned
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("get", "interface", iface, "options:peer")` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `_, stderr, err = RunOVSVsctl("--if-exists", "del-port", "br-int", peer)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-				// Below row is for mocking the `stdout, stderr, err = RunOVSVsctl("--", "--if-exists", "del-br", bridge)` code path
-				{OnCallMethodName: "Command", OnCallMethodsArgsStrTypeAppendCount: 6, OnCallMethodArgType: []string{}, RetArgList: []interface{}{mockCmd}},
-			},
-			onRetArgsNetLinkLibOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `bridgeLink, err := netlink.LinkByName(bridge)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below row entry is for mocking the `addrs, err := netlink.AddrList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "AddrList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Addr{{IPNet: ovntest.MustParseIPNet("192.168.1.15/24")}}, nil}},
-				// Below row entry is for mocking the `routes, err := netlink.RouteList(bridgeLink, syscall.AF_INET)` code path
-				{OnCallMethodName: "RouteList", OnCallMethodArgType: []string{"*mocks.Link", "int"}, RetArgList: []interface{}{[]netlink.Route{{Gw: ovntest.MustParseIP("10.10.10.1"), LinkIndex: 1}}, nil}},
-				// Below row entry is for mocking the `ifaceLink, err := netlink.LinkByName(nicName)` code path
-				{OnCallMethodName: "LinkByName", OnCallMethodArgType: []string{"string"}, RetArgList: []interface{}{mockLink, nil}},
-				// Below three row entries are for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "AddrDel", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "AddrAdd", OnCallMethodArgType: []string{"*mocks.Link", "*netlink.Addr"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "LinkSetUp", OnCallMethodArgType: []string{"*mocks.Link"}, RetArgList: []interface{}{nil}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path
-				{OnCallMethodName: "RouteDel", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-				{OnCallMethodName: "RouteAdd", OnCallMethodArgType: []string{"*netlink.Route"}, RetArgList: []interface{}{nil}},
-			},
-			onRetArgsLinkIfaceOpers: []ovntest.TestifyMockHelper{
-				// Below row entry is for mocking the `if err = saveIPAddress(bridgeLink, ifaceLink, addrs); err != nil` code path
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-				// Below two row entries are for mocking the `if err = saveRoute(bridgeLink, ifaceLink, routes); err != nil` code path.
-				//The delAddRoute function is indirectly invoked and needs mocking of Attrs() function.
-				{OnCallMethodName: "Attrs", OnCallMethodArgType: []string{}, RetArgList: []interface{}{&netlink.LinkAttrs{Name: "testIfaceName"}}},
-			},
+			desc:   "the addresses of the bridge of an OVS bond are moved to its first NIC",
+			bridge: "brbond0",
+			nmcli:  true,
+			links:  []netlink.Link{fakeEth1, fakeEth2, brbond0},
+			addrs:  map[string][]string{"brbond0": {"192.168.1.10/24", "fd00::10/64"}},
+			cmds: []ovntest.ExpectedCmd{
+				{Cmd: "ovs-vsctl --timeout=15 list-ports brbond0", Output: "bond0"},
+				{Cmd: "ovs-vsctl --timeout=15 get Port bond0 Interfaces", Output: "[080841d8-4e12-4003-b0ac-36fefd63bae1, 64ef6b1a-8c3f-4a79-a4b6-2a1b8b2f0f44]"},
+				{Cmd: "ovs-vsctl --timeout=15 get Interface 080841d8-4e12-4003-b0ac-36fefd63bae1 Type", Output: "system"},
+				{Cmd: "ovs-vsctl --timeout=15 get Interface 64ef6b1a-8c3f-4a79-a4b6-2a1b8b2f0f44 Type", Output: "system"},
+				{Cmd: "ovs-vsctl --timeout=15 br-get-external-id brbond0 bridge-uplink", Output: "bond0"},
+				{Cmd: "ovs-vsctl --timeout=15 br-get-external-id brbond0",
+					Output: "bridge-id=brbond0\nbridge-uplink=bond0\nbridge-uplink-members=\"eth1,eth2\"\nnm-managed=\"eth1,eth2\"\n"},
+				{Cmd: "ovs-vsctl --timeout=15 list-ifaces brbond0", Output: "eth1\neth2"},
+				{Cmd: "ovs-vsctl --timeout=15 get interface eth1 type", Output: "\"\""},
+				{Cmd: "ovs-vsctl --timeout=15 get interface eth2 type", Output: "\"\""},
+				{Cmd: "ovs-vsctl --timeout=15 -- --if-exists del-br brbond0"},
+				{Cmd: "nmcli device set eth1 managed yes"},
+				{Cmd: "nmcli device set eth2 managed yes"},
+			},
+			expAddrs:  map[string][]string{"eth1": {"192.168.1.10/24", "fd00::10/64"}},
+			expRoutes: fakeNicRoutes(fakeEth1.Index)[:4],
 		},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
-			ovntest.ProcessMockFnList(&mockExecRunner.Mock, tc.onRetArgsExecUtilsIface)
-			ovntest.ProcessMockFnList(&mockKexecIface.Mock, tc.onRetArgsKexecIface)
-			ovntest.ProcessMockFnList(&mockNetLinkOps.Mock, tc.onRetArgsNetLinkLibOpers)
-			ovntest.ProcessMockFnList(&mockLink.Mock, tc.onRetArgsLinkIfaceOpers)
+			var routes []netlink.Route
+			if len(tc.links) > 0 {
+				routes = fakeNicRoutes(fakeBridgeIndex)
+				if tc.bridge == "brbond0" {
+					routes = routes[:4]
+				}
+			}
+			fakeOps := newFakeNicNetLinkOps(tc.links, tc.addrs, routes, tc.errs)
+			netLinkOps = fakeOps
+			defer ResetNetLinkOpMockInst()
+
+			runCmdExecRunner = &defaultExecRunner{}
+			fexec := ovntest.NewFakeExec()
+			commands := []string{"ovs-vsctl"}
+			if tc.nmcli {
+				commands = append(commands, "nmcli")
+			}
+			if err := SetSpecificExec(fexec, commands...); err != nil {
+				t.Fatal(err)
+			}
+			for j := range tc.cmds {
+				fexec.AddFakeCmd(&tc.cmds[j])
+			}
 
-			err := BridgeToNic(tc.inpBridge)
+			err := BridgeToNic(tc.bridge)
 			t.Log(err)
 			if tc.errExp {
 				assert.Error(t, err)
 			} else {
 				assert.Nil(t, err)
 			}
-			mockKexecIface.AssertExpectations(t)
-			mockExecRunner.AssertExpectations(t)
-			mockNetLinkOps.AssertExpectations(t)
-			mockLink.AssertExpectations(t)
+			assert.True(t, fexec.CalledMatchesExpected(), fexec.ErrorDesc())
+			if tc.expAddrs == nil {
+				return
+			}
+			resAddrs := fakeOps.addrsByLink()
+			for name := range resAddrs {
+				assert.ElementsMatch(t, tc.expAddrs[name], resAddrs[name], "addresses of %s", name)
+			}
+			assert.ElementsMatch(t, fakeRouteKeys(tc.expRoutes), fakeRouteKeys(fakeOps.routes))
 		})
 	}
 }
@@ -7,12 +7,14 @@ import (
 	"net"
 	"net/netip"
 	"strconv"
+	"strings"
 
 	"github.com/gaissmai/cidrtree"
 	corev1 "k8s.io/api/core/v1"
 	kapi "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/config"
 	"github.com/ovn-org/ovn-kubernetes/go-controller/pkg/kube"
@@ -122,8 +124,39 @@ const (
 
 	// invalidNetworkID signifies its an invalid network id
 	InvalidNetworkID = -1
+
+	// OvnNodeGatewayUplinks is set by the cluster administrator to add uplinks to the gateway of the node, in
+	// addition to the gateway interface. The namespaces and the localnet secondary networks mapped to an uplink
+	// egress through it. Changes are applied by ovnkube-node while it runs.
+	//
+	//   k8s.ovn.org/gateway-uplinks: |
+	//     {
+	//       "storage": {
+	//         "interface": "eth2",
+	//         "next-hops": ["192.168.100.1"],
+	//         "namespaces": ["database"],
+	//         "networks": ["storage-localnet"]
+	//       }
+	//     }
+	OvnNodeGatewayUplinks = "k8s.ovn.org/gateway-uplinks"
+
+	// OvnNodeGatewayMode is set by the cluster administrator to request the gateway mode of the node, overriding
+	// the configured gateway mode. Changing it migrates the gateway of the node to the requested mode.
+	OvnNodeGatewayMode = "k8s.ovn.org/gateway-mode"
+
+	// OvnNodeEgressIPHealthChecks is set by the cluster administrator to configure the egress IP health checks the
+	// node runs in addition to serving the gRPC health check, overriding the configured ones, e.g.
+	// '{"checks": ["icmp", "bfd"], "icmp-targets": ["172.18.0.1"]}'
+	OvnNodeEgressIPHealthChecks = "k8s.ovn.org/egressip-node-healthchecks"
 )
 
+// EgressIPNodeHealthChecks are the egress IP health checks requested with the OvnNodeEgressIPHealthChecks
+// annotation of a node
+type EgressIPNodeHealthChecks struct {
+	Checks      []string `json:"checks"`
+	ICMPTargets []string `json:"icmp-targets,omitempty"`
+}
+
 type L3GatewayConfig struct {
 	Mode                config.GatewayMode
 	ChassisID           string
@@ -136,22 +169,43 @@ type L3GatewayConfig struct {
 	NextHops            []net.IP
 	NodePortEnable      bool
 	VLANID              *uint
+	Uplinks             []L3GatewayUplink
+}
+
+// L3GatewayUplink is an uplink of the gateway requested with the OvnNodeGatewayUplinks annotation
+type L3GatewayUplink struct {
+	Name        string
+	InterfaceID string
+	MACAddress  net.HardwareAddr
+	IPAddresses []*net.IPNet
+	NextHops    []net.IP
+	Namespaces  []string
+}
+
+type l3GatewayUplinkJSON struct {
+	Name        string   `json:"name"`
+	InterfaceID string   `json:"interface-id"`
+	MACAddress  string   `json:"mac-address"`
+	IPAddresses []string `json:"ip-addresses"`
+	NextHops    []string `json:"next-hops,omitempty"`
+	Namespaces  []string `json:"namespaces,omitempty"`
 }
 
 type l3GatewayConfigJSON struct {
-	Mode                config.GatewayMode `json:"mode"`
-	InterfaceID         string             `json:"interface-id,omitempty"`
-	MACAddress          string             `json:"mac-address,omitempty"`
-	IPAddresses         []string           `json:"ip-addresses,omitempty"`
-	IPAddress           string             `json:"ip-address,omitempty"`
-	EgressGWInterfaceID string             `json:"exgw-interface-id,omitempty"`
-	EgressGWMACAddress  string             `json:"exgw-mac-address,omitempty"`
-	EgressGWIPAddresses []string           `json:"exgw-ip-addresses,omitempty"`
-	EgressGWIPAddress   string             `json:"exgw-ip-address,omitempty"`
-	NextHops            []string           `json:"next-hops,omitempty"`
-	NextHop             string             `json:"next-hop,omitempty"`
-	NodePortEnable      string             `json:"node-port-enable,omitempty"`
-	VLANID              string             `json:"vlan-id,omitempty"`
+	Mode                config.GatewayMode    `json:"mode"`
+	InterfaceID         string                `json:"interface-id,omitempty"`
+	MACAddress          string                `json:"mac-address,omitempty"`
+	IPAddresses         []string              `json:"ip-addresses,omitempty"`
+	IPAddress           string                `json:"ip-address,omitempty"`
+	EgressGWInterfaceID string                `json:"exgw-interface-id,omitempty"`
+	EgressGWMACAddress  string                `json:"exgw-mac-address,omitempty"`
+	EgressGWIPAddresses []string              `json:"exgw-ip-addresses,omitempty"`
+	EgressGWIPAddress   string                `json:"exgw-ip-address,omitempty"`
+	NextHops            []string              `json:"next-hops,omitempty"`
+	NextHop             string                `json:"next-hop,omitempty"`
+	NodePortEnable      string                `json:"node-port-enable,omitempty"`
+	VLANID              string                `json:"vlan-id,omitempty"`
+	Uplinks             []l3GatewayUplinkJSON `json:"uplinks,omitempty"`
 }
 
 func (cfg *L3GatewayConfig) MarshalJSON() ([]byte, error) {
@@ -192,6 +246,22 @@ func (cfg *L3GatewayConfig) MarshalJSON() ([]byte, error) {
 	if len(cfgjson.NextHops) == 1 {
 		cfgjson.NextHop = cfgjson.NextHops[0]
 	}
+	for _, uplink := range cfg.Uplinks {
+		uplinkjson := l3GatewayUplinkJSON{
+			Name:        uplink.Name,
+			InterfaceID: uplink.InterfaceID,
+			MACAddress:  uplink.MACAddress.String(),
+			IPAddresses: make([]string, len(uplink.IPAddresses)),
+			Namespaces:  uplink.Namespaces,
+		}
+		for i, ip := range uplink.IPAddresses {
+			uplinkjson.IPAddresses[i] = ip.String()
+		}
+		for _, nh := range uplink.NextHops {
+			uplinkjson.NextHops = append(uplinkjson.NextHops, nh.String())
+		}
+		cfgjson.Uplinks = append(cfgjson.Uplinks, uplinkjson)
+	}
 
 	return json.Marshal(&cfgjson)
 }
@@ -285,6 +355,33 @@ func (cfg *L3GatewayConfig) UnmarshalJSON(bytes []byte) error {
 		}
 	}
 
+	for _, uplinkjson := range cfgjson.Uplinks {
+		uplink := L3GatewayUplink{
+			Name:        uplinkjson.Name,
+			InterfaceID: uplinkjson.InterfaceID,
+			Namespaces:  uplinkjson.Namespaces,
+		}
+		uplink.MACAddress, err = net.ParseMAC(uplinkjson.MACAddress)
+		if err != nil {
+			return fmt.Errorf("bad uplink %s 'mac-address' value %q: %v", uplink.Name, uplinkjson.MACAddress, err)
+		}
+		for _, ipStr := range uplinkjson.IPAddresses {
+			ip, ipnet, err := net.ParseCIDR(ipStr)
+			if err != nil {
+				return fmt.Errorf("bad uplink %s 'ip-addresses' value %q: %v", uplink.Name, ipStr, err)
+			}
+			uplink.IPAddresses = append(uplink.IPAddresses, &net.IPNet{IP: ip, Mask: ipnet.Mask})
+		}
+		for _, nextHopStr := range uplinkjson.NextHops {
+			nextHop := net.ParseIP(nextHopStr)
+			if nextHop == nil {
+				return fmt.Errorf("bad uplink %s 'next-hops' value %q", uplink.Name, nextHopStr)
+			}
+			uplink.NextHops = append(uplink.NextHops, nextHop)
+		}
+		cfg.Uplinks = append(cfg.Uplinks, uplink)
+	}
+
 	return nil
 }
 
@@ -972,6 +1069,93 @@ func NodeZoneAnnotationChanged(oldNode, newNode *corev1.Node) bool {
 	return oldNode.Annotations[OvnNodeZoneName] != newNode.Annotations[OvnNodeZoneName]
 }
 
+// GatewayUplink is an uplink of the gateway of the node requested with the OvnNodeGatewayUplinks annotation
+type GatewayUplink struct {
+	// Interface is the interface or the OVS bridge of the uplink
+	Interface string `json:"interface"`
+	// NextHops are the gateways of the uplink the traffic of the namespaces is routed to
+	NextHops []string `json:"next-hops,omitempty"`
+	// Namespaces are the namespaces whose traffic leaving the cluster egresses through the uplink
+	Namespaces []string `json:"namespaces,omitempty"`
+	// Networks are the localnet secondary networks mapped to the bridge of the uplink
+	Networks []string `json:"networks,omitempty"`
+}
+
+// ParseNodeGatewayUplinks returns the gateway uplinks requested for the node, by name
+func ParseNodeGatewayUplinks(node *kapi.Node) (map[string]GatewayUplink, error) {
+	annotation, ok := node.Annotations[OvnNodeGatewayUplinks]
+	if !ok {
+		return nil, nil
+	}
+	uplinks := map[string]GatewayUplink{}
+	if err := json.Unmarshal([]byte(annotation), &uplinks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s annotation on node %s: %v", OvnNodeGatewayUplinks, node.Name, err)
+	}
+	networks := map[string]string{}
+	namespaces := map[string]string{}
+	for name, uplink := range uplinks {
+		// the name is part of the names of the OVN entities of the uplink
+		if errs := validation.IsDNS1123Label(name); len(errs) > 0 {
+			return nil, fmt.Errorf("invalid uplink name %q in %s annotation on node %s: %s", name,
+				OvnNodeGatewayUplinks, node.Name, strings.Join(errs, ", "))
+		}
+		if uplink.Interface == "" {
+			return nil, fmt.Errorf("missing interface of uplink %s in %s annotation on node %s", name,
+				OvnNodeGatewayUplinks, node.Name)
+		}
+		for _, nextHop := range uplink.NextHops {
+			if net.ParseIP(nextHop) == nil {
+				return nil, fmt.Errorf("invalid next hop %q of uplink %s in %s annotation on node %s", nextHop, name,
+					OvnNodeGatewayUplinks, node.Name)
+			}
+		}
+		for _, network := range uplink.Networks {
+			if other, ok := networks[network]; ok {
+				return nil, fmt.Errorf("network %s mapped to both uplinks %s and %s in %s annotation on node %s",
+					network, other, name, OvnNodeGatewayUplinks, node.Name)
+			}
+			networks[network] = name
+		}
+		for _, namespace := range uplink.Namespaces {
+			if other, ok := namespaces[namespace]; ok {
+				return nil, fmt.Errorf("namespace %s mapped to both uplinks %s and %s in %s annotation on node %s",
+					namespace, other, name, OvnNodeGatewayUplinks, node.Name)
+			}
+			namespaces[namespace] = name
+		}
+	}
+	return uplinks, nil
+}
+
+// ParseNodeGatewayMode returns the gateway mode requested for the node, empty if none
+func ParseNodeGatewayMode(node *kapi.Node) (config.GatewayMode, error) {
+	mode, ok := node.Annotations[OvnNodeGatewayMode]
+	if !ok {
+		return "", nil
+	}
+	switch config.GatewayMode(mode) {
+	case config.GatewayModeShared, config.GatewayModeLocal:
+		return config.GatewayMode(mode), nil
+	}
+	return "", fmt.Errorf("invalid %s annotation %q on node %s, must be %q or %q", OvnNodeGatewayMode, mode,
+		node.Name, config.GatewayModeShared, config.GatewayModeLocal)
+}
+
+// ParseNodeEgressIPHealthChecks returns the egress IP health checks requested with the OvnNodeEgressIPHealthChecks
+// annotation of the node, or nil if the node runs the configured ones
+func ParseNodeEgressIPHealthChecks(node *kapi.Node) (*EgressIPNodeHealthChecks, error) {
+	annotation, ok := node.Annotations[OvnNodeEgressIPHealthChecks]
+	if !ok {
+		return nil, nil
+	}
+	checks := &EgressIPNodeHealthChecks{}
+	if err := json.Unmarshal([]byte(annotation), checks); err != nil {
+		return nil, fmt.Errorf("invalid %s annotation %q on node %s: %v", OvnNodeEgressIPHealthChecks, annotation,
+			node.Name, err)
+	}
+	return checks, nil
+}
+
 func parseNetworkIDsAnnotation(nodeAnnotations map[string]string, annotationName string) (map[string]string, error) {
 	annotation, ok := nodeAnnotations[annotationName]
 	if !ok {
@@ -72,6 +72,23 @@ func TestL3GatewayConfig_MarshalJSON(t *testing.T) {
 			},
 			expOutput: []byte(`{"mode":"local","interface-id":"INTERFACE-ID","mac-address":"11:22:33:44:55:66","ip-addresses":["192.168.1.10/24","fd01::1234/64"],"next-hops":["192.168.1.1","fd01::1"],"node-port-enable":"false","vlan-id":"1024"}`),
 		},
+		{
+			desc: "test gateway uplinks",
+			inpL3GwCfg: &L3GatewayConfig{
+				Mode: config.GatewayModeShared,
+				Uplinks: []L3GatewayUplink{
+					{
+						Name:        "storage",
+						InterfaceID: "breth2_node1",
+						MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:77"),
+						IPAddresses: []*net.IPNet{ovntest.MustParseIPNet("192.168.100.10/24")},
+						NextHops:    []net.IP{ovntest.MustParseIP("192.168.100.1")},
+						Namespaces:  []string{"database"},
+					},
+				},
+			},
+			expOutput: []byte(`{"mode":"shared","node-port-enable":"false","uplinks":[{"name":"storage","interface-id":"breth2_node1","mac-address":"11:22:33:44:55:77","ip-addresses":["192.168.100.10/24"],"next-hops":["192.168.100.1"],"namespaces":["database"]}]}`),
+		},
 	}
 	for i, tc := range tests {
 		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
@@ -108,6 +125,31 @@ func TestL3GatewayConfig_UnmarshalJSON(t *testing.T) {
 			inputParam: []byte(`{"mode":"blah"}`),
 			errMatch:   fmt.Errorf("bad 'mode' value"),
 		},
+		{
+			desc:       "success: test gateway uplinks",
+			inputParam: []byte(`{"mode":"shared","mac-address":"11:22:33:44:55:66","ip-addresses":["192.168.1.5/24"],"uplinks":[{"name":"storage","interface-id":"breth2_node1","mac-address":"11:22:33:44:55:77","ip-addresses":["192.168.100.10/24"],"next-hops":["192.168.100.1"],"namespaces":["database"]}]}`),
+			expOut: L3GatewayConfig{
+				Mode:        config.GatewayModeShared,
+				MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:66"),
+				IPAddresses: ovntest.MustParseIPNets("192.168.1.5/24"),
+				NextHops:    []net.IP{},
+				Uplinks: []L3GatewayUplink{
+					{
+						Name:        "storage",
+						InterfaceID: "breth2_node1",
+						MACAddress:  ovntest.MustParseMAC("11:22:33:44:55:77"),
+						IPAddresses: []*net.IPNet{ovntest.MustParseIPNet("192.168.100.10/24")},
+						NextHops:    []net.IP{ovntest.MustParseIP("192.168.100.1")},
+						Namespaces:  []string{"database"},
+					},
+				},
+			},
+		},
+		{
+			desc:       "error: test invalid gateway uplink IP address",
+			inputParam: []byte(`{"mode":"shared","mac-address":"11:22:33:44:55:66","ip-addresses":["192.168.1.5/24"],"uplinks":[{"name":"storage","interface-id":"breth2_node1","mac-address":"11:22:33:44:55:77","ip-addresses":["192.168.100.10"]}]}`),
+			errAssert:  true,
+		},
 		{
 			desc:       "error: test bad VLANID input",
 			inputParam: []byte(`{"mode":"shared","vlan-id":"A"}`),
@@ -616,3 +658,243 @@ func TestParseNodeGatewayMTUSupport(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNodeGatewayMode(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inpNode   *v1.Node
+		res       config.GatewayMode
+		expectErr bool
+	}{
+		{
+			desc:    "annotation not found for node",
+			inpNode: &v1.Node{},
+			res:     "",
+		},
+		{
+			desc: "parse completed for shared gateway mode",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-mode": "shared",
+					},
+				},
+			},
+			res: config.GatewayModeShared,
+		},
+		{
+			desc: "parse completed for local gateway mode",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-mode": "local",
+					},
+				},
+			},
+			res: config.GatewayModeLocal,
+		},
+		{
+			desc: "error: disabled gateway mode can't be requested",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-mode": "",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "error: invalid gateway mode",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-mode": "Local",
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res, err := ParseNodeGatewayMode(tc.inpNode)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestParseNodeEgressIPHealthChecks(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inpNode   *v1.Node
+		res       *EgressIPNodeHealthChecks
+		expectErr bool
+	}{
+		{
+			desc:    "annotation not found for node",
+			inpNode: &v1.Node{},
+		},
+		{
+			desc: "parse completed for icmp and bfd checks",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/egressip-node-healthchecks": `{"checks": ["icmp", "bfd"], "icmp-targets": ["172.18.0.1"]}`,
+					},
+				},
+			},
+			res: &EgressIPNodeHealthChecks{Checks: []string{"icmp", "bfd"}, ICMPTargets: []string{"172.18.0.1"}},
+		},
+		{
+			desc: "parse completed for no checks",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/egressip-node-healthchecks": `{"checks": []}`,
+					},
+				},
+			},
+			res: &EgressIPNodeHealthChecks{Checks: []string{}},
+		},
+		{
+			desc: "error: invalid annotation",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/egressip-node-healthchecks": "icmp,bfd",
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res, err := ParseNodeEgressIPHealthChecks(tc.inpNode)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, res)
+		})
+	}
+}
+
+func TestParseNodeGatewayUplinks(t *testing.T) {
+	tests := []struct {
+		desc      string
+		inpNode   *v1.Node
+		res       map[string]GatewayUplink
+		expectErr bool
+	}{
+		{
+			desc:    "annotation not found for node",
+			inpNode: &v1.Node{},
+		},
+		{
+			desc: "parse completed",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"storage":{"interface":"eth2","next-hops":["192.168.100.1","fd00:100::1"],"namespaces":["database"],"networks":["storage-localnet"]},"backup":{"interface":"breth3"}}`,
+					},
+				},
+			},
+			res: map[string]GatewayUplink{
+				"storage": {
+					Interface:  "eth2",
+					NextHops:   []string{"192.168.100.1", "fd00:100::1"},
+					Namespaces: []string{"database"},
+					Networks:   []string{"storage-localnet"},
+				},
+				"backup": {
+					Interface: "breth3",
+				},
+			},
+		},
+		{
+			desc: "error: fails to unmarshal the annotation",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"storage":`,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "error: invalid uplink name",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"Storage_1":{"interface":"eth2"}}`,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "error: missing interface",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"storage":{"namespaces":["database"]}}`,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "error: invalid next hop",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"storage":{"interface":"eth2","next-hops":["192.168.100.0/24"]}}`,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "error: network mapped to two uplinks",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"storage":{"interface":"eth2","networks":["localnet"]},"backup":{"interface":"eth3","networks":["localnet"]}}`,
+					},
+				},
+			},
+			expectErr: true,
+		},
+		{
+			desc: "error: namespace mapped to two uplinks",
+			inpNode: &v1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						"k8s.ovn.org/gateway-uplinks": `{"storage":{"interface":"eth2","namespaces":["database"]},"backup":{"interface":"eth3","namespaces":["database"]}}`,
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+	for i, tc := range tests {
+		t.Run(fmt.Sprintf("%d:%s", i, tc.desc), func(t *testing.T) {
+			res, err := ParseNodeGatewayUplinks(tc.inpNode)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.res, res)
+		})
+	}
+}
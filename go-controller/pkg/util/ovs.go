@@ -2,6 +2,7 @@ package util
 
 import (
 	"bytes"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -39,6 +40,7 @@ const (
 	netshCommand       = "netsh"
 	routeCommand       = "route"
 	sysctlCommand      = "sysctl"
+	nmcliCommand       = "nmcli"
 	osRelease          = "/etc/os-release"
 	rhel               = "RHEL"
 	ubuntu             = "Ubuntu"
@@ -132,6 +134,7 @@ type execHelper struct {
 	netshPath       string
 	routePath       string
 	sysctlPath      string
+	nmcliPath       string
 }
 
 var runner *execHelper
@@ -275,6 +278,10 @@ func SetSpecificExec(exec kexec.Interface, commands ...string) error {
 			if err != nil {
 				return err
 			}
+		case nmcliCommand:
+			// NetworkManager is optional, commands using it are skipped
+			// when it is not installed
+			runner.nmcliPath, _ = exec.LookPath(nmcliCommand)
 		default:
 			return fmt.Errorf("unknown command: %q", command)
 		}
@@ -473,6 +480,16 @@ func RunOVSDBClient(args ...string) (string, string, error) {
 	return strings.Trim(strings.TrimSpace(stdout.String()), "\""), stderr.String(), err
 }
 
+// RunOVSDBClientRaw runs an 'ovsdb-client [OPTIONS] COMMAND [SERVER] [ARG...] command' and returns its
+// stdout untouched, for commands like 'backup' whose output must be kept byte for byte.
+func RunOVSDBClientRaw(args ...string) ([]byte, string, error) {
+	stdout, stderr, err := runOVNretry(runner.ovsdbClientPath, nil, args...)
+	if stdout == nil {
+		return nil, stderr.String(), err
+	}
+	return stdout.Bytes(), stderr.String(), err
+}
+
 // RunOVSDBTool runs an 'ovsdb-tool [OPTIONS] COMMAND [ARG...] command'.
 func RunOVSDBTool(args ...string) (string, string, error) {
 	stdout, stderr, err := run(runner.ovsdbToolPath, args...)
@@ -614,6 +631,18 @@ func RunSysctl(args ...string) (string, string, error) {
 	return strings.TrimSpace(stdout.String()), stderr.String(), err
 }
 
+// IsNmcliAvailable returns whether the NetworkManager nmcli utility was found
+// by SetSpecificExec
+func IsNmcliAvailable() bool {
+	return runner != nil && runner.nmcliPath != ""
+}
+
+// RunNmcli runs a command via the NetworkManager "nmcli" utility
+func RunNmcli(args ...string) (string, string, error) {
+	stdout, stderr, err := run(runner.nmcliPath, args...)
+	return strings.TrimSpace(stdout.String()), stderr.String(), err
+}
+
 // RunPowershell runs a command via the Windows powershell utility
 func RunPowershell(args ...string) (string, string, error) {
 	stdout, stderr, err := run(runner.powershellPath, args...)
@@ -829,6 +858,10 @@ type OvsDbProperties struct {
 	DbAlias       string
 	DbName        string
 	ElectionTimer int
+	// CompactionLogSize is the size in bytes the raft log can grow to before being compacted, 0 if disabled
+	CompactionLogSize int64
+	// CompactionMaxAge is the time after which the database is compacted, 0 if disabled
+	CompactionMaxAge time.Duration
 }
 
 // GetOvsDbProperties inits OvsDbProperties based on db file path given to it.
@@ -836,17 +869,21 @@ type OvsDbProperties struct {
 func GetOvsDbProperties(db string) (*OvsDbProperties, error) {
 	if strings.Contains(db, "ovnnb") {
 		return &OvsDbProperties{
-			ElectionTimer: int(config.OvnNorth.ElectionTimer) * 1000,
-			AppCtl:        RunOVNNBAppCtlWithTimeout,
-			DbName:        "OVN_Northbound",
-			DbAlias:       db,
+			ElectionTimer:     int(config.OvnNorth.ElectionTimer) * 1000,
+			CompactionLogSize: int64(config.OvnNorth.CompactionLogSize) * 1024 * 1024,
+			CompactionMaxAge:  time.Duration(config.OvnNorth.CompactionMaxAge) * time.Second,
+			AppCtl:            RunOVNNBAppCtlWithTimeout,
+			DbName:            "OVN_Northbound",
+			DbAlias:           db,
 		}, nil
 	} else if strings.Contains(db, "ovnsb") {
 		return &OvsDbProperties{
-			ElectionTimer: int(config.OvnSouth.ElectionTimer) * 1000,
-			AppCtl:        RunOVNSBAppCtlWithTimeout,
-			DbName:        "OVN_Southbound",
-			DbAlias:       db,
+			ElectionTimer:     int(config.OvnSouth.ElectionTimer) * 1000,
+			CompactionLogSize: int64(config.OvnSouth.CompactionLogSize) * 1024 * 1024,
+			CompactionMaxAge:  time.Duration(config.OvnSouth.CompactionMaxAge) * time.Second,
+			AppCtl:            RunOVNSBAppCtlWithTimeout,
+			DbName:            "OVN_Southbound",
+			DbAlias:           db,
 		}, nil
 	} else {
 		return nil, fmt.Errorf("failed to parse ovn db type Northbound/Southbound from the path %s", db)
@@ -864,6 +901,31 @@ func GetExternalIDValByKey(keyValString, key string) string {
 	return ""
 }
 
+// ReadOVSCSV parses the output of an ovs-vsctl command run with --format=csv into records of the given number of
+// fields. The fields with a quote, e.g. a map with a key or a value containing a '/', are quoted by ovs-vsctl and
+// RunOVSVsctl trims the quotes around its output, so the last field may be left unterminated.
+func ReadOVSCSV(output string, fields int) ([][]string, error) {
+	if output == "" {
+		return nil, nil
+	}
+	reader := csv.NewReader(strings.NewReader(output))
+	reader.FieldsPerRecord = fields
+	reader.LazyQuotes = true
+	return reader.ReadAll()
+}
+
+// ParseBareMap parses a map column output with --data=bare, e.g. direction=Ingress "k8s.ovn.org/name"=default
+func ParseBareMap(value string) map[string]string {
+	m := map[string]string{}
+	for _, pair := range strings.Fields(value) {
+		key, value, ok := strings.Cut(pair, "=")
+		if ok {
+			m[strings.Trim(key, `"`)] = strings.Trim(value, `"`)
+		}
+	}
+	return m
+}
+
 // GetOVSPortPodInfo gets OVS interface associated pod information (sandbox/NAD),
 // returns false if the OVS interface does not exists
 func GetOVSPortPodInfo(hostIfName string) (bool, string, string, error) {
@@ -349,6 +349,12 @@ func PodNadNames(pod *v1.Pod, netinfo NetInfo) ([]string, error) {
 	return nadNames, nil
 }
 
+// PodNADIPs returns the IP addresses annotated for the pod on the network attachment
+// definition nadName, or none if the pod is not attached to it.
+func PodNADIPs(pod *v1.Pod, nadName string) []net.IP {
+	return getAnnotatedPodIPs(pod, nadName)
+}
+
 func getAnnotatedPodIPs(pod *v1.Pod, nadName string) []net.IP {
 	var ips []net.IP
 	annotation, _ := UnmarshalPodAnnotation(pod.Annotations, nadName)
@@ -6,6 +6,11 @@ package util
 import (
 	"fmt"
 	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/k8snetworkplumbingwg/govdpa/pkg/kvdpa"
 	nadapi "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
@@ -16,6 +21,7 @@ import (
 type SriovnetOps interface {
 	GetNetDevicesFromPci(pciAddress string) ([]string, error)
 	GetNetDevicesFromAux(auxDev string) ([]string, error)
+	GetAuxNetDevicesFromPci(pciAddr string) ([]string, error)
 	GetUplinkRepresentor(vfPciAddress string) (string, error)
 	GetUplinkRepresentorFromAux(auxDev string) (string, error)
 	GetVfIndexByPciAddress(vfPciAddress string) (int, error)
@@ -28,6 +34,7 @@ type SriovnetOps interface {
 	GetVfRepresentorDPU(pfID, vfIndex string) (string, error)
 	GetRepresentorPeerMacAddress(netdev string) (net.HardwareAddr, error)
 	GetRepresentorPortFlavour(netdev string) (sriovnet.PortFlavour, error)
+	GetNetDevPhysPortName(netdev string) (string, error)
 }
 
 type defaultSriovnetOps struct {
@@ -53,6 +60,10 @@ func (defaultSriovnetOps) GetNetDevicesFromAux(auxDev string) ([]string, error)
 	return sriovnet.GetNetDevicesFromAux(auxDev)
 }
 
+func (defaultSriovnetOps) GetAuxNetDevicesFromPci(pciAddr string) ([]string, error) {
+	return sriovnet.GetAuxNetDevicesFromPci(pciAddr)
+}
+
 func (defaultSriovnetOps) GetUplinkRepresentor(vfPciAddress string) (string, error) {
 	return sriovnet.GetUplinkRepresentor(vfPciAddress)
 }
@@ -101,6 +112,40 @@ func (defaultSriovnetOps) GetRepresentorPortFlavour(netdev string) (sriovnet.Por
 	return sriovnet.GetRepresentorPortFlavour(netdev)
 }
 
+func (defaultSriovnetOps) GetNetDevPhysPortName(netdev string) (string, error) {
+	physPortName, err := os.ReadFile(filepath.Join(sriovnet.NetSysDir, netdev, "phys_port_name"))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(physPortName)), nil
+}
+
+// uplinkPhysPortNameRegex matches the physical port name of an uplink representor, p<PF number>
+var uplinkPhysPortNameRegex = regexp.MustCompile(`^p(\d+)$`)
+
+// GetPfNumber returns the PCI function number of a PF in switchdev mode, the pfnum of its devlink ports, from the
+// physical port name of its uplink representor
+func GetPfNumber(pfPciAddress string) (uint16, error) {
+	uplink, err := GetSriovnetOps().GetUplinkRepresentor(pfPciAddress)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the uplink representor of PF %s: %v", pfPciAddress, err)
+	}
+	physPortName, err := GetSriovnetOps().GetNetDevPhysPortName(uplink)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get the physical port name of uplink representor %s: %v", uplink, err)
+	}
+	match := uplinkPhysPortNameRegex.FindStringSubmatch(physPortName)
+	if match == nil {
+		return 0, fmt.Errorf("unexpected physical port name %q of uplink representor %s", physPortName, uplink)
+	}
+	pfNum, err := strconv.ParseUint(match[1], 10, 16)
+	if err != nil {
+		return 0, fmt.Errorf("invalid PF number in physical port name %q of uplink representor %s: %v",
+			physPortName, uplink, err)
+	}
+	return uint16(pfNum), nil
+}
+
 // GetFunctionRepresentorName returns representor name for passed device ID. Supported devices are Virtual Function
 // or Scalable Function
 func GetFunctionRepresentorName(deviceID string) (string, error) {
@@ -163,6 +208,23 @@ func GetNetdevNameFromDeviceId(deviceId string, deviceInfo nadapi.DeviceInfo) (s
 
 		netdevices, err = GetSriovnetOps().GetNetDevicesFromPci(deviceId)
 	} else { // Auxiliary network device
+		// a vDPA device created on a scalable function takes preference over it, like on a VF
+		var vdpaDevice kvdpa.VdpaDevice
+		vdpaDevice, err = GetVdpaOps().GetVdpaDeviceByAuxDev(deviceId)
+		if err == nil && vdpaDevice != nil {
+			switch vdpaDevice.Driver() {
+			case kvdpa.VhostVdpaDriver:
+				klog.V(2).Infof("vDPA device %s of %s is bound to vhost, returning empty netdev", vdpaDevice.Name(), deviceId)
+				return "", nil
+			case kvdpa.VirtioVdpaDriver:
+				if vdpaDevice.VirtioNet() == nil {
+					return "", fmt.Errorf("virtio netdev of vDPA device %s of %s not found", vdpaDevice.Name(), deviceId)
+				}
+				klog.V(2).Infof("vDPA device %s of %s is bound to virtio, returning netdev %s", vdpaDevice.Name(),
+					deviceId, vdpaDevice.VirtioNet().NetDev())
+				return vdpaDevice.VirtioNet().NetDev(), nil
+			}
+		}
 		netdevices, err = GetSriovnetOps().GetNetDevicesFromAux(deviceId)
 	}
 	if err != nil {
@@ -10,6 +10,9 @@ type VdpaDevice interface {
 
 type VdpaOps interface {
 	GetVdpaDeviceByPci(pciAddress string) (kvdpa.VdpaDevice, error)
+	GetVdpaDeviceByAuxDev(auxDev string) (kvdpa.VdpaDevice, error)
+	AddVdpaDevice(mgmtDeviceName string, vdpaDeviceName string) error
+	DeleteVdpaDevice(vdpaDeviceName string) error
 }
 
 type defaultVdpaOps struct {
@@ -35,3 +38,21 @@ func (v *defaultVdpaOps) GetVdpaDeviceByPci(pciAddress string) (kvdpa.VdpaDevice
 	}
 	return nil, err
 }
+
+// GetVdpaDeviceByAuxDev returns the vDPA device created on the given auxiliary device, e.g. a scalable function
+func (v *defaultVdpaOps) GetVdpaDeviceByAuxDev(auxDev string) (kvdpa.VdpaDevice, error) {
+	vdpaDevices, err := kvdpa.GetVdpaDevicesByMgmtDev("auxiliary", auxDev)
+	if len(vdpaDevices) > 0 {
+		return vdpaDevices[0], nil
+	}
+	return nil, err
+}
+
+// AddVdpaDevice creates a vDPA device on the given management device, in the <bus>/<device> format
+func (v *defaultVdpaOps) AddVdpaDevice(mgmtDeviceName string, vdpaDeviceName string) error {
+	return kvdpa.AddVdpaDevice(mgmtDeviceName, vdpaDeviceName)
+}
+
+func (v *defaultVdpaOps) DeleteVdpaDevice(vdpaDeviceName string) error {
+	return kvdpa.DeleteVdpaDevice(vdpaDeviceName)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net"
 	"os"
+	"regexp"
 	"strconv"
 	"strings"
 	"sync"
@@ -1744,6 +1745,29 @@ var _ = ginkgo.Describe("External Gateway", func() {
 				ginkgo.Entry("IPV4 tcp", &addressesv4, "tcp", externalTCPPort, srcHTTPPort),
 				ginkgo.Entry("IPV6 udp", &addressesv6, "udp", externalUDPPort, srcUDPPort),
 				ginkgo.Entry("IPV6 tcp", &addressesv6, "tcp", externalTCPPort, srcHTTPPort))
+
+			// The weight of a static hop is implemented with as many ECMP static routes to the gateway, this checks that
+			// ovn-northd keeps every one of them as a member of the ECMP group of the pod instead of merging them.
+			ginkgo.DescribeTable("Should program an ECMP member per unit of weight of the static hops", func(addresses *gatewayTestIPs) {
+				if addresses.srcPodIP == "" || addresses.nodeIP == "" {
+					skipper.Skipf("Skipping as pod ip / node ip are not set pod ip %s node ip %s", addresses.srcPodIP, addresses.nodeIP)
+				}
+				if len(addresses.gatewayIPs) < 2 {
+					skipper.Skipf("Skipping as the test requires two gateways, got %v", addresses.gatewayIPs)
+				}
+				weights := map[string]int{
+					addresses.gatewayIPs[0]: 3,
+					addresses.gatewayIPs[1]: 1,
+				}
+				createAPBExternalRouteCRWithWeightedStaticHops(defaultPolicyName, f.Namespace.Name, weights)
+
+				srcPod := getGatewayPod(f, f.Namespace.Name, srcPodName)
+				ginkgo.By("Verifying the ECMP members of the pod on the gateway router of its node")
+				gomega.Eventually(func() map[string]int {
+					return getECMPMembersOfSource(srcPod.Spec.NodeName, addresses.srcPodIP)
+				}, time.Minute, time.Second).Should(gomega.Equal(weights))
+			}, ginkgo.Entry("IPV4", &addressesv4),
+				ginkgo.Entry("IPV6", &addressesv6))
 		})
 
 		var _ = ginkgo.Describe("e2e multiple external gateway stale conntrack entry deletion validation", func() {
@@ -3212,6 +3236,90 @@ spec:
 	}, time.Minute, 1).Should(gomega.Equal(status))
 }
 
+func createAPBExternalRouteCRWithWeightedStaticHops(policyName, namespaceName string, weights map[string]int) {
+	b := strings.Builder{}
+	for _, gateway := range sets.StringKeySet(weights).List() {
+		b.WriteString(fmt.Sprintf(`     - ip: "%s"
+       weight: %d
+`, gateway, weights[gateway]))
+	}
+	data := fmt.Sprintf(`apiVersion: k8s.ovn.org/v1
+kind: AdminPolicyBasedExternalRoute
+metadata:
+  name: %s
+spec:
+  from:
+    namespaceSelector:
+      matchLabels:
+        kubernetes.io/metadata.name: %s
+  nextHops:
+    static:
+%s
+`, policyName, namespaceName, b.String())
+	_, err := e2ekubectl.RunKubectlInput("", data, "create", "-f", "-", "--save-config")
+	gomega.Expect(err).NotTo(gomega.HaveOccurred())
+	gomega.Eventually(func() string {
+		status, err := e2ekubectl.RunKubectl("", "get", "apbexternalroute", policyName, "-ojsonpath={.status.status}")
+		gomega.Expect(err).NotTo(gomega.HaveOccurred())
+		return status
+	}, time.Minute, 1).Should(gomega.Equal("Success"))
+}
+
+var (
+	ecmpGroupRegex   = regexp.MustCompile(`reg8\[0\.\.15\] = (\d+);`)
+	ecmpMembersRegex = regexp.MustCompile(`reg8\[16\.\.31\] = select\(([^)]*)\)`)
+)
+
+// getECMPMembersOfSource returns the number of ECMP members of every next hop of the ECMP group ovn-northd built
+// for the routes of the source IP on the gateway router of the node, read from the logical flows of the SB database.
+func getECMPMembersOfSource(nodeName, srcIP string) map[string]int {
+	dbPods, err := e2ekubectl.RunKubectl("ovn-kubernetes", "get", "pods", "-l", "name=ovnkube-db", "-o=jsonpath={.items..metadata.name}")
+	if isInterconnectEnabled() {
+		dbPods, err = e2ekubectl.RunKubectl("ovn-kubernetes", "get", "pods", "-l", "name=ovnkube-node", "--field-selector",
+			fmt.Sprintf("spec.nodeName=%s", nodeName), "-o=jsonpath={.items..metadata.name}")
+	}
+	framework.ExpectNoError(err, "failed to get the OVN SB database pod")
+	dbPod := strings.Fields(dbPods)[0]
+	lflows, err := e2ekubectl.RunKubectl("ovn-kubernetes", "exec", dbPod, "-c", "sb-ovsdb", "--", "ovn-sbctl",
+		"--no-leader-only", "lflow-list", "GR_"+nodeName)
+	framework.ExpectNoError(err, "failed to list the logical flows of the gateway router of node %s", nodeName)
+
+	prefix := srcIP + "/32"
+	if utilnet.IsIPv6String(srcIP) {
+		prefix = srcIP + "/128"
+	}
+	group := ""
+	for _, lflow := range strings.Split(lflows, "\n") {
+		if !strings.Contains(lflow, "(lr_in_ip_routing ") || !strings.Contains(lflow, ".src == "+prefix+")") {
+			continue
+		}
+		if groupMatch := ecmpGroupRegex.FindStringSubmatch(lflow); groupMatch != nil && ecmpMembersRegex.MatchString(lflow) {
+			group = groupMatch[1]
+			break
+		}
+	}
+	members := map[string]int{}
+	if group == "" {
+		return members
+	}
+	for _, lflow := range strings.Split(lflows, "\n") {
+		if !strings.Contains(lflow, "(lr_in_ip_routing_ecmp") ||
+			!strings.Contains(lflow, fmt.Sprintf("reg8[0..15] == %s && reg8[16..31] == ", group)) {
+			continue
+		}
+		_, actions, _ := strings.Cut(lflow, "action=(")
+		for _, action := range strings.Split(actions, ";") {
+			action = strings.TrimSpace(action)
+			if nextHop, found := strings.CutPrefix(action, "reg0 = "); found {
+				members[nextHop]++
+			} else if nextHop, found := strings.CutPrefix(action, "xxreg0 = "); found {
+				members[nextHop]++
+			}
+		}
+	}
+	return members
+}
+
 func updateAPBExternalRouteCRWithStaticHop(policyName, namespaceName string, bfd bool, gateways ...string) {
 
 	lastUpdatetime, err := e2ekubectl.RunKubectl("", "get", "apbexternalroute", policyName, "-ojsonpath={.status.lastTransitionTime}")